@@ -7,15 +7,24 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/api/bitbucket"
+	"github.com/vilaca/ci-dashboard/internal/api/gitea"
 	"github.com/vilaca/ci-dashboard/internal/api/github"
 	"github.com/vilaca/ci-dashboard/internal/api/gitlab"
+	"github.com/vilaca/ci-dashboard/internal/api/jenkins"
+	"github.com/vilaca/ci-dashboard/internal/audit"
+	"github.com/vilaca/ci-dashboard/internal/auth"
 	"github.com/vilaca/ci-dashboard/internal/config"
 	"github.com/vilaca/ci-dashboard/internal/dashboard"
 	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+	"github.com/vilaca/ci-dashboard/internal/metrics"
+	"github.com/vilaca/ci-dashboard/internal/notify"
 	"github.com/vilaca/ci-dashboard/internal/service"
 )
 
@@ -27,11 +36,11 @@ func main() {
 	}
 
 	// Wire up dependencies (Dependency Injection / IoC)
-	server, handler, refresher := buildServer(cfg)
+	server, handler, workers := buildServer(cfg)
 
-	// Start background refresher to pre-populate and maintain cache
-	if refresher != nil {
-		refresher.Start()
+	// Start background workers (cache snapshotter, refresher, event poller) to pre-populate and maintain cache
+	for _, worker := range workers {
+		worker.Start()
 	}
 
 	// Start server
@@ -60,6 +69,10 @@ func main() {
 	if cfg.HasGitHubConfig() {
 		log.Printf("GitHub: ENABLED")
 		log.Printf("  URL: %s", cfg.GitHubURL)
+		log.Printf("  API: %s", cfg.GitHubAPI)
+		if cfg.HasGitHubApp() {
+			log.Printf("  Auth: GitHub App %s", cfg.GitHubAppID)
+		}
 		log.Printf("  Cache TTL: %ds", cfg.GitHubCacheDurationSeconds)
 		if cfg.GitHubCurrentUser != "" {
 			log.Printf("  Current user: %s", cfg.GitHubCurrentUser)
@@ -70,10 +83,121 @@ func main() {
 			log.Printf("  Watching: all accessible repositories")
 		}
 	} else {
-		log.Printf("GitHub: DISABLED (set GITHUB_TOKEN to enable)")
+		log.Printf("GitHub: DISABLED (set GITHUB_TOKEN or GITHUB_APP_ID to enable)")
+	}
+
+	if cfg.HasGiteaConfig() {
+		log.Printf("Gitea: ENABLED")
+		log.Printf("  URL: %s", cfg.GiteaURL)
+		log.Printf("  Cache TTL: %ds", cfg.GiteaCacheDurationSeconds)
+		if cfg.GiteaCurrentUser != "" {
+			log.Printf("  Current user: %s", cfg.GiteaCurrentUser)
+		}
+		if len(cfg.GetGiteaWatchedRepos()) > 0 {
+			log.Printf("  Watching: %d specific repositories", len(cfg.GetGiteaWatchedRepos()))
+		} else {
+			log.Printf("  Watching: all accessible repositories")
+		}
+	} else {
+		log.Printf("Gitea: DISABLED (set GITEA_URL and GITEA_TOKEN to enable)")
+	}
+
+	if cfg.HasBitbucketConfig() {
+		log.Printf("Bitbucket: ENABLED")
+		log.Printf("  URL: %s", cfg.BitbucketURL)
+		log.Printf("  Cache TTL: %ds", cfg.BitbucketCacheDurationSeconds)
+		if cfg.BitbucketWorkspace != "" {
+			log.Printf("  Workspace: %s", cfg.BitbucketWorkspace)
+		}
+		if cfg.BitbucketCurrentUser != "" {
+			log.Printf("  Current user: %s", cfg.BitbucketCurrentUser)
+		}
+		if len(cfg.GetBitbucketWatchedRepos()) > 0 {
+			log.Printf("  Watching: %d specific repositories", len(cfg.GetBitbucketWatchedRepos()))
+		} else {
+			log.Printf("  Watching: all accessible repositories")
+		}
+	} else {
+		log.Printf("Bitbucket: DISABLED (set BITBUCKET_TOKEN to enable)")
 	}
 
-	if !cfg.HasGitLabConfig() && !cfg.HasGitHubConfig() {
+	for _, conn := range cfg.Connections {
+		log.Printf("Connection %s: %s", conn.Name, conn.Platform)
+		log.Printf("  URL: %s", conn.URL)
+		if cfg.HasConnectionActions(conn) {
+			log.Printf("  Write actions: ENABLED")
+		}
+		if conn.WebhookSecret != "" {
+			log.Printf("  Webhook: /api/webhooks/%s", conn.Name)
+		}
+		log.Printf("  Cache TTL: %ds", conn.CacheDurationSeconds)
+		if len(conn.WatchedRepos) > 0 {
+			log.Printf("  Watching: %d specific repositories", len(conn.WatchedRepos))
+		} else {
+			log.Printf("  Watching: all accessible repositories")
+		}
+	}
+
+	if cfg.HasJenkinsConfig() {
+		log.Printf("Jenkins: ENABLED")
+		log.Printf("  URL: %s", cfg.JenkinsURL)
+		log.Printf("  Cache TTL: %ds", cfg.JenkinsCacheDurationSeconds)
+		log.Printf("  Linked jobs: %d", len(cfg.JenkinsJobs))
+	} else {
+		log.Printf("Jenkins: DISABLED (set JENKINS_URL and link jobs in the config file to enable)")
+	}
+
+	if cfg.EventPollIntervalSeconds > 0 {
+		log.Printf("Event polling: every %ds", cfg.EventPollIntervalSeconds)
+	} else {
+		log.Printf("Event polling: DISABLED")
+	}
+
+	if cfg.CacheSnapshotIntervalSeconds > 0 {
+		log.Printf("Cache snapshots: every %ds to %s", cfg.CacheSnapshotIntervalSeconds, cfg.DataDir)
+	} else {
+		log.Printf("Cache snapshots: DISABLED")
+	}
+
+	if cfg.HistoryRetentionDays > 0 {
+		log.Printf("Pipeline history: %d days in %s", cfg.HistoryRetentionDays, cfg.DataDir)
+	} else {
+		log.Printf("Pipeline history: DISABLED")
+	}
+
+	if cfg.HasActions() {
+		log.Printf("Write actions: GitLab %s, GitHub %s (audit log: %s)", enabledString(cfg.HasGitLabActions()), enabledString(cfg.HasGitHubActions()), auditLogPath(cfg))
+		if cfg.AuthMode == "" {
+			log.Printf("WARNING: write actions are allowed without authentication (ACTIONS_ALLOW_UNAUTHENTICATED) - anyone reaching the dashboard can run pipelines")
+		}
+	} else if cfg.ActionsEnabled {
+		log.Printf("Write actions: DISABLED (set GITLAB_WRITE_TOKEN / GITHUB_WRITE_TOKEN to enable)")
+	} else {
+		log.Printf("Write actions: DISABLED (set ACTIONS_ENABLED=true and a write token to enable)")
+	}
+
+	switch cfg.AuthMode {
+	case "oidc":
+		log.Printf("Authentication: OIDC (%s)", cfg.OIDCIssuerURL)
+	case "basic":
+		log.Printf("Authentication: basic (%s)", cfg.AuthHtpasswdFile)
+	default:
+		log.Printf("Authentication: DISABLED (set AUTH_MODE to basic or oidc to enable)")
+	}
+
+	if cfg.HasNotifications() {
+		log.Printf("Notifications: %d rules, %d sinks", len(cfg.Notifications.Rules), len(cfg.Notifications.Sinks))
+	} else {
+		log.Printf("Notifications: DISABLED (add notifications rules to the config file to enable)")
+	}
+
+	if cfg.GitLabWebhookSecret != "" || cfg.GitHubWebhookSecret != "" {
+		log.Printf("Webhooks: GitLab %s, GitHub %s", enabledString(cfg.GitLabWebhookSecret != ""), enabledString(cfg.GitHubWebhookSecret != ""))
+	} else {
+		log.Printf("Webhooks: DISABLED (set GITLAB_WEBHOOK_SECRET / GITHUB_WEBHOOK_SECRET to enable)")
+	}
+
+	if !cfg.HasGitLabConfig() && !cfg.HasGitHubConfig() && !cfg.HasGiteaConfig() && !cfg.HasBitbucketConfig() && len(cfg.Connections) == 0 {
 		log.Printf("WARNING: No CI platforms configured!")
 	}
 	log.Printf("==================================")
@@ -100,9 +224,9 @@ func main() {
 	<-sigChan
 	log.Printf("Shutdown signal received, shutting down gracefully...")
 
-	// Stop background refresher first
-	if refresher != nil {
-		refresher.Stop()
+	// Stop background workers first (in reverse start order)
+	for i := len(workers) - 1; i >= 0; i-- {
+		workers[i].Stop()
 	}
 
 	// Stop handler background goroutines
@@ -121,10 +245,16 @@ func main() {
 	log.Printf("Server stopped")
 }
 
-// buildServer wires up all dependencies and returns the configured HTTP handler, dashboard handler, and background refresher.
+// backgroundWorker is a long-running component started after wiring and stopped on shutdown.
+type backgroundWorker interface {
+	Start()
+	Stop()
+}
+
+// buildServer wires up all dependencies and returns the configured HTTP handler, dashboard handler, and background workers.
 // This is the composition root where all dependencies are created and injected.
 // Follows SOLID principles and IoC (Inversion of Control).
-func buildServer(cfg *config.Config) (http.Handler, *dashboard.Handler, *service.BackgroundRefresher) {
+func buildServer(cfg *config.Config) (http.Handler, *dashboard.Handler, []backgroundWorker) {
 	// Create shared dependencies
 	logger := dashboard.NewStdLogger()
 	renderer := dashboard.NewHTMLRenderer()
@@ -133,11 +263,16 @@ func buildServer(cfg *config.Config) (http.Handler, *dashboard.Handler, *service
 	}
 
 	// Create pipeline service with whitelists and user filter
-	pipelineService := service.NewPipelineService(
-		cfg.GetGitLabWatchedRepos(),
-		cfg.GetGitHubWatchedRepos(),
-		cfg.FilterUserRepos,
-	)
+	whitelists := map[string][]string{
+		domain.PlatformGitLab:    cfg.GetGitLabWatchedRepos(),
+		domain.PlatformGitHub:    cfg.GetGitHubWatchedRepos(),
+		domain.PlatformGitea:     cfg.GetGiteaWatchedRepos(),
+		domain.PlatformBitbucket: cfg.GetBitbucketWatchedRepos(),
+	}
+	for _, conn := range cfg.Connections {
+		whitelists[conn.Name] = conn.WatchedRepos
+	}
+	pipelineService := service.NewPipelineService(whitelists, cfg.FilterUserRepos)
 
 	// Register CI clients based on configuration with stale-while-revalidate caching
 	if cfg.HasGitLabConfig() {
@@ -156,10 +291,14 @@ func buildServer(cfg *config.Config) (http.Handler, *dashboard.Handler, *service
 	}
 
 	if cfg.HasGitHubConfig() {
-		githubClient := github.NewClient(api.ClientConfig{
+		clientConfig := api.ClientConfig{
 			BaseURL: cfg.GitHubURL,
 			Token:   cfg.GitHubToken,
-		}, httpClient)
+		}
+		if cfg.HasGitHubApp() {
+			clientConfig.TokenSource = newGitHubAppTokenSource(cfg.GitHubURL, cfg.GitHubAppID, cfg.GitHubAppPrivateKeyFile, cfg.GitHubAppInstallationID, httpClient)
+		}
+		githubClient := newGitHubClient(cfg.GitHubAPI, clientConfig, httpClient)
 
 		// Wrap with stale-while-revalidate caching layer
 		cacheDuration := time.Duration(cfg.GitHubCacheDurationSeconds) * time.Second
@@ -168,25 +307,411 @@ func buildServer(cfg *config.Config) (http.Handler, *dashboard.Handler, *service
 		pipelineService.RegisterClient(domain.PlatformGitHub, cachedGitHubClient)
 	}
 
+	if cfg.HasGiteaConfig() {
+		giteaClient := gitea.NewClient(api.ClientConfig{
+			BaseURL: cfg.GiteaURL,
+			Token:   cfg.GiteaToken,
+		}, httpClient)
+
+		// Gitea IDs (owner/repo) would collide with GitHub's, so they are qualified like a connection's
+		// Wrap with stale-while-revalidate caching layer
+		cacheDuration := time.Duration(cfg.GiteaCacheDurationSeconds) * time.Second
+		staleTTL := time.Duration(cfg.StaleCacheTTLSeconds) * time.Second
+		cachedGiteaClient := api.NewStaleCachingClient(api.NewNamespacedClient(giteaClient, domain.PlatformGitea), cacheDuration, staleTTL)
+		pipelineService.RegisterClient(domain.PlatformGitea, cachedGiteaClient)
+	}
+
+	if cfg.HasBitbucketConfig() {
+		bitbucketClient := bitbucket.NewClient(api.ClientConfig{
+			BaseURL:  cfg.BitbucketURL,
+			Token:    cfg.BitbucketToken,
+			Username: cfg.BitbucketUsername,
+		}, cfg.BitbucketWorkspace, httpClient)
+
+		// Bitbucket IDs (workspace/repo) would collide with GitHub's, so they are qualified like a connection's
+		// Wrap with stale-while-revalidate caching layer
+		cacheDuration := time.Duration(cfg.BitbucketCacheDurationSeconds) * time.Second
+		staleTTL := time.Duration(cfg.StaleCacheTTLSeconds) * time.Second
+		cachedBitbucketClient := api.NewStaleCachingClient(api.NewNamespacedClient(bitbucketClient, domain.PlatformBitbucket), cacheDuration, staleTTL)
+		pipelineService.RegisterClient(domain.PlatformBitbucket, cachedBitbucketClient)
+	}
+
+	// Register additional named connections, qualifying their project IDs with the connection name
+	staleTTL := time.Duration(cfg.StaleCacheTTLSeconds) * time.Second
+	for _, conn := range cfg.Connections {
+		client := newConnectionClient(conn, httpClient)
+		cacheDuration := time.Duration(conn.CacheDurationSeconds) * time.Second
+		cachedClient := api.NewStaleCachingClient(api.NewNamespacedClient(client, conn.Name), cacheDuration, staleTTL)
+		pipelineService.RegisterConnection(conn.Name, conn.Platform, cachedClient)
+	}
+
+	// Jenkins runs pipelines of repositories hosted on the other platforms
+	if cfg.HasJenkinsConfig() {
+		registerJenkinsClients(cfg, pipelineService, httpClient)
+	}
+
+	// Record finished pipelines on disk for success-rate and duration trends
+	if cfg.HistoryRetentionDays > 0 {
+		retention := time.Duration(cfg.HistoryRetentionDays) * 24 * time.Hour
+		store, err := history.Open(filepath.Join(cfg.DataDir, history.FileName), retention)
+		if err != nil {
+			log.Printf("Pipeline history disabled: %v", err)
+		} else {
+			log.Printf("Pipeline history: loaded %d records", store.Len())
+			pipelineService.SetPipelineHistory(store)
+		}
+	}
+
+	// Alert on default branch status transitions
+	if cfg.HasNotifications() {
+		notifier, err := buildNotifier(cfg.Notifications)
+		if err != nil {
+			log.Printf("Notifications disabled: %v", err)
+		} else {
+			pipelineService.SetStatusNotifier(notifier)
+		}
+	}
+
+	// Write actions use separate write-scoped tokens so reads never need write access
+	var auditLog dashboard.AuditLog
+	if cfg.HasActions() {
+		if cfg.HasGitLabActions() {
+			pipelineService.RegisterActionClient(domain.PlatformGitLab, gitlab.NewClient(api.ClientConfig{
+				BaseURL: cfg.GitLabURL,
+				Token:   cfg.GitLabWriteToken,
+			}, httpClient))
+		}
+		if cfg.HasGitHubActions() {
+			pipelineService.RegisterActionClient(domain.PlatformGitHub, github.NewClient(api.ClientConfig{
+				BaseURL: cfg.GitHubURL,
+				Token:   cfg.GitHubWriteToken,
+			}, httpClient))
+		}
+		for _, conn := range cfg.Connections {
+			if cfg.HasConnectionActions(conn) {
+				pipelineService.RegisterActionClient(conn.Name, api.NewNamespacedActionClient(newConnectionActionClient(conn, httpClient), conn.Name))
+			}
+		}
+
+		// Actions must not run unrecorded
+		actionLog, err := audit.Open(auditLogPath(cfg))
+		if err != nil {
+			log.Fatalf("Failed to open audit log: %v", err)
+		}
+		auditLog = actionLog
+	}
+
+	// DORA metrics are computed from the pipeline history (nil = disabled)
+	var metricsService dashboard.MetricsService
+	if pipelineService.HasPipelineHistory() {
+		metricsService = metrics.NewService(pipelineService)
+	}
+
 	// Create handler with dependencies (Dependency Injection)
 	handler := dashboard.NewHandler(dashboard.HandlerConfig{
 		Renderer:          renderer,
 		Logger:            logger,
 		PipelineService:   pipelineService,
+		MetricsService:    metricsService,
+		AuditLog:          auditLog,
 		RunsPerRepo:       cfg.RunsPerRepository,
 		RecentLimit:       cfg.RecentPipelinesLimit,
 		UIRefreshInterval: cfg.UIRefreshIntervalSeconds,
 		GitLabUser:        cfg.GitLabCurrentUser,
 		GitHubUser:        cfg.GitHubCurrentUser,
+		GiteaUser:         cfg.GiteaCurrentUser,
+		BitbucketUser:     cfg.BitbucketCurrentUser,
+		Webhooks:          webhookEndpoints(cfg),
 	})
 
 	// Register routes
 	mux := http.NewServeMux()
 	handler.RegisterRoutes(mux)
 
+	var workers []backgroundWorker
+
+	// Restore the cache from disk first and save it last, so restarts don't start cold
+	if cfg.CacheSnapshotIntervalSeconds > 0 {
+		snapshotInterval := time.Duration(cfg.CacheSnapshotIntervalSeconds) * time.Second
+		workers = append(workers, service.NewCacheSnapshotter(pipelineService, cfg.DataDir, snapshotInterval, logger))
+	}
+
 	// Create background refresher to pre-populate and maintain cache
 	refreshInterval := time.Duration(cfg.BackgroundRefreshIntervalSeconds) * time.Second
 	refresher := service.NewBackgroundRefresher(pipelineService, refreshInterval, logger)
+	workers = append(workers, refresher)
+
+	// Poll project events to refresh changed branches/MRs between full refreshes
+	if cfg.EventPollIntervalSeconds > 0 {
+		pollInterval := time.Duration(cfg.EventPollIntervalSeconds) * time.Second
+		workers = append(workers, service.NewEventPoller(pipelineService, pollInterval, logger))
+	}
+
+	// Require authentication in front of every route except the health check and webhooks
+	server, err := wrapWithAuth(cfg, mux)
+	if err != nil {
+		log.Fatalf("Failed to configure authentication: %v", err)
+	}
+
+	return server, handler, workers
+}
+
+// auditLogPath returns the audit log file of write actions, defaulting to the data directory.
+func auditLogPath(cfg *config.Config) string {
+	if cfg.AuditLogFile != "" {
+		return cfg.AuditLogFile
+	}
+	return filepath.Join(cfg.DataDir, audit.FileName)
+}
+
+// webhookEndpoints returns the webhook endpoints of connections with a webhook secret,
+// keyed by connection name (the platform name for the default connections).
+func webhookEndpoints(cfg *config.Config) map[string]dashboard.Webhook {
+	webhooks := make(map[string]dashboard.Webhook)
+	if cfg.GitLabWebhookSecret != "" {
+		webhooks[domain.PlatformGitLab] = dashboard.Webhook{Platform: domain.PlatformGitLab, Secret: cfg.GitLabWebhookSecret}
+	}
+	if cfg.GitHubWebhookSecret != "" {
+		webhooks[domain.PlatformGitHub] = dashboard.Webhook{Platform: domain.PlatformGitHub, Secret: cfg.GitHubWebhookSecret}
+	}
+	for _, conn := range cfg.Connections {
+		if conn.WebhookSecret != "" {
+			webhooks[conn.Name] = dashboard.Webhook{Platform: conn.Platform, Secret: conn.WebhookSecret}
+		}
+	}
+	return webhooks
+}
+
+// newConnectionClient creates the client of a named connection.
+func newConnectionClient(conn config.Connection, httpClient api.HTTPClient) api.Client {
+	clientConfig := api.ClientConfig{
+		BaseURL:  conn.URL,
+		Token:    conn.Token,
+		Username: conn.Username,
+	}
 
-	return mux, handler, refresher
+	switch conn.Platform {
+	case domain.PlatformGitHub:
+		if conn.AppID != "" {
+			clientConfig.TokenSource = newGitHubAppTokenSource(conn.URL, conn.AppID, conn.AppPrivateKeyFile, conn.AppInstallationID, httpClient)
+		}
+		return newGitHubClient(conn.API, clientConfig, httpClient)
+	case domain.PlatformGitea:
+		return gitea.NewClient(clientConfig, httpClient)
+	case domain.PlatformBitbucket:
+		return bitbucket.NewClient(clientConfig, conn.Workspace, httpClient)
+	default:
+		return gitlab.NewClient(clientConfig, httpClient)
+	}
+}
+
+// newConnectionActionClient creates the write action client of a named connection with its write token.
+func newConnectionActionClient(conn config.Connection, httpClient api.HTTPClient) api.ActionClient {
+	clientConfig := api.ClientConfig{
+		BaseURL: conn.URL,
+		Token:   conn.WriteToken,
+	}
+	if conn.Platform == domain.PlatformGitHub {
+		return github.NewClient(clientConfig, httpClient)
+	}
+	return gitlab.NewClient(clientConfig, httpClient)
+}
+
+// newGitHubClient creates a REST or GraphQL-backed GitHub client.
+func newGitHubClient(apiFlavour string, clientConfig api.ClientConfig, httpClient api.HTTPClient) api.Client {
+	if apiFlavour == config.GitHubAPIGraphQL {
+		return github.NewGraphQLClient(clientConfig, httpClient)
+	}
+	return github.NewClient(clientConfig, httpClient)
+}
+
+// newGitHubAppTokenSource creates the installation token source of a GitHub App.
+// Exits on an unreadable or invalid private key since GitHub can't be reached without it.
+func newGitHubAppTokenSource(baseURL, appID, privateKeyFile, installationID string, httpClient api.HTTPClient) api.TokenSource {
+	privateKey, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		log.Fatalf("Failed to read GitHub App private key: %v", err)
+	}
+
+	tokenSource, err := github.NewAppTokenSource(baseURL, github.AppConfig{
+		AppID:          appID,
+		PrivateKey:     privateKey,
+		InstallationID: installationID,
+	}, httpClient)
+	if err != nil {
+		log.Fatalf("Failed to configure GitHub App %s: %v", appID, err)
+	}
+	return tokenSource
+}
+
+// registerJenkinsClients registers a Jenkins client per connection with linked jobs.
+// Project IDs are only unique within a connection, so each connection gets its own client and cache.
+func registerJenkinsClients(cfg *config.Config, pipelineService *service.PipelineService, httpClient api.HTTPClient) {
+	jobsByConnection := make(map[string]map[string]jenkins.Job)
+	for _, link := range cfg.JenkinsJobs {
+		if link.Job == "" || (link.Platform == "" && link.Connection == "") || link.Project == "" {
+			log.Printf("Jenkins: ignoring incomplete job link %+v (job, platform or connection, and project are required)", link)
+			continue
+		}
+		connection, err := jenkinsLinkConnection(cfg, link)
+		if err != nil {
+			log.Printf("Jenkins: ignoring job link %s: %v", link.Job, err)
+			continue
+		}
+		if jobsByConnection[connection] == nil {
+			jobsByConnection[connection] = make(map[string]jenkins.Job)
+		}
+
+		// Gitea and Bitbucket are qualified with their platform name, named connections with theirs
+		projectID := link.Project
+		if _, linked := api.SplitProjectID(projectID); linked == "" &&
+			connection != domain.PlatformGitLab && connection != domain.PlatformGitHub {
+			projectID = api.NamespaceProjectID(projectID, connection)
+		}
+		jobsByConnection[connection][projectID] = jenkins.Job{Path: link.Job, Branch: link.Branch}
+	}
+
+	cacheDuration := time.Duration(cfg.JenkinsCacheDurationSeconds) * time.Second
+	staleTTL := time.Duration(cfg.StaleCacheTTLSeconds) * time.Second
+	for connection, jobs := range jobsByConnection {
+		jenkinsClient := jenkins.NewClient(api.ClientConfig{
+			BaseURL:  cfg.JenkinsURL,
+			Token:    cfg.JenkinsToken,
+			Username: cfg.JenkinsUsername,
+		}, jobs, httpClient)
+
+		projectIDs := make([]string, 0, len(jobs))
+		for projectID := range jobs {
+			projectIDs = append(projectIDs, projectID)
+		}
+
+		// Wrap with stale-while-revalidate caching layer
+		cachedJenkinsClient := api.NewStaleCachingClient(jenkinsClient, cacheDuration, staleTTL)
+		pipelineService.RegisterPipelineClient(connection, projectIDs, cachedJenkinsClient)
+	}
+}
+
+// jenkinsLinkConnection returns the connection hosting the repository of a Jenkins job link:
+// the named connection, or the platform's default connection.
+// Links naming a connection as their platform are accepted too.
+func jenkinsLinkConnection(cfg *config.Config, link config.JenkinsJob) (string, error) {
+	name := link.Connection
+	if name == "" {
+		name = link.Platform
+	}
+
+	for _, conn := range cfg.Connections {
+		if conn.Name == name {
+			if link.Connection != "" && link.Platform != "" && link.Platform != conn.Platform {
+				return "", fmt.Errorf("connection %s is a %s connection, not %s", conn.Name, conn.Platform, link.Platform)
+			}
+			return conn.Name, nil
+		}
+	}
+	if link.Connection != "" {
+		return "", fmt.Errorf("unknown connection %s", link.Connection)
+	}
+
+	switch name {
+	case domain.PlatformGitLab, domain.PlatformGitHub, domain.PlatformGitea, domain.PlatformBitbucket:
+		return name, nil
+	}
+	return "", fmt.Errorf("unknown platform %s", name)
+}
+
+// wrapWithAuth returns next protected by the configured authentication mode.
+// Misconfiguration is an error rather than a silent fallback to an open dashboard.
+func wrapWithAuth(cfg *config.Config, next http.Handler) (http.Handler, error) {
+	switch cfg.AuthMode {
+	case "":
+		return next, nil
+
+	case "basic":
+		if cfg.AuthHtpasswdFile == "" {
+			return nil, fmt.Errorf("AUTH_HTPASSWD_FILE is required for basic auth")
+		}
+		htpasswd, err := auth.LoadHtpasswd(cfg.AuthHtpasswdFile)
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewBasicAuth(htpasswd, "CI Dashboard").Wrap(next), nil
+
+	case "oidc":
+		if cfg.OIDCIssuerURL == "" || cfg.OIDCClientID == "" || cfg.OIDCRedirectURL == "" {
+			return nil, fmt.Errorf("OIDC_ISSUER_URL, OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required for OIDC auth")
+		}
+
+		secret := []byte(cfg.SessionSecret)
+		if cfg.SessionSecret == "" {
+			log.Printf("WARNING: SESSION_SECRET not set, using a random secret - sessions end on restart")
+			secret = auth.GenerateSecret()
+		}
+		sessions, err := auth.NewSessionCodec(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		provider := auth.NewOIDCProvider(auth.OIDCConfig{
+			IssuerURL:      cfg.OIDCIssuerURL,
+			ClientID:       cfg.OIDCClientID,
+			ClientSecret:   cfg.OIDCClientSecret,
+			RedirectURL:    cfg.OIDCRedirectURL,
+			AllowedDomains: cfg.OIDCAllowedDomains,
+			AllowedGroups:  cfg.OIDCAllowedGroups,
+			GroupsClaim:    cfg.OIDCGroupsClaim,
+		}, &http.Client{Timeout: 30 * time.Second})
+		sessionTTL := time.Duration(cfg.SessionTTLHours) * time.Hour
+		return auth.NewOIDCAuth(provider, sessions, sessionTTL).Wrap(next), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported AUTH_MODE %q (want basic or oidc)", cfg.AuthMode)
+	}
+}
+
+// buildNotifier creates the notifier and its sinks from configuration.
+func buildNotifier(cfg config.NotificationsConfig) (*notify.Notifier, error) {
+	httpClient := &http.Client{Timeout: notify.SendTimeout}
+
+	sinks := make([]notify.Sink, 0, len(cfg.Sinks))
+	for _, sc := range cfg.Sinks {
+		switch sc.Type {
+		case "webhook":
+			sinks = append(sinks, notify.NewWebhookSink(sc.Name, sc.URL, httpClient))
+		case "slack":
+			sinks = append(sinks, notify.NewSlackSink(sc.Name, sc.URL, httpClient))
+		case "email":
+			sinks = append(sinks, notify.NewEmailSink(sc.Name, notify.EmailConfig{
+				Host:     sc.SMTPHost,
+				Port:     sc.SMTPPort,
+				Username: sc.Username,
+				Password: sc.Password,
+				From:     sc.From,
+				To:       sc.To,
+			}))
+		default:
+			return nil, fmt.Errorf("sink %q: unsupported type %q (want webhook, slack or email)", sc.Name, sc.Type)
+		}
+	}
+
+	rules := make([]notify.Rule, 0, len(cfg.Rules))
+	for _, rc := range cfg.Rules {
+		rules = append(rules, notify.Rule{
+			Name:              rc.Name,
+			Repositories:      rc.Repositories,
+			Branches:          rc.Branches,
+			Status:            domain.Status(rc.Status),
+			MinFailedDuration: time.Duration(rc.MinFailedMinutes) * time.Minute,
+			Sinks:             rc.Sinks,
+		})
+	}
+
+	return notify.New(rules, sinks)
+}
+
+// enabledString formats a feature flag for startup logging.
+func enabledString(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
 }
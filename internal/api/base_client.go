@@ -2,7 +2,12 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"sync"
+	"time"
 )
 
 const (
@@ -12,6 +17,10 @@ const (
 	MaxRetryAttempts = 3
 	// DefaultPageSize is the default number of items per page
 	DefaultPageSize = 100
+	// DefaultRetryBackoff is the base delay of the exponential backoff between retries (2s, 4s, 8s)
+	DefaultRetryBackoff = time.Second
+	// MaxRateLimitWait is the longest a rate limited request waits before failing instead
+	MaxRateLimitWait = 10 * time.Minute
 )
 
 // HTTPClient interface for HTTP operations (allows mocking in tests).
@@ -23,19 +32,43 @@ type HTTPClient interface {
 // BaseClient contains common fields and functionality for all API clients.
 // Follows DRY principle by extracting shared code.
 type BaseClient struct {
-	BaseURL    string
-	Token      string
-	HTTPClient HTTPClient
-	Semaphore  chan struct{} // Limits concurrent requests
+	BaseURL      string
+	Token        string
+	HTTPClient   HTTPClient
+	Semaphore    chan struct{} // Limits concurrent requests
+	RetryBackoff time.Duration // Base delay between retries (doubled on every attempt)
+
+	rateLimitMu sync.RWMutex
+	rateLimits  map[string]rateLimit // resource -> last reported quota ("" = the default quota)
+}
+
+// rateLimit is the state of a quota as last reported by the API.
+type rateLimit struct {
+	remaining int
+	reset     time.Time
+}
+
+// RequestOptions adjusts how DoWithOptions sends a request.
+type RequestOptions struct {
+	// RateLimitResource names the quota the request counts against when the API keeps separate ones,
+	// like GitHub's X-RateLimit-Resource (core, graphql). Empty means the default quota.
+	RateLimitResource string
+
+	// Retry retries network errors and 5xx responses of a request that isn't a GET or HEAD,
+	// for requests that are safe to repeat, e.g. read-only GraphQL queries.
+	Retry bool
 }
 
 // NewBaseClient creates a new base client with rate limiting.
+// GET requests are sent as conditional requests once a response with an ETag or Last-Modified was seen.
 func NewBaseClient(baseURL, token string, httpClient HTTPClient) *BaseClient {
 	return &BaseClient{
-		BaseURL:    baseURL,
-		Token:      token,
-		HTTPClient: httpClient,
-		Semaphore:  make(chan struct{}, MaxConcurrentRequests),
+		BaseURL:      baseURL,
+		Token:        token,
+		HTTPClient:   NewConditionalClient(httpClient),
+		Semaphore:    make(chan struct{}, MaxConcurrentRequests),
+		RetryBackoff: DefaultRetryBackoff,
+		rateLimits:   make(map[string]rateLimit),
 	}
 }
 
@@ -53,3 +86,95 @@ func (c *BaseClient) DoRateLimited(ctx context.Context, fn func() (interface{},
 	// Execute the request
 	return fn()
 }
+
+// DoWithRetry sends a request with retries and rate limit handling, and returns the final response.
+// newRequest is called for every attempt, so a request body is never sent twice from the same reader.
+// Network errors and 5xx responses are retried with exponential backoff, for GET and HEAD requests only.
+// Rate limited responses (429, or 403 with an exhausted quota) are retried after the delay the API asks for.
+// Any other response, including the last rate limited or 5xx one, is returned for the caller to handle.
+func (c *BaseClient) DoWithRetry(ctx context.Context, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	return c.DoWithOptions(ctx, RequestOptions{}, newRequest)
+}
+
+// DoWithOptions sends a request like DoWithRetry, against the quota and with the retries of options.
+func (c *BaseClient) DoWithOptions(ctx context.Context, options RequestOptions, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	// Don't spend a request that is known to be rejected
+	if err := c.waitForRateLimit(ctx, options.RateLimitResource); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead || options.Retry
+		lastAttempt := attempt == MaxRetryAttempts
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			if !idempotent || lastAttempt || ctx.Err() != nil {
+				if attempt > 0 {
+					return nil, fmt.Errorf("request failed after %d retries: %w", attempt, err)
+				}
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
+			wait := c.backoff(attempt)
+			log.Printf("[%s] Request failed: %v. Retrying in %v (attempt %d/%d)", req.URL.Host, err, wait, attempt+1, MaxRetryAttempts)
+			if err := sleepContext(ctx, wait); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		c.updateRateLimit(req.URL.Host, options.RateLimitResource, resp.Header)
+
+		var wait time.Duration
+		switch {
+		case isRateLimited(resp):
+			wait = retryDelay(resp.Header)
+			if wait < 0 {
+				wait = c.backoff(attempt)
+			}
+			if lastAttempt || wait > MaxRateLimitWait {
+				return resp, nil
+			}
+			log.Printf("[%s] Rate limited (status %d). Retrying in %v (attempt %d/%d)", req.URL.Host, resp.StatusCode, wait.Round(time.Second), attempt+1, MaxRetryAttempts)
+		case resp.StatusCode >= http.StatusInternalServerError && idempotent && !lastAttempt:
+			wait = c.backoff(attempt)
+			log.Printf("[%s] API returned status %d. Retrying in %v (attempt %d/%d)", req.URL.Host, resp.StatusCode, wait, attempt+1, MaxRetryAttempts)
+		default:
+			return resp, nil
+		}
+
+		// Drain the body so the connection can be reused
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns the delay before the retry following attempt (2s, 4s, 8s with the default backoff).
+func (c *BaseClient) backoff(attempt int) time.Duration {
+	return c.RetryBackoff * time.Duration(1<<uint(attempt+1))
+}
+
+// sleepContext waits for d or until the context is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
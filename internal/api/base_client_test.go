@@ -0,0 +1,288 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// mockHTTPClient is a test double for HTTPClient that replays a sequence of responses.
+// Follows FIRST principles - tests are Fast and Independent.
+type mockHTTPClient struct {
+	doFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return m.doFunc(req)
+}
+
+// statusResponse builds a response with a status, headers and an empty JSON body.
+func statusResponse(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+	}
+	for name, value := range headers {
+		resp.Header.Set(name, value)
+	}
+	return resp
+}
+
+// newTestBaseClient creates a base client with a negligible retry backoff.
+func newTestBaseClient(doFunc func(req *http.Request) (*http.Response, error)) *BaseClient {
+	client := NewBaseClient("https://api.example.com", "token", &mockHTTPClient{doFunc: doFunc})
+	client.RetryBackoff = time.Millisecond
+	return client
+}
+
+func getRequest(method string) func() (*http.Request, error) {
+	return func() (*http.Request, error) {
+		return http.NewRequest(method, "https://api.example.com/projects", nil)
+	}
+}
+
+// TestDoWithRetry_RetriesServerErrorsAndNetworkErrors tests that transient failures are retried.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestDoWithRetry_RetriesServerErrorsAndNetworkErrors(t *testing.T) {
+	// Arrange
+	attempts := 0
+	client := newTestBaseClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		switch attempts {
+		case 1:
+			return nil, errors.New("connection reset")
+		case 2:
+			return statusResponse(http.StatusBadGateway, nil), nil
+		default:
+			return statusResponse(http.StatusOK, nil), nil
+		}
+	})
+
+	// Act
+	resp, err := client.DoWithRetry(context.Background(), getRequest(http.MethodGet))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+// TestDoWithRetry_GivesUpAfterMaxAttempts tests that the last server error is returned to the caller.
+func TestDoWithRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	attempts := 0
+	client := newTestBaseClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return statusResponse(http.StatusServiceUnavailable, nil), nil
+	})
+
+	// Act
+	resp, err := client.DoWithRetry(context.Background(), getRequest(http.MethodGet))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", resp.StatusCode)
+	}
+	if attempts != MaxRetryAttempts+1 {
+		t.Errorf("expected %d attempts, got %d", MaxRetryAttempts+1, attempts)
+	}
+}
+
+// TestDoWithRetry_DoesNotRetryPostOnServerError tests that non-idempotent requests are sent once.
+func TestDoWithRetry_DoesNotRetryPostOnServerError(t *testing.T) {
+	// Arrange
+	attempts := 0
+	client := newTestBaseClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return statusResponse(http.StatusInternalServerError, nil), nil
+	})
+
+	// Act
+	resp, err := client.DoWithRetry(context.Background(), getRequest(http.MethodPost))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError || attempts != 1 {
+		t.Errorf("expected a single attempt returning 500, got %d attempts and status %d", attempts, resp.StatusCode)
+	}
+}
+
+// TestDoWithRetry_RateLimited tests that 429 responses are retried and the quota is tracked.
+func TestDoWithRetry_RateLimited(t *testing.T) {
+	// Arrange
+	reset := time.Now().Add(time.Hour).Unix()
+	attempts := 0
+	client := newTestBaseClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return statusResponse(http.StatusTooManyRequests, map[string]string{"Retry-After": "0"}), nil
+		}
+		return statusResponse(http.StatusOK, map[string]string{
+			"RateLimit-Limit":     "2000",
+			"RateLimit-Remaining": "1999",
+			"RateLimit-Reset":     strconv.FormatInt(reset, 10),
+		}), nil
+	})
+
+	// Act
+	resp, err := client.DoWithRetry(context.Background(), getRequest(http.MethodPost))
+	remaining, resetTime := client.RateLimit()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || attempts != 2 {
+		t.Errorf("expected success on the second attempt, got %d attempts and status %d", attempts, resp.StatusCode)
+	}
+	if remaining != 1999 || resetTime.Unix() != reset {
+		t.Errorf("expected 1999 requests remaining until %d, got %d until %d", reset, remaining, resetTime.Unix())
+	}
+}
+
+// TestDoWithRetry_RateLimitResetTooFar tests that requests fail fast when the quota resets too late.
+func TestDoWithRetry_RateLimitResetTooFar(t *testing.T) {
+	// Arrange
+	attempts := 0
+	client := newTestBaseClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return statusResponse(http.StatusForbidden, map[string]string{
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+		}), nil
+	})
+
+	// Act
+	resp, err := client.DoWithRetry(context.Background(), getRequest(http.MethodGet))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusForbidden || attempts != 1 {
+		t.Errorf("expected a single attempt returning 403, got %d attempts and status %d", attempts, resp.StatusCode)
+	}
+}
+
+// TestDoWithRetry_WaitsForExhaustedQuota tests that no request is sent while the quota is exhausted.
+func TestDoWithRetry_WaitsForExhaustedQuota(t *testing.T) {
+	// Arrange
+	client := newTestBaseClient(func(req *http.Request) (*http.Response, error) {
+		t.Error("unexpected request while the rate limit is exhausted")
+		return statusResponse(http.StatusOK, nil), nil
+	})
+	client.updateRateLimit("api.example.com", "", http.Header{
+		"Ratelimit-Remaining": {"0"},
+		"Ratelimit-Reset":     {"3600"},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := client.DoWithRetry(ctx, getRequest(http.MethodGet))
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded while waiting for reset, got %v", err)
+	}
+}
+
+// TestDoWithOptions_RateLimitResources tests that an exhausted quota only holds back requests against it.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestDoWithOptions_RateLimitResources(t *testing.T) {
+	// Arrange
+	client := newTestBaseClient(func(req *http.Request) (*http.Response, error) {
+		return statusResponse(http.StatusOK, nil), nil
+	})
+	client.updateRateLimit("api.example.com", "graphql", http.Header{
+		"X-Ratelimit-Remaining": {"0"},
+		"X-Ratelimit-Reset":     {"3600"},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	restResp, restErr := client.DoWithRetry(ctx, getRequest(http.MethodGet))
+	_, graphqlErr := client.DoWithOptions(ctx, RequestOptions{RateLimitResource: "graphql"}, getRequest(http.MethodPost))
+
+	// Assert
+	if restErr != nil || restResp.StatusCode != http.StatusOK {
+		t.Errorf("expected the default quota to be unaffected, got %v", restErr)
+	}
+	if !errors.Is(graphqlErr, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded while waiting for the graphql reset, got %v", graphqlErr)
+	}
+	if remaining, _ := client.RateLimit(); remaining != -1 {
+		t.Errorf("expected the default quota to be unknown, got %d remaining", remaining)
+	}
+}
+
+// TestDoWithOptions_RetriesPost tests that requests opting into retries are retried on server errors.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestDoWithOptions_RetriesPost(t *testing.T) {
+	// Arrange
+	attempts := 0
+	client := newTestBaseClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return statusResponse(http.StatusBadGateway, nil), nil
+		}
+		return statusResponse(http.StatusOK, nil), nil
+	})
+
+	// Act
+	resp, err := client.DoWithOptions(context.Background(), RequestOptions{Retry: true}, getRequest(http.MethodPost))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || attempts != 2 {
+		t.Errorf("expected success on the second attempt, got %d attempts and status %d", attempts, resp.StatusCode)
+	}
+}
+
+// TestParseRetryAfter tests parsing delays in seconds and HTTP dates.
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"30", 30 * time.Second, true},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			// Act
+			wait, ok := parseRetryAfter(tt.value)
+
+			// Assert
+			if wait != tt.expected || ok != tt.ok {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tt.expected, tt.ok, wait, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,738 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+const (
+	// DefaultBaseURL is the Bitbucket Cloud API root
+	DefaultBaseURL = "https://api.bitbucket.org/2.0"
+	// webBaseURL is where Bitbucket Cloud serves repository pages
+	webBaseURL = "https://bitbucket.org"
+	// maxPageLen is the largest page Bitbucket returns for most collections
+	maxPageLen = 100
+	// maxPullRequestPageLen is the largest page Bitbucket returns for pull requests
+	maxPullRequestPageLen = 50
+)
+
+// errNotFound is returned by doRequest for 404 responses.
+var errNotFound = errors.New("not found")
+
+// Client implements api.Client for Bitbucket Cloud and Bitbucket Pipelines.
+// Follows Single Responsibility Principle - only handles Bitbucket API communication.
+type Client struct {
+	*api.BaseClient
+	username  string // non-empty = app password basic auth, empty = bearer access token
+	workspace string // empty = all repositories the user is a member of
+}
+
+// NewClient creates a new Bitbucket Cloud client.
+// workspace limits repositories to one workspace, which is required for workspace access tokens.
+// Uses dependency injection for HTTPClient (IoC).
+func NewClient(config api.ClientConfig, workspace string, httpClient api.HTTPClient) *Client {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Client{
+		BaseClient: api.NewBaseClient(strings.TrimSuffix(baseURL, "/"), config.Token, httpClient),
+		username:   config.Username,
+		workspace:  workspace,
+	}
+}
+
+// GetProjects retrieves all repositories, following next links.
+func (c *Client) GetProjects(ctx context.Context) ([]domain.Project, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		repos, err := getAllPages[bitbucketRepository](ctx, c, c.repositoriesURL(maxPageLen, 0))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repositories: %w", err)
+		}
+		return c.convertProjects(repos), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Project), nil
+}
+
+// GetProjectCount returns the total number of repositories.
+func (c *Client) GetProjectCount(ctx context.Context) (int, error) {
+	var response bitbucketPage[bitbucketRepository]
+	if err := c.doRequest(ctx, c.repositoriesURL(1, 1), &response); err != nil {
+		return 0, err
+	}
+
+	if response.Size == nil {
+		return 0, fmt.Errorf("repository count not reported")
+	}
+
+	log.Printf("[Bitbucket] GetProjectCount: %d", *response.Size)
+	return *response.Size, nil
+}
+
+// GetProjectsPage fetches a single page of repositories.
+func (c *Client) GetProjectsPage(ctx context.Context, page int) ([]domain.Project, bool, error) {
+	var response bitbucketPage[bitbucketRepository]
+	if err := c.doRequest(ctx, c.repositoriesURL(maxPageLen, page), &response); err != nil {
+		return nil, false, err
+	}
+
+	hasNextPage := response.Next != "" && len(response.Values) > 0
+	return c.convertProjects(response.Values), hasNextPage, nil
+}
+
+// repositoriesURL returns the repository listing URL, most recently updated first.
+// page 0 omits the page parameter (for next link pagination).
+func (c *Client) repositoriesURL(pageLen, page int) string {
+	query := url.Values{}
+	query.Set("pagelen", strconv.Itoa(pageLen))
+	query.Set("sort", "-updated_on")
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+
+	if c.workspace != "" {
+		return fmt.Sprintf("%s/repositories/%s?%s", c.BaseURL, url.PathEscape(c.workspace), query.Encode())
+	}
+	query.Set("role", "member")
+	return fmt.Sprintf("%s/repositories?%s", c.BaseURL, query.Encode())
+}
+
+// GetLatestPipeline retrieves the most recent pipeline for a repository and branch.
+func (c *Client) GetLatestPipeline(ctx context.Context, projectID, branch string) (*domain.Pipeline, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		query := url.Values{}
+		query.Set("target.branch", branch)
+		query.Set("sort", "-created_on")
+		query.Set("pagelen", "10")
+		url := fmt.Sprintf("%s/repositories/%s/pipelines/?%s", c.BaseURL, projectID, query.Encode())
+
+		var response bitbucketPage[bitbucketPipeline]
+		if err := c.doRequest(ctx, url, &response); err != nil {
+			return nil, fmt.Errorf("failed to get pipelines: %w", err)
+		}
+
+		for _, bbp := range response.Values {
+			if bbp.branch() != branch {
+				continue
+			}
+
+			pipeline := c.convertPipeline(bbp, projectID)
+
+			// Steps are best-effort - a pipeline without step breakdown is still useful
+			builds, err := c.fetchSteps(ctx, projectID, bbp.UUID, pipeline.WebURL)
+			if err != nil {
+				log.Printf("[Bitbucket] Failed to get steps for pipeline %s of %s: %v", pipeline.ID, projectID, err)
+			} else {
+				pipeline.Builds = builds
+			}
+
+			return pipeline, nil
+		}
+
+		return (*domain.Pipeline)(nil), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.Pipeline), nil
+}
+
+// GetPipelines retrieves recent pipelines for a repository.
+func (c *Client) GetPipelines(ctx context.Context, projectID string, limit int) ([]domain.Pipeline, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		if limit <= 0 || limit > maxPageLen {
+			limit = maxPageLen
+		}
+		url := fmt.Sprintf("%s/repositories/%s/pipelines/?sort=-created_on&pagelen=%d", c.BaseURL, projectID, limit)
+
+		var response bitbucketPage[bitbucketPipeline]
+		if err := c.doRequest(ctx, url, &response); err != nil {
+			return nil, fmt.Errorf("failed to get pipelines: %w", err)
+		}
+
+		pipelines := make([]domain.Pipeline, len(response.Values))
+		for i, bbp := range response.Values {
+			pipelines[i] = *c.convertPipeline(bbp, projectID)
+		}
+
+		return pipelines, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Pipeline), nil
+}
+
+// fetchSteps fetches the steps of a pipeline without acquiring the rate limit semaphore.
+func (c *Client) fetchSteps(ctx context.Context, projectID, pipelineUUID, pipelineURL string) ([]domain.Build, error) {
+	stepsURL := fmt.Sprintf("%s/repositories/%s/pipelines/%s/steps/?pagelen=%d", c.BaseURL, projectID, url.PathEscape(pipelineUUID), maxPageLen)
+
+	steps, err := getAllPages[bitbucketStep](ctx, c, stepsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	builds := make([]domain.Build, len(steps))
+	for i, step := range steps {
+		builds[i] = c.convertStep(step, pipelineURL)
+	}
+	return builds, nil
+}
+
+// GetBranches retrieves all branches for a repository (with pagination).
+// limit parameter is ignored - fetches all branches.
+// Bitbucket includes the last commit in the branch list, so no per-branch requests are needed.
+func (c *Client) GetBranches(ctx context.Context, projectID string, limit int) ([]domain.Branch, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		defaultBranch := c.fetchDefaultBranch(ctx, projectID)
+
+		url := fmt.Sprintf("%s/repositories/%s/refs/branches?pagelen=%d&sort=-target.date", c.BaseURL, projectID, maxPageLen)
+		bbBranches, err := getAllPages[bitbucketBranch](ctx, c, url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get branches: %w", err)
+		}
+
+		branches := make([]domain.Branch, len(bbBranches))
+		for i, bbb := range bbBranches {
+			branches[i] = c.convertBranch(bbb, projectID, defaultBranch)
+		}
+
+		return branches, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Branch), nil
+}
+
+// GetBranch retrieves a single branch by name.
+func (c *Client) GetBranch(ctx context.Context, projectID, branchName string) (*domain.Branch, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		defaultBranch := c.fetchDefaultBranch(ctx, projectID)
+
+		url := fmt.Sprintf("%s/repositories/%s/refs/branches/%s", c.BaseURL, projectID, branchName)
+
+		var bbb bitbucketBranch
+		if err := c.doRequest(ctx, url, &bbb); err != nil {
+			return nil, fmt.Errorf("failed to get branch %s (URL: %s): %w", branchName, url, err)
+		}
+
+		branch := c.convertBranch(bbb, projectID, defaultBranch)
+		return &branch, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.Branch), nil
+}
+
+// fetchDefaultBranch returns the repository's main branch, or "" when unavailable.
+func (c *Client) fetchDefaultBranch(ctx context.Context, projectID string) string {
+	repoURL := fmt.Sprintf("%s/repositories/%s", c.BaseURL, projectID)
+
+	var repo bitbucketRepository
+	if err := c.doRequest(ctx, repoURL, &repo); err != nil {
+		log.Printf("[Bitbucket] Failed to get repo info for %s (URL: %s): %v", projectID, repoURL, err)
+		return ""
+	}
+	if repo.MainBranch == nil {
+		return ""
+	}
+	return repo.MainBranch.Name
+}
+
+// GetMergeRequests retrieves all open pull requests for a repository (with pagination).
+func (c *Client) GetMergeRequests(ctx context.Context, projectID string) ([]domain.MergeRequest, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		url := fmt.Sprintf("%s/repositories/%s/pullrequests?state=OPEN&pagelen=%d", c.BaseURL, projectID, maxPullRequestPageLen)
+
+		prs, err := getAllPages[bitbucketPullRequest](ctx, c, url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull requests: %w", err)
+		}
+
+		mrs := make([]domain.MergeRequest, len(prs))
+		for i, pr := range prs {
+			mrs[i] = c.convertPullRequest(pr, projectID)
+		}
+
+		return mrs, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.MergeRequest), nil
+}
+
+// GetIssues retrieves open issues for a repository.
+// Repositories without the issue tracker enabled have no issues.
+func (c *Client) GetIssues(ctx context.Context, projectID string) ([]domain.Issue, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		query := url.Values{}
+		query.Set("q", `state="new" OR state="open"`)
+		query.Set("sort", "-updated_on")
+		query.Set("pagelen", "50")
+		url := fmt.Sprintf("%s/repositories/%s/issues?%s", c.BaseURL, projectID, query.Encode())
+
+		var response bitbucketPage[bitbucketIssue]
+		if err := c.doRequest(ctx, url, &response); err != nil {
+			if errors.Is(err, errNotFound) {
+				return []domain.Issue{}, nil
+			}
+			return nil, fmt.Errorf("failed to get issues: %w", err)
+		}
+
+		issues := make([]domain.Issue, len(response.Values))
+		for i, issue := range response.Values {
+			issues[i] = c.convertIssue(issue, projectID)
+		}
+
+		return issues, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Issue), nil
+}
+
+// getAllPages follows Bitbucket next links from url and returns the values of every page.
+func getAllPages[T any](ctx context.Context, c *Client, url string) ([]T, error) {
+	var all []T
+	for url != "" {
+		var page bitbucketPage[T]
+		if err := c.doRequest(ctx, url, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Values...)
+
+		// Only follow links back to the API, so the token is never sent elsewhere
+		if page.Next != "" && !strings.HasPrefix(page.Next, c.BaseURL+"/") {
+			return nil, fmt.Errorf("unexpected next page URL: %s", page.Next)
+		}
+		url = page.Next
+	}
+	return all, nil
+}
+
+// doRequest performs an HTTP request to the Bitbucket API.
+// Returns errNotFound for 404 responses.
+// Follows Single Level of Abstraction Principle (SLAP).
+func (c *Client) doRequest(ctx context.Context, url string, result interface{}) error {
+	resp, err := c.DoWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.username != "" {
+			req.SetBasicAuth(c.username, c.Token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+c.Token)
+		}
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := api.DecodeJSON(resp, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// convertProjects converts Bitbucket repositories to domain models.
+// Permissions are left unset: listings only contain repositories the user is a member of.
+func (c *Client) convertProjects(repos []bitbucketRepository) []domain.Project {
+	projects := make([]domain.Project, 0, len(repos))
+	for _, repo := range repos {
+		project := domain.Project{
+			ID:           repo.FullName,
+			Name:         repo.Name,
+			WebURL:       repo.Links.HTML.Href,
+			Platform:     domain.PlatformBitbucket,
+			IsFork:       repo.Parent != nil,
+			LastActivity: repo.UpdatedOn,
+		}
+
+		if repo.MainBranch != nil {
+			project.DefaultBranch = repo.MainBranch.Name
+		}
+
+		project.Owner = &domain.ProjectOwner{
+			Username: repo.Owner.username(),
+			Name:     repo.Owner.DisplayName,
+			Type:     repo.Owner.Type,
+		}
+
+		workspace, _, _ := strings.Cut(repo.FullName, "/")
+		project.Namespace = &domain.ProjectNamespace{
+			ID:   workspace,
+			Path: workspace,
+			Kind: "workspace",
+		}
+
+		projects = append(projects, project)
+	}
+	return projects
+}
+
+// convertPipeline converts a Bitbucket pipeline to domain model.
+func (c *Client) convertPipeline(bbp bitbucketPipeline, projectID string) *domain.Pipeline {
+	repository := projectID
+	if _, slug, found := strings.Cut(projectID, "/"); found {
+		repository = slug
+	}
+
+	updatedAt := bbp.CreatedOn.Add(time.Duration(bbp.DurationInSeconds) * time.Second)
+	if bbp.CompletedOn != nil {
+		updatedAt = *bbp.CompletedOn
+	} else if bbp.DurationInSeconds == 0 {
+		updatedAt = time.Now()
+	}
+
+	return &domain.Pipeline{
+		ID:         strconv.Itoa(bbp.BuildNumber),
+		ProjectID:  projectID,
+		Repository: repository,
+		Branch:     bbp.branch(),
+		CommitSHA:  bbp.Target.Commit.Hash,
+		Status:     convertStatus(bbp.State.name()),
+		CreatedAt:  bbp.CreatedOn,
+		UpdatedAt:  updatedAt,
+		Duration:   updatedAt.Sub(bbp.CreatedOn),
+		WebURL:     fmt.Sprintf("%s/%s/pipelines/results/%d", webBaseURL, projectID, bbp.BuildNumber),
+	}
+}
+
+// convertStep converts a Bitbucket pipeline step to domain Build.
+// Bitbucket steps have no stages, so the stage is left empty.
+func (c *Client) convertStep(step bitbucketStep, pipelineURL string) domain.Build {
+	build := domain.Build{
+		ID:       step.UUID,
+		Name:     step.Name,
+		Status:   convertStatus(step.State.name()),
+		Duration: time.Duration(step.DurationInSeconds) * time.Second,
+		WebURL:   pipelineURL + "/steps/" + step.UUID,
+	}
+
+	if step.StartedOn != nil {
+		build.StartedAt = *step.StartedOn
+	}
+
+	return build
+}
+
+// convertBranch converts Bitbucket branch to domain model.
+func (c *Client) convertBranch(bbb bitbucketBranch, projectID, defaultBranch string) domain.Branch {
+	author, email := parseAuthor(bbb.Target.Author.Raw)
+	if bbb.Target.Author.User != nil {
+		author = bbb.Target.Author.User.username()
+	}
+
+	return domain.Branch{
+		Name:           bbb.Name,
+		ProjectID:      projectID,
+		Repository:     projectID,
+		LastCommitSHA:  bbb.Target.Hash,
+		LastCommitMsg:  bbb.Target.Message,
+		LastCommitDate: bbb.Target.Date,
+		CommitAuthor:   author,
+		AuthorEmail:    email,
+		IsDefault:      defaultBranch != "" && bbb.Name == defaultBranch,
+		WebURL:         bbb.Links.HTML.Href,
+		Platform:       domain.PlatformBitbucket,
+	}
+}
+
+// parseAuthor splits a raw "Name <email>" commit author.
+func parseAuthor(raw string) (string, string) {
+	address, err := mail.ParseAddress(raw)
+	if err != nil {
+		return raw, ""
+	}
+	if address.Name == "" {
+		return address.Address, address.Address
+	}
+	return address.Name, address.Address
+}
+
+// convertPullRequest converts Bitbucket PR to domain MergeRequest.
+func (c *Client) convertPullRequest(pr bitbucketPullRequest, projectID string) domain.MergeRequest {
+	repoName := projectID
+	if _, slug, found := strings.Cut(projectID, "/"); found {
+		repoName = slug
+	}
+
+	reviewers := make([]string, 0, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		reviewers = append(reviewers, reviewer.username())
+	}
+
+	return domain.MergeRequest{
+		ID:           strconv.Itoa(pr.ID),
+		Number:       pr.ID,
+		Title:        pr.Title,
+		Description:  pr.Description,
+		State:        strings.ToLower(pr.State),
+		IsDraft:      pr.Draft,
+		SourceBranch: pr.Source.Branch.Name,
+		TargetBranch: pr.Destination.Branch.Name,
+		Author:       pr.Author.username(),
+		Reviewers:    reviewers,
+		CreatedAt:    pr.CreatedOn,
+		UpdatedAt:    pr.UpdatedOn,
+		WebURL:       pr.Links.HTML.Href,
+		ProjectID:    projectID,
+		Repository:   repoName,
+	}
+}
+
+// convertIssue converts Bitbucket issue to domain Issue.
+// Kind and priority are exposed as labels, as Bitbucket issues have no labels.
+func (c *Client) convertIssue(issue bitbucketIssue, projectID string) domain.Issue {
+	repoName := projectID
+	if _, slug, found := strings.Cut(projectID, "/"); found {
+		repoName = slug
+	}
+
+	var labels []string
+	if issue.Kind != "" {
+		labels = append(labels, issue.Kind)
+	}
+	if issue.Priority != "" {
+		labels = append(labels, issue.Priority)
+	}
+
+	author := ""
+	if issue.Reporter != nil {
+		author = issue.Reporter.username()
+	}
+
+	assignee := ""
+	if issue.Assignee != nil {
+		assignee = issue.Assignee.username()
+	}
+
+	return domain.Issue{
+		ID:          strconv.Itoa(issue.ID),
+		Number:      issue.ID,
+		Title:       issue.Title,
+		Description: issue.Content.Raw,
+		State:       issue.State,
+		Labels:      labels,
+		Author:      author,
+		Assignee:    assignee,
+		CreatedAt:   issue.CreatedOn,
+		UpdatedAt:   issue.UpdatedOn,
+		WebURL:      issue.Links.HTML.Href,
+		ProjectID:   projectID,
+		Repository:  repoName,
+	}
+}
+
+// convertStatus converts Bitbucket pipeline and step states to domain status.
+func convertStatus(bbStatus string) domain.Status {
+	switch bbStatus {
+	case "PENDING", "PAUSED", "HALTED":
+		return domain.StatusPending
+	case "IN_PROGRESS", "RUNNING":
+		return domain.StatusRunning
+	case "SUCCESSFUL":
+		return domain.StatusSuccess
+	case "FAILED", "ERROR":
+		return domain.StatusFailed
+	case "STOPPED", "EXPIRED":
+		return domain.StatusCanceled
+	case "NOT_RUN":
+		return domain.StatusSkipped
+	default:
+		return domain.Status(strings.ToLower(bbStatus))
+	}
+}
+
+// Bitbucket API response types
+
+// bitbucketPage is a page of a paginated collection.
+type bitbucketPage[T any] struct {
+	Size   *int   `json:"size"` // total count, not reported by every collection
+	Next   string `json:"next"`
+	Values []T    `json:"values"`
+}
+
+type bitbucketLinks struct {
+	HTML struct {
+		Href string `json:"href"`
+	} `json:"html"`
+}
+
+type bitbucketAccount struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+	Username    string `json:"username"` // teams and workspaces
+	Type        string `json:"type"`
+}
+
+// username returns the account's handle.
+func (a bitbucketAccount) username() string {
+	if a.Nickname != "" {
+		return a.Nickname
+	}
+	if a.Username != "" {
+		return a.Username
+	}
+	return a.DisplayName
+}
+
+type bitbucketRepository struct {
+	Name       string           `json:"name"`
+	FullName   string           `json:"full_name"`
+	Owner      bitbucketAccount `json:"owner"`
+	MainBranch *struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+	Parent *struct {
+		FullName string `json:"full_name"`
+	} `json:"parent"`
+	UpdatedOn time.Time      `json:"updated_on"`
+	Links     bitbucketLinks `json:"links"`
+}
+
+type bitbucketState struct {
+	Name  string `json:"name"` // PENDING, IN_PROGRESS or COMPLETED
+	Stage *struct {
+		Name string `json:"name"` // e.g. RUNNING, PAUSED, HALTED
+	} `json:"stage"`
+	Result *struct {
+		Name string `json:"name"` // e.g. SUCCESSFUL, FAILED, STOPPED
+	} `json:"result"`
+}
+
+// name returns the most specific state name: the result when completed, the stage when in progress.
+func (s bitbucketState) name() string {
+	if s.Result != nil && s.Result.Name != "" {
+		return s.Result.Name
+	}
+	if s.Stage != nil && s.Stage.Name != "" {
+		return s.Stage.Name
+	}
+	return s.Name
+}
+
+type bitbucketPipeline struct {
+	UUID        string         `json:"uuid"`
+	BuildNumber int            `json:"build_number"`
+	State       bitbucketState `json:"state"`
+	Target      struct {
+		RefName string `json:"ref_name"` // branch pipelines
+		Source  string `json:"source"`   // pull request pipelines
+		Commit  struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"target"`
+	CreatedOn         time.Time  `json:"created_on"`
+	CompletedOn       *time.Time `json:"completed_on"`
+	DurationInSeconds int        `json:"duration_in_seconds"`
+}
+
+// branch returns the branch the pipeline ran for.
+func (p bitbucketPipeline) branch() string {
+	if p.Target.RefName != "" {
+		return p.Target.RefName
+	}
+	return p.Target.Source
+}
+
+type bitbucketStep struct {
+	UUID              string         `json:"uuid"`
+	Name              string         `json:"name"`
+	State             bitbucketState `json:"state"`
+	StartedOn         *time.Time     `json:"started_on"`
+	DurationInSeconds int            `json:"duration_in_seconds"`
+}
+
+type bitbucketBranch struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash    string    `json:"hash"`
+		Date    time.Time `json:"date"`
+		Message string    `json:"message"`
+		Author  struct {
+			Raw  string            `json:"raw"` // "Name <email>"
+			User *bitbucketAccount `json:"user"`
+		} `json:"author"`
+	} `json:"target"`
+	Links bitbucketLinks `json:"links"`
+}
+
+type bitbucketPullRequest struct {
+	ID          int                `json:"id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	State       string             `json:"state"`
+	Draft       bool               `json:"draft"`
+	Author      bitbucketAccount   `json:"author"`
+	Reviewers   []bitbucketAccount `json:"reviewers"`
+	Source      bitbucketEndpoint  `json:"source"`
+	Destination bitbucketEndpoint  `json:"destination"`
+	CreatedOn   time.Time          `json:"created_on"`
+	UpdatedOn   time.Time          `json:"updated_on"`
+	Links       bitbucketLinks     `json:"links"`
+}
+
+type bitbucketEndpoint struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+}
+
+type bitbucketIssue struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	Kind     string `json:"kind"`
+	Priority string `json:"priority"`
+	Content  struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Reporter  *bitbucketAccount `json:"reporter"`
+	Assignee  *bitbucketAccount `json:"assignee"`
+	CreatedOn time.Time         `json:"created_on"`
+	UpdatedOn time.Time         `json:"updated_on"`
+	Links     bitbucketLinks    `json:"links"`
+}
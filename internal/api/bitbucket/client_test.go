@@ -0,0 +1,180 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// mockHTTPClient is a test double for HTTPClient.
+// Follows FIRST principles - tests are Fast and Independent.
+type mockHTTPClient struct {
+	doFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return m.doFunc(req)
+}
+
+// jsonResponse builds a 200 response with a JSON body.
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+// TestGetProjects_FollowsNextLinks tests that repository pagination follows next links.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetProjects_FollowsNextLinks(t *testing.T) {
+	// Arrange
+	pages := map[string]string{
+		"1": `{"next": "https://api.bitbucket.org/2.0/repositories/acme?page=2", "values": [
+			{"name": "api", "full_name": "acme/api", "mainbranch": {"name": "main"}, "links": {"html": {"href": "https://bitbucket.org/acme/api"}}}
+		]}`,
+		"2": `{"values": [
+			{"name": "web", "full_name": "acme/web", "mainbranch": {"name": "master"}, "parent": {"full_name": "other/web"}}
+		]}`,
+	}
+
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if user, pass, ok := req.BasicAuth(); !ok || user != "jdoe" || pass != "app-password" {
+				t.Errorf("expected app password basic auth, got %q", req.Header.Get("Authorization"))
+			}
+			page := req.URL.Query().Get("page")
+			if page == "" {
+				page = "1"
+			}
+			return jsonResponse(pages[page]), nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{Token: "app-password", Username: "jdoe"}, "acme", mockHTTP)
+
+	// Act
+	projects, err := client.GetProjects(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d", len(projects))
+	}
+	if projects[0].ID != "acme/api" || projects[0].DefaultBranch != "main" || projects[0].Platform != domain.PlatformBitbucket {
+		t.Errorf("unexpected first project: %+v", projects[0])
+	}
+	if !projects[1].IsFork {
+		t.Error("expected second project to be a fork")
+	}
+}
+
+// TestGetProjects_RejectsForeignNextLink tests that the token is never sent to another host.
+func TestGetProjects_RejectsForeignNextLink(t *testing.T) {
+	// Arrange
+	requests := 0
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			requests++
+			return jsonResponse(`{"next": "https://evil.example.com/steal", "values": []}`), nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{Token: "token"}, "", mockHTTP)
+
+	// Act
+	_, err := client.GetProjects(context.Background())
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected error for foreign next link")
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+// TestGetLatestPipeline tests mapping a pipeline and its steps.
+func TestGetLatestPipeline(t *testing.T) {
+	// Arrange
+	pipelinesBody := `{"values": [{
+		"uuid": "{p-1}", "build_number": 42,
+		"state": {"name": "COMPLETED", "result": {"name": "FAILED"}},
+		"target": {"ref_name": "main", "commit": {"hash": "abc123"}},
+		"created_on": "2026-01-02T10:00:00Z", "completed_on": "2026-01-02T10:03:00Z", "duration_in_seconds": 170
+	}]}`
+	stepsBody := `{"values": [
+		{"uuid": "{s-1}", "name": "Build", "state": {"name": "COMPLETED", "result": {"name": "SUCCESSFUL"}}, "duration_in_seconds": 60},
+		{"uuid": "{s-2}", "name": "Test", "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}, "duration_in_seconds": 100}
+	]}`
+
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if strings.Contains(req.URL.Path, "/steps/") {
+				return jsonResponse(stepsBody), nil
+			}
+			if req.URL.Query().Get("target.branch") != "main" {
+				t.Errorf("expected branch filter, got %s", req.URL.RawQuery)
+			}
+			return jsonResponse(pipelinesBody), nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{Token: "token"}, "", mockHTTP)
+
+	// Act
+	pipeline, err := client.GetLatestPipeline(context.Background(), "acme/api", "main")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pipeline == nil {
+		t.Fatal("expected pipeline, got nil")
+	}
+	if pipeline.ID != "42" || pipeline.Status != domain.StatusFailed || pipeline.CommitSHA != "abc123" {
+		t.Errorf("unexpected pipeline: %+v", pipeline)
+	}
+	if pipeline.WebURL != "https://bitbucket.org/acme/api/pipelines/results/42" {
+		t.Errorf("unexpected web URL %s", pipeline.WebURL)
+	}
+	if len(pipeline.Builds) != 2 || pipeline.Builds[1].Status != domain.StatusFailed {
+		t.Errorf("expected 2 steps with the second failed, got %+v", pipeline.Builds)
+	}
+}
+
+// TestConvertStatus tests Bitbucket state conversion.
+func TestConvertStatus(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected domain.Status
+	}{
+		{"PENDING", domain.StatusPending},
+		{"PAUSED", domain.StatusPending},
+		{"RUNNING", domain.StatusRunning},
+		{"SUCCESSFUL", domain.StatusSuccess},
+		{"FAILED", domain.StatusFailed},
+		{"ERROR", domain.StatusFailed},
+		{"STOPPED", domain.StatusCanceled},
+		{"NOT_RUN", domain.StatusSkipped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// Act
+			result := convertStatus(tt.input)
+
+			// Assert
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
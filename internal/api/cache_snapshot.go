@@ -0,0 +1,253 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// CacheSnapshotVersion is the on-disk snapshot format version.
+// Snapshots with a different version are ignored on load.
+const CacheSnapshotVersion = 1
+
+// Snapshot value kinds - one per domain type stored in the cache.
+const (
+	snapshotKindProjects      = "projects"
+	snapshotKindPipeline      = "pipeline"
+	snapshotKindPipelines     = "pipelines"
+	snapshotKindBranch        = "branch"
+	snapshotKindBranches      = "branches"
+	snapshotKindMergeRequests = "merge_requests"
+	snapshotKindIssues        = "issues"
+	snapshotKindEnvironments  = "environments"
+	snapshotKindDeployments   = "deployments"
+	snapshotKindTestReport    = "test_report"
+	snapshotKindCoverage      = "coverage"
+	snapshotKindJobNeeds      = "job_needs"
+	snapshotKindUserProfile   = "user_profile"
+	snapshotKindInt           = "int"
+)
+
+// cacheSnapshot is the on-disk representation of a StaleCache.
+type cacheSnapshot struct {
+	Version int                  `json:"version"`
+	SavedAt time.Time            `json:"saved_at"`
+	Entries []cacheSnapshotEntry `json:"entries"`
+}
+
+// cacheSnapshotEntry is a single cache entry with its value encoded according to Kind.
+type cacheSnapshotEntry struct {
+	Key        string          `json:"key"`
+	Kind       string          `json:"kind"`
+	Value      json.RawMessage `json:"value"`
+	CachedAt   time.Time       `json:"cached_at"`
+	ExpiresAt  time.Time       `json:"expires_at"`
+	StaleUntil time.Time       `json:"stale_until"`
+	ProjectID  string          `json:"project_id,omitempty"`
+	LastCommit time.Time       `json:"last_commit,omitempty"`
+}
+
+// SaveSnapshot writes all usable cache entries to path.
+// The file is written atomically (temp file + rename) so a crash never leaves a truncated snapshot.
+// Returns the number of entries written.
+func (c *StaleCache) SaveSnapshot(path string) (int, error) {
+	snapshot := cacheSnapshot{
+		Version: CacheSnapshotVersion,
+		SavedAt: time.Now(),
+	}
+
+	c.mu.RLock()
+	for key, entry := range c.entries {
+		if snapshot.SavedAt.After(entry.staleUntil) {
+			continue
+		}
+
+		kind, ok := snapshotKind(entry.value)
+		if !ok {
+			log.Printf("[StaleCache] Snapshot: skipping %s (unsupported type %T)", key, entry.value)
+			continue
+		}
+
+		value, err := json.Marshal(entry.value)
+		if err != nil {
+			c.mu.RUnlock()
+			return 0, fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+
+		snapshot.Entries = append(snapshot.Entries, cacheSnapshotEntry{
+			Key:        key,
+			Kind:       kind,
+			Value:      value,
+			CachedAt:   entry.cachedAt,
+			ExpiresAt:  entry.expiresAt,
+			StaleUntil: entry.staleUntil,
+			ProjectID:  entry.projectID,
+			LastCommit: entry.lastCommit,
+		})
+	}
+	c.mu.RUnlock()
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return 0, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return 0, fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+
+	return len(snapshot.Entries), nil
+}
+
+// LoadSnapshot restores cache entries from a snapshot written by SaveSnapshot.
+// Original cachedAt/expiresAt/staleUntil are preserved, so expired entries are served stale
+// and picked up by the background refresher. Entries past staleUntil are dropped.
+// Entries already present in the cache are not overwritten.
+// Returns the number of entries restored; a missing file is not an error.
+func (c *StaleCache) LoadSnapshot(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot cacheSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return 0, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	if snapshot.Version != CacheSnapshotVersion {
+		return 0, fmt.Errorf("unsupported snapshot version %d (expected %d)", snapshot.Version, CacheSnapshotVersion)
+	}
+
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	restored := 0
+	for _, e := range snapshot.Entries {
+		if now.After(e.StaleUntil) {
+			continue
+		}
+		if _, exists := c.entries[e.Key]; exists {
+			continue
+		}
+
+		value, err := decodeSnapshotValue(e.Kind, e.Value)
+		if err != nil {
+			log.Printf("[StaleCache] Snapshot: skipping %s: %v", e.Key, err)
+			continue
+		}
+
+		c.entries[e.Key] = &staleCacheEntry{
+			value:      value,
+			cachedAt:   e.CachedAt,
+			expiresAt:  e.ExpiresAt,
+			staleUntil: e.StaleUntil,
+			projectID:  e.ProjectID,
+			lastCommit: e.LastCommit,
+		}
+		restored++
+	}
+
+	return restored, nil
+}
+
+// snapshotKind returns the snapshot kind for a cached value.
+func snapshotKind(value interface{}) (string, bool) {
+	switch value.(type) {
+	case []domain.Project:
+		return snapshotKindProjects, true
+	case *domain.Pipeline:
+		return snapshotKindPipeline, true
+	case []domain.Pipeline:
+		return snapshotKindPipelines, true
+	case *domain.Branch:
+		return snapshotKindBranch, true
+	case []domain.Branch:
+		return snapshotKindBranches, true
+	case []domain.MergeRequest:
+		return snapshotKindMergeRequests, true
+	case []domain.Issue:
+		return snapshotKindIssues, true
+	case []domain.Environment:
+		return snapshotKindEnvironments, true
+	case []domain.Deployment:
+		return snapshotKindDeployments, true
+	case *domain.TestReport:
+		return snapshotKindTestReport, true
+	case *float64:
+		return snapshotKindCoverage, true
+	case map[string][]string:
+		return snapshotKindJobNeeds, true
+	case *domain.UserProfile:
+		return snapshotKindUserProfile, true
+	case int:
+		return snapshotKindInt, true
+	default:
+		return "", false
+	}
+}
+
+// decodeSnapshotValue decodes a snapshot value into the exact type the cache readers expect.
+// Nil pointers decode as typed nils, so cached "no pipeline" results keep working.
+func decodeSnapshotValue(kind string, raw json.RawMessage) (interface{}, error) {
+	switch kind {
+	case snapshotKindProjects:
+		return decodeSnapshotAs[[]domain.Project](raw)
+	case snapshotKindPipeline:
+		return decodeSnapshotAs[*domain.Pipeline](raw)
+	case snapshotKindPipelines:
+		return decodeSnapshotAs[[]domain.Pipeline](raw)
+	case snapshotKindBranch:
+		return decodeSnapshotAs[*domain.Branch](raw)
+	case snapshotKindBranches:
+		return decodeSnapshotAs[[]domain.Branch](raw)
+	case snapshotKindMergeRequests:
+		return decodeSnapshotAs[[]domain.MergeRequest](raw)
+	case snapshotKindIssues:
+		return decodeSnapshotAs[[]domain.Issue](raw)
+	case snapshotKindEnvironments:
+		return decodeSnapshotAs[[]domain.Environment](raw)
+	case snapshotKindDeployments:
+		return decodeSnapshotAs[[]domain.Deployment](raw)
+	case snapshotKindTestReport:
+		return decodeSnapshotAs[*domain.TestReport](raw)
+	case snapshotKindCoverage:
+		return decodeSnapshotAs[*float64](raw)
+	case snapshotKindJobNeeds:
+		return decodeSnapshotAs[map[string][]string](raw)
+	case snapshotKindUserProfile:
+		return decodeSnapshotAs[*domain.UserProfile](raw)
+	case snapshotKindInt:
+		return decodeSnapshotAs[int](raw)
+	default:
+		return nil, fmt.Errorf("unknown kind %q", kind)
+	}
+}
+
+// decodeSnapshotAs decodes raw JSON into a value of type T.
+func decodeSnapshotAs[T any](raw json.RawMessage) (interface{}, error) {
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
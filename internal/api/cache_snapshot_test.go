@@ -0,0 +1,78 @@
+package api
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestStaleCache_SnapshotRoundTrip tests that a saved snapshot restores typed values and timestamps.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestStaleCache_SnapshotRoundTrip(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "cache.json")
+	source := NewStaleCache(time.Minute, time.Hour)
+	source.Set("GetProjects", []domain.Project{{ID: "123", Name: "test-project"}}, "", time.Time{})
+	source.Set("GetLatestPipeline:123:main", &domain.Pipeline{ID: "456", Status: domain.StatusSuccess}, "123", time.Time{})
+	source.Set("GetLatestPipeline:123:dev", (*domain.Pipeline)(nil), "123", time.Time{})
+	source.Set("GetProjectCount", 1, "", time.Time{})
+
+	// Act
+	saved, saveErr := source.SaveSnapshot(path)
+	restored := NewStaleCache(time.Minute, time.Hour)
+	loaded, loadErr := restored.LoadSnapshot(path)
+
+	// Assert
+	if saveErr != nil || loadErr != nil {
+		t.Fatalf("expected no errors, got save=%v load=%v", saveErr, loadErr)
+	}
+
+	if saved != 4 || loaded != 4 {
+		t.Fatalf("expected 4 entries saved and loaded, got %d and %d", saved, loaded)
+	}
+
+	projects, found := getCached(restored, "GetProjects", []domain.Project{})
+	if !found || len(projects) != 1 || projects[0].Name != "test-project" {
+		t.Errorf("expected restored projects, got %v (found=%v)", projects, found)
+	}
+
+	pipeline, found := getCached(restored, "GetLatestPipeline:123:main", (*domain.Pipeline)(nil))
+	if !found || pipeline == nil || pipeline.ID != "456" {
+		t.Errorf("expected restored pipeline 456, got %v (found=%v)", pipeline, found)
+	}
+
+	noPipeline, found := getCached(restored, "GetLatestPipeline:123:dev", &domain.Pipeline{})
+	if !found || noPipeline != nil {
+		t.Errorf("expected restored nil pipeline, got %v (found=%v)", noPipeline, found)
+	}
+
+	source.mu.RLock()
+	original := source.entries["GetProjects"].staleUntil
+	source.mu.RUnlock()
+	restored.mu.RLock()
+	reloaded := restored.entries["GetProjects"].staleUntil
+	restored.mu.RUnlock()
+	if !original.Equal(reloaded) {
+		t.Errorf("expected staleUntil %v to be preserved, got %v", original, reloaded)
+	}
+}
+
+// TestStaleCache_LoadSnapshotMissingFile tests that a missing snapshot is not an error.
+func TestStaleCache_LoadSnapshotMissingFile(t *testing.T) {
+	// Arrange
+	cache := NewStaleCache(time.Minute, time.Hour)
+
+	// Act
+	loaded, err := cache.LoadSnapshot(filepath.Join(t.TempDir(), "missing.json"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if loaded != 0 {
+		t.Errorf("expected 0 entries, got %d", loaded)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/vilaca/ci-dashboard/internal/domain"
@@ -58,6 +59,15 @@ type ExtendedClient interface {
 	GetIssues(ctx context.Context, projectID string) ([]domain.Issue, error)
 }
 
+// MergedMergeRequestsClient extends Client with merged merge request history.
+// Follows Interface Segregation Principle.
+type MergedMergeRequestsClient interface {
+	Client
+
+	// GetMergedMergeRequests returns merge requests (PRs) merged since the given time, most recently updated first.
+	GetMergedMergeRequests(ctx context.Context, projectID string, since time.Time) ([]domain.MergeRequest, error)
+}
+
 // UserClient extends Client with user profile operations.
 // Follows Interface Segregation Principle.
 type UserClient interface {
@@ -76,8 +86,125 @@ type EventsClient interface {
 	GetEvents(ctx context.Context, projectID string, since time.Time) ([]domain.Event, error)
 }
 
+// JobsClient extends Client with pipeline job operations.
+// Follows Interface Segregation Principle.
+type JobsClient interface {
+	Client
+
+	// GetPipelineJobs returns the jobs (builds) of a pipeline, in execution order.
+	GetPipelineJobs(ctx context.Context, projectID, pipelineID string) ([]domain.Build, error)
+}
+
+// JobNeedsClient extends Client with the dependencies between the jobs of a pipeline.
+// Follows Interface Segregation Principle.
+type JobNeedsClient interface {
+	Client
+
+	// GetJobNeeds returns the names of the jobs each job of a pipeline waits for, by job name.
+	// Jobs missing from the result wait for every job of the previous stage.
+	GetJobNeeds(ctx context.Context, projectID, pipelineID string) (map[string][]string, error)
+}
+
+// WebhookClient extends Client with webhook payload parsing.
+// Follows Interface Segregation Principle.
+type WebhookClient interface {
+	Client
+
+	// ParseWebhook translates a webhook payload into events carrying snapshots of the changed data.
+	// eventType is the platform's event header value. Unsupported event types yield no events.
+	ParseWebhook(eventType string, payload []byte) ([]domain.Event, error)
+}
+
+// BatchClient extends Client with loading the data of many projects in a few requests.
+// Follows Interface Segregation Principle.
+type BatchClient interface {
+	Client
+
+	// PrefetchProjects loads the data of several projects at once, so the next reads
+	// of that data for these projects need no further requests.
+	PrefetchProjects(ctx context.Context, projectIDs []string) error
+}
+
+// DeploymentsClient extends Client with environments and what is deployed to them.
+// Follows Interface Segregation Principle.
+type DeploymentsClient interface {
+	Client
+
+	// GetEnvironments returns the environments of a project with their latest deployment, sorted by name.
+	GetEnvironments(ctx context.Context, projectID string) ([]domain.Environment, error)
+}
+
+// ProductionDeploymentsClient extends Client with the history of deployments to production,
+// the deployments DORA metrics are computed from.
+// Follows Interface Segregation Principle.
+type ProductionDeploymentsClient interface {
+	Client
+
+	// GetProductionDeployments returns the deployments of a project to production environments
+	// created since the given time, newest first.
+	GetProductionDeployments(ctx context.Context, projectID string, since time.Time) ([]domain.Deployment, error)
+}
+
+// JobLogClient extends Client with the logs of pipeline jobs.
+// Follows Interface Segregation Principle.
+type JobLogClient interface {
+	Client
+
+	// GetJobLog returns the raw log of a job, ANSI escape sequences included, as it is downloaded.
+	// The caller must close the returned reader.
+	GetJobLog(ctx context.Context, projectID, jobID string) (io.ReadCloser, error)
+}
+
+// TestReportClient extends Client with the test results of pipelines.
+// Follows Interface Segregation Principle.
+type TestReportClient interface {
+	Client
+
+	// GetTestReport returns the test results of a pipeline, or nil if it has none.
+	GetTestReport(ctx context.Context, projectID, pipelineID string) (*domain.TestReport, error)
+}
+
+// CoverageClient extends Client with code coverage read from the artifacts of pipelines.
+// Only needed where pipelines don't report their coverage themselves.
+// Follows Interface Segregation Principle.
+type CoverageClient interface {
+	Client
+
+	// GetCoverage returns the percentage of lines covered in a pipeline, or nil if it reported none.
+	GetCoverage(ctx context.Context, projectID, pipelineID string) (*float64, error)
+}
+
+// ActionClient extends Client with write actions on pipelines.
+// Clients implementing it need a token with write access to pipelines.
+// Follows Interface Segregation Principle.
+type ActionClient interface {
+	Client
+
+	// RetryPipeline runs all jobs of a pipeline again.
+	RetryPipeline(ctx context.Context, projectID, pipelineID string) error
+
+	// RerunFailedJobs runs the failed and canceled jobs of a pipeline again.
+	RerunFailedJobs(ctx context.Context, projectID, pipelineID string) error
+
+	// CancelPipeline cancels a pending or running pipeline.
+	CancelPipeline(ctx context.Context, projectID, pipelineID string) error
+
+	// RunPipeline starts a new pipeline for a branch or tag.
+	// workflowID selects the workflow to run on platforms with several workflows per repository (GitHub Actions).
+	RunPipeline(ctx context.Context, projectID, ref, workflowID string) error
+}
+
+// TokenSource issues short-lived access tokens, e.g. GitHub App installation tokens.
+// Follows Interface Segregation Principle.
+type TokenSource interface {
+	// Token returns a valid access token, refreshing it when it is about to expire.
+	Token(ctx context.Context) (string, error)
+}
+
 // ClientConfig holds common configuration for API clients.
 type ClientConfig struct {
-	BaseURL string
-	Token   string
+	BaseURL     string
+	Token       string
+	Username    string      // optional: sends Token as a basic auth password (app passwords, API tokens)
+	TokenSource TokenSource // optional: replaces Token with short-lived tokens (GitHub only)
 }
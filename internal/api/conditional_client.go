@@ -0,0 +1,244 @@
+package api
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+const (
+	// maxConditionalBytes bounds the memory held by the response bodies kept for conditional requests.
+	// Least recently used responses are evicted first; evicting one only costs one unconditional request.
+	maxConditionalBytes = 64 << 20
+
+	// maxConditionalBodySize is the largest response body kept. Larger responses are always fetched in full.
+	maxConditionalBodySize = 1 << 20
+)
+
+// conditionalEntry is the last successful response of a URL and its validators.
+type conditionalEntry struct {
+	key          string
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+
+	decodedMu sync.Mutex
+	decoded   reflect.Value // value DecodeJSON decoded from body (invalid until decoded)
+}
+
+// decodedAs returns the value decoded from the entry's body if it was decoded into a value of type t.
+func (e *conditionalEntry) decodedAs(t reflect.Type) (reflect.Value, bool) {
+	e.decodedMu.Lock()
+	defer e.decodedMu.Unlock()
+	if !e.decoded.IsValid() || e.decoded.Type() != t {
+		return reflect.Value{}, false
+	}
+	return e.decoded, true
+}
+
+// setDecoded keeps the value decoded from the entry's body.
+func (e *conditionalEntry) setDecoded(value reflect.Value) {
+	copied := reflect.New(value.Type()).Elem()
+	copied.Set(value)
+
+	e.decodedMu.Lock()
+	defer e.decodedMu.Unlock()
+	e.decoded = copied
+}
+
+// conditionalBody is the body of a response stored or replayed by a ConditionalClient.
+// It lets DecodeJSON reuse the value decoded from the same stored response.
+type conditionalBody struct {
+	*bytes.Reader
+	entry *conditionalEntry
+}
+
+// Close implements io.Closer; the body is held in memory.
+func (b *conditionalBody) Close() error {
+	return nil
+}
+
+// DecodeJSON decodes the JSON body of a response into result.
+// A response replayed by a ConditionalClient after 304 Not Modified reuses the value decoded from the
+// stored response instead of decoding its body again, so callers must not modify decoded values in place.
+func DecodeJSON(resp *http.Response, result interface{}) error {
+	body, ok := resp.Body.(*conditionalBody)
+	target := reflect.ValueOf(result)
+	if !ok || target.Kind() != reflect.Pointer || target.IsNil() {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+
+	if decoded, found := body.entry.decodedAs(target.Elem().Type()); found {
+		target.Elem().Set(decoded)
+		return nil
+	}
+
+	if err := json.NewDecoder(body).Decode(result); err != nil {
+		return err
+	}
+	body.entry.setDecoded(target.Elem())
+	return nil
+}
+
+// ConditionalClient sends conditional GET requests (If-None-Match / If-Modified-Since)
+// for URLs it has seen before, and replays the stored response when the API answers 304 Not Modified.
+// Callers always receive a complete 200 response and decode it as usual; GitHub doesn't count
+// 304 responses against the rate limit, so refreshing unchanged data is free there.
+// Stored responses are bounded by maxConditionalBytes and evicted least recently used first.
+// Follows Decorator Pattern - wraps any HTTPClient without modifying it.
+type ConditionalClient struct {
+	client   HTTPClient
+	entries  map[string]*list.Element // URL -> element of lru holding its *conditionalEntry
+	lru      *list.List               // most recently used first
+	size     int                      // bytes of stored bodies
+	maxBytes int
+	mu       sync.Mutex
+}
+
+// NewConditionalClient creates an HTTP client that revalidates responses with their ETag or Last-Modified.
+func NewConditionalClient(client HTTPClient) *ConditionalClient {
+	return &ConditionalClient{
+		client:   client,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		maxBytes: maxConditionalBytes,
+	}
+}
+
+// Do sends the request, made conditional if a validated response of the URL is stored.
+// Requests sent with "Cache-Control: no-store" (e.g. large job logs) are passed through without being stored.
+func (c *ConditionalClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Cache-Control") == "no-store" {
+		return c.client.Do(req)
+	}
+
+	key := req.URL.String()
+	entry := c.lookup(key)
+
+	if entry != nil {
+		if entry.etag != "" && req.Header.Get("If-None-Match") == "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" && req.Header.Get("If-Modified-Since") == "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		return c.replay(resp, entry), nil
+	case resp.StatusCode == http.StatusOK:
+		return c.store(key, resp)
+	default:
+		return resp, nil
+	}
+}
+
+// lookup returns the stored response of a URL, marking it as recently used.
+func (c *ConditionalClient) lookup(key string) *conditionalEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(element)
+	return element.Value.(*conditionalEntry)
+}
+
+// replay turns a 304 response into the stored 200 response.
+// Headers of the 304 response (rate limits, refreshed validators) override the stored ones.
+func (c *ConditionalClient) replay(resp *http.Response, entry *conditionalEntry) *http.Response {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	header := entry.header.Clone()
+	for name, values := range resp.Header {
+		header[name] = values
+	}
+
+	replayed := *resp
+	replayed.StatusCode = http.StatusOK
+	replayed.Status = "200 OK"
+	replayed.Header = header
+	replayed.Body = &conditionalBody{Reader: bytes.NewReader(entry.body), entry: entry}
+	replayed.ContentLength = int64(len(entry.body))
+	return &replayed
+}
+
+// store keeps a 200 response carrying a validator, and returns it with a re-readable body.
+// Responses larger than maxConditionalBodySize are returned as they are, without being stored.
+func (c *ConditionalClient) store(key string, resp *http.Response) (*http.Response, error) {
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if (etag == "" && lastModified == "") || resp.ContentLength > maxConditionalBodySize {
+		c.remove(key)
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxConditionalBodySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(body) > maxConditionalBodySize {
+		// Too large to keep: hand back what was read followed by the rest of the body
+		c.remove(key)
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	entry := &conditionalEntry{
+		key:          key,
+		etag:         etag,
+		lastModified: lastModified,
+		header:       resp.Header.Clone(),
+		body:         body,
+	}
+	resp.Body = &conditionalBody{Reader: bytes.NewReader(body), entry: entry}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(key)
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += len(body)
+	for c.size > c.maxBytes {
+		c.removeLocked(c.lru.Back().Value.(*conditionalEntry).key)
+	}
+
+	return resp, nil
+}
+
+// remove drops the stored response of a URL.
+func (c *ConditionalClient) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(key)
+}
+
+// removeLocked drops the stored response of a URL. Caller must hold the lock.
+func (c *ConditionalClient) removeLocked(key string) {
+	element, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	c.lru.Remove(element)
+	delete(c.entries, key)
+	c.size -= len(element.Value.(*conditionalEntry).body)
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestConditionalClient_ReplaysNotModified tests that a 304 response is replaced by the stored response.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestConditionalClient_ReplaysNotModified(t *testing.T) {
+	// Arrange
+	var conditionalHeader string
+	requests := 0
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			requests++
+			if requests == 1 {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Etag": {`"v1"`}, "X-Total-Pages": {"3"}, "X-Ratelimit-Remaining": {"99"}},
+					Body:       io.NopCloser(bytes.NewBufferString(`[{"id": 1}]`)),
+				}, nil
+			}
+			conditionalHeader = req.Header.Get("If-None-Match")
+			return &http.Response{
+				StatusCode: http.StatusNotModified,
+				Header:     http.Header{"Etag": {`"v1"`}, "X-Ratelimit-Remaining": {"98"}},
+				Body:       http.NoBody,
+			}, nil
+		},
+	}
+	client := NewConditionalClient(mock)
+
+	// Act
+	first, _ := client.Do(mustRequest(t, http.MethodGet))
+	firstBody, _ := io.ReadAll(first.Body)
+	second, err := client.Do(mustRequest(t, http.MethodGet))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if conditionalHeader != `"v1"` {
+		t.Errorf("expected If-None-Match \"v1\", got %q", conditionalHeader)
+	}
+	secondBody, _ := io.ReadAll(second.Body)
+	if second.StatusCode != http.StatusOK || string(secondBody) != string(firstBody) {
+		t.Errorf("expected stored 200 response, got %d with body %q", second.StatusCode, secondBody)
+	}
+	if second.Header.Get("X-Total-Pages") != "3" || second.Header.Get("X-RateLimit-Remaining") != "98" {
+		t.Errorf("expected stored headers updated by the 304 response, got %v", second.Header)
+	}
+}
+
+// TestConditionalClient_IgnoresUnvalidatedResponses tests that responses without validators are never made conditional.
+func TestConditionalClient_IgnoresUnvalidatedResponses(t *testing.T) {
+	// Arrange
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+				t.Error("expected unconditional request")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+			}, nil
+		},
+	}
+	client := NewConditionalClient(mock)
+
+	// Act
+	client.Do(mustRequest(t, http.MethodGet))
+	_, err := client.Do(mustRequest(t, http.MethodGet))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestConditionalClient_EvictsLeastRecentlyUsed tests that stored bodies stay within the byte budget,
+// evicting the responses used least recently first.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestConditionalClient_EvictsLeastRecentlyUsed(t *testing.T) {
+	// Arrange
+	conditional := make(map[string]bool)
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("If-None-Match") != "" {
+				conditional[req.URL.Path] = true
+				return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: http.NoBody}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": {`"v1"`}},
+				Body:       io.NopCloser(strings.NewReader("0123456789")),
+			}, nil
+		},
+	}
+	client := NewConditionalClient(mock)
+	client.maxBytes = 20
+	get := func(path string) {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.example.com"+path, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		io.ReadAll(resp.Body)
+	}
+
+	// Act
+	get("/a")
+	get("/b")
+	get("/a") // /a is now used more recently than /b
+	get("/c") // over budget: evicts /b
+	conditional = make(map[string]bool)
+	get("/a")
+	get("/b")
+
+	// Assert
+	if !conditional["/a"] {
+		t.Error("expected the recently used response to be kept")
+	}
+	if conditional["/b"] {
+		t.Error("expected the least recently used response to be evicted")
+	}
+	if client.size > client.maxBytes {
+		t.Errorf("expected at most %d stored bytes, got %d", client.maxBytes, client.size)
+	}
+}
+
+// TestConditionalClient_SkipsOversizeBodies tests that bodies over the size threshold are returned intact but not stored.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestConditionalClient_SkipsOversizeBodies(t *testing.T) {
+	// Arrange
+	large := strings.Repeat("x", maxConditionalBodySize+1)
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("If-None-Match") != "" {
+				t.Error("expected unconditional request")
+			}
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				Header:        http.Header{"Etag": {`"v1"`}},
+				Body:          io.NopCloser(strings.NewReader(large)),
+				ContentLength: -1, // unknown length, as with chunked responses
+			}, nil
+		},
+	}
+	client := NewConditionalClient(mock)
+
+	// Act
+	first, _ := client.Do(mustRequest(t, http.MethodGet))
+	body, _ := io.ReadAll(first.Body)
+	_, err := client.Do(mustRequest(t, http.MethodGet))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(body) != large {
+		t.Errorf("expected the complete body of %d bytes, got %d", len(large), len(body))
+	}
+	if client.size != 0 {
+		t.Errorf("expected nothing stored, got %d bytes", client.size)
+	}
+}
+
+// TestDecodeJSON_ReusesDecodedValue tests that a replayed 304 response yields the value decoded from the stored one.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestDecodeJSON_ReusesDecodedValue(t *testing.T) {
+	// Arrange
+	requests := 0
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			requests++
+			if requests == 1 {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Etag": {`"v1"`}},
+					Body:       io.NopCloser(bytes.NewBufferString(`[{"id": 1}, {"id": 2}]`)),
+				}, nil
+			}
+			return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: http.NoBody}, nil
+		},
+	}
+	client := NewConditionalClient(mock)
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	// Act
+	var first, second []item
+	firstResp, _ := client.Do(mustRequest(t, http.MethodGet))
+	firstErr := DecodeJSON(firstResp, &first)
+	secondResp, _ := client.Do(mustRequest(t, http.MethodGet))
+	secondErr := DecodeJSON(secondResp, &second)
+
+	// Assert
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("expected no errors, got %v, %v", firstErr, secondErr)
+	}
+	if len(second) != 2 || second[1].ID != 2 {
+		t.Fatalf("expected the stored items, got %+v", second)
+	}
+	if &first[0] != &second[0] {
+		t.Error("expected the decoded value to be reused instead of decoding the body again")
+	}
+	if unread := secondResp.Body.(*conditionalBody).Len(); unread == 0 {
+		t.Error("expected the replayed body not to be read")
+	}
+}
+
+func mustRequest(t *testing.T, method string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(method, "https://api.example.com/projects?page=1", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	return req
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseCobertura returns the percentage of lines covered by a Cobertura XML report.
+// Only the root's line-rate is read, so the per-file details are never decoded.
+func ParseCobertura(r io.Reader) (float64, error) {
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse Cobertura XML: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local != "coverage" {
+			return 0, fmt.Errorf("not a Cobertura report: root element is <%s>", start.Name.Local)
+		}
+
+		for _, attr := range start.Attr {
+			if attr.Name.Local != "line-rate" {
+				continue
+			}
+			rate, err := strconv.ParseFloat(strings.TrimSpace(attr.Value), 64)
+			if err != nil || rate < 0 || rate > 1 {
+				return 0, fmt.Errorf("invalid line-rate %q", attr.Value)
+			}
+			return rate * 100, nil
+		}
+		return 0, fmt.Errorf("Cobertura report has no line-rate")
+	}
+}
+
+// ParseLcov returns the percentage of lines covered by an lcov tracefile.
+// The LF (lines found) and LH (lines hit) totals of every file record are summed.
+func ParseLcov(r io.Reader) (float64, error) {
+	var found, hit int
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || (key != "LF" && key != "LH") {
+			continue
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid lcov line %s:%s", key, value)
+		}
+		if key == "LF" {
+			found += n
+		} else {
+			hit += n
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read lcov tracefile: %w", err)
+	}
+
+	if found == 0 {
+		return 0, fmt.Errorf("lcov tracefile has no lines")
+	}
+	return float64(hit) * 100 / float64(found), nil
+}
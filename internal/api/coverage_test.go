@@ -0,0 +1,99 @@
+package api
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestParseCobertura tests reading the line coverage of Cobertura XML reports.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestParseCobertura(t *testing.T) {
+	tests := []struct {
+		name    string
+		xml     string
+		want    float64
+		wantErr bool
+	}{
+		{
+			name: "report with doctype",
+			xml: `<?xml version="1.0" ?>
+<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">
+<coverage line-rate="0.8753" branch-rate="0.5" version="1.9" timestamp="1714557600">
+  <packages><package name="app" line-rate="0.8753"/></packages>
+</coverage>`,
+			want: 87.53,
+		},
+		{
+			name:    "JUnit report",
+			xml:     `<testsuites><testsuite name="unit"/></testsuites>`,
+			wantErr: true,
+		},
+		{
+			name:    "missing line-rate",
+			xml:     `<coverage branch-rate="0.5"/>`,
+			wantErr: true,
+		},
+		{
+			name:    "line-rate out of range",
+			xml:     `<coverage line-rate="87.5"/>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, err := ParseCobertura(strings.NewReader(tt.xml))
+
+			// Assert
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestParseLcov tests summing the line coverage of the files of an lcov tracefile.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestParseLcov(t *testing.T) {
+	// Arrange
+	tracefile := `TN:
+SF:src/app.js
+DA:1,1
+DA:2,0
+LF:10
+LH:9
+end_of_record
+SF:src/util.js
+LF:30
+LH:21
+BRF:4
+BRH:2
+end_of_record
+`
+
+	// Act
+	got, err := ParseLcov(strings.NewReader(tracefile))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 75 {
+		t.Errorf("expected 75, got %v", got)
+	}
+
+	if _, err := ParseLcov(strings.NewReader("<coverage/>")); err == nil {
+		t.Error("expected error for a file without lcov records")
+	}
+}
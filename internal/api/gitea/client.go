@@ -0,0 +1,899 @@
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// PageSize is the number of items requested per page.
+// Gitea caps pages at 50 items by default (MAX_RESPONSE_ITEMS).
+const PageSize = 50
+
+// errNotFound is returned by doRequest for 404 responses.
+var errNotFound = errors.New("not found")
+
+// Client implements api.Client for Gitea and Forgejo, using Gitea Actions runs as pipelines.
+// Follows Single Responsibility Principle - only handles Gitea API communication.
+type Client struct {
+	*api.BaseClient
+	jobsUnsupported atomic.Bool // set once the server returns 404 for run jobs (Forgejo, older Gitea)
+}
+
+// NewClient creates a new Gitea/Forgejo client.
+// BaseURL is the instance URL, e.g. https://gitea.example.com.
+// Uses dependency injection for HTTPClient (IoC).
+func NewClient(config api.ClientConfig, httpClient api.HTTPClient) *Client {
+	return &Client{
+		BaseClient: api.NewBaseClient(strings.TrimSuffix(config.BaseURL, "/"), config.Token, httpClient),
+	}
+}
+
+// GetProjects retrieves all repositories accessible by the token.
+func (c *Client) GetProjects(ctx context.Context) ([]domain.Project, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		var allProjects []domain.Project
+		page := 1
+
+		for {
+			pageProjects, hasNext, err := c.GetProjectsPage(ctx, page)
+			if err != nil {
+				return nil, err
+			}
+
+			allProjects = append(allProjects, pageProjects...)
+
+			if !hasNext {
+				break
+			}
+
+			page++
+		}
+
+		return allProjects, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Project), nil
+}
+
+// GetProjectCount returns the total number of repositories accessible by the token.
+func (c *Client) GetProjectCount(ctx context.Context) (int, error) {
+	url := fmt.Sprintf("%s/api/v1/user/repos?limit=1&page=1", c.BaseURL)
+
+	var repos []giteaRepository
+	headers, err := c.doRequest(ctx, url, &repos)
+	if err != nil {
+		return 0, err
+	}
+
+	totalHeader := headers.Get("X-Total-Count")
+	if totalHeader == "" {
+		return 0, fmt.Errorf("X-Total-Count header not found")
+	}
+
+	total, err := strconv.Atoi(totalHeader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse X-Total-Count header: %w", err)
+	}
+
+	log.Printf("[Gitea] GetProjectCount: %d", total)
+	return total, nil
+}
+
+// GetProjectsPage fetches a single page of repositories.
+func (c *Client) GetProjectsPage(ctx context.Context, page int) ([]domain.Project, bool, error) {
+	url := fmt.Sprintf("%s/api/v1/user/repos?limit=%d&page=%d", c.BaseURL, PageSize, page)
+
+	var repos []giteaRepository
+	headers, err := c.doRequest(ctx, url, &repos)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Use the total count when available, otherwise assume more pages after a full page
+	hasNextPage := len(repos) >= PageSize
+	if total, err := strconv.Atoi(headers.Get("X-Total-Count")); err == nil {
+		hasNextPage = page*PageSize < total
+	}
+
+	// If no repositories returned, definitely no more pages
+	if len(repos) == 0 {
+		hasNextPage = false
+	}
+
+	return c.convertProjects(repos), hasNextPage, nil
+}
+
+// GetLatestPipeline retrieves the most recent Actions run for a repository and branch.
+func (c *Client) GetLatestPipeline(ctx context.Context, projectID, branch string) (*domain.Pipeline, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		// Gitea filters by branch, Forgejo by full ref - each ignores the other's parameter
+		query := url.Values{}
+		query.Set("branch", branch)
+		query.Set("ref", "refs/heads/"+branch)
+
+		runs, err := c.fetchRuns(ctx, projectID, query, PageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, run := range runs {
+			if run.branch() != branch {
+				continue
+			}
+
+			pipeline := c.convertPipeline(run, projectID)
+
+			// Jobs are best-effort - a run without job breakdown is still useful
+			builds, err := c.fetchPipelineJobs(ctx, projectID, pipeline.ID)
+			if err != nil {
+				log.Printf("[Gitea] Failed to get jobs for run %s of %s: %v", pipeline.ID, projectID, err)
+			} else {
+				pipeline.Builds = builds
+			}
+
+			return pipeline, nil
+		}
+
+		return (*domain.Pipeline)(nil), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.Pipeline), nil
+}
+
+// GetPipelines retrieves recent Actions runs for a repository.
+func (c *Client) GetPipelines(ctx context.Context, projectID string, limit int) ([]domain.Pipeline, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		runs, err := c.fetchRuns(ctx, projectID, url.Values{}, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		pipelines := make([]domain.Pipeline, len(runs))
+		for i, run := range runs {
+			pipelines[i] = *c.convertPipeline(run, projectID)
+		}
+
+		return pipelines, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Pipeline), nil
+}
+
+// fetchRuns fetches the first page of Actions runs, newest first.
+// Instances without the runs API (Actions disabled or too old) yield no runs.
+func (c *Client) fetchRuns(ctx context.Context, projectID string, query url.Values, limit int) ([]giteaRun, error) {
+	if limit <= 0 || limit > PageSize {
+		limit = PageSize
+	}
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("page", "1")
+
+	url := fmt.Sprintf("%s/api/v1/repos/%s/actions/runs?%s", c.BaseURL, projectID, query.Encode())
+
+	var response giteaRunsResponse
+	if _, err := c.doRequest(ctx, url, &response); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get action runs (URL: %s): %w", url, err)
+	}
+
+	return response.WorkflowRuns, nil
+}
+
+// GetPipelineJobs retrieves the jobs of an Actions run.
+func (c *Client) GetPipelineJobs(ctx context.Context, projectID, pipelineID string) ([]domain.Build, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		return c.fetchPipelineJobs(ctx, projectID, pipelineID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Build), nil
+}
+
+// fetchPipelineJobs fetches run jobs without acquiring the rate limit semaphore.
+// Used by methods that already hold a semaphore slot.
+// Servers without the jobs API (Forgejo, Gitea before 1.24) are remembered and not asked again.
+func (c *Client) fetchPipelineJobs(ctx context.Context, projectID, pipelineID string) ([]domain.Build, error) {
+	if c.jobsUnsupported.Load() {
+		return nil, nil
+	}
+
+	url := fmt.Sprintf("%s/api/v1/repos/%s/actions/runs/%s/jobs?limit=%d", c.BaseURL, projectID, pipelineID, PageSize)
+
+	var response giteaJobsResponse
+	if _, err := c.doRequest(ctx, url, &response); err != nil {
+		if errors.Is(err, errNotFound) {
+			log.Printf("[Gitea] Run jobs API not available, skipping job breakdown")
+			c.jobsUnsupported.Store(true)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get run jobs (URL: %s): %w", url, err)
+	}
+
+	builds := make([]domain.Build, len(response.Jobs))
+	for i, job := range response.Jobs {
+		builds[i] = c.convertJob(job)
+	}
+	domain.MarkRetriedBuilds(builds)
+
+	return builds, nil
+}
+
+// GetBranches retrieves all branches for a repository (with pagination).
+// limit parameter is ignored - fetches all branches.
+// Gitea includes the last commit in the branch list, so no per-branch requests are needed.
+func (c *Client) GetBranches(ctx context.Context, projectID string, limit int) ([]domain.Branch, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		// Repository info provides the default branch and web URL
+		repoURL := fmt.Sprintf("%s/api/v1/repos/%s", c.BaseURL, projectID)
+		var repo giteaRepository
+		if _, err := c.doRequest(ctx, repoURL, &repo); err != nil {
+			log.Printf("[Gitea] Failed to get repo info for %s (URL: %s): %v", projectID, repoURL, err)
+			// Continue without default branch info
+		}
+
+		var allBranches []domain.Branch
+		page := 1
+
+		for {
+			url := fmt.Sprintf("%s/api/v1/repos/%s/branches?limit=%d&page=%d", c.BaseURL, projectID, PageSize, page)
+
+			var giteaBranches []giteaBranch
+			if _, err := c.doRequest(ctx, url, &giteaBranches); err != nil {
+				return nil, fmt.Errorf("failed to get branches (page %d): %w", page, err)
+			}
+
+			for _, gb := range giteaBranches {
+				allBranches = append(allBranches, c.convertBranch(gb, projectID, repo))
+			}
+
+			// If we got fewer results than a full page, we're on the last page
+			if len(giteaBranches) < PageSize {
+				break
+			}
+
+			page++
+		}
+
+		return allBranches, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Branch), nil
+}
+
+// GetBranch retrieves a single branch by name.
+func (c *Client) GetBranch(ctx context.Context, projectID, branchName string) (*domain.Branch, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		repoURL := fmt.Sprintf("%s/api/v1/repos/%s", c.BaseURL, projectID)
+		var repo giteaRepository
+		if _, err := c.doRequest(ctx, repoURL, &repo); err != nil {
+			log.Printf("[Gitea] Failed to get repo info for %s (URL: %s): %v", projectID, repoURL, err)
+		}
+
+		url := fmt.Sprintf("%s/api/v1/repos/%s/branches/%s", c.BaseURL, projectID, branchName)
+
+		var gb giteaBranch
+		if _, err := c.doRequest(ctx, url, &gb); err != nil {
+			return nil, fmt.Errorf("failed to get branch %s (URL: %s): %w", branchName, url, err)
+		}
+
+		branch := c.convertBranch(gb, projectID, repo)
+		return &branch, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.Branch), nil
+}
+
+// GetMergeRequests retrieves all open pull requests for a repository (with pagination).
+func (c *Client) GetMergeRequests(ctx context.Context, projectID string) ([]domain.MergeRequest, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		var allPRs []domain.MergeRequest
+		page := 1
+
+		for {
+			url := fmt.Sprintf("%s/api/v1/repos/%s/pulls?state=open&sort=recentupdate&limit=%d&page=%d",
+				c.BaseURL, projectID, PageSize, page)
+
+			var prs []giteaPullRequest
+			if _, err := c.doRequest(ctx, url, &prs); err != nil {
+				return nil, fmt.Errorf("failed to get pull requests (page %d): %w", page, err)
+			}
+
+			for _, pr := range prs {
+				allPRs = append(allPRs, c.convertPullRequest(pr, projectID))
+			}
+
+			if len(prs) < PageSize {
+				break
+			}
+
+			page++
+		}
+
+		return allPRs, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.MergeRequest), nil
+}
+
+// GetMergedMergeRequests retrieves pull requests merged since the given time (with pagination).
+// Closed pull requests are listed most recently updated first, so paging stops once older ones are reached.
+func (c *Client) GetMergedMergeRequests(ctx context.Context, projectID string, since time.Time) ([]domain.MergeRequest, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		var allPRs []domain.MergeRequest
+		page := 1
+
+		for {
+			url := fmt.Sprintf("%s/api/v1/repos/%s/pulls?state=closed&sort=recentupdate&limit=%d&page=%d",
+				c.BaseURL, projectID, PageSize, page)
+
+			var prs []giteaPullRequest
+			if _, err := c.doRequest(ctx, url, &prs); err != nil {
+				return nil, fmt.Errorf("failed to get closed pull requests (page %d): %w", page, err)
+			}
+
+			reachedOlder := false
+			for _, pr := range prs {
+				// A PR merged since "since" was also updated since then
+				if pr.UpdatedAt.Before(since) {
+					reachedOlder = true
+					break
+				}
+				if pr.Merged && pr.MergedAt != nil && !pr.MergedAt.Before(since) {
+					mr := c.convertPullRequest(pr, projectID)
+					mr.State = "merged"
+					allPRs = append(allPRs, mr)
+				}
+			}
+
+			// Stop on the last page or once PRs updated before "since" are reached
+			if reachedOlder || len(prs) < PageSize {
+				break
+			}
+
+			page++
+		}
+
+		return allPRs, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.MergeRequest), nil
+}
+
+// GetIssues retrieves open issues for a repository.
+func (c *Client) GetIssues(ctx context.Context, projectID string) ([]domain.Issue, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		// type=issues excludes pull requests, which Gitea also lists as issues
+		url := fmt.Sprintf("%s/api/v1/repos/%s/issues?state=open&type=issues&limit=%d", c.BaseURL, projectID, PageSize)
+
+		var giteaIssues []giteaIssue
+		if _, err := c.doRequest(ctx, url, &giteaIssues); err != nil {
+			return nil, fmt.Errorf("failed to get issues: %w", err)
+		}
+
+		issues := make([]domain.Issue, 0, len(giteaIssues))
+		for _, issue := range giteaIssues {
+			issues = append(issues, c.convertIssue(issue, projectID))
+		}
+
+		return issues, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Issue), nil
+}
+
+// GetCurrentUser retrieves the authenticated user's profile.
+func (c *Client) GetCurrentUser(ctx context.Context) (*domain.UserProfile, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		url := fmt.Sprintf("%s/api/v1/user", c.BaseURL)
+
+		var user giteaUser
+		if _, err := c.doRequest(ctx, url, &user); err != nil {
+			return nil, fmt.Errorf("failed to get current user: %w", err)
+		}
+
+		webURL := user.HTMLURL
+		if webURL == "" {
+			webURL = fmt.Sprintf("%s/%s", c.BaseURL, user.Login)
+		}
+
+		profile := &domain.UserProfile{
+			Username:  user.Login,
+			Name:      user.FullName,
+			Email:     user.Email,
+			AvatarURL: user.AvatarURL,
+			WebURL:    webURL,
+			Platform:  domain.PlatformGitea,
+		}
+
+		return profile, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.UserProfile), nil
+}
+
+// doRequest performs an HTTP GET to the Gitea API and decodes the JSON response.
+// Returns the response headers for pagination info, and errNotFound for 404 responses.
+// Follows Single Level of Abstraction Principle (SLAP).
+func (c *Client) doRequest(ctx context.Context, url string, result interface{}) (http.Header, error) {
+	resp, err := c.DoWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "token "+c.Token)
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := api.DecodeJSON(resp, result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return resp.Header, nil
+}
+
+// convertProjects converts Gitea repositories to domain models.
+func (c *Client) convertProjects(repos []giteaRepository) []domain.Project {
+	projects := make([]domain.Project, 0, len(repos))
+	for _, repo := range repos {
+		project := domain.Project{
+			ID:            repo.FullName,
+			Name:          repo.Name,
+			WebURL:        repo.HTMLURL,
+			Platform:      domain.PlatformGitea,
+			IsFork:        repo.Fork,
+			DefaultBranch: repo.DefaultBranch,
+			LastActivity:  repo.UpdatedAt,
+		}
+
+		project.Owner = &domain.ProjectOwner{
+			Username: repo.Owner.Login,
+			Name:     repo.Owner.FullName,
+			Type:     "user",
+		}
+
+		project.Namespace = &domain.ProjectNamespace{
+			ID:   repo.Owner.Login,
+			Path: repo.Owner.Login,
+			Kind: "user",
+		}
+
+		if repo.Permissions != nil {
+			accessLevel := 10
+			if repo.Permissions.Push {
+				accessLevel = 30
+			}
+			if repo.Permissions.Admin {
+				accessLevel = 50
+			}
+
+			project.Permissions = &domain.ProjectPermissions{
+				AccessLevel: accessLevel,
+				Admin:       repo.Permissions.Admin,
+				Push:        repo.Permissions.Push,
+				Pull:        repo.Permissions.Pull,
+			}
+		}
+
+		projects = append(projects, project)
+	}
+	return projects
+}
+
+// convertPipeline converts an Actions run to domain model.
+func (c *Client) convertPipeline(run giteaRun, projectID string) *domain.Pipeline {
+	// Extract repository name from projectID (owner/repo)
+	repository := projectID
+	if _, name, found := strings.Cut(projectID, "/"); found {
+		repository = name
+	}
+
+	createdAt, updatedAt := run.times()
+
+	pipeline := &domain.Pipeline{
+		ID:         strconv.FormatInt(run.ID, 10),
+		ProjectID:  projectID,
+		Repository: repository,
+		Branch:     run.branch(),
+		CommitSHA:  run.commitSHA(),
+		Status:     convertStatus(run.Status, run.Conclusion),
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+		Duration:   updatedAt.Sub(createdAt),
+		WebURL:     run.HTMLURL,
+	}
+
+	// Workflow file name groups runs like GitHub workflows do
+	if workflow := run.workflow(); workflow != "" {
+		pipeline.WorkflowName = &workflow
+		pipeline.WorkflowID = &workflow
+	}
+
+	return pipeline
+}
+
+// convertJob converts an Actions job to domain Build.
+func (c *Client) convertJob(job giteaJob) domain.Build {
+	build := domain.Build{
+		ID:     strconv.FormatInt(job.ID, 10),
+		Name:   job.Name,
+		Status: convertStatus(job.Status, job.Conclusion),
+		WebURL: job.HTMLURL,
+	}
+
+	if job.StartedAt != nil {
+		build.StartedAt = *job.StartedAt
+		if job.CompletedAt != nil {
+			build.Duration = job.CompletedAt.Sub(*job.StartedAt)
+		}
+	}
+
+	return build
+}
+
+// convertBranch converts Gitea branch to domain model.
+func (c *Client) convertBranch(gb giteaBranch, projectID string, repo giteaRepository) domain.Branch {
+	// Gitea returns the username when the commit email belongs to an account
+	author := gb.Commit.Author.Username
+	if author == "" {
+		author = gb.Commit.Author.Name
+	}
+
+	webURL := ""
+	if repo.HTMLURL != "" {
+		webURL = fmt.Sprintf("%s/src/branch/%s", repo.HTMLURL, gb.Name)
+	}
+
+	return domain.Branch{
+		Name:           gb.Name,
+		ProjectID:      projectID,
+		Repository:     projectID,
+		LastCommitSHA:  gb.Commit.ID,
+		LastCommitMsg:  gb.Commit.Message,
+		LastCommitDate: gb.Commit.Timestamp,
+		CommitAuthor:   author,
+		AuthorEmail:    gb.Commit.Author.Email,
+		IsDefault:      repo.DefaultBranch != "" && gb.Name == repo.DefaultBranch,
+		IsProtected:    gb.Protected,
+		WebURL:         webURL,
+		Platform:       domain.PlatformGitea,
+	}
+}
+
+// convertPullRequest converts Gitea PR to domain MergeRequest.
+func (c *Client) convertPullRequest(pr giteaPullRequest, projectID string) domain.MergeRequest {
+	repoName := projectID
+	if _, name, found := strings.Cut(projectID, "/"); found {
+		repoName = name
+	}
+
+	var mergedAt time.Time
+	if pr.MergedAt != nil {
+		mergedAt = *pr.MergedAt
+	}
+
+	reviewers := make([]string, 0, len(pr.RequestedReviewers))
+	for _, reviewer := range pr.RequestedReviewers {
+		reviewers = append(reviewers, reviewer.Login)
+	}
+
+	return domain.MergeRequest{
+		ID:           strconv.Itoa(pr.Number),
+		Number:       pr.Number,
+		Title:        pr.Title,
+		Description:  pr.Body,
+		State:        pr.State,
+		IsDraft:      pr.Draft || isWorkInProgress(pr.Title),
+		SourceBranch: pr.Head.Ref,
+		TargetBranch: pr.Base.Ref,
+		Author:       pr.User.Login,
+		Reviewers:    reviewers,
+		CreatedAt:    pr.CreatedAt,
+		UpdatedAt:    pr.UpdatedAt,
+		MergedAt:     mergedAt,
+		WebURL:       pr.HTMLURL,
+		ProjectID:    projectID,
+		Repository:   repoName,
+	}
+}
+
+// isWorkInProgress reports whether a PR title carries one of Gitea's default WIP prefixes.
+// Older Gitea versions mark drafts only through the title.
+func isWorkInProgress(title string) bool {
+	lower := strings.ToLower(title)
+	for _, prefix := range []string{"wip:", "[wip]", "draft:", "[draft]"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertIssue converts Gitea issue to domain Issue.
+func (c *Client) convertIssue(issue giteaIssue, projectID string) domain.Issue {
+	repoName := projectID
+	if _, name, found := strings.Cut(projectID, "/"); found {
+		repoName = name
+	}
+
+	labels := make([]string, len(issue.Labels))
+	for i, label := range issue.Labels {
+		labels[i] = label.Name
+	}
+
+	assignee := ""
+	if issue.Assignee != nil {
+		assignee = issue.Assignee.Login
+	}
+
+	return domain.Issue{
+		ID:          strconv.Itoa(issue.Number),
+		Number:      issue.Number,
+		Title:       issue.Title,
+		Description: issue.Body,
+		State:       issue.State,
+		Labels:      labels,
+		Author:      issue.User.Login,
+		Assignee:    assignee,
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+		WebURL:      issue.HTMLURL,
+		ProjectID:   projectID,
+		Repository:  repoName,
+	}
+}
+
+// convertStatus converts Gitea and Forgejo run/job status to domain status.
+// Gitea reports GitHub-style status and conclusion; Forgejo reports a single status.
+func convertStatus(status, conclusion string) domain.Status {
+	if status == "completed" {
+		status = conclusion
+	}
+
+	switch status {
+	case "queued", "waiting", "blocked", "pending", "unknown":
+		return domain.StatusPending
+	case "in_progress", "running":
+		return domain.StatusRunning
+	case "success":
+		return domain.StatusSuccess
+	case "failure", "failed":
+		return domain.StatusFailed
+	case "cancelled", "canceled":
+		return domain.StatusCanceled
+	case "skipped":
+		return domain.StatusSkipped
+	default:
+		return domain.StatusFailed
+	}
+}
+
+// Gitea API response types
+type giteaRepository struct {
+	ID            int64             `json:"id"`
+	Name          string            `json:"name"`
+	FullName      string            `json:"full_name"`
+	HTMLURL       string            `json:"html_url"`
+	DefaultBranch string            `json:"default_branch"`
+	Fork          bool              `json:"fork"`
+	Owner         giteaUser         `json:"owner"`
+	Permissions   *giteaPermissions `json:"permissions"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+type giteaPermissions struct {
+	Admin bool `json:"admin"`
+	Push  bool `json:"push"`
+	Pull  bool `json:"pull"`
+}
+
+type giteaUser struct {
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+	HTMLURL   string `json:"html_url"`
+}
+
+type giteaBranch struct {
+	Name      string `json:"name"`
+	Protected bool   `json:"protected"`
+	Commit    struct {
+		ID        string    `json:"id"`
+		Message   string    `json:"message"`
+		Timestamp time.Time `json:"timestamp"`
+		Author    struct {
+			Name     string `json:"name"`
+			Email    string `json:"email"`
+			Username string `json:"username"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+type giteaRunsResponse struct {
+	TotalCount   int        `json:"total_count"`
+	WorkflowRuns []giteaRun `json:"workflow_runs"`
+}
+
+// giteaRun holds the fields of both Gitea (GitHub-style) and Forgejo Actions runs.
+type giteaRun struct {
+	ID      int64  `json:"id"`
+	Status  string `json:"status"`
+	HTMLURL string `json:"html_url"`
+
+	// Gitea fields
+	Path        string     `json:"path"` // e.g. "build.yml@refs/heads/main"
+	HeadBranch  string     `json:"head_branch"`
+	HeadSHA     string     `json:"head_sha"`
+	Conclusion  string     `json:"conclusion"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+
+	// Forgejo fields
+	WorkflowID string     `json:"workflow_id"` // workflow file name
+	PrettyRef  string     `json:"prettyref"`   // branch name
+	CommitSHA  string     `json:"commit_sha"`
+	Created    *time.Time `json:"created"`
+	Updated    *time.Time `json:"updated"`
+}
+
+// branch returns the branch the run was triggered for.
+func (r giteaRun) branch() string {
+	if r.HeadBranch != "" {
+		return r.HeadBranch
+	}
+	return r.PrettyRef
+}
+
+// commitSHA returns the commit the run ran on.
+func (r giteaRun) commitSHA() string {
+	if r.HeadSHA != "" {
+		return r.HeadSHA
+	}
+	return r.CommitSHA
+}
+
+// workflow returns the workflow file name of the run.
+func (r giteaRun) workflow() string {
+	if r.WorkflowID != "" {
+		return r.WorkflowID
+	}
+	workflow, _, _ := strings.Cut(r.Path, "@")
+	return workflow
+}
+
+// times returns when the run was created and last updated.
+// Running Gitea runs have no completion time yet, so they are treated as updated now.
+func (r giteaRun) times() (time.Time, time.Time) {
+	var createdAt, updatedAt time.Time
+	switch {
+	case r.Created != nil:
+		createdAt = *r.Created
+	case r.StartedAt != nil:
+		createdAt = *r.StartedAt
+	}
+
+	switch {
+	case r.Updated != nil:
+		updatedAt = *r.Updated
+	case r.CompletedAt != nil && !r.CompletedAt.IsZero():
+		updatedAt = *r.CompletedAt
+	case !createdAt.IsZero():
+		updatedAt = time.Now()
+	}
+
+	if updatedAt.Before(createdAt) {
+		updatedAt = createdAt
+	}
+	return createdAt, updatedAt
+}
+
+type giteaJobsResponse struct {
+	TotalCount int        `json:"total_count"`
+	Jobs       []giteaJob `json:"jobs"`
+}
+
+type giteaJob struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	HTMLURL     string     `json:"html_url"`
+}
+
+type giteaPullRequest struct {
+	Number             int         `json:"number"`
+	Title              string      `json:"title"`
+	Body               string      `json:"body"`
+	State              string      `json:"state"`
+	Draft              bool        `json:"draft"`
+	Merged             bool        `json:"merged"`
+	Head               giteaRef    `json:"head"`
+	Base               giteaRef    `json:"base"`
+	User               giteaUser   `json:"user"`
+	RequestedReviewers []giteaUser `json:"requested_reviewers"`
+	CreatedAt          time.Time   `json:"created_at"`
+	UpdatedAt          time.Time   `json:"updated_at"`
+	MergedAt           *time.Time  `json:"merged_at"`
+	HTMLURL            string      `json:"html_url"`
+}
+
+type giteaRef struct {
+	Ref string `json:"ref"`
+}
+
+type giteaIssue struct {
+	Number    int          `json:"number"`
+	Title     string       `json:"title"`
+	Body      string       `json:"body"`
+	State     string       `json:"state"`
+	Labels    []giteaLabel `json:"labels"`
+	User      giteaUser    `json:"user"`
+	Assignee  *giteaUser   `json:"assignee"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	HTMLURL   string       `json:"html_url"`
+}
+
+type giteaLabel struct {
+	Name string `json:"name"`
+}
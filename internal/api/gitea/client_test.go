@@ -0,0 +1,166 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// mockHTTPClient is a test double for HTTPClient.
+// Follows FIRST principles - tests are Fast and Independent.
+type mockHTTPClient struct {
+	doFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return m.doFunc(req)
+}
+
+// jsonResponse builds a 200 response with a JSON body.
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+// TestGetProjects tests retrieving repositories from Gitea.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetProjects(t *testing.T) {
+	// Arrange
+	responseBody := `[
+		{"id": 7, "name": "deploy", "full_name": "infra/deploy", "html_url": "https://git.example.com/infra/deploy",
+		 "default_branch": "main", "owner": {"login": "infra"}, "permissions": {"admin": false, "push": true, "pull": true}}
+	]`
+
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Authorization") != "token test-token" {
+				t.Errorf("expected token Authorization header, got %q", req.Header.Get("Authorization"))
+			}
+			if !strings.HasPrefix(req.URL.String(), "https://git.example.com/api/v1/user/repos") {
+				t.Errorf("unexpected URL %s", req.URL)
+			}
+
+			resp := jsonResponse(responseBody)
+			resp.Header.Set("X-Total-Count", "1")
+			return resp, nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{
+		BaseURL: "https://git.example.com/",
+		Token:   "test-token",
+	}, mockHTTP)
+
+	// Act
+	projects, err := client.GetProjects(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(projects) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(projects))
+	}
+
+	if projects[0].ID != "infra/deploy" {
+		t.Errorf("expected project ID 'infra/deploy', got '%s'", projects[0].ID)
+	}
+
+	if projects[0].Platform != domain.PlatformGitea {
+		t.Errorf("expected platform 'gitea', got '%s'", projects[0].Platform)
+	}
+
+	if projects[0].Permissions == nil || !projects[0].Permissions.Push {
+		t.Errorf("expected push permission, got %+v", projects[0].Permissions)
+	}
+}
+
+// TestGetLatestPipeline_Forgejo tests reading Forgejo-style runs on a server without the jobs API.
+func TestGetLatestPipeline_Forgejo(t *testing.T) {
+	// Arrange
+	runsBody := `{"total_count": 2, "workflow_runs": [
+		{"id": 12, "status": "running", "prettyref": "feature", "commit_sha": "bbb", "workflow_id": "ci.yml",
+		 "created": "2026-01-02T10:00:00Z", "updated": "2026-01-02T10:01:00Z"},
+		{"id": 11, "status": "failure", "prettyref": "main", "commit_sha": "aaa", "workflow_id": "ci.yml",
+		 "html_url": "https://git.example.com/infra/deploy/actions/runs/11",
+		 "created": "2026-01-02T09:00:00Z", "updated": "2026-01-02T09:05:00Z"}
+	]}`
+
+	jobRequests := 0
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "/jobs") {
+				jobRequests++
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("404"))}, nil
+			}
+			return jsonResponse(runsBody), nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{BaseURL: "https://git.example.com", Token: "test-token"}, mockHTTP)
+
+	// Act
+	pipeline, err := client.GetLatestPipeline(context.Background(), "infra/deploy", "main")
+	_, _ = client.GetLatestPipeline(context.Background(), "infra/deploy", "main")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pipeline == nil {
+		t.Fatal("expected pipeline, got nil")
+	}
+	if pipeline.ID != "11" || pipeline.Branch != "main" || pipeline.CommitSHA != "aaa" {
+		t.Errorf("expected run 11 on main at aaa, got %s on %s at %s", pipeline.ID, pipeline.Branch, pipeline.CommitSHA)
+	}
+	if pipeline.Status != domain.StatusFailed {
+		t.Errorf("expected status failed, got %s", pipeline.Status)
+	}
+	if pipeline.Duration.Minutes() != 5 {
+		t.Errorf("expected 5m duration, got %v", pipeline.Duration)
+	}
+	if jobRequests != 1 {
+		t.Errorf("expected jobs API to be tried once, got %d requests", jobRequests)
+	}
+}
+
+// TestConvertStatus tests status conversion for Gitea and Forgejo runs.
+func TestConvertStatus(t *testing.T) {
+	tests := []struct {
+		status     string
+		conclusion string
+		expected   domain.Status
+	}{
+		{"completed", "success", domain.StatusSuccess},
+		{"completed", "failure", domain.StatusFailed},
+		{"completed", "cancelled", domain.StatusCanceled},
+		{"in_progress", "", domain.StatusRunning},
+		{"queued", "", domain.StatusPending},
+		{"waiting", "", domain.StatusPending},
+		{"running", "", domain.StatusRunning},
+		{"success", "", domain.StatusSuccess},
+		{"skipped", "", domain.StatusSkipped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status+"/"+tt.conclusion, func(t *testing.T) {
+			// Act
+			result := convertStatus(tt.status, tt.conclusion)
+
+			// Assert
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
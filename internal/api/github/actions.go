@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// RetryPipeline re-runs all jobs of a workflow run.
+func (c *Client) RetryPipeline(ctx context.Context, projectID, pipelineID string) error {
+	actionURL := fmt.Sprintf("%s/repos/%s/actions/runs/%s/rerun", c.BaseURL, projectID, pipelineID)
+	if err := c.doAction(ctx, actionURL, nil); err != nil {
+		return fmt.Errorf("failed to re-run workflow run %s: %w", pipelineID, err)
+	}
+	return nil
+}
+
+// RerunFailedJobs re-runs the failed jobs of a workflow run and the jobs depending on them.
+func (c *Client) RerunFailedJobs(ctx context.Context, projectID, pipelineID string) error {
+	actionURL := fmt.Sprintf("%s/repos/%s/actions/runs/%s/rerun-failed-jobs", c.BaseURL, projectID, pipelineID)
+	if err := c.doAction(ctx, actionURL, nil); err != nil {
+		return fmt.Errorf("failed to re-run failed jobs of workflow run %s: %w", pipelineID, err)
+	}
+	return nil
+}
+
+// CancelPipeline cancels a workflow run.
+func (c *Client) CancelPipeline(ctx context.Context, projectID, pipelineID string) error {
+	actionURL := fmt.Sprintf("%s/repos/%s/actions/runs/%s/cancel", c.BaseURL, projectID, pipelineID)
+	if err := c.doAction(ctx, actionURL, nil); err != nil {
+		return fmt.Errorf("failed to cancel workflow run %s: %w", pipelineID, err)
+	}
+	return nil
+}
+
+// RunPipeline dispatches a workflow on a branch or tag.
+// The workflow must have a workflow_dispatch trigger.
+func (c *Client) RunPipeline(ctx context.Context, projectID, ref, workflowID string) error {
+	if workflowID == "" {
+		return fmt.Errorf("a workflow is required to run a GitHub Actions pipeline")
+	}
+
+	body, err := json.Marshal(map[string]string{"ref": ref})
+	if err != nil {
+		return err
+	}
+
+	actionURL := fmt.Sprintf("%s/repos/%s/actions/workflows/%s/dispatches", c.BaseURL, projectID, workflowID)
+	if err := c.doAction(ctx, actionURL, body); err != nil {
+		return fmt.Errorf("failed to dispatch workflow %s on %s: %w", workflowID, ref, err)
+	}
+	return nil
+}
+
+// doAction sends an authenticated POST request, accepting any 2xx response.
+func (c *Client) doAction(ctx context.Context, actionURL string, body []byte) error {
+	resp, err := c.DoWithRetry(ctx, c.newRequest(ctx, http.MethodPost, actionURL, body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+)
+
+// TestPipelineActions tests that write actions call the matching GitHub Actions endpoints.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestPipelineActions(t *testing.T) {
+	// Arrange
+	var requests []string
+	var dispatchBody string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.Path)
+			if strings.HasSuffix(req.URL.Path, "/dispatches") {
+				body, _ := io.ReadAll(req.Body)
+				dispatchBody = string(body)
+			}
+			resp := jsonResponse(`{}`)
+			resp.StatusCode = http.StatusAccepted
+			return resp, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "write-token"}, mockHTTP)
+	ctx := context.Background()
+
+	// Act
+	errs := []error{
+		client.RetryPipeline(ctx, "acme/api", "42"),
+		client.RerunFailedJobs(ctx, "acme/api", "42"),
+		client.CancelPipeline(ctx, "acme/api", "42"),
+		client.RunPipeline(ctx, "acme/api", "main", "7"),
+	}
+	missingWorkflowErr := client.RunPipeline(ctx, "acme/api", "main", "")
+
+	// Assert
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	expected := []string{
+		"POST /repos/acme/api/actions/runs/42/rerun",
+		"POST /repos/acme/api/actions/runs/42/rerun-failed-jobs",
+		"POST /repos/acme/api/actions/runs/42/cancel",
+		"POST /repos/acme/api/actions/workflows/7/dispatches",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+	if dispatchBody != `{"ref":"main"}` {
+		t.Errorf("expected dispatch on main, got %s", dispatchBody)
+	}
+	if missingWorkflowErr == nil {
+		t.Error("expected error when running without a workflow")
+	}
+}
@@ -0,0 +1,217 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+)
+
+const (
+	// appJWTLifetime is the lifetime of the JWTs authenticating as the app (GitHub allows at most 10 minutes).
+	appJWTLifetime = 9 * time.Minute
+
+	// appClockSkew backdates JWTs to tolerate clock drift between the dashboard and GitHub.
+	appClockSkew = time.Minute
+
+	// tokenRefreshMargin renews installation tokens this long before their 1-hour expiry.
+	tokenRefreshMargin = 5 * time.Minute
+)
+
+// AppConfig identifies a GitHub App and the installation whose repositories are read.
+type AppConfig struct {
+	AppID          string
+	PrivateKey     []byte // PEM-encoded RSA private key downloaded from the app settings
+	InstallationID string // optional when the app has exactly one installation
+}
+
+// AppTokenSource authenticates as a GitHub App and issues installation access tokens.
+// A JWT signed with the app's private key is exchanged for an installation token,
+// which is cached and renewed shortly before it expires.
+// Implements api.TokenSource.
+type AppTokenSource struct {
+	*api.BaseClient
+	appID          string
+	installationID string
+	key            *rsa.PrivateKey
+	now            func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewAppTokenSource creates a token source for a GitHub App installation.
+// baseURL is the REST API URL (https://api.github.com or https://HOST/api/v3).
+func NewAppTokenSource(baseURL string, config AppConfig, httpClient api.HTTPClient) (*AppTokenSource, error) {
+	if config.AppID == "" {
+		return nil, fmt.Errorf("GitHub App ID is required")
+	}
+
+	key, err := parsePrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub App private key: %w", err)
+	}
+
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+
+	return &AppTokenSource{
+		BaseClient:     api.NewBaseClient(strings.TrimSuffix(baseURL, "/"), "", httpClient),
+		appID:          config.AppID,
+		installationID: config.InstallationID,
+		key:            key,
+		now:            time.Now,
+	}, nil
+}
+
+// parsePrivateKey parses a PEM-encoded RSA key in PKCS#1 (GitHub's format) or PKCS#8 form.
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("key is not an RSA key")
+	}
+	return key, nil
+}
+
+// Token returns the current installation token, exchanging a new one when it is about to expire.
+func (s *AppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Add(tokenRefreshMargin).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	jwt, err := s.signJWT()
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+
+	if s.installationID == "" {
+		s.installationID, err = s.findInstallation(ctx, jwt)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var response struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	url := fmt.Sprintf("%s/app/installations/%s/access_tokens", s.BaseURL, s.installationID)
+	if err := s.doAppRequest(ctx, http.MethodPost, url, jwt, http.StatusCreated, &response); err != nil {
+		return "", fmt.Errorf("failed to create installation token: %w", err)
+	}
+
+	s.token = response.Token
+	s.expiresAt = response.ExpiresAt
+	return s.token, nil
+}
+
+// findInstallation returns the ID of the app's only installation.
+func (s *AppTokenSource) findInstallation(ctx context.Context, jwt string) (string, error) {
+	var installations []struct {
+		ID      int64 `json:"id"`
+		Account struct {
+			Login string `json:"login"`
+		} `json:"account"`
+	}
+	if err := s.doAppRequest(ctx, http.MethodGet, s.BaseURL+"/app/installations", jwt, http.StatusOK, &installations); err != nil {
+		return "", fmt.Errorf("failed to list GitHub App installations: %w", err)
+	}
+
+	switch len(installations) {
+	case 0:
+		return "", fmt.Errorf("GitHub App %s is not installed on any account", s.appID)
+	case 1:
+		return strconv.FormatInt(installations[0].ID, 10), nil
+	default:
+		accounts := make([]string, len(installations))
+		for i, installation := range installations {
+			accounts[i] = fmt.Sprintf("%s (%d)", installation.Account.Login, installation.ID)
+		}
+		return "", fmt.Errorf("GitHub App %s has %d installations, set the installation ID to one of: %s",
+			s.appID, len(installations), strings.Join(accounts, ", "))
+	}
+}
+
+// signJWT creates the RS256 JWT that authenticates as the app itself.
+func (s *AppTokenSource) signJWT() (string, error) {
+	now := s.now()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-appClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": s.appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// doAppRequest sends a request authenticated with the app JWT and decodes the JSON response.
+func (s *AppTokenSource) doAppRequest(ctx context.Context, method, url, jwt string, wantStatus int, result interface{}) error {
+	resp, err := s.DoWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+jwt)
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != wantStatus {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAppTokenSource_ExchangesAndRefreshesToken tests that installation tokens are cached and renewed before expiry.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestAppTokenSource_ExchangesAndRefreshesToken(t *testing.T) {
+	// Arrange
+	key, keyPEM := generateTestKey(t)
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	var jwts []string
+	exchanges := 0
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			jwts = append(jwts, strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+			switch req.URL.Path {
+			case "/app/installations":
+				return jsonResponse(`[{"id": 77, "account": {"login": "acme"}}]`), nil
+			case "/app/installations/77/access_tokens":
+				exchanges++
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Header:     make(http.Header),
+					Body: io.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"token": "ghs_%d", "expires_at": %q}`,
+						exchanges, now.Add(time.Hour).Format(time.RFC3339)))),
+				}, nil
+			}
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+			return jsonResponse(`{}`), nil
+		},
+	}
+
+	source, err := NewAppTokenSource("", AppConfig{AppID: "123", PrivateKey: keyPEM}, mockHTTP)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	source.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Act
+	first, _ := source.Token(ctx)
+	now = now.Add(50 * time.Minute)
+	cached, _ := source.Token(ctx)
+	now = now.Add(6 * time.Minute)
+	refreshed, err := source.Token(ctx)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if first != "ghs_1" || cached != "ghs_1" || refreshed != "ghs_2" {
+		t.Errorf("expected ghs_1, ghs_1, ghs_2, got %s, %s, %s", first, cached, refreshed)
+	}
+	if len(jwts) != 3 {
+		t.Fatalf("expected installation lookup and two exchanges, got %d requests", len(jwts))
+	}
+	verifyTestJWT(t, jwts[0], &key.PublicKey)
+}
+
+// TestAppTokenSource_RequiresInstallationID tests that an ambiguous installation is reported.
+func TestAppTokenSource_RequiresInstallationID(t *testing.T) {
+	// Arrange
+	_, keyPEM := generateTestKey(t)
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(`[{"id": 1, "account": {"login": "acme"}}, {"id": 2, "account": {"login": "globex"}}]`), nil
+		},
+	}
+	source, _ := NewAppTokenSource("https://github.example.com/api/v3", AppConfig{AppID: "123", PrivateKey: keyPEM}, mockHTTP)
+
+	// Act
+	_, err := source.Token(context.Background())
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "acme (1), globex (2)") {
+		t.Errorf("expected error listing installations, got %v", err)
+	}
+}
+
+// TestNewAppTokenSource_InvalidKey tests that a malformed private key is rejected up front.
+func TestNewAppTokenSource_InvalidKey(t *testing.T) {
+	// Act
+	_, err := NewAppTokenSource("", AppConfig{AppID: "123", PrivateKey: []byte("not a key")}, &mockHTTPClient{})
+
+	// Assert
+	if err == nil {
+		t.Error("expected error for invalid private key")
+	}
+}
+
+func generateTestKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func verifyTestJWT(t *testing.T, jwt string, publicKey *rsa.PublicKey) {
+	t.Helper()
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected JWT with 3 parts, got %q", jwt)
+	}
+
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("invalid JWT signature: %v", err)
+	}
+
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}
+	json.Unmarshal(payload, &claims)
+	if claims.Issuer != "123" || claims.ExpiresAt-claims.IssuedAt > int64(10*time.Minute/time.Second) {
+		t.Errorf("unexpected JWT claims: %+v", claims)
+	}
+}
@@ -0,0 +1,94 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MaxArtifactBytes is the largest artifact downloaded, zipped or unzipped.
+const MaxArtifactBytes = 20 * 1024 * 1024
+
+// listArtifacts returns the artifacts uploaded by a workflow run.
+func (c *Client) listArtifacts(ctx context.Context, projectID, runID string) ([]githubArtifact, error) {
+	artifactsURL := fmt.Sprintf("%s/repos/%s/actions/runs/%s/artifacts?per_page=100", c.BaseURL, projectID, runID)
+
+	var response githubArtifactsResponse
+	if err := c.doRequest(ctx, artifactsURL, &response); err != nil {
+		return nil, fmt.Errorf("failed to get artifacts of run %s: %w", runID, err)
+	}
+	return response.Artifacts, nil
+}
+
+// isReadableArtifact returns true if an artifact can still be downloaded, is small enough
+// and its name contains one of the given lowercase words.
+func isReadableArtifact(artifact githubArtifact, words ...string) bool {
+	if artifact.Expired || artifact.SizeInBytes > MaxArtifactBytes {
+		return false
+	}
+
+	name := strings.ToLower(artifact.Name)
+	for _, word := range words {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// downloadArtifact downloads an artifact and opens it as a zip archive.
+func (c *Client) downloadArtifact(ctx context.Context, projectID string, artifact githubArtifact) (*zip.Reader, error) {
+	// Redirects to a short-lived download URL, like job logs
+	downloadURL := fmt.Sprintf("%s/repos/%s/actions/artifacts/%d/zip", c.BaseURL, projectID, artifact.ID)
+	resp, err := c.DoWithRetry(ctx, c.logRequest(ctx, downloadURL))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxArtifactBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	if len(data) > MaxArtifactBytes {
+		return nil, fmt.Errorf("artifact is larger than %d bytes", MaxArtifactBytes)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	return archive, nil
+}
+
+// parseArtifactFile opens a file of an artifact and parses it, reading at most MaxArtifactBytes.
+func parseArtifactFile[T any](file *zip.File, parse func(io.Reader) (T, error)) (T, error) {
+	r, err := file.Open()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer r.Close()
+
+	return parse(io.LimitReader(r, MaxArtifactBytes))
+}
+
+type githubArtifactsResponse struct {
+	Artifacts []githubArtifact `json:"artifacts"`
+}
+
+type githubArtifact struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	SizeInBytes int64  `json:"size_in_bytes"`
+	Expired     bool   `json:"expired"`
+}
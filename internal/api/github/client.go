@@ -1,15 +1,14 @@
 package github
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"strconv"
+	"sort"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/vilaca/ci-dashboard/internal/api"
@@ -20,9 +19,7 @@ import (
 // Follows Single Responsibility Principle - only handles GitHub API communication.
 type Client struct {
 	*api.BaseClient
-	rateLimitMu       sync.RWMutex
-	rateLimitRemaining int
-	rateLimitReset     time.Time
+	tokenSource api.TokenSource // GitHub App installation tokens (nil = static Token)
 }
 
 // NewClient creates a new GitHub Actions client.
@@ -34,8 +31,8 @@ func NewClient(config api.ClientConfig, httpClient api.HTTPClient) *Client {
 	}
 
 	return &Client{
-		BaseClient:         api.NewBaseClient(baseURL, config.Token, httpClient),
-		rateLimitRemaining: -1, // -1 means "not yet known"
+		BaseClient:  api.NewBaseClient(baseURL, config.Token, httpClient),
+		tokenSource: config.TokenSource,
 	}
 }
 
@@ -72,27 +69,24 @@ func (c *Client) GetProjects(ctx context.Context) ([]domain.Project, error) {
 // GetProjectsPage fetches a single page of projects.
 // GetProjectCount returns the total number of repositories for the authenticated user.
 func (c *Client) GetProjectCount(ctx context.Context) (int, error) {
+	if c.tokenSource != nil {
+		var response githubInstallationRepositories
+		if err := c.doRequest(ctx, fmt.Sprintf("%s/installation/repositories?per_page=1", c.BaseURL), &response); err != nil {
+			return 0, fmt.Errorf("failed to get installation repositories: %w", err)
+		}
+		return response.TotalCount, nil
+	}
+
 	// Use search API to get total count
 	// Search for all repos owned by the authenticated user
 	url := fmt.Sprintf("%s/user/repos?per_page=1&page=1", c.BaseURL)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.DoWithRetry(ctx, c.getRequest(ctx, url))
 	if err != nil {
-		return 0, fmt.Errorf("request failed: %w", err)
+		return 0, err
 	}
 	defer resp.Body.Close()
 
-	c.updateRateLimit(resp.Header)
-
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		return 0, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
@@ -135,25 +129,18 @@ func (c *Client) GetProjectCount(ctx context.Context) (int, error) {
 }
 
 func (c *Client) GetProjectsPage(ctx context.Context, page int) ([]domain.Project, bool, error) {
-	// Sort by last push time - most recently updated first
-	url := fmt.Sprintf("%s/user/repos?per_page=%d&page=%d&sort=pushed&direction=desc", c.BaseURL, api.DefaultPageSize, page)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	if c.tokenSource != nil {
+		return c.getInstallationProjectsPage(ctx, page)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	// Sort by last push time - most recently updated first
+	url := fmt.Sprintf("%s/user/repos?per_page=%d&page=%d&sort=pushed&direction=desc", c.BaseURL, api.DefaultPageSize, page)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.DoWithRetry(ctx, c.getRequest(ctx, url))
 	if err != nil {
-		return nil, false, fmt.Errorf("request failed: %w", err)
+		return nil, false, err
 	}
 
-	c.updateRateLimit(resp.Header)
-
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
@@ -162,7 +149,7 @@ func (c *Client) GetProjectsPage(ctx context.Context, page int) ([]domain.Projec
 
 	// Decode this page's repositories
 	var ghRepos []githubRepository
-	if err := json.NewDecoder(resp.Body).Decode(&ghRepos); err != nil {
+	if err := api.DecodeJSON(resp, &ghRepos); err != nil {
 		resp.Body.Close()
 		return nil, false, fmt.Errorf("failed to decode response: %w", err)
 	}
@@ -188,6 +175,20 @@ func (c *Client) GetProjectsPage(ctx context.Context, page int) ([]domain.Projec
 	return pageProjects, hasNextPage, nil
 }
 
+// getInstallationProjectsPage fetches a page of the repositories a GitHub App installation can access.
+// Installation tokens can't list /user/repos, which belongs to a user.
+func (c *Client) getInstallationProjectsPage(ctx context.Context, page int) ([]domain.Project, bool, error) {
+	url := fmt.Sprintf("%s/installation/repositories?per_page=%d&page=%d", c.BaseURL, api.DefaultPageSize, page)
+
+	var response githubInstallationRepositories
+	if err := c.doRequest(ctx, url, &response); err != nil {
+		return nil, false, fmt.Errorf("failed to get installation repositories (page %d): %w", page, err)
+	}
+
+	hasNextPage := page*api.DefaultPageSize < response.TotalCount && len(response.Repositories) > 0
+	return c.convertProjects(response.Repositories), hasNextPage, nil
+}
+
 // GetLatestPipeline retrieves the most recent workflow run for a repository and branch.
 func (c *Client) GetLatestPipeline(ctx context.Context, projectID, branch string) (*domain.Pipeline, error) {
 	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
@@ -203,7 +204,17 @@ func (c *Client) GetLatestPipeline(ctx context.Context, projectID, branch string
 			return (*domain.Pipeline)(nil), nil
 		}
 
-		return c.convertPipeline(response.WorkflowRuns[0], projectID), nil
+		pipeline := c.convertPipeline(response.WorkflowRuns[0], projectID)
+
+		// Jobs are best-effort - a run without job breakdown is still useful
+		builds, err := c.fetchPipelineJobs(ctx, projectID, pipeline.ID)
+		if err != nil {
+			log.Printf("[GitHub] Failed to get jobs for run %s of %s: %v", pipeline.ID, projectID, err)
+		} else {
+			pipeline.Builds = builds
+		}
+
+		return pipeline, nil
 	})
 
 	if err != nil {
@@ -236,6 +247,53 @@ func (c *Client) GetPipelines(ctx context.Context, projectID string, limit int)
 	return result.([]domain.Pipeline), nil
 }
 
+// GetPipelineJobs retrieves the jobs of a workflow run.
+func (c *Client) GetPipelineJobs(ctx context.Context, projectID, pipelineID string) ([]domain.Build, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		return c.fetchPipelineJobs(ctx, projectID, pipelineID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Build), nil
+}
+
+// fetchPipelineJobs fetches workflow run jobs without acquiring the rate limit semaphore.
+// Used by methods that already hold a semaphore slot.
+func (c *Client) fetchPipelineJobs(ctx context.Context, projectID, pipelineID string) ([]domain.Build, error) {
+	var jobs []githubJob
+	for page := 1; ; page++ {
+		// filter=all includes jobs of earlier run attempts, so re-run jobs can be detected
+		url := fmt.Sprintf("%s/repos/%s/actions/runs/%s/jobs?filter=all&per_page=%d&page=%d",
+			c.BaseURL, projectID, pipelineID, api.DefaultPageSize, page)
+
+		var response githubJobsResponse
+		if err := c.doRequest(ctx, url, &response); err != nil {
+			return nil, fmt.Errorf("failed to get workflow jobs (URL: %s): %w", url, err)
+		}
+		jobs = append(jobs, response.Jobs...)
+
+		// If we got fewer results than per_page, we're on the last page
+		if len(response.Jobs) < api.DefaultPageSize || len(jobs) >= response.TotalCount {
+			break
+		}
+	}
+
+	// Earlier attempts first, so later attempts supersede them
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].RunAttempt < jobs[j].RunAttempt
+	})
+
+	builds := make([]domain.Build, len(jobs))
+	for i, job := range jobs {
+		builds[i] = c.convertJob(job)
+	}
+	domain.MarkRetriedBuilds(builds)
+
+	return builds, nil
+}
+
 // GetWorkflowRuns retrieves runs for a specific workflow.
 func (c *Client) GetWorkflowRuns(ctx context.Context, projectID string, workflowID string, limit int) ([]domain.Pipeline, error) {
 	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
@@ -358,193 +416,72 @@ func (c *Client) GetBranch(ctx context.Context, projectID, branchName string) (*
 	return result.(*domain.Branch), nil
 }
 
-// doRequest performs an HTTP request to GitHub API with rate limit handling.
+// doRequest performs an HTTP request to GitHub API.
+// Retries and rate limits are handled by api.BaseClient.DoWithRetry.
 // Follows Single Level of Abstraction Principle (SLAP).
 func (c *Client) doRequest(ctx context.Context, url string, result interface{}) error {
-	return c.doRequestWithRetry(ctx, url, result, api.MaxRetryAttempts)
-}
-
-// doRequestWithRetry performs an HTTP request with exponential backoff retry.
-func (c *Client) doRequestWithRetry(ctx context.Context, url string, result interface{}, maxRetries int) error {
-	// Check if rate limit is exhausted before making request
-	if err := c.waitForRateLimit(ctx); err != nil {
+	resp, err := c.DoWithRetry(ctx, c.getRequest(ctx, url))
+	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
-	var lastErr error
-
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		if attempt > 0 {
-			// Exponential backoff: 2s, 4s, 8s
-			backoff := time.Duration(1<<uint(attempt)) * time.Second
-			log.Printf("GitHub API: Retrying in %v (attempt %d/%d)", backoff, attempt, maxRetries)
-
-			select {
-			case <-time.After(backoff):
-			case <-ctx.Done():
-				return ctx.Err()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+			if remaining, reset := c.RateLimit(); remaining == 0 {
+				return fmt.Errorf("GitHub API rate limit exceeded (resets at %v): %s", reset.Format("15:04:05"), string(body))
 			}
 		}
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-		if err != nil {
-			return fmt.Errorf("failed to create request: %w", err)
-		}
-
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
-		req.Header.Set("Accept", "application/vnd.github+json")
-		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if err := api.DecodeJSON(resp, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
 
-		resp, err := c.HTTPClient.Do(req)
-		if err != nil {
-			lastErr = fmt.Errorf("request failed: %w", err)
-			continue
-		}
-
-		// Update rate limit tracking and log status
-		c.updateRateLimit(resp.Header)
-
-		// Handle rate limit errors (403)
-		if resp.StatusCode == http.StatusForbidden {
-			body, _ := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			bodyStr := string(body)
-
-			// Check if this is a rate limit error
-			if strings.Contains(bodyStr, "rate limit") || strings.Contains(bodyStr, "API rate limit exceeded") {
-				resetTime := c.getRateLimitReset(resp.Header)
-				if !resetTime.IsZero() {
-					waitDuration := time.Until(resetTime)
-					if waitDuration > 0 && waitDuration < 10*time.Minute {
-						log.Printf("GitHub API: Rate limit exceeded. Waiting until %v (%v)", resetTime.Format("15:04:05"), waitDuration.Round(time.Second))
-
-						select {
-						case <-time.After(waitDuration + time.Second):
-							// Retry after rate limit resets (don't count against retry attempts)
-							attempt--
-							continue
-						case <-ctx.Done():
-							return ctx.Err()
-						}
-					}
-				}
-				// Rate limit with no reset time or reset time too far in future - fail immediately
-				return fmt.Errorf("GitHub API rate limit exceeded (resets at %v): %s", resetTime.Format("15:04:05"), bodyStr)
-			}
+	return nil
+}
 
-			return fmt.Errorf("API returned status %d: %s", resp.StatusCode, bodyStr)
-		}
+// getRequest returns a builder of authenticated GET requests to the GitHub API, one per attempt.
+func (c *Client) getRequest(ctx context.Context, url string) func() (*http.Request, error) {
+	return c.newRequest(ctx, http.MethodGet, url, nil)
+}
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+// newRequest returns a builder of authenticated requests to the GitHub API with an optional JSON body, one per attempt.
+func (c *Client) newRequest(ctx context.Context, method, url string, body []byte) func() (*http.Request, error) {
+	return func() (*http.Request, error) {
+		token, err := c.token(ctx)
+		if err != nil {
+			return nil, err
 		}
 
-		// Success - decode and return
-		err = json.NewDecoder(resp.Body).Decode(result)
-		resp.Body.Close()
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 		if err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
+			return nil, err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
 		}
 
-		return nil
-	}
-
-	if lastErr != nil {
-		return fmt.Errorf("request failed after %d retries: %w", maxRetries, lastErr)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		return req, nil
 	}
-	return fmt.Errorf("request failed after %d retries", maxRetries)
 }
 
-// getRateLimitReset extracts the rate limit reset time from headers.
-func (c *Client) getRateLimitReset(headers http.Header) time.Time {
-	reset := headers.Get("X-RateLimit-Reset")
-	if reset == "" {
-		return time.Time{}
+// token returns the token to authenticate requests with.
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.tokenSource == nil {
+		return c.Token, nil
 	}
 
-	resetUnix, err := strconv.ParseInt(reset, 10, 64)
+	token, err := c.tokenSource.Token(ctx)
 	if err != nil {
-		return time.Time{}
-	}
-
-	return time.Unix(resetUnix, 0)
-}
-
-// waitForRateLimit blocks if rate limit is exhausted, waiting until reset time.
-func (c *Client) waitForRateLimit(ctx context.Context) error {
-	c.rateLimitMu.RLock()
-	remaining := c.rateLimitRemaining
-	resetTime := c.rateLimitReset
-	c.rateLimitMu.RUnlock()
-
-	// If we haven't checked rate limit yet (-1) or have requests remaining, proceed
-	// Also proceed if reset time is unknown (shouldn't happen but defensive)
-	if remaining < 0 || remaining > 0 || resetTime.IsZero() {
-		return nil
-	}
-
-	// Rate limit exhausted - calculate wait time
-	waitDuration := time.Until(resetTime)
-	if waitDuration <= 0 {
-		// Reset time has passed, proceed
-		return nil
-	}
-
-	log.Printf("GitHub API: Rate limit exhausted (0 requests remaining). Waiting %v until reset at %v",
-		waitDuration.Round(time.Second), resetTime.Format("15:04:05"))
-
-	// Wait until reset time or context cancellation
-	select {
-	case <-time.After(waitDuration):
-		log.Printf("GitHub API: Rate limit reset, resuming requests")
-		return nil
-	case <-ctx.Done():
-		return fmt.Errorf("context cancelled while waiting for rate limit reset: %w", ctx.Err())
-	}
-}
-
-// updateRateLimit updates the rate limit state from response headers and logs warnings.
-func (c *Client) updateRateLimit(headers http.Header) {
-	limit := headers.Get("X-RateLimit-Limit")
-	remaining := headers.Get("X-RateLimit-Remaining")
-	reset := headers.Get("X-RateLimit-Reset")
-
-	if limit == "" || remaining == "" || reset == "" {
-		return
-	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		return
-	}
-
-	remainingInt, err := strconv.Atoi(remaining)
-	if err != nil {
-		return
-	}
-
-	resetUnix, err := strconv.ParseInt(reset, 10, 64)
-	if err != nil {
-		return
-	}
-	resetTime := time.Unix(resetUnix, 0)
-
-	// Update stored rate limit state
-	c.rateLimitMu.Lock()
-	c.rateLimitRemaining = remainingInt
-	c.rateLimitReset = resetTime
-	c.rateLimitMu.Unlock()
-
-	// Log warning when below 5% of rate limit (but not at 0 - that will trigger blocking message)
-	if limitInt > 0 && remainingInt > 0 && remainingInt < limitInt/20 {
-		log.Printf("GitHub API: Rate limit warning - %d/%d requests remaining (resets at %v)",
-			remainingInt, limitInt, resetTime.Format("15:04:05"))
-	} else if remainingInt == 0 {
-		log.Printf("GitHub API: Rate limit exhausted - further requests will block until %v",
-			resetTime.Format("15:04:05"))
+		return "", fmt.Errorf("failed to get installation token: %w", err)
 	}
+	return token, nil
 }
 
 // convertProjects converts GitHub repositories to domain models.
@@ -619,6 +556,7 @@ func (c *Client) convertPipeline(run githubWorkflowRun, projectID string) *domai
 		ProjectID:    projectID,
 		Repository:   repository,
 		Branch:       run.HeadBranch,
+		CommitSHA:    run.HeadSHA,
 		Status:       convertStatus(run.Status, run.Conclusion),
 		CreatedAt:    run.CreatedAt,
 		UpdatedAt:    run.UpdatedAt,
@@ -654,6 +592,31 @@ func convertStatus(status, conclusion string) domain.Status {
 	}
 }
 
+// convertJob converts a GitHub workflow job to domain Build.
+// GitHub Actions has no stages, so the workflow name is used as the stage.
+func (c *Client) convertJob(job githubJob) domain.Build {
+	build := domain.Build{
+		ID:     fmt.Sprintf("%d", job.ID),
+		Name:   job.Name,
+		Status: convertStatus(job.Status, job.Conclusion),
+		Stage:  job.WorkflowName,
+		WebURL: job.HTMLURL,
+	}
+
+	if job.StartedAt != nil {
+		build.StartedAt = *job.StartedAt
+		if job.CompletedAt != nil {
+			build.Duration = job.CompletedAt.Sub(*job.StartedAt)
+		}
+		// Jobs are created when they are queued, once their needs finished
+		if job.CreatedAt != nil && job.StartedAt.After(*job.CreatedAt) {
+			build.Queued = job.StartedAt.Sub(*job.CreatedAt)
+		}
+	}
+
+	return build
+}
+
 // convertBranch converts GitHub branch to domain model.
 func (c *Client) convertBranch(ghb githubBranch, projectID string, commit *githubCommit, isDefault bool) domain.Branch {
 	// Parse owner/repo to construct branch URL
@@ -697,6 +660,11 @@ type githubRepository struct {
 	UpdatedAt     time.Time            `json:"updated_at"`
 }
 
+type githubInstallationRepositories struct {
+	TotalCount   int                `json:"total_count"`
+	Repositories []githubRepository `json:"repositories"`
+}
+
 type githubPermissions struct {
 	Admin    bool `json:"admin"`
 	Maintain bool `json:"maintain"`
@@ -714,6 +682,8 @@ type githubWorkflowRun struct {
 	Name       string    `json:"name"`
 	WorkflowID int       `json:"workflow_id"`
 	HeadBranch string    `json:"head_branch"`
+	HeadSHA    string    `json:"head_sha"`
+	Path       string    `json:"path"` // workflow file, e.g. .github/workflows/ci.yml
 	Status     string    `json:"status"`
 	Conclusion string    `json:"conclusion"`
 	HTMLURL    string    `json:"html_url"`
@@ -721,6 +691,24 @@ type githubWorkflowRun struct {
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+type githubJobsResponse struct {
+	TotalCount int         `json:"total_count"`
+	Jobs       []githubJob `json:"jobs"`
+}
+
+type githubJob struct {
+	ID           int        `json:"id"`
+	Name         string     `json:"name"`
+	WorkflowName string     `json:"workflow_name"`
+	Status       string     `json:"status"`
+	Conclusion   string     `json:"conclusion"`
+	RunAttempt   int        `json:"run_attempt"`
+	CreatedAt    *time.Time `json:"created_at"`
+	StartedAt    *time.Time `json:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	HTMLURL      string     `json:"html_url"`
+}
+
 type githubBranch struct {
 	Name      string `json:"name"`
 	Protected bool   `json:"protected"`
@@ -786,6 +774,55 @@ func (c *Client) GetMergeRequests(ctx context.Context, projectID string) ([]doma
 	return result.([]domain.MergeRequest), nil
 }
 
+// GetMergedMergeRequests retrieves pull requests merged since the given time (with pagination).
+// Closed pull requests are listed most recently updated first, so paging stops once older ones are reached.
+func (c *Client) GetMergedMergeRequests(ctx context.Context, projectID string, since time.Time) ([]domain.MergeRequest, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		// projectID format: "owner/repo"
+		var allPRs []domain.MergeRequest
+		page := 1
+		perPage := 100
+
+		for {
+			url := fmt.Sprintf("%s/repos/%s/pulls?state=closed&per_page=%d&page=%d&sort=updated&direction=desc",
+				c.BaseURL, projectID, perPage, page)
+
+			var ghPRs []githubPullRequest
+			if err := c.doRequest(ctx, url, &ghPRs); err != nil {
+				return nil, fmt.Errorf("failed to get closed pull requests (page %d): %w", page, err)
+			}
+
+			reachedOlder := false
+			for _, pr := range ghPRs {
+				// A PR merged since "since" was also updated since then
+				if pr.UpdatedAt.Before(since) {
+					reachedOlder = true
+					break
+				}
+				if pr.MergedAt != nil && !pr.MergedAt.Before(since) {
+					mr := c.convertPullRequest(pr, projectID)
+					mr.State = "merged"
+					allPRs = append(allPRs, mr)
+				}
+			}
+
+			// Stop on the last page or once PRs updated before "since" are reached
+			if reachedOlder || len(ghPRs) < perPage {
+				break
+			}
+
+			page++
+		}
+
+		return allPRs, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.MergeRequest), nil
+}
+
 // GetIssues retrieves open issues for a repository.
 func (c *Client) GetIssues(ctx context.Context, projectID string) ([]domain.Issue, error) {
 	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
@@ -816,6 +853,10 @@ func (c *Client) GetIssues(ctx context.Context, projectID string) ([]domain.Issu
 
 // GetCurrentUser retrieves the authenticated user's profile.
 func (c *Client) GetCurrentUser(ctx context.Context) (*domain.UserProfile, error) {
+	if c.tokenSource != nil {
+		return nil, fmt.Errorf("GitHub App installations have no user profile")
+	}
+
 	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
 		url := fmt.Sprintf("%s/user", c.BaseURL)
 
@@ -841,6 +882,98 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*domain.UserProfile, error
 	return result.(*domain.UserProfile), nil
 }
 
+// MaxEventPages is the number of pages of 100 events read per repository (GitHub serves at most 300 events).
+const MaxEventPages = 3
+
+// GetEvents retrieves repository activity events created after since.
+// The repository events API returns newest first, so paging stops once older events are reached.
+func (c *Client) GetEvents(ctx context.Context, projectID string, since time.Time) ([]domain.Event, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		events := []domain.Event{}
+
+		for page := 1; page <= MaxEventPages; page++ {
+			// projectID format: "owner/repo"
+			url := fmt.Sprintf("%s/repos/%s/events?per_page=%d&page=%d", c.BaseURL, projectID, api.DefaultPageSize, page)
+
+			var ghEvents []githubEvent
+			if err := c.doRequest(ctx, url, &ghEvents); err != nil {
+				return nil, fmt.Errorf("failed to get events (URL: %s): %w", url, err)
+			}
+
+			reachedSince := false
+			for _, ghEvent := range ghEvents {
+				if !ghEvent.CreatedAt.After(since) {
+					reachedSince = true
+					break
+				}
+				if event, ok := c.convertEvent(ghEvent, projectID); ok {
+					events = append(events, event)
+				}
+			}
+
+			if reachedSince || len(ghEvents) < api.DefaultPageSize {
+				break
+			}
+		}
+
+		return events, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Event), nil
+}
+
+// convertEvent converts a GitHub repository event to domain Event.
+// Returns false for event types that don't affect cached dashboard data.
+func (c *Client) convertEvent(ghEvent githubEvent, projectID string) (domain.Event, bool) {
+	event := domain.Event{
+		ID:        ghEvent.ID,
+		ProjectID: projectID,
+		CreatedAt: ghEvent.CreatedAt,
+		Author:    ghEvent.Actor.Login,
+	}
+
+	switch ghEvent.Type {
+	case "PushEvent":
+		event.Type = "push"
+		event.TargetType = "branch"
+		if strings.HasPrefix(ghEvent.Payload.Ref, "refs/tags/") {
+			event.TargetType = "tag"
+		}
+		event.TargetID = strings.TrimPrefix(strings.TrimPrefix(ghEvent.Payload.Ref, "refs/heads/"), "refs/tags/")
+		event.ActionName = "updated"
+
+	case "CreateEvent", "DeleteEvent":
+		// Repository creation events have no ref
+		if ghEvent.Payload.RefType != "branch" && ghEvent.Payload.RefType != "tag" {
+			return event, false
+		}
+		event.Type = "push"
+		event.TargetType = ghEvent.Payload.RefType
+		event.TargetID = ghEvent.Payload.Ref
+		event.ActionName = "created"
+		if ghEvent.Type == "DeleteEvent" {
+			event.ActionName = "deleted"
+		}
+
+	case "PullRequestEvent":
+		event.Type = "merge_request"
+		event.TargetType = "merge_request"
+		event.TargetID = fmt.Sprintf("%d", ghEvent.Payload.Number)
+		event.ActionName = ghEvent.Payload.Action
+		if ghEvent.Payload.Action == "closed" && ghEvent.Payload.PullRequest != nil && ghEvent.Payload.PullRequest.Merged {
+			event.ActionName = "merged"
+		}
+
+	default:
+		return event, false
+	}
+
+	return event, true
+}
+
 // convertPullRequest converts GitHub PR to domain MergeRequest.
 func (c *Client) convertPullRequest(pr githubPullRequest, projectID string) domain.MergeRequest {
 	parts := strings.Split(projectID, "/")
@@ -849,6 +982,19 @@ func (c *Client) convertPullRequest(pr githubPullRequest, projectID string) doma
 		repoName = parts[1]
 	}
 
+	var mergedAt time.Time
+	if pr.MergedAt != nil {
+		mergedAt = *pr.MergedAt
+	}
+
+	reviewers := make([]string, 0, len(pr.RequestedReviewers)+len(pr.RequestedTeams))
+	for _, reviewer := range pr.RequestedReviewers {
+		reviewers = append(reviewers, reviewer.Login)
+	}
+	for _, team := range pr.RequestedTeams {
+		reviewers = append(reviewers, team.Slug)
+	}
+
 	return domain.MergeRequest{
 		ID:           fmt.Sprintf("%d", pr.Number),
 		Number:       pr.Number,
@@ -859,8 +1005,10 @@ func (c *Client) convertPullRequest(pr githubPullRequest, projectID string) doma
 		SourceBranch: pr.Head.Ref,
 		TargetBranch: pr.Base.Ref,
 		Author:       pr.User.Login,
+		Reviewers:    reviewers,
 		CreatedAt:    pr.CreatedAt,
 		UpdatedAt:    pr.UpdatedAt,
+		MergedAt:     mergedAt,
 		WebURL:       pr.HTMLURL,
 		ProjectID:    projectID,
 		Repository:   repoName,
@@ -914,7 +1062,11 @@ type githubPullRequest struct {
 	User      githubUser `json:"user"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
+	MergedAt  *time.Time `json:"merged_at"`
 	HTMLURL   string     `json:"html_url"`
+
+	RequestedReviewers []githubUser `json:"requested_reviewers"`
+	RequestedTeams     []githubTeam `json:"requested_teams"`
 }
 
 // GitHub Issue type
@@ -946,6 +1098,11 @@ type githubUser struct {
 	HTMLURL   string `json:"html_url"`
 }
 
+// GitHub Team type
+type githubTeam struct {
+	Slug string `json:"slug"`
+}
+
 // GitHub Label type
 type githubLabel struct {
 	Name string `json:"name"`
@@ -955,3 +1112,26 @@ type githubLabel struct {
 type githubPRRef struct {
 	URL string `json:"url"`
 }
+
+// GitHub Event type
+type githubEvent struct {
+	ID        string             `json:"id"`
+	Type      string             `json:"type"`
+	Actor     githubUser         `json:"actor"`
+	Payload   githubEventPayload `json:"payload"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// GitHub event payload (only the fields used for cache invalidation)
+type githubEventPayload struct {
+	Ref         string                  `json:"ref"`
+	RefType     string                  `json:"ref_type"` // CreateEvent/DeleteEvent: "branch", "tag" or "repository"
+	Action      string                  `json:"action"`
+	Number      int                     `json:"number"`
+	PullRequest *githubEventPullRequest `json:"pull_request"`
+}
+
+// GitHub pull request summary embedded in PullRequestEvent payloads
+type githubEventPullRequest struct {
+	Merged bool `json:"merged"`
+}
@@ -0,0 +1,130 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+)
+
+// mockHTTPClient is a test double for HTTPClient.
+// Follows FIRST principles - tests are Fast and Independent.
+type mockHTTPClient struct {
+	doFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return m.doFunc(req)
+}
+
+// jsonResponse builds a 200 response with a JSON body.
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+// TestGetEvents_Paging tests that events are paged until events older than since are reached.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetEvents_Paging(t *testing.T) {
+	// Arrange
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	event := func(id int, createdAt time.Time) string {
+		return fmt.Sprintf(`{"id": "%d", "type": "PushEvent", "actor": {"login": "alice"}, "payload": {"ref": "refs/heads/main"}, "created_at": %q}`,
+			id, createdAt.Format(time.RFC3339))
+	}
+
+	// A full first page after since, then a second page reaching past it
+	var firstPage []string
+	for i := 0; i < api.DefaultPageSize; i++ {
+		firstPage = append(firstPage, event(1000-i, since.Add(time.Duration(1000-i)*time.Minute)))
+	}
+	secondPage := []string{event(1, since.Add(time.Minute)), event(0, since.Add(-time.Minute))}
+
+	var pages []string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			page := req.URL.Query().Get("page")
+			pages = append(pages, page)
+			switch page {
+			case "1":
+				return jsonResponse("[" + strings.Join(firstPage, ",") + "]"), nil
+			case "2":
+				return jsonResponse("[" + strings.Join(secondPage, ",") + "]"), nil
+			}
+			t.Fatalf("unexpected request %s", req.URL)
+			return nil, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, mockHTTP)
+
+	// Act
+	events, err := client.GetEvents(context.Background(), "acme/api", since)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pages) != 2 {
+		t.Errorf("expected 2 pages to be read, got %v", pages)
+	}
+	if len(events) != api.DefaultPageSize+1 {
+		t.Fatalf("expected %d events after since, got %d", api.DefaultPageSize+1, len(events))
+	}
+	if last := events[len(events)-1]; last.ID != "1" || last.TargetID != "main" {
+		t.Errorf("expected the oldest event to be push 1 to main, got %+v", last)
+	}
+}
+
+// TestGetPipelineJobs_Paging tests that the jobs of runs with more jobs than a page are read page by page.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetPipelineJobs_Paging(t *testing.T) {
+	// Arrange
+	total := api.DefaultPageSize + 1
+	job := func(id int) string {
+		return fmt.Sprintf(`{"id": %d, "name": "job-%d", "status": "completed", "conclusion": "success", "run_attempt": 1}`, id, id)
+	}
+	var firstPage []string
+	for i := 0; i < api.DefaultPageSize; i++ {
+		firstPage = append(firstPage, job(i))
+	}
+
+	var pages []string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			page := req.URL.Query().Get("page")
+			pages = append(pages, page)
+			switch page {
+			case "1":
+				return jsonResponse(fmt.Sprintf(`{"total_count": %d, "jobs": [%s]}`, total, strings.Join(firstPage, ","))), nil
+			case "2":
+				return jsonResponse(fmt.Sprintf(`{"total_count": %d, "jobs": [%s]}`, total, job(total-1))), nil
+			}
+			t.Fatalf("unexpected request %s", req.URL)
+			return nil, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, mockHTTP)
+
+	// Act
+	builds, err := client.GetPipelineJobs(context.Background(), "acme/api", "42")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(builds) != total {
+		t.Errorf("expected %d builds, got %d", total, len(builds))
+	}
+	if strings.Join(pages, ",") != "1,2" {
+		t.Errorf("expected pages 1 and 2 to be read, got %v", pages)
+	}
+}
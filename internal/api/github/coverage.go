@@ -0,0 +1,82 @@
+package github
+
+import (
+	"context"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+)
+
+// MaxCoverageArtifacts is the number of coverage artifacts tried per workflow run.
+const MaxCoverageArtifacts = 3
+
+// GetCoverage reads the line coverage of a workflow run from its uploaded coverage report.
+// GitHub has no coverage of its own - artifacts whose name mentions "coverage", "lcov" or "cobertura"
+// are downloaded and the first Cobertura XML (*.xml) or lcov tracefile (*.info, lcov*) found is used.
+// Returns nil if the run has no such artifacts or they hold no coverage report.
+func (c *Client) GetCoverage(ctx context.Context, projectID, pipelineID string) (*float64, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		artifacts, err := c.listArtifacts(ctx, projectID, pipelineID)
+		if err != nil {
+			return nil, err
+		}
+
+		read := 0
+		for _, artifact := range artifacts {
+			if read == MaxCoverageArtifacts {
+				break
+			}
+			if !isReadableArtifact(artifact, "coverage", "lcov", "cobertura") {
+				continue
+			}
+			read++
+
+			coverage, err := c.readCoverageArtifact(ctx, projectID, artifact)
+			if err != nil {
+				log.Printf("[GitHub] Failed to read coverage artifact %s of run %s of %s: %v", artifact.Name, pipelineID, projectID, err)
+				continue
+			}
+			if coverage != nil {
+				return coverage, nil
+			}
+		}
+		return (*float64)(nil), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(*float64), nil
+}
+
+// readCoverageArtifact downloads an artifact and returns the coverage of the first report in it.
+// Returns nil if no file in it parses as Cobertura XML or lcov.
+func (c *Client) readCoverageArtifact(ctx context.Context, projectID string, artifact githubArtifact) (*float64, error) {
+	archive, err := c.downloadArtifact(ctx, projectID, artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range archive.File {
+		if file.UncompressedSize64 > MaxArtifactBytes {
+			continue
+		}
+
+		name := strings.ToLower(path.Base(file.Name))
+		var coverage float64
+		switch {
+		case path.Ext(name) == ".xml":
+			coverage, err = parseArtifactFile(file, api.ParseCobertura)
+		case path.Ext(name) == ".info" || strings.HasPrefix(name, "lcov"):
+			coverage, err = parseArtifactFile(file, api.ParseLcov)
+		default:
+			continue
+		}
+		if err == nil {
+			return &coverage, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+)
+
+// TestGetCoverage tests reading coverage from the lcov tracefile of a coverage artifact.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetCoverage(t *testing.T) {
+	// Arrange
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/runs/99/artifacts"):
+				return jsonResponse(`{"artifacts": [
+					{"id": 1, "name": "test-results", "size_in_bytes": 1024},
+					{"id": 2, "name": "coverage-report", "size_in_bytes": 1024}
+				]}`), nil
+			case strings.HasSuffix(req.URL.Path, "/artifacts/2/zip"):
+				return zipResponse(t, map[string]string{
+					"coverage/lcov.info": "SF:src/app.js\nLF:8\nLH:6\nend_of_record\n",
+				}), nil
+			}
+			t.Fatalf("unexpected request %s", req.URL)
+			return nil, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, mockHTTP)
+
+	// Act
+	coverage, err := client.GetCoverage(context.Background(), "acme/api", "99")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if coverage == nil || *coverage != 75 {
+		t.Errorf("expected 75%% coverage, got %v", coverage)
+	}
+}
+
+// TestGetCoverage_NoReport tests that coverage artifacts without a coverage report have no coverage.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetCoverage_NoReport(t *testing.T) {
+	// Arrange
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(req.URL.Path, "/runs/99/artifacts") {
+				return jsonResponse(`{"artifacts": [{"id": 2, "name": "coverage", "size_in_bytes": 1024}]}`), nil
+			}
+			return zipResponse(t, map[string]string{"index.html": "<html></html>"}), nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, mockHTTP)
+
+	// Act
+	coverage, err := client.GetCoverage(context.Background(), "acme/api", "99")
+
+	// Assert
+	if err != nil || coverage != nil {
+		t.Errorf("expected no coverage and no error, got %v (err=%v)", coverage, err)
+	}
+}
@@ -0,0 +1,238 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// GetEnvironments retrieves the environments of a repository with their latest deployment.
+// Environments come from the repository's deployments, plus configured environments nothing was deployed to yet.
+// The status of each latest deployment is read from its most recent deployment status.
+func (c *Client) GetEnvironments(ctx context.Context, projectID string) ([]domain.Environment, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		deployURL := fmt.Sprintf("%s/repos/%s/deployments?per_page=100", c.BaseURL, projectID)
+
+		var ghDeployments []githubDeployment
+		if err := c.doRequest(ctx, deployURL, &ghDeployments); err != nil {
+			return nil, fmt.Errorf("failed to get deployments: %w", err)
+		}
+
+		// Newest first - the first deployment of each environment is its latest
+		environments := make(map[string]*domain.Environment)
+		for _, ghDeployment := range ghDeployments {
+			if _, seen := environments[ghDeployment.Environment]; seen {
+				continue
+			}
+
+			deployment := convertDeployment(ghDeployment, projectID)
+			environment := &domain.Environment{
+				Name:           ghDeployment.Environment,
+				ProjectID:      projectID,
+				Repository:     projectID,
+				LastDeployment: deployment,
+			}
+			if err := c.fetchDeploymentStatus(ctx, projectID, ghDeployment.ID, environment); err != nil {
+				log.Printf("[GitHub] Failed to get status of deployment %d of %s: %v", ghDeployment.ID, projectID, err)
+			}
+			environments[ghDeployment.Environment] = environment
+		}
+
+		// Configured environments are best-effort - listing them may need more permissions than deployments
+		envURL := fmt.Sprintf("%s/repos/%s/environments?per_page=100", c.BaseURL, projectID)
+		var ghEnvironments githubEnvironments
+		if err := c.doRequest(ctx, envURL, &ghEnvironments); err != nil {
+			log.Printf("[GitHub] Failed to get environments of %s: %v", projectID, err)
+		}
+		for _, ghEnvironment := range ghEnvironments.Environments {
+			if _, seen := environments[ghEnvironment.Name]; !seen {
+				environments[ghEnvironment.Name] = &domain.Environment{
+					Name:       ghEnvironment.Name,
+					ProjectID:  projectID,
+					Repository: projectID,
+				}
+			}
+		}
+
+		result := make([]domain.Environment, 0, len(environments))
+		for _, environment := range environments {
+			result = append(result, *environment)
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].Name < result[j].Name
+		})
+		return result, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Environment), nil
+}
+
+const (
+	// MaxDeploymentPages is the number of pages of 100 deployments read for the deployment history.
+	MaxDeploymentPages = 5
+
+	// MaxDeploymentStatuses is the number of production deployments whose status is read for the
+	// deployment history - each costs a request. Older deployments are left out.
+	MaxDeploymentStatuses = 100
+)
+
+// GetProductionDeployments retrieves the deployments of a repository to production environments created since the given time.
+// Production environments are those GitHub flags as production, or named like production.
+// The status of each deployment is read from its most recent deployment status.
+func (c *Client) GetProductionDeployments(ctx context.Context, projectID string, since time.Time) ([]domain.Deployment, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		deployments := []domain.Deployment{}
+		perPage := 100
+
+		// Newest first - stop at the first page reaching past the window
+		for page := 1; page <= MaxDeploymentPages; page++ {
+			deployURL := fmt.Sprintf("%s/repos/%s/deployments?per_page=%d&page=%d", c.BaseURL, projectID, perPage, page)
+
+			var ghDeployments []githubDeployment
+			if err := c.doRequest(ctx, deployURL, &ghDeployments); err != nil {
+				return nil, fmt.Errorf("failed to get deployments (page %d): %w", page, err)
+			}
+
+			reachedSince := false
+			for _, ghDeployment := range ghDeployments {
+				if ghDeployment.CreatedAt.Before(since) {
+					reachedSince = true
+					break
+				}
+				if len(deployments) == MaxDeploymentStatuses {
+					reachedSince = true
+					break
+				}
+				if !ghDeployment.ProductionEnvironment && !domain.IsProductionEnvironment(ghDeployment.Environment) {
+					continue
+				}
+
+				deployment := convertDeployment(ghDeployment, projectID)
+				status, err := c.latestDeploymentStatus(ctx, projectID, ghDeployment.ID)
+				if err != nil {
+					log.Printf("[GitHub] Failed to get status of deployment %d of %s: %v", ghDeployment.ID, projectID, err)
+				} else if status != nil {
+					applyDeploymentStatus(deployment, *status)
+				}
+				deployments = append(deployments, *deployment)
+			}
+
+			if reachedSince || len(ghDeployments) < perPage {
+				break
+			}
+		}
+		return deployments, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Deployment), nil
+}
+
+// fetchDeploymentStatus sets the status of an environment's latest deployment from its most recent deployment status.
+// Deployments without statuses are pending.
+func (c *Client) fetchDeploymentStatus(ctx context.Context, projectID string, deploymentID int64, environment *domain.Environment) error {
+	status, err := c.latestDeploymentStatus(ctx, projectID, deploymentID)
+	if err != nil || status == nil {
+		return err
+	}
+
+	applyDeploymentStatus(environment.LastDeployment, *status)
+	environment.URL = status.EnvironmentURL
+	return nil
+}
+
+// latestDeploymentStatus returns the most recent status of a deployment, or nil if it has none.
+func (c *Client) latestDeploymentStatus(ctx context.Context, projectID string, deploymentID int64) (*githubDeploymentStatus, error) {
+	statusURL := fmt.Sprintf("%s/repos/%s/deployments/%d/statuses?per_page=1", c.BaseURL, projectID, deploymentID)
+
+	var statuses []githubDeploymentStatus
+	if err := c.doRequest(ctx, statusURL, &statuses); err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+	return &statuses[0], nil
+}
+
+// applyDeploymentStatus sets a deployment's status, update time and link from a deployment status.
+func applyDeploymentStatus(deployment *domain.Deployment, status githubDeploymentStatus) {
+	deployment.Status = convertDeploymentStatus(status.State)
+	deployment.UpdatedAt = status.CreatedAt
+	if status.LogURL != "" {
+		deployment.WebURL = status.LogURL
+	} else if status.TargetURL != "" {
+		deployment.WebURL = status.TargetURL
+	}
+}
+
+// convertDeployment converts a GitHub deployment to a domain deployment.
+func convertDeployment(ghDeployment githubDeployment, projectID string) *domain.Deployment {
+	deployment := &domain.Deployment{
+		ID:          fmt.Sprintf("%d", ghDeployment.ID),
+		ProjectID:   projectID,
+		Environment: ghDeployment.Environment,
+		Ref:         ghDeployment.Ref,
+		CommitSHA:   ghDeployment.SHA,
+		Status:      domain.StatusPending,
+		CreatedAt:   ghDeployment.CreatedAt,
+		UpdatedAt:   ghDeployment.UpdatedAt,
+		WebURL:      fmt.Sprintf("https://github.com/%s/deployments/%s", projectID, url.PathEscape(ghDeployment.Environment)),
+		Production:  ghDeployment.ProductionEnvironment || domain.IsProductionEnvironment(ghDeployment.Environment),
+	}
+
+	if ghDeployment.Creator != nil {
+		deployment.Author = ghDeployment.Creator.Login
+	}
+	return deployment
+}
+
+// convertDeploymentStatus converts a GitHub deployment status state to domain status.
+// Inactive deployments were successful and have since been replaced.
+func convertDeploymentStatus(state string) domain.Status {
+	switch state {
+	case "success", "inactive":
+		return domain.StatusSuccess
+	case "failure", "error":
+		return domain.StatusFailed
+	case "in_progress":
+		return domain.StatusRunning
+	default:
+		return domain.StatusPending
+	}
+}
+
+type githubDeployment struct {
+	ID                    int64       `json:"id"`
+	SHA                   string      `json:"sha"`
+	Ref                   string      `json:"ref"`
+	Environment           string      `json:"environment"`
+	ProductionEnvironment bool        `json:"production_environment"`
+	Creator               *githubUser `json:"creator"`
+	CreatedAt             time.Time   `json:"created_at"`
+	UpdatedAt             time.Time   `json:"updated_at"`
+}
+
+type githubDeploymentStatus struct {
+	State          string    `json:"state"`
+	LogURL         string    `json:"log_url"`
+	TargetURL      string    `json:"target_url"`
+	EnvironmentURL string    `json:"environment_url"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type githubEnvironments struct {
+	Environments []struct {
+		Name string `json:"name"`
+	} `json:"environments"`
+}
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestGetEnvironments tests that environments get their latest deployment and its most recent status.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetEnvironments(t *testing.T) {
+	// Arrange
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/deployments/7/statuses"):
+				return jsonResponse(`[{"state": "failure", "log_url": "https://github.com/acme/api/actions/runs/99", "environment_url": "https://staging.example.com"}]`), nil
+			case strings.HasSuffix(req.URL.Path, "/statuses"):
+				return jsonResponse(`[]`), nil
+			case strings.HasSuffix(req.URL.Path, "/deployments"):
+				return jsonResponse(`[
+					{"id": 7, "sha": "abcdef1234567890", "ref": "main", "environment": "staging", "creator": {"login": "alice"}},
+					{"id": 5, "sha": "1234567890abcdef", "ref": "v1.0.0", "environment": "production", "creator": {"login": "bob"}},
+					{"id": 3, "sha": "0000000000000000", "ref": "main", "environment": "staging"}
+				]`), nil
+			case strings.HasSuffix(req.URL.Path, "/environments"):
+				resp := jsonResponse(`{"message": "Resource not accessible by integration"}`)
+				resp.StatusCode = http.StatusForbidden
+				return resp, nil
+			}
+			t.Fatalf("unexpected request %s", req.URL)
+			return nil, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, mockHTTP)
+
+	// Act
+	environments, err := client.GetEnvironments(context.Background(), "acme/api")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(environments) != 2 {
+		t.Fatalf("expected 2 environments, got %d", len(environments))
+	}
+
+	production, staging := environments[0], environments[1]
+	if production.Name != "production" || production.LastDeployment.Status != domain.StatusPending {
+		t.Errorf("expected production deployment without statuses to be pending, got %+v", production.LastDeployment)
+	}
+	deployment := staging.LastDeployment
+	if deployment.ID != "7" || deployment.Status != domain.StatusFailed || deployment.Author != "alice" {
+		t.Errorf("expected latest staging deployment 7 failed by alice, got %+v", deployment)
+	}
+	if deployment.WebURL != "https://github.com/acme/api/actions/runs/99" {
+		t.Errorf("expected deployment log URL, got %q", deployment.WebURL)
+	}
+	if staging.URL != "https://staging.example.com" {
+		t.Errorf("expected staging URL, got %q", staging.URL)
+	}
+}
+
+// TestGetProductionDeployments tests that deployments to production are read with their status until the window starts.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetProductionDeployments(t *testing.T) {
+	// Arrange
+	var statusRequests int
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/deployments/9/statuses"):
+				statusRequests++
+				return jsonResponse(`[{"state": "failure", "created_at": "2024-03-04T10:05:00Z"}]`), nil
+			case strings.HasSuffix(req.URL.Path, "/statuses"):
+				statusRequests++
+				return jsonResponse(`[{"state": "inactive", "created_at": "2024-03-03T10:05:00Z"}]`), nil
+			case strings.HasSuffix(req.URL.Path, "/deployments"):
+				return jsonResponse(`[
+					{"id": 9, "environment": "eu-west", "production_environment": true, "created_at": "2024-03-04T10:00:00Z"},
+					{"id": 8, "environment": "staging", "created_at": "2024-03-03T12:00:00Z"},
+					{"id": 7, "environment": "prod", "created_at": "2024-03-03T10:00:00Z"},
+					{"id": 3, "environment": "production", "created_at": "2024-02-01T10:00:00Z"}
+				]`), nil
+			}
+			t.Fatalf("unexpected request %s", req.URL)
+			return nil, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, mockHTTP)
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	deployments, err := client.GetProductionDeployments(context.Background(), "acme/api", since)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(deployments) != 2 || statusRequests != 2 {
+		t.Fatalf("expected 2 production deployments with 2 status requests, got %d with %d", len(deployments), statusRequests)
+	}
+	if deployments[0].ID != "9" || deployments[0].Status != domain.StatusFailed || !deployments[0].Production {
+		t.Errorf("expected failed deployment 9 to a production environment, got %+v", deployments[0])
+	}
+	if deployments[1].ID != "7" || deployments[1].Status != domain.StatusSuccess {
+		t.Errorf("expected replaced deployment 7 to count as successful, got %+v", deployments[1])
+	}
+}
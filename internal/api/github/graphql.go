@@ -0,0 +1,514 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+const (
+	// graphqlBatchSize is the number of repositories fetched per GraphQL query.
+	// Keeps each query well below GitHub's 500,000 node limit.
+	graphqlBatchSize = 20
+
+	// prefetchTTL bounds how long prefetched data may answer reads.
+	// A page-by-page refresh reads it within seconds; older data is refetched over REST.
+	prefetchTTL = 2 * time.Minute
+
+	// graphqlResource is the rate limit resource of GraphQL queries, a separate quota from REST's.
+	graphqlResource = "graphql"
+)
+
+// repositoryFragment selects everything the dashboard needs from one repository:
+// the default branch head with its check suites (workflow runs and jobs), branches with
+// their last commit, and open pull requests with reviewers and draft state.
+const repositoryFragment = `fragment repositoryData on Repository {
+  defaultBranchRef {
+    name
+    target {
+      ... on Commit {
+        oid
+        checkSuites(first: 20) {
+          nodes {
+            status
+            conclusion
+            workflowRun {
+              databaseId
+              url
+              createdAt
+              updatedAt
+              workflow { databaseId name }
+            }
+            checkRuns(first: 50) {
+              nodes { databaseId name status conclusion startedAt completedAt detailsUrl }
+            }
+          }
+        }
+      }
+    }
+  }
+  refs(refPrefix: "refs/heads/", first: 100) {
+    pageInfo { hasNextPage }
+    nodes {
+      name
+      branchProtectionRule { id }
+      target { ... on Commit { oid message author { name date } } }
+    }
+  }
+  pullRequests(states: OPEN, first: 100, orderBy: {field: UPDATED_AT, direction: DESC}) {
+    pageInfo { hasNextPage }
+    nodes {
+      number
+      title
+      body
+      isDraft
+      headRefName
+      baseRefName
+      url
+      createdAt
+      updatedAt
+      author { login }
+      reviewRequests(first: 20) {
+        nodes { requestedReviewer { ... on User { login } ... on Team { slug } } }
+      }
+    }
+  }
+}`
+
+// GraphQLClient reads branches, open pull requests and the default branch pipeline of many
+// repositories with one GraphQL query per batch, instead of several REST requests per repository.
+// Data is loaded by PrefetchProjects and answers the next read of each kind per repository;
+// everything else, and anything not prefetched, is read over REST.
+// Follows Open/Closed Principle - extends the REST client without modifying it.
+type GraphQLClient struct {
+	*Client
+	graphqlURL string
+	prefetched map[string]*prefetchedRepository
+	mu         sync.Mutex
+}
+
+// prefetchedRepository holds the data of one repository loaded by PrefetchProjects.
+// Each part is cleared once read, so later reads (e.g. after a push event) go to the API.
+type prefetchedRepository struct {
+	fetchedAt     time.Time
+	branches      []domain.Branch // nil if not prefetched or incomplete
+	mergeRequests []domain.MergeRequest
+	defaultBranch string
+	pipeline      *domain.Pipeline // latest workflow run of the default branch head
+}
+
+// NewGraphQLClient creates a GitHub client that batches reads through the GraphQL API.
+// Uses dependency injection for HTTPClient (IoC).
+func NewGraphQLClient(config api.ClientConfig, httpClient api.HTTPClient) *GraphQLClient {
+	client := NewClient(config, httpClient)
+	return &GraphQLClient{
+		Client:     client,
+		graphqlURL: graphqlURL(client.BaseURL),
+		prefetched: make(map[string]*prefetchedRepository),
+	}
+}
+
+// graphqlURL derives the GraphQL endpoint from the REST API URL.
+// GitHub Enterprise Server serves REST under /api/v3 and GraphQL under /api/graphql.
+func graphqlURL(baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if strings.HasSuffix(baseURL, "/api/v3") {
+		return strings.TrimSuffix(baseURL, "/v3") + "/graphql"
+	}
+	return baseURL + "/graphql"
+}
+
+// PrefetchProjects loads the data of several repositories with one query per batch.
+// Repositories that cannot be read over GraphQL are left to REST.
+func (c *GraphQLClient) PrefetchProjects(ctx context.Context, projectIDs []string) error {
+	for start := 0; start < len(projectIDs); start += graphqlBatchSize {
+		end := min(start+graphqlBatchSize, len(projectIDs))
+
+		_, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+			return nil, c.prefetchBatch(ctx, projectIDs[start:end])
+		})
+		if err != nil {
+			return fmt.Errorf("failed to prefetch repositories: %w", err)
+		}
+	}
+	return nil
+}
+
+// prefetchBatch fetches one batch of repositories, each under its own alias (r0, r1, ...).
+func (c *GraphQLClient) prefetchBatch(ctx context.Context, projectIDs []string) error {
+	var params, fields []string
+	variables := make(map[string]string)
+	aliases := make(map[string]string)
+
+	for i, projectID := range projectIDs {
+		owner, name, ok := strings.Cut(projectID, "/")
+		if !ok {
+			continue
+		}
+		alias := fmt.Sprintf("r%d", i)
+		params = append(params, fmt.Sprintf("$o%d: String!, $n%d: String!", i, i))
+		fields = append(fields, fmt.Sprintf("%s: repository(owner: $o%d, name: $n%d) { ...repositoryData }", alias, i, i))
+		variables[fmt.Sprintf("o%d", i)] = owner
+		variables[fmt.Sprintf("n%d", i)] = name
+		aliases[alias] = projectID
+	}
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("query(%s) {\n%s\n}\n%s", strings.Join(params, ", "), strings.Join(fields, "\n"), repositoryFragment)
+
+	var response struct {
+		Data   map[string]json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := c.doGraphQL(ctx, query, variables, &response); err != nil {
+		return err
+	}
+
+	// Partial errors (e.g. a repository that no longer exists) only null their own alias
+	if len(response.Data) == 0 && len(response.Errors) > 0 {
+		return fmt.Errorf("GraphQL query failed: %s", response.Errors[0].Message)
+	}
+	for _, e := range response.Errors {
+		log.Printf("[GitHub] GraphQL warning: %s", e.Message)
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for alias, projectID := range aliases {
+		raw := response.Data[alias]
+		if len(raw) == 0 || string(raw) == "null" {
+			delete(c.prefetched, projectID)
+			continue
+		}
+
+		var repo graphqlRepository
+		if err := json.Unmarshal(raw, &repo); err != nil {
+			log.Printf("[GitHub] Failed to decode GraphQL data for %s: %v", projectID, err)
+			delete(c.prefetched, projectID)
+			continue
+		}
+		c.prefetched[projectID] = c.convertRepository(repo, projectID, now)
+	}
+	return nil
+}
+
+// doGraphQL posts a GraphQL query and decodes the response.
+// Queries are read-only, so failed ones are retried like GET requests.
+func (c *GraphQLClient) doGraphQL(ctx context.Context, query string, variables map[string]string, result interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	options := api.RequestOptions{RateLimitResource: graphqlResource, Retry: true}
+	resp, err := c.DoWithOptions(ctx, options, func() (*http.Request, error) {
+		token, err := c.token(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.graphqlURL, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GraphQL API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// take returns the prefetched data of a repository if it is fresh, for the caller to consume.
+// Must be called with c.mu held.
+func (c *GraphQLClient) take(projectID string) *prefetchedRepository {
+	repo, ok := c.prefetched[projectID]
+	if !ok {
+		return nil
+	}
+	if time.Since(repo.fetchedAt) > prefetchTTL {
+		delete(c.prefetched, projectID)
+		return nil
+	}
+	return repo
+}
+
+// GetBranches returns prefetched branches, or reads them over REST.
+func (c *GraphQLClient) GetBranches(ctx context.Context, projectID string, limit int) ([]domain.Branch, error) {
+	c.mu.Lock()
+	var branches []domain.Branch
+	if repo := c.take(projectID); repo != nil {
+		branches, repo.branches = repo.branches, nil
+	}
+	c.mu.Unlock()
+
+	if branches != nil {
+		return branches, nil
+	}
+	return c.Client.GetBranches(ctx, projectID, limit)
+}
+
+// GetMergeRequests returns prefetched open pull requests, or reads them over REST.
+func (c *GraphQLClient) GetMergeRequests(ctx context.Context, projectID string) ([]domain.MergeRequest, error) {
+	c.mu.Lock()
+	var mrs []domain.MergeRequest
+	if repo := c.take(projectID); repo != nil {
+		mrs, repo.mergeRequests = repo.mergeRequests, nil
+	}
+	c.mu.Unlock()
+
+	if mrs != nil {
+		return mrs, nil
+	}
+	return c.Client.GetMergeRequests(ctx, projectID)
+}
+
+// GetLatestPipeline returns the prefetched default branch pipeline, or reads it over REST.
+// Other branches, and default branch heads without workflow runs, are always read over REST.
+func (c *GraphQLClient) GetLatestPipeline(ctx context.Context, projectID, branch string) (*domain.Pipeline, error) {
+	c.mu.Lock()
+	var pipeline *domain.Pipeline
+	if repo := c.take(projectID); repo != nil && repo.defaultBranch == branch {
+		pipeline, repo.pipeline = repo.pipeline, nil
+	}
+	c.mu.Unlock()
+
+	if pipeline != nil {
+		return pipeline, nil
+	}
+	return c.Client.GetLatestPipeline(ctx, projectID, branch)
+}
+
+// convertRepository converts GraphQL repository data to the prefetched domain models.
+// Lists that were cut off by the page size are left out, so they are read in full over REST.
+func (c *GraphQLClient) convertRepository(repo graphqlRepository, projectID string, fetchedAt time.Time) *prefetchedRepository {
+	prefetched := &prefetchedRepository{fetchedAt: fetchedAt}
+
+	if repo.DefaultBranchRef != nil {
+		prefetched.defaultBranch = repo.DefaultBranchRef.Name
+		prefetched.pipeline = c.convertCheckSuites(&repo.DefaultBranchRef.Target, projectID, prefetched.defaultBranch)
+	}
+
+	if !repo.Refs.PageInfo.HasNextPage {
+		prefetched.branches = make([]domain.Branch, 0, len(repo.Refs.Nodes))
+		for _, ref := range repo.Refs.Nodes {
+			ghb := githubBranch{Name: ref.Name, Protected: ref.BranchProtectionRule != nil}
+			ghb.Commit.SHA = ref.Target.OID
+
+			var commit githubCommit
+			commit.Commit.Message = ref.Target.Message
+			commit.Commit.Author.Name = ref.Target.Author.Name
+			commit.Commit.Author.Date = ref.Target.Author.Date
+
+			prefetched.branches = append(prefetched.branches, c.convertBranch(ghb, projectID, &commit, ref.Name == prefetched.defaultBranch))
+		}
+	}
+
+	if !repo.PullRequests.PageInfo.HasNextPage {
+		prefetched.mergeRequests = make([]domain.MergeRequest, 0, len(repo.PullRequests.Nodes))
+		for _, pr := range repo.PullRequests.Nodes {
+			prefetched.mergeRequests = append(prefetched.mergeRequests, c.convertGraphQLPullRequest(pr, projectID))
+		}
+	}
+
+	return prefetched
+}
+
+// convertCheckSuites converts the most recent workflow run among a commit's check suites to a pipeline.
+// Returns nil if no check suite belongs to a workflow run (e.g. only third-party checks).
+func (c *GraphQLClient) convertCheckSuites(commit *graphqlCommit, projectID, branch string) *domain.Pipeline {
+	var latest *graphqlCheckSuite
+	for i, suite := range commit.CheckSuites.Nodes {
+		if suite.WorkflowRun == nil {
+			continue
+		}
+		if latest == nil || suite.WorkflowRun.CreatedAt.After(latest.WorkflowRun.CreatedAt) {
+			latest = &commit.CheckSuites.Nodes[i]
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+
+	run := githubWorkflowRun{
+		ID:         latest.WorkflowRun.DatabaseID,
+		Name:       latest.WorkflowRun.Workflow.Name,
+		WorkflowID: latest.WorkflowRun.Workflow.DatabaseID,
+		HeadBranch: branch,
+		HeadSHA:    commit.OID,
+		HTMLURL:    latest.WorkflowRun.URL,
+		CreatedAt:  latest.WorkflowRun.CreatedAt,
+		UpdatedAt:  latest.WorkflowRun.UpdatedAt,
+	}
+	run.Status, run.Conclusion = restStatus(latest.Status, latest.Conclusion)
+	pipeline := c.convertPipeline(run, projectID)
+
+	pipeline.Builds = make([]domain.Build, 0, len(latest.CheckRuns.Nodes))
+	for _, checkRun := range latest.CheckRuns.Nodes {
+		job := githubJob{
+			ID:           checkRun.DatabaseID,
+			Name:         checkRun.Name,
+			WorkflowName: run.Name,
+			StartedAt:    checkRun.StartedAt,
+			CompletedAt:  checkRun.CompletedAt,
+			HTMLURL:      checkRun.DetailsURL,
+		}
+		job.Status, job.Conclusion = restStatus(checkRun.Status, checkRun.Conclusion)
+		pipeline.Builds = append(pipeline.Builds, c.convertJob(job))
+	}
+
+	return pipeline
+}
+
+// restStatus maps GraphQL check statuses (QUEUED, WAITING, ...) to the REST values understood by convertStatus.
+func restStatus(status, conclusion string) (string, string) {
+	switch status {
+	case "COMPLETED":
+		return "completed", strings.ToLower(conclusion)
+	case "IN_PROGRESS":
+		return "in_progress", ""
+	default:
+		return "queued", ""
+	}
+}
+
+// convertGraphQLPullRequest converts a GraphQL pull request to domain MergeRequest.
+func (c *GraphQLClient) convertGraphQLPullRequest(pr graphqlPullRequest, projectID string) domain.MergeRequest {
+	ghPR := githubPullRequest{
+		Number:    pr.Number,
+		Title:     pr.Title,
+		Body:      pr.Body,
+		State:     "open",
+		Draft:     pr.IsDraft,
+		Head:      githubRef{Ref: pr.HeadRefName},
+		Base:      githubRef{Ref: pr.BaseRefName},
+		User:      githubUser{Login: pr.Author.Login},
+		CreatedAt: pr.CreatedAt,
+		UpdatedAt: pr.UpdatedAt,
+		HTMLURL:   pr.URL,
+	}
+	for _, request := range pr.ReviewRequests.Nodes {
+		if request.RequestedReviewer.Login != "" {
+			ghPR.RequestedReviewers = append(ghPR.RequestedReviewers, githubUser{Login: request.RequestedReviewer.Login})
+		} else if request.RequestedReviewer.Slug != "" {
+			ghPR.RequestedTeams = append(ghPR.RequestedTeams, githubTeam{Slug: request.RequestedReviewer.Slug})
+		}
+	}
+	return c.convertPullRequest(ghPR, projectID)
+}
+
+// GitHub GraphQL response types
+type graphqlRepository struct {
+	DefaultBranchRef *struct {
+		Name   string        `json:"name"`
+		Target graphqlCommit `json:"target"`
+	} `json:"defaultBranchRef"`
+	Refs struct {
+		PageInfo graphqlPageInfo `json:"pageInfo"`
+		Nodes    []struct {
+			Name                 string `json:"name"`
+			BranchProtectionRule *struct {
+				ID string `json:"id"`
+			} `json:"branchProtectionRule"`
+			Target graphqlCommit `json:"target"`
+		} `json:"nodes"`
+	} `json:"refs"`
+	PullRequests struct {
+		PageInfo graphqlPageInfo      `json:"pageInfo"`
+		Nodes    []graphqlPullRequest `json:"nodes"`
+	} `json:"pullRequests"`
+}
+
+type graphqlPageInfo struct {
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+type graphqlCommit struct {
+	OID     string `json:"oid"`
+	Message string `json:"message"`
+	Author  struct {
+		Name string    `json:"name"`
+		Date time.Time `json:"date"`
+	} `json:"author"`
+	CheckSuites struct {
+		Nodes []graphqlCheckSuite `json:"nodes"`
+	} `json:"checkSuites"`
+}
+
+type graphqlCheckSuite struct {
+	Status      string `json:"status"`
+	Conclusion  string `json:"conclusion"`
+	WorkflowRun *struct {
+		DatabaseID int       `json:"databaseId"`
+		URL        string    `json:"url"`
+		CreatedAt  time.Time `json:"createdAt"`
+		UpdatedAt  time.Time `json:"updatedAt"`
+		Workflow   struct {
+			DatabaseID int    `json:"databaseId"`
+			Name       string `json:"name"`
+		} `json:"workflow"`
+	} `json:"workflowRun"`
+	CheckRuns struct {
+		Nodes []struct {
+			DatabaseID  int        `json:"databaseId"`
+			Name        string     `json:"name"`
+			Status      string     `json:"status"`
+			Conclusion  string     `json:"conclusion"`
+			StartedAt   *time.Time `json:"startedAt"`
+			CompletedAt *time.Time `json:"completedAt"`
+			DetailsURL  string     `json:"detailsUrl"`
+		} `json:"nodes"`
+	} `json:"checkRuns"`
+}
+
+type graphqlPullRequest struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	IsDraft     bool      `json:"isDraft"`
+	HeadRefName string    `json:"headRefName"`
+	BaseRefName string    `json:"baseRefName"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Author      struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer struct {
+				Login string `json:"login"` // users
+				Slug  string `json:"slug"`  // teams
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+}
@@ -0,0 +1,152 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+const graphqlBody = `{"data": {
+	"r0": {
+		"defaultBranchRef": {"name": "main", "target": {"oid": "abc123", "checkSuites": {"nodes": [
+			{"status": "COMPLETED", "conclusion": "SUCCESS", "workflowRun": null, "checkRuns": {"nodes": []}},
+			{"status": "IN_PROGRESS", "conclusion": null,
+			 "workflowRun": {"databaseId": 42, "url": "https://github.com/acme/api/actions/runs/42",
+				"createdAt": "2026-01-02T10:00:00Z", "updatedAt": "2026-01-02T10:05:00Z",
+				"workflow": {"databaseId": 7, "name": "CI"}},
+			 "checkRuns": {"nodes": [
+				{"databaseId": 1, "name": "build", "status": "COMPLETED", "conclusion": "SUCCESS",
+				 "startedAt": "2026-01-02T10:00:00Z", "completedAt": "2026-01-02T10:02:00Z", "detailsUrl": "https://github.com/acme/api/runs/1"},
+				{"databaseId": 2, "name": "test", "status": "QUEUED", "conclusion": null, "startedAt": null, "completedAt": null}
+			 ]}}
+		]}}},
+		"refs": {"pageInfo": {"hasNextPage": false}, "nodes": [
+			{"name": "main", "branchProtectionRule": {"id": "BPR_1"},
+			 "target": {"oid": "abc123", "message": "Fix build", "author": {"name": "Jane", "date": "2026-01-02T09:00:00Z"}}},
+			{"name": "feature", "branchProtectionRule": null,
+			 "target": {"oid": "def456", "message": "WIP", "author": {"name": "John", "date": "2026-01-01T09:00:00Z"}}}
+		]},
+		"pullRequests": {"pageInfo": {"hasNextPage": false}, "nodes": [
+			{"number": 5, "title": "Add feature", "isDraft": true, "headRefName": "feature", "baseRefName": "main",
+			 "url": "https://github.com/acme/api/pull/5", "author": {"login": "john"},
+			 "reviewRequests": {"nodes": [{"requestedReviewer": {"login": "jane"}}, {"requestedReviewer": {"slug": "core"}}]}}
+		]}
+	},
+	"r1": null
+}, "errors": [{"message": "Could not resolve to a Repository with the name 'acme/gone'."}]}`
+
+// TestGraphQLClient_PrefetchProjects tests that one query answers branch, pull request and pipeline reads.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGraphQLClient_PrefetchProjects(t *testing.T) {
+	// Arrange
+	var graphqlRequests, restRequests int
+	var variables map[string]string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.String() != "https://github.example.com/api/graphql" {
+				restRequests++
+				return jsonResponse(`[]`), nil
+			}
+			graphqlRequests++
+			var payload struct {
+				Query     string            `json:"query"`
+				Variables map[string]string `json:"variables"`
+			}
+			json.NewDecoder(req.Body).Decode(&payload)
+			variables = payload.Variables
+			if !strings.Contains(payload.Query, "r1: repository(owner: $o1, name: $n1)") {
+				t.Errorf("expected an aliased repository per project, got %s", payload.Query)
+			}
+			return jsonResponse(graphqlBody), nil
+		},
+	}
+
+	client := NewGraphQLClient(api.ClientConfig{BaseURL: "https://github.example.com/api/v3", Token: "token"}, mockHTTP)
+	ctx := context.Background()
+
+	// Act
+	err := client.PrefetchProjects(ctx, []string{"acme/api", "acme/gone"})
+	branches, _ := client.GetBranches(ctx, "acme/api", 200)
+	mrs, _ := client.GetMergeRequests(ctx, "acme/api")
+	pipeline, _ := client.GetLatestPipeline(ctx, "acme/api", "main")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if graphqlRequests != 1 || restRequests != 0 {
+		t.Fatalf("expected a single GraphQL request, got %d GraphQL and %d REST requests", graphqlRequests, restRequests)
+	}
+	if variables["o1"] != "acme" || variables["n1"] != "gone" {
+		t.Errorf("expected owner and name variables, got %v", variables)
+	}
+
+	if len(branches) != 2 || !branches[0].IsDefault || !branches[0].IsProtected || branches[1].CommitAuthor != "John" {
+		t.Errorf("unexpected branches: %+v", branches)
+	}
+
+	if len(mrs) != 1 || !mrs[0].IsDraft || strings.Join(mrs[0].Reviewers, ",") != "jane,core" {
+		t.Errorf("unexpected merge requests: %+v", mrs)
+	}
+
+	if pipeline == nil || pipeline.ID != "42" || pipeline.Status != domain.StatusRunning || pipeline.CommitSHA != "abc123" {
+		t.Fatalf("unexpected pipeline: %+v", pipeline)
+	}
+	if len(pipeline.Builds) != 2 || pipeline.Builds[0].Status != domain.StatusSuccess || pipeline.Builds[1].Status != domain.StatusPending {
+		t.Errorf("unexpected builds: %+v", pipeline.Builds)
+	}
+}
+
+// TestGraphQLClient_FallsBackToREST tests that consumed or missing data is read over REST.
+func TestGraphQLClient_FallsBackToREST(t *testing.T) {
+	// Arrange
+	var restPaths []string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPost {
+				return jsonResponse(graphqlBody), nil
+			}
+			restPaths = append(restPaths, req.URL.Path)
+			return jsonResponse(`[]`), nil
+		},
+	}
+
+	client := NewGraphQLClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, mockHTTP)
+	ctx := context.Background()
+	client.PrefetchProjects(ctx, []string{"acme/api", "acme/gone"})
+	client.GetMergeRequests(ctx, "acme/api")
+
+	// Act
+	client.GetMergeRequests(ctx, "acme/api")
+	client.GetMergeRequests(ctx, "acme/gone")
+
+	// Assert
+	expected := "/repos/acme/api/pulls,/repos/acme/gone/pulls"
+	if strings.Join(restPaths, ",") != expected {
+		t.Errorf("expected REST requests %s, got %v", expected, restPaths)
+	}
+}
+
+// TestGraphqlURL tests deriving the GraphQL endpoint on github.com and GitHub Enterprise Server.
+func TestGraphqlURL(t *testing.T) {
+	tests := map[string]string{
+		"https://api.github.com":             "https://api.github.com/graphql",
+		"https://github.example.com/api/v3/": "https://github.example.com/api/graphql",
+		"https://github.example.com/api/v3":  "https://github.example.com/api/graphql",
+	}
+
+	for baseURL, expected := range tests {
+		// Act
+		result := graphqlURL(baseURL)
+
+		// Assert
+		if result != expected {
+			t.Errorf("graphqlURL(%q) = %q, expected %q", baseURL, result, expected)
+		}
+	}
+}
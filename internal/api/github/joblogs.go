@@ -0,0 +1,49 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// GetJobLog returns the log of a workflow job, as it is downloaded.
+// GitHub redirects to a short-lived download URL, which the HTTP client follows without the token.
+// Logs are only available once the job has finished.
+func (c *Client) GetJobLog(ctx context.Context, projectID, jobID string) (io.ReadCloser, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		logURL := fmt.Sprintf("%s/repos/%s/actions/jobs/%s/logs", c.BaseURL, projectID, jobID)
+
+		resp, err := c.DoWithRetry(ctx, c.logRequest(ctx, logURL))
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		}
+		return resp.Body, nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log of job %s: %w", jobID, err)
+	}
+	return result.(io.ReadCloser), nil
+}
+
+// logRequest returns a builder of GET requests for plain text logs.
+// Logs can be large, so they are never stored for conditional requests.
+func (c *Client) logRequest(ctx context.Context, url string) func() (*http.Request, error) {
+	newRequest := c.getRequest(ctx, url)
+	return func() (*http.Request, error) {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Cache-Control", "no-store")
+		return req, nil
+	}
+}
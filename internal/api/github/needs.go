@@ -0,0 +1,152 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GetJobNeeds reads the needs of a workflow run's jobs from its workflow file at the run's commit.
+// The jobs API doesn't expose needs, so job names are matched to the file's jobs by their name
+// (or id when unnamed), including matrix ("build (linux)") and reusable workflow ("deploy / prod") jobs.
+// Jobs that can't be matched are left out.
+func (c *Client) GetJobNeeds(ctx context.Context, projectID, pipelineID string) (map[string][]string, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		runURL := fmt.Sprintf("%s/repos/%s/actions/runs/%s", c.BaseURL, projectID, pipelineID)
+		var run githubWorkflowRun
+		if err := c.doRequest(ctx, runURL, &run); err != nil {
+			return nil, fmt.Errorf("failed to get run %s: %w", pipelineID, err)
+		}
+		if !strings.HasPrefix(run.Path, ".github/workflows/") {
+			// Dynamic workflows (e.g. Dependabot, CodeQL default setup) have no file to read
+			return map[string][]string{}, nil
+		}
+
+		contentsURL := fmt.Sprintf("%s/repos/%s/contents/%s?ref=%s", c.BaseURL, projectID, run.Path, url.QueryEscape(run.HeadSHA))
+		var contents githubContents
+		if err := c.doRequest(ctx, contentsURL, &contents); err != nil {
+			return nil, fmt.Errorf("failed to get workflow file %s: %w", run.Path, err)
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(contents.Content, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode workflow file %s: %w", run.Path, err)
+		}
+
+		var workflow githubWorkflowFile
+		if err := yaml.Unmarshal(data, &workflow); err != nil {
+			return nil, fmt.Errorf("failed to parse workflow file %s: %w", run.Path, err)
+		}
+
+		jobs, err := c.fetchPipelineJobs(ctx, projectID, pipelineID)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			names = append(names, job.Name)
+		}
+		return workflowJobNeeds(workflow, names), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string][]string), nil
+}
+
+// workflowJobNeeds maps the needs of a workflow file's jobs, by job id, to the names of the run's jobs.
+func workflowJobNeeds(workflow githubWorkflowFile, jobNames []string) map[string][]string {
+	// Workflow job id of each run job
+	jobIDs := make(map[string]string, len(jobNames))
+	for _, name := range jobNames {
+		if id, ok := workflowJobID(workflow, name); ok {
+			jobIDs[name] = id
+		}
+	}
+
+	needs := make(map[string][]string, len(jobIDs))
+	for name, id := range jobIDs {
+		needed := make(map[string]bool)
+		for _, need := range workflow.Jobs[id].Needs {
+			needed[need] = true
+		}
+
+		names := []string{}
+		for other, otherID := range jobIDs {
+			if needed[otherID] {
+				names = append(names, other)
+			}
+		}
+		sort.Strings(names)
+		needs[name] = names
+	}
+	return needs
+}
+
+// workflowJobID returns the id of the workflow file job a run job was created from.
+// Exact names win over matrix and reusable workflow suffixes; longer names over shorter ones.
+func workflowJobID(workflow githubWorkflowFile, jobName string) (string, bool) {
+	bestID, bestLen := "", -1
+	for id, job := range workflow.Jobs {
+		display := job.Name
+		if display == "" {
+			display = id
+		}
+		if display == jobName {
+			return id, true
+		}
+
+		// Names with expressions can only be matched on their literal prefix
+		if i := strings.Index(display, "${{"); i >= 0 {
+			display = strings.TrimSpace(display[:i])
+			if display == "" || !strings.HasPrefix(jobName, display) {
+				continue
+			}
+		} else if !strings.HasPrefix(jobName, display+" (") && !strings.HasPrefix(jobName, display+" / ") {
+			continue
+		}
+
+		if len(display) > bestLen || (len(display) == bestLen && id < bestID) {
+			bestID, bestLen = id, len(display)
+		}
+	}
+	return bestID, bestLen >= 0
+}
+
+type githubContents struct {
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+// githubWorkflowFile is the part of a workflow file that describes job dependencies.
+type githubWorkflowFile struct {
+	Jobs map[string]githubWorkflowJob `yaml:"jobs"`
+}
+
+type githubWorkflowJob struct {
+	Name  string             `yaml:"name"`
+	Needs githubWorkflowList `yaml:"needs"`
+}
+
+// githubWorkflowList is a workflow value that is either a single string or a list of them.
+type githubWorkflowList []string
+
+// UnmarshalYAML accepts a scalar or a sequence of scalars.
+func (l *githubWorkflowList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = githubWorkflowList{value.Value}
+		return nil
+	}
+
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+)
+
+// TestGetJobNeeds tests mapping the needs of a workflow file to the run's job names.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetJobNeeds(t *testing.T) {
+	// Arrange
+	workflow := `name: CI
+on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+  build:
+    name: Build
+    strategy:
+      matrix:
+        os: [linux, macos]
+  test:
+    needs: build
+  deploy:
+    name: Deploy ${{ inputs.environment }}
+    needs: [lint, test]
+`
+	content := base64.StdEncoding.EncodeToString([]byte(workflow))
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/actions/runs/99"):
+				return jsonResponse(`{"id": 99, "head_sha": "abc123", "path": ".github/workflows/ci.yml"}`), nil
+			case strings.HasSuffix(req.URL.Path, "/contents/.github/workflows/ci.yml"):
+				if req.URL.Query().Get("ref") != "abc123" {
+					t.Errorf("expected the workflow file at the run's commit, got %s", req.URL)
+				}
+				return jsonResponse(`{"encoding": "base64", "content": "` + content + `"}`), nil
+			case strings.HasSuffix(req.URL.Path, "/actions/runs/99/jobs"):
+				return jsonResponse(`{"total_count": 5, "jobs": [
+					{"id": 1, "name": "lint", "run_attempt": 1},
+					{"id": 2, "name": "Build (linux)", "run_attempt": 1},
+					{"id": 3, "name": "Build (macos)", "run_attempt": 1},
+					{"id": 4, "name": "test", "run_attempt": 1},
+					{"id": 5, "name": "Deploy production", "run_attempt": 1}
+				]}`), nil
+			}
+			t.Fatalf("unexpected request %s", req.URL)
+			return nil, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, mockHTTP)
+
+	// Act
+	needs, err := client.GetJobNeeds(context.Background(), "acme/api", "99")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := map[string]string{
+		"lint":              "",
+		"Build (linux)":     "",
+		"test":              "Build (linux),Build (macos)",
+		"Deploy production": "lint,test",
+	}
+	for job, wantNeeds := range want {
+		got, ok := needs[job]
+		if !ok || strings.Join(got, ",") != wantNeeds {
+			t.Errorf("expected %s to need %q, got %v (found=%v)", job, wantNeeds, got, ok)
+		}
+	}
+}
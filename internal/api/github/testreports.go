@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// MaxTestArtifacts is the number of test result artifacts read per workflow run.
+const MaxTestArtifacts = 5
+
+// GetTestReport reads the JUnit XML files of a workflow run's test result artifacts.
+// GitHub has no test report of its own - artifacts whose name mentions "test" or "junit" are
+// downloaded and every XML file in them that parses as JUnit is included.
+// Returns nil if the run has no such artifacts or they hold no test results.
+func (c *Client) GetTestReport(ctx context.Context, projectID, pipelineID string) (*domain.TestReport, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		artifacts, err := c.listArtifacts(ctx, projectID, pipelineID)
+		if err != nil {
+			return nil, err
+		}
+
+		var suites []domain.TestSuite
+		read := 0
+		for _, artifact := range artifacts {
+			if read == MaxTestArtifacts {
+				break
+			}
+			if !isReadableArtifact(artifact, "test", "junit") {
+				continue
+			}
+			read++
+
+			artifactSuites, err := c.readTestArtifact(ctx, projectID, artifact)
+			if err != nil {
+				log.Printf("[GitHub] Failed to read test artifact %s of run %s of %s: %v", artifact.Name, pipelineID, projectID, err)
+				continue
+			}
+			suites = append(suites, artifactSuites...)
+		}
+
+		if len(suites) == 0 {
+			return (*domain.TestReport)(nil), nil
+		}
+		return &domain.TestReport{ProjectID: projectID, PipelineID: pipelineID, Suites: suites}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.TestReport), nil
+}
+
+// readTestArtifact downloads an artifact and parses the JUnit XML files in it.
+// XML files that aren't JUnit reports, e.g. coverage reports, are skipped.
+func (c *Client) readTestArtifact(ctx context.Context, projectID string, artifact githubArtifact) ([]domain.TestSuite, error) {
+	archive, err := c.downloadArtifact(ctx, projectID, artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	var suites []domain.TestSuite
+	for _, file := range archive.File {
+		if !strings.EqualFold(path.Ext(file.Name), ".xml") || file.UncompressedSize64 > MaxArtifactBytes {
+			continue
+		}
+
+		fileSuites, err := parseArtifactFile(file, api.ParseJUnit)
+		if err != nil {
+			continue
+		}
+		suites = append(suites, fileSuites...)
+	}
+	return suites, nil
+}
@@ -0,0 +1,101 @@
+package github
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+)
+
+// zipResponse returns a response with a zip archive of the given files.
+func zipResponse(t *testing.T, files map[string]string) *http.Response {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create zip entry: %v", err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to write zip: %v", err)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(&buf),
+	}
+}
+
+// TestGetTestReport tests that JUnit XML files are read from test result artifacts only.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetTestReport(t *testing.T) {
+	// Arrange
+	var downloaded []string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/runs/99/artifacts"):
+				return jsonResponse(`{"artifacts": [
+					{"id": 1, "name": "junit-results", "size_in_bytes": 1024},
+					{"id": 2, "name": "dist", "size_in_bytes": 1024},
+					{"id": 3, "name": "test-results-old", "size_in_bytes": 1024, "expired": true}
+				]}`), nil
+			case strings.HasSuffix(req.URL.Path, "/artifacts/1/zip"):
+				downloaded = append(downloaded, req.URL.Path)
+				return zipResponse(t, map[string]string{
+					"unit/TEST-api.xml": `<testsuite name="api"><testcase name="TestGet"/><testcase name="TestPost"><failure message="boom"/></testcase></testsuite>`,
+					"coverage.xml":      `<coverage line-rate="0.8"></coverage>`,
+					"README.md":         `not xml`,
+				}), nil
+			}
+			t.Fatalf("unexpected request %s", req.URL)
+			return nil, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, mockHTTP)
+
+	// Act
+	report, err := client.GetTestReport(context.Background(), "acme/api", "99")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(downloaded) != 1 {
+		t.Errorf("expected only the unexpired test artifact to be downloaded, got %v", downloaded)
+	}
+	if report == nil || report.Total() != 2 {
+		t.Fatalf("expected a report of 2 tests, got %+v", report)
+	}
+	if failed := report.FailedCases(); len(failed) != 1 || failed[0].Name != "TestPost" || failed[0].Message != "boom" {
+		t.Errorf("expected TestPost to have failed, got %+v", failed)
+	}
+}
+
+// TestGetTestReport_NoArtifacts tests that runs without test artifacts have no report.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetTestReport_NoArtifacts(t *testing.T) {
+	// Arrange
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(`{"artifacts": [{"id": 2, "name": "dist", "size_in_bytes": 1024}]}`), nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, mockHTTP)
+
+	// Act
+	report, err := client.GetTestReport(context.Background(), "acme/api", "99")
+
+	// Assert
+	if err != nil || report != nil {
+		t.Errorf("expected no report and no error, got %+v (err=%v)", report, err)
+	}
+}
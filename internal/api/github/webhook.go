@@ -0,0 +1,191 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// ParseWebhook translates a GitHub webhook payload into domain events.
+// eventType is the value of the X-GitHub-Event header.
+// Supports workflow_run, push and pull_request events; other events (e.g. ping) yield no events.
+func (c *Client) ParseWebhook(eventType string, payload []byte) ([]domain.Event, error) {
+	switch eventType {
+	case "workflow_run":
+		var hook githubWorkflowRunHook
+		if err := json.Unmarshal(payload, &hook); err != nil {
+			return nil, fmt.Errorf("failed to decode workflow_run event: %w", err)
+		}
+		return []domain.Event{c.convertWorkflowRunHook(hook)}, nil
+
+	case "push":
+		var hook githubPushHook
+		if err := json.Unmarshal(payload, &hook); err != nil {
+			return nil, fmt.Errorf("failed to decode push event: %w", err)
+		}
+		if !strings.HasPrefix(hook.Ref, "refs/heads/") {
+			return nil, nil
+		}
+		return []domain.Event{c.convertPushHook(hook)}, nil
+
+	case "pull_request":
+		var hook githubPullRequestHook
+		if err := json.Unmarshal(payload, &hook); err != nil {
+			return nil, fmt.Errorf("failed to decode pull_request event: %w", err)
+		}
+		return []domain.Event{c.convertPullRequestHook(hook)}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// convertWorkflowRunHook converts a workflow_run event to an event with a pipeline snapshot.
+// Jobs aren't part of the payload, so the snapshot has no builds.
+func (c *Client) convertWorkflowRunHook(hook githubWorkflowRunHook) domain.Event {
+	projectID := hook.Repository.FullName
+	pipeline := c.convertPipeline(hook.WorkflowRun, projectID)
+
+	return domain.Event{
+		ID:         pipeline.ID,
+		ProjectID:  projectID,
+		Type:       "pipeline",
+		TargetType: "pipeline",
+		TargetID:   pipeline.ID,
+		CreatedAt:  pipeline.UpdatedAt,
+		Author:     hook.Sender.Login,
+		ActionName: hook.Action,
+		Pipeline:   pipeline,
+	}
+}
+
+// convertPushHook converts a branch push event to an event with a branch snapshot.
+// The snapshot is omitted when the payload has no head commit.
+func (c *Client) convertPushHook(hook githubPushHook) domain.Event {
+	projectID := hook.Repository.FullName
+	branchName := strings.TrimPrefix(hook.Ref, "refs/heads/")
+
+	event := domain.Event{
+		ID:         hook.After,
+		ProjectID:  projectID,
+		Type:       "push",
+		TargetType: "branch",
+		TargetID:   branchName,
+		CreatedAt:  time.Now(),
+		Author:     hook.Sender.Login,
+		ActionName: "updated",
+	}
+
+	switch {
+	case hook.Deleted:
+		event.ActionName = "deleted"
+		event.Branch = &domain.Branch{Name: branchName, ProjectID: projectID, Platform: "github"}
+		return event
+	case hook.Created:
+		event.ActionName = "created"
+	}
+
+	if hook.HeadCommit == nil {
+		return event
+	}
+
+	event.CreatedAt = hook.HeadCommit.Timestamp
+	event.Branch = &domain.Branch{
+		Name:           branchName,
+		ProjectID:      projectID,
+		Repository:     projectID,
+		LastCommitSHA:  hook.HeadCommit.ID,
+		LastCommitMsg:  hook.HeadCommit.Message,
+		LastCommitDate: hook.HeadCommit.Timestamp,
+		CommitAuthor:   hook.HeadCommit.Author.Name,
+		AuthorEmail:    hook.HeadCommit.Author.Email,
+		IsDefault:      branchName == hook.Repository.DefaultBranch,
+		WebURL:         fmt.Sprintf("%s/tree/%s", hook.Repository.HTMLURL, branchName),
+		Platform:       "github",
+	}
+
+	return event
+}
+
+// convertPullRequestHook converts a pull_request event to an event with a merge request snapshot.
+func (c *Client) convertPullRequestHook(hook githubPullRequestHook) domain.Event {
+	projectID := hook.Repository.FullName
+	mr := c.convertPullRequest(hook.PullRequest.githubPullRequest, projectID)
+
+	reviewers := make([]string, 0, len(hook.PullRequest.RequestedReviewers))
+	for _, reviewer := range hook.PullRequest.RequestedReviewers {
+		reviewers = append(reviewers, reviewer.Login)
+	}
+	mr.Reviewers = reviewers
+
+	action := hook.Action
+	switch {
+	case action == "closed" && hook.PullRequest.Merged:
+		action = "merged"
+		mr.State = "merged"
+	case action == "opened" || action == "closed" || action == "reopened":
+		// Keep as-is
+	default:
+		action = "updated"
+	}
+
+	return domain.Event{
+		ID:           mr.ID,
+		ProjectID:    projectID,
+		Type:         "merge_request",
+		TargetType:   "merge_request",
+		TargetID:     mr.ID,
+		CreatedAt:    mr.UpdatedAt,
+		Author:       hook.Sender.Login,
+		ActionName:   action,
+		MergeRequest: &mr,
+	}
+}
+
+// GitHub webhook payload types (only the fields used for cache updates)
+type githubHookRepository struct {
+	FullName      string `json:"full_name"`
+	HTMLURL       string `json:"html_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+type githubWorkflowRunHook struct {
+	Action      string               `json:"action"`
+	WorkflowRun githubWorkflowRun    `json:"workflow_run"`
+	Repository  githubHookRepository `json:"repository"`
+	Sender      githubUser           `json:"sender"`
+}
+
+type githubPushHook struct {
+	Ref        string               `json:"ref"`
+	After      string               `json:"after"`
+	Created    bool                 `json:"created"`
+	Deleted    bool                 `json:"deleted"`
+	HeadCommit *githubHookCommit    `json:"head_commit"`
+	Repository githubHookRepository `json:"repository"`
+	Sender     githubUser           `json:"sender"`
+}
+
+type githubHookCommit struct {
+	ID        string    `json:"id"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+	Author    struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+}
+
+type githubPullRequestHook struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		githubPullRequest
+		Merged             bool         `json:"merged"`
+		RequestedReviewers []githubUser `json:"requested_reviewers"`
+	} `json:"pull_request"`
+	Repository githubHookRepository `json:"repository"`
+	Sender     githubUser           `json:"sender"`
+}
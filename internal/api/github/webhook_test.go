@@ -0,0 +1,155 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestParseWebhook tests translating workflow_run, push and pull_request payloads into events.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestParseWebhook(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		payload   string
+		check     func(t *testing.T, events []domain.Event)
+	}{
+		{
+			name:      "workflow_run",
+			eventType: "workflow_run",
+			payload: `{
+				"action": "completed",
+				"workflow_run": {"id": 42, "name": "CI", "head_branch": "main", "head_sha": "abc123", "status": "completed", "conclusion": "failure",
+					"html_url": "https://github.com/acme/api/actions/runs/42", "created_at": "2024-01-01T10:00:00Z", "updated_at": "2024-01-01T10:05:00Z"},
+				"repository": {"full_name": "acme/api", "html_url": "https://github.com/acme/api", "default_branch": "main"},
+				"sender": {"login": "alice"}
+			}`,
+			check: func(t *testing.T, events []domain.Event) {
+				if len(events) != 1 || events[0].Pipeline == nil {
+					t.Fatalf("expected 1 event with a pipeline snapshot, got %+v", events)
+				}
+				event, pipeline := events[0], events[0].Pipeline
+				if event.Type != "pipeline" || event.ProjectID != "acme/api" || event.Author != "alice" || event.ActionName != "completed" {
+					t.Errorf("unexpected event: %+v", event)
+				}
+				if pipeline.ID != "42" || pipeline.Branch != "main" || pipeline.Status != domain.StatusFailed {
+					t.Errorf("expected failed pipeline 42 on main, got %+v", pipeline)
+				}
+			},
+		},
+		{
+			name:      "push to a branch",
+			eventType: "push",
+			payload: `{
+				"ref": "refs/heads/feature/login", "after": "def456",
+				"head_commit": {"id": "def456", "message": "Add login", "timestamp": "2024-01-02T09:00:00Z", "author": {"name": "Bob", "email": "bob@example.com"}},
+				"repository": {"full_name": "acme/api", "html_url": "https://github.com/acme/api", "default_branch": "main"},
+				"sender": {"login": "bob"}
+			}`,
+			check: func(t *testing.T, events []domain.Event) {
+				if len(events) != 1 || events[0].Branch == nil {
+					t.Fatalf("expected 1 event with a branch snapshot, got %+v", events)
+				}
+				event, branch := events[0], events[0].Branch
+				if event.Type != "push" || event.TargetID != "feature/login" || event.ActionName != "updated" {
+					t.Errorf("unexpected event: %+v", event)
+				}
+				if branch.LastCommitSHA != "def456" || branch.IsDefault || branch.WebURL != "https://github.com/acme/api/tree/feature/login" {
+					t.Errorf("unexpected branch snapshot: %+v", branch)
+				}
+			},
+		},
+		{
+			name:      "deleted branch",
+			eventType: "push",
+			payload:   `{"ref": "refs/heads/old", "deleted": true, "repository": {"full_name": "acme/api"}, "sender": {"login": "bob"}}`,
+			check: func(t *testing.T, events []domain.Event) {
+				if len(events) != 1 || events[0].ActionName != "deleted" || events[0].Branch == nil || events[0].Branch.Name != "old" {
+					t.Errorf("expected a deleted event for branch old, got %+v", events)
+				}
+			},
+		},
+		{
+			name:      "push to a tag",
+			eventType: "push",
+			payload:   `{"ref": "refs/tags/v1.0.0", "repository": {"full_name": "acme/api"}}`,
+			check: func(t *testing.T, events []domain.Event) {
+				if len(events) != 0 {
+					t.Errorf("expected no events for tags, got %+v", events)
+				}
+			},
+		},
+		{
+			name:      "merged pull_request",
+			eventType: "pull_request",
+			payload: `{
+				"action": "closed",
+				"pull_request": {"number": 7, "title": "Fix login", "state": "closed", "merged": true,
+					"head": {"ref": "fix/login"}, "base": {"ref": "main"}, "user": {"login": "carol"},
+					"requested_reviewers": [{"login": "dave"}],
+					"created_at": "2024-01-03T10:00:00Z", "updated_at": "2024-01-03T12:00:00Z", "merged_at": "2024-01-03T12:00:00Z"},
+				"repository": {"full_name": "acme/api"},
+				"sender": {"login": "carol"}
+			}`,
+			check: func(t *testing.T, events []domain.Event) {
+				if len(events) != 1 || events[0].MergeRequest == nil {
+					t.Fatalf("expected 1 event with a merge request snapshot, got %+v", events)
+				}
+				event, mr := events[0], events[0].MergeRequest
+				if event.Type != "merge_request" || event.ActionName != "merged" {
+					t.Errorf("expected a merged event, got %+v", event)
+				}
+				if mr.ID != "7" || mr.State != "merged" || mr.SourceBranch != "fix/login" || len(mr.Reviewers) != 1 || mr.Reviewers[0] != "dave" {
+					t.Errorf("unexpected merge request snapshot: %+v", mr)
+				}
+			},
+		},
+		{
+			name:      "ping",
+			eventType: "ping",
+			payload:   `{"zen": "Keep it logically awesome."}`,
+			check: func(t *testing.T, events []domain.Event) {
+				if len(events) != 0 {
+					t.Errorf("expected no events for ping, got %+v", events)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, &mockHTTPClient{})
+
+			// Act
+			events, err := client.ParseWebhook(tt.eventType, []byte(tt.payload))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			tt.check(t, events)
+		})
+	}
+}
+
+// TestParseWebhook_InvalidPayload tests that malformed payloads of supported events are rejected.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestParseWebhook_InvalidPayload(t *testing.T) {
+	for _, eventType := range []string{"workflow_run", "push", "pull_request"} {
+		t.Run(eventType, func(t *testing.T) {
+			// Arrange
+			client := NewClient(api.ClientConfig{BaseURL: "https://api.github.com", Token: "token"}, &mockHTTPClient{})
+
+			// Act
+			_, err := client.ParseWebhook(eventType, []byte(`{"ref": 1`))
+
+			// Assert
+			if err == nil {
+				t.Error("expected an error for a malformed payload")
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// RetryPipeline runs all jobs of a pipeline again.
+// GitLab can only retry failed jobs in place, so a new pipeline is created for the pipeline's ref.
+func (c *Client) RetryPipeline(ctx context.Context, projectID, pipelineID string) error {
+	var pipeline gitlabPipeline
+	pipelineURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s", c.BaseURL, projectID, pipelineID)
+	if err := c.doRequest(ctx, pipelineURL, &pipeline); err != nil {
+		return fmt.Errorf("failed to get pipeline %s: %w", pipelineID, err)
+	}
+
+	return c.RunPipeline(ctx, projectID, pipeline.Ref, "")
+}
+
+// RerunFailedJobs retries the failed and canceled jobs of a pipeline.
+func (c *Client) RerunFailedJobs(ctx context.Context, projectID, pipelineID string) error {
+	actionURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/retry", c.BaseURL, projectID, pipelineID)
+	if err := c.doAction(ctx, actionURL); err != nil {
+		return fmt.Errorf("failed to retry pipeline %s: %w", pipelineID, err)
+	}
+	return nil
+}
+
+// CancelPipeline cancels the pending and running jobs of a pipeline.
+func (c *Client) CancelPipeline(ctx context.Context, projectID, pipelineID string) error {
+	actionURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/cancel", c.BaseURL, projectID, pipelineID)
+	if err := c.doAction(ctx, actionURL); err != nil {
+		return fmt.Errorf("failed to cancel pipeline %s: %w", pipelineID, err)
+	}
+	return nil
+}
+
+// RunPipeline creates a new pipeline for a branch or tag. GitLab has one pipeline per ref, so workflowID is ignored.
+func (c *Client) RunPipeline(ctx context.Context, projectID, ref, workflowID string) error {
+	actionURL := fmt.Sprintf("%s/api/v4/projects/%s/pipeline?ref=%s", c.BaseURL, projectID, url.QueryEscape(ref))
+	if err := c.doAction(ctx, actionURL); err != nil {
+		return fmt.Errorf("failed to run pipeline for %s: %w", ref, err)
+	}
+	return nil
+}
+
+// doAction sends an authenticated POST request, accepting any 2xx response.
+func (c *Client) doAction(ctx context.Context, actionURL string) error {
+	resp, err := c.DoWithRetry(ctx, c.newRequest(ctx, http.MethodPost, actionURL))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+)
+
+// TestPipelineActions tests that write actions call the matching GitLab endpoints.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestPipelineActions(t *testing.T) {
+	// Arrange
+	var requests []string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.Method+" "+req.URL.RequestURI())
+			body := `{}`
+			if req.Method == http.MethodGet {
+				body = `{"id": 42, "ref": "release/1.0"}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://gitlab.example.com", Token: "write-token"}, mockHTTP)
+	ctx := context.Background()
+
+	// Act
+	errs := []error{
+		client.RerunFailedJobs(ctx, "123", "42"),
+		client.CancelPipeline(ctx, "123", "42"),
+		client.RunPipeline(ctx, "123", "main", ""),
+	}
+
+	// Assert
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	expected := []string{
+		"POST /api/v4/projects/123/pipelines/42/retry",
+		"POST /api/v4/projects/123/pipelines/42/cancel",
+		"POST /api/v4/projects/123/pipeline?ref=main",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+// TestRetryPipeline tests that a full retry creates a new pipeline for the retried pipeline's ref.
+func TestRetryPipeline(t *testing.T) {
+	// Arrange
+	var runURL string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodGet {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     make(http.Header),
+					Body:       io.NopCloser(bytes.NewBufferString(`{"id": 42, "ref": "release/1.0"}`)),
+				}, nil
+			}
+			runURL = req.URL.RequestURI()
+			return &http.Response{
+				StatusCode: http.StatusForbidden,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(bytes.NewBufferString(`{"message": "403 Forbidden"}`)),
+			}, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://gitlab.example.com", Token: "read-token"}, mockHTTP)
+
+	// Act
+	err := client.RetryPipeline(context.Background(), "123", "42")
+
+	// Assert
+	if runURL != "/api/v4/projects/123/pipeline?ref=release%2F1.0" {
+		t.Errorf("expected new pipeline on release/1.0, got %s", runURL)
+	}
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected forbidden error, got %v", err)
+	}
+}
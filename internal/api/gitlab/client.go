@@ -2,12 +2,12 @@ package gitlab
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/vilaca/ci-dashboard/internal/api"
@@ -66,17 +66,9 @@ func (c *Client) GetProjectCount(ctx context.Context) (int, error) {
 	// Make a lightweight request to get count from headers
 	url := fmt.Sprintf("%s/api/v4/projects?per_page=1&page=1", c.BaseURL)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	resp, err := c.DoWithRetry(ctx, c.getRequest(ctx, url))
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("PRIVATE-TOKEN", c.Token)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return 0, fmt.Errorf("request failed: %w", err)
+		return 0, err
 	}
 	defer resp.Body.Close()
 
@@ -104,17 +96,9 @@ func (c *Client) GetProjectsPage(ctx context.Context, page int) ([]domain.Projec
 	// Order by last activity (commits, MRs, issues) - most recent first
 	url := fmt.Sprintf("%s/api/v4/projects?per_page=%d&page=%d&order_by=last_activity_at&sort=desc", c.BaseURL, api.DefaultPageSize, page)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, false, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("PRIVATE-TOKEN", c.Token)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.DoWithRetry(ctx, c.getRequest(ctx, url))
 	if err != nil {
-		return nil, false, fmt.Errorf("request failed: %w", err)
+		return nil, false, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -125,7 +109,7 @@ func (c *Client) GetProjectsPage(ctx context.Context, page int) ([]domain.Projec
 
 	// Decode this page's projects
 	var glProjects []gitlabProject
-	if err := json.NewDecoder(resp.Body).Decode(&glProjects); err != nil {
+	if err := api.DecodeJSON(resp, &glProjects); err != nil {
 		resp.Body.Close()
 		return nil, false, fmt.Errorf("failed to decode response: %w", err)
 	}
@@ -175,7 +159,22 @@ func (c *Client) GetLatestPipeline(ctx context.Context, projectID, branch string
 			return (*domain.Pipeline)(nil), nil
 		}
 
-		return c.convertPipeline(glPipelines[0], projectID), nil
+		pipeline := c.convertPipeline(glPipelines[0], projectID)
+
+		// Jobs are best-effort - a pipeline without job breakdown is still useful
+		builds, err := c.fetchPipelineJobs(ctx, projectID, pipeline.ID)
+		if err != nil {
+			log.Printf("[GitLab] Failed to get jobs for pipeline %s of project %s: %v", pipeline.ID, projectID, err)
+		} else {
+			pipeline.Builds = builds
+		}
+
+		// Pipeline lists leave coverage out - it is the average of the jobs' coverage
+		if pipeline.Coverage == nil {
+			pipeline.Coverage = pipeline.JobCoverage()
+		}
+
+		return pipeline, nil
 	})
 
 	if err != nil {
@@ -208,6 +207,49 @@ func (c *Client) GetPipelines(ctx context.Context, projectID string, limit int)
 	return result.([]domain.Pipeline), nil
 }
 
+// GetPipelineJobs retrieves the jobs of a pipeline.
+func (c *Client) GetPipelineJobs(ctx context.Context, projectID, pipelineID string) ([]domain.Build, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		return c.fetchPipelineJobs(ctx, projectID, pipelineID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Build), nil
+}
+
+// fetchPipelineJobs fetches pipeline jobs without acquiring the rate limit semaphore.
+// Used by methods that already hold a semaphore slot.
+func (c *Client) fetchPipelineJobs(ctx context.Context, projectID, pipelineID string) ([]domain.Build, error) {
+	var glJobs []gitlabJob
+	for page := 1; ; page++ {
+		// include_retried returns superseded attempts too, so retried jobs can be detected
+		url := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/jobs?include_retried=true&per_page=%d&page=%d",
+			c.BaseURL, projectID, pipelineID, api.DefaultPageSize, page)
+
+		var pageJobs []gitlabJob
+		if err := c.doRequest(ctx, url, &pageJobs); err != nil {
+			return nil, fmt.Errorf("failed to get pipeline jobs (URL: %s): %w", url, err)
+		}
+		glJobs = append(glJobs, pageJobs...)
+
+		// If we got fewer results than per_page, we're on the last page
+		if len(pageJobs) < api.DefaultPageSize {
+			break
+		}
+	}
+
+	// GitLab returns the most recent job first - reverse into execution order
+	builds := make([]domain.Build, len(glJobs))
+	for i, glJob := range glJobs {
+		builds[len(glJobs)-1-i] = c.convertJob(glJob)
+	}
+	domain.MarkRetriedBuilds(builds)
+
+	return builds, nil
+}
+
 // GetBranches retrieves all branches for a project (with pagination).
 // limit parameter is ignored - fetches all branches.
 func (c *Client) GetBranches(ctx context.Context, projectID string, limit int) ([]domain.Branch, error) {
@@ -275,19 +317,12 @@ func (c *Client) GetBranch(ctx context.Context, projectID, branchName string) (*
 }
 
 // doRequest performs an HTTP request to GitLab API.
+// Retries and rate limits are handled by api.BaseClient.DoWithRetry.
 // Follows Single Level of Abstraction Principle (SLAP).
 func (c *Client) doRequest(ctx context.Context, url string, result interface{}) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("PRIVATE-TOKEN", c.Token)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.DoWithRetry(ctx, c.getRequest(ctx, url))
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
@@ -296,13 +331,32 @@ func (c *Client) doRequest(ctx context.Context, url string, result interface{})
 		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+	if err := api.DecodeJSON(resp, result); err != nil {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return nil
 }
 
+// getRequest returns a builder of authenticated GET requests to the GitLab API, one per attempt.
+func (c *Client) getRequest(ctx context.Context, url string) func() (*http.Request, error) {
+	return c.newRequest(ctx, http.MethodGet, url)
+}
+
+// newRequest returns a builder of authenticated requests to the GitLab API, one per attempt.
+func (c *Client) newRequest(ctx context.Context, method, url string) func() (*http.Request, error) {
+	return func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("PRIVATE-TOKEN", c.Token)
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	}
+}
+
 // convertProjects converts GitLab projects to domain models.
 func (c *Client) convertProjects(glProjects []gitlabProject) []domain.Project {
 	projects := make([]domain.Project, len(glProjects))
@@ -363,15 +417,39 @@ func (c *Client) convertPipeline(glp gitlabPipeline, projectID string) *domain.P
 		ID:         fmt.Sprintf("%d", glp.ID),
 		ProjectID:  projectID,
 		Branch:     glp.Ref,
+		CommitSHA:  glp.SHA,
 		Status:     convertStatus(glp.Status),
 		CreatedAt:  glp.CreatedAt,
 		UpdatedAt:  glp.UpdatedAt,
 		Duration:   duration,
 		WebURL:     glp.WebURL,
+		Coverage:   glp.Coverage.Percent,
 		Repository: "", // Will be filled by service layer
 	}
 }
 
+// convertJob converts a GitLab job to domain Build.
+func (c *Client) convertJob(glJob gitlabJob) domain.Build {
+	build := domain.Build{
+		ID:       fmt.Sprintf("%d", glJob.ID),
+		Name:     glJob.Name,
+		Status:   convertStatus(glJob.Status),
+		Stage:    glJob.Stage,
+		Duration: time.Duration(glJob.Duration * float64(time.Second)),
+		WebURL:   glJob.WebURL,
+		Coverage: glJob.Coverage,
+	}
+
+	if glJob.StartedAt != nil {
+		build.StartedAt = *glJob.StartedAt
+	}
+	if glJob.QueuedDuration != nil {
+		build.Queued = time.Duration(*glJob.QueuedDuration * float64(time.Second))
+	}
+
+	return build
+}
+
 // convertBranch converts GitLab branch to domain model.
 func (c *Client) convertBranch(glb gitlabBranch, projectID string) domain.Branch {
 	return domain.Branch{
@@ -444,12 +522,28 @@ type gitlabAccessLevel struct {
 }
 
 type gitlabPipeline struct {
-	ID        int       `json:"id"`
-	Status    string    `json:"status"`
-	Ref       string    `json:"ref"`
-	WebURL    string    `json:"web_url"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        int            `json:"id"`
+	IID       int            `json:"iid"`
+	Status    string         `json:"status"`
+	Ref       string         `json:"ref"`
+	SHA       string         `json:"sha"`
+	WebURL    string         `json:"web_url"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	Coverage  gitlabCoverage `json:"coverage"` // only returned for single pipelines
+}
+
+type gitlabJob struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Stage     string     `json:"stage"`
+	Status    string     `json:"status"`
+	Duration  float64    `json:"duration"` // seconds, null while pending
+	StartedAt *time.Time `json:"started_at"`
+	WebURL    string     `json:"web_url"`
+	Coverage  *float64   `json:"coverage"` // null unless the job's coverage regex matched
+
+	QueuedDuration *float64 `json:"queued_duration"` // seconds pending before a runner picked the job up
 }
 
 type gitlabBranch struct {
@@ -509,6 +603,47 @@ func (c *Client) GetMergeRequests(ctx context.Context, projectID string) ([]doma
 	return result.([]domain.MergeRequest), nil
 }
 
+// GetMergedMergeRequests retrieves merge requests merged since the given time (with pagination).
+func (c *Client) GetMergedMergeRequests(ctx context.Context, projectID string, since time.Time) ([]domain.MergeRequest, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		var allMRs []domain.MergeRequest
+		page := 1
+		perPage := 100
+
+		for {
+			// merged_at >= since implies updated_at >= since, so updated_after narrows the result server-side
+			url := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests?state=merged&updated_after=%s&per_page=%d&page=%d&order_by=updated_at&sort=desc",
+				c.BaseURL, projectID, since.UTC().Format(time.RFC3339), perPage, page)
+
+			var glMRs []gitlabMergeRequest
+			if err := c.doRequest(ctx, url, &glMRs); err != nil {
+				return nil, fmt.Errorf("failed to get merged merge requests (page %d): %w", page, err)
+			}
+
+			for _, glMR := range glMRs {
+				mr := c.convertMergeRequest(glMR, projectID)
+				if !mr.MergedAt.Before(since) {
+					allMRs = append(allMRs, mr)
+				}
+			}
+
+			// If we got fewer results than perPage, we're on the last page
+			if len(glMRs) < perPage {
+				break
+			}
+
+			page++
+		}
+
+		return allMRs, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.MergeRequest), nil
+}
+
 // GetIssues retrieves open issues for a project.
 func (c *Client) GetIssues(ctx context.Context, projectID string) ([]domain.Issue, error) {
 	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
@@ -561,6 +696,87 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*domain.UserProfile, error
 	return result.(*domain.UserProfile), nil
 }
 
+// GetEvents retrieves project activity events created after since.
+// GitLab's "after" filter has day granularity, so results are filtered by timestamp locally.
+func (c *Client) GetEvents(ctx context.Context, projectID string, since time.Time) ([]domain.Event, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		// "after" is exclusive, so ask for events after the previous day
+		after := since.AddDate(0, 0, -1).Format("2006-01-02")
+		url := fmt.Sprintf("%s/api/v4/projects/%s/events?after=%s&per_page=%d&sort=desc",
+			c.BaseURL, projectID, after, api.DefaultPageSize)
+
+		var glEvents []gitlabEvent
+		if err := c.doRequest(ctx, url, &glEvents); err != nil {
+			return nil, fmt.Errorf("failed to get events (URL: %s): %w", url, err)
+		}
+
+		events := make([]domain.Event, 0, len(glEvents))
+		for _, glEvent := range glEvents {
+			if !glEvent.CreatedAt.After(since) {
+				continue
+			}
+			events = append(events, c.convertEvent(glEvent, projectID))
+		}
+
+		return events, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Event), nil
+}
+
+// convertEvent converts a GitLab project event to domain Event.
+func (c *Client) convertEvent(glEvent gitlabEvent, projectID string) domain.Event {
+	event := domain.Event{
+		ID:         fmt.Sprintf("%d", glEvent.ID),
+		ProjectID:  projectID,
+		Type:       strings.ToLower(glEvent.TargetType),
+		TargetType: strings.ToLower(glEvent.TargetType),
+		CreatedAt:  glEvent.CreatedAt,
+		Author:     glEvent.AuthorUsername,
+		ActionName: glEvent.ActionName,
+	}
+
+	if glEvent.TargetIID != 0 {
+		event.TargetID = fmt.Sprintf("%d", glEvent.TargetIID)
+	} else if glEvent.TargetID != 0 {
+		event.TargetID = fmt.Sprintf("%d", glEvent.TargetID)
+	}
+
+	// Push events carry ref information in push_data instead of a target
+	if glEvent.PushData != nil {
+		event.Type = "push"
+		event.TargetType = glEvent.PushData.RefType
+		event.TargetID = glEvent.PushData.Ref
+		switch glEvent.PushData.Action {
+		case "created":
+			event.ActionName = "created"
+		case "removed":
+			event.ActionName = "deleted"
+		default:
+			event.ActionName = "updated"
+		}
+		return event
+	}
+
+	if glEvent.TargetType == "MergeRequest" {
+		event.Type = "merge_request"
+		event.TargetType = "merge_request"
+		switch glEvent.ActionName {
+		case "accepted":
+			event.ActionName = "merged"
+		case "opened", "closed", "reopened":
+			event.ActionName = glEvent.ActionName
+		default:
+			event.ActionName = "updated"
+		}
+	}
+
+	return event
+}
+
 // convertMergeRequest converts GitLab MR to domain MergeRequest.
 func (c *Client) convertMergeRequest(glMR gitlabMergeRequest, projectID string) domain.MergeRequest {
 	// Extract reviewer usernames
@@ -571,6 +787,11 @@ func (c *Client) convertMergeRequest(glMR gitlabMergeRequest, projectID string)
 		}
 	}
 
+	var mergedAt time.Time
+	if glMR.MergedAt != nil {
+		mergedAt = *glMR.MergedAt
+	}
+
 	return domain.MergeRequest{
 		ID:           fmt.Sprintf("%d", glMR.IID),
 		Number:       glMR.IID,
@@ -584,6 +805,7 @@ func (c *Client) convertMergeRequest(glMR gitlabMergeRequest, projectID string)
 		Reviewers:    reviewers,
 		CreatedAt:    glMR.CreatedAt,
 		UpdatedAt:    glMR.UpdatedAt,
+		MergedAt:     mergedAt,
 		WebURL:       glMR.WebURL,
 		ProjectID:    projectID,
 		Repository:   "", // Will be set by service layer from project name
@@ -630,6 +852,7 @@ type gitlabMergeRequest struct {
 	Reviewers    []gitlabUser `json:"reviewers"` // Reviewers assigned to this MR
 	CreatedAt    time.Time    `json:"created_at"`
 	UpdatedAt    time.Time    `json:"updated_at"`
+	MergedAt     *time.Time   `json:"merged_at"`
 	WebURL       string       `json:"web_url"`
 }
 
@@ -656,3 +879,22 @@ type gitlabUser struct {
 	AvatarURL string `json:"avatar_url"`
 	WebURL    string `json:"web_url"`
 }
+
+// GitLab Event type
+type gitlabEvent struct {
+	ID             int             `json:"id"`
+	ActionName     string          `json:"action_name"`
+	TargetID       int             `json:"target_id"`
+	TargetIID      int             `json:"target_iid"`
+	TargetType     string          `json:"target_type"`
+	AuthorUsername string          `json:"author_username"`
+	CreatedAt      time.Time       `json:"created_at"`
+	PushData       *gitlabPushData `json:"push_data"`
+}
+
+// GitLab push event payload
+type gitlabPushData struct {
+	Action  string `json:"action"`   // "pushed", "created", "removed"
+	RefType string `json:"ref_type"` // "branch" or "tag"
+	Ref     string `json:"ref"`
+}
@@ -3,10 +3,12 @@ package gitlab
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/vilaca/ci-dashboard/internal/api"
 )
@@ -107,6 +109,53 @@ func TestGetProjects_APIError(t *testing.T) {
 	}
 }
 
+// TestGetProjectsPage_RetriesTransientErrors tests that rate limited and failed requests are retried.
+func TestGetProjectsPage_RetriesTransientErrors(t *testing.T) {
+	// Arrange
+	responses := []*http.Response{
+		{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": {"0"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`Retry later`)),
+		},
+		{
+			StatusCode: http.StatusBadGateway,
+			Body:       io.NopCloser(bytes.NewBufferString(`Bad Gateway`)),
+		},
+		{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"X-Total-Pages": {"1"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`[{"id": 123, "name": "test-project"}]`)),
+		},
+	}
+
+	attempts := 0
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			resp := responses[attempts]
+			attempts++
+			return resp, nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{BaseURL: "https://gitlab.com", Token: "test-token"}, mockHTTP)
+	client.RetryBackoff = time.Millisecond
+
+	// Act
+	projects, hasNext, err := client.GetProjectsPage(context.Background(), 1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if len(projects) != 1 || hasNext {
+		t.Errorf("expected a single page with 1 project, got %d projects (hasNext=%v)", len(projects), hasNext)
+	}
+}
+
 // TestGetLatestPipeline tests retrieving the latest pipeline.
 func TestGetLatestPipeline(t *testing.T) {
 	// Arrange
@@ -217,3 +266,328 @@ func TestConvertStatus(t *testing.T) {
 		})
 	}
 }
+
+// TestGetEvents tests retrieving and converting project events.
+func TestGetEvents(t *testing.T) {
+	// Arrange
+	responseBody := `[
+		{
+			"id": 3,
+			"action_name": "pushed to",
+			"target_type": null,
+			"author_username": "alice",
+			"created_at": "2024-01-02T10:00:00Z",
+			"push_data": {"action": "pushed", "ref_type": "branch", "ref": "main"}
+		},
+		{
+			"id": 2,
+			"action_name": "accepted",
+			"target_iid": 7,
+			"target_type": "MergeRequest",
+			"author_username": "bob",
+			"created_at": "2024-01-02T09:00:00Z"
+		},
+		{
+			"id": 1,
+			"action_name": "pushed to",
+			"author_username": "alice",
+			"created_at": "2024-01-01T08:00:00Z",
+			"push_data": {"action": "pushed", "ref_type": "branch", "ref": "old"}
+		}
+	]`
+
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if !strings.Contains(req.URL.Path, "/projects/123/events") {
+				t.Errorf("unexpected URL path: %s", req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			}, nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{
+		BaseURL: "https://gitlab.com",
+		Token:   "test-token",
+	}, mockHTTP)
+
+	since := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	events, err := client.GetEvents(context.Background(), "123", since)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events after %v, got %d", since, len(events))
+	}
+
+	if events[0].Type != "push" || events[0].TargetType != "branch" || events[0].TargetID != "main" {
+		t.Errorf("expected push to branch main, got %+v", events[0])
+	}
+
+	if events[1].Type != "merge_request" || events[1].TargetID != "7" || events[1].ActionName != "merged" {
+		t.Errorf("expected merged merge request 7, got %+v", events[1])
+	}
+}
+
+// TestGetPipelineJobs tests retrieving pipeline jobs in execution order.
+func TestGetPipelineJobs(t *testing.T) {
+	// Arrange
+	responseBody := `[
+		{
+			"id": 2,
+			"name": "unit",
+			"stage": "test",
+			"status": "failed",
+			"duration": 42.5,
+			"queued_duration": 3.5,
+			"web_url": "https://gitlab.com/user/test-project/-/jobs/2",
+			"created_at": "2024-01-01T10:01:00Z",
+			"started_at": "2024-01-01T10:01:05Z"
+		},
+		{
+			"id": 1,
+			"name": "compile",
+			"stage": "build",
+			"status": "success",
+			"duration": 30,
+			"web_url": "https://gitlab.com/user/test-project/-/jobs/1",
+			"created_at": "2024-01-01T10:00:00Z",
+			"started_at": "2024-01-01T10:00:05Z"
+		}
+	]`
+
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if !strings.Contains(req.URL.Path, "/pipelines/456/jobs") {
+				t.Errorf("unexpected request path: %s", req.URL.Path)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			}, nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{
+		BaseURL: "https://gitlab.com",
+		Token:   "test-token",
+	}, mockHTTP)
+
+	// Act
+	builds, err := client.GetPipelineJobs(context.Background(), "123", "456")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(builds) != 2 {
+		t.Fatalf("expected 2 builds, got %d", len(builds))
+	}
+
+	if builds[0].Name != "compile" || builds[0].Stage != "build" {
+		t.Errorf("expected first build 'compile' in stage 'build', got '%s' in '%s'", builds[0].Name, builds[0].Stage)
+	}
+
+	if builds[1].Status != "failed" {
+		t.Errorf("expected second build status 'failed', got '%s'", builds[1].Status)
+	}
+
+	if builds[1].Duration != 42500*time.Millisecond {
+		t.Errorf("expected duration 42.5s, got %v", builds[1].Duration)
+	}
+
+	if builds[1].Queued != 3500*time.Millisecond || builds[0].Queued != 0 {
+		t.Errorf("expected only the second build to have queued 3.5s, got %v and %v", builds[0].Queued, builds[1].Queued)
+	}
+}
+
+// TestParseWebhook_PipelineHook tests translating a pipeline hook into an event with a pipeline snapshot.
+func TestParseWebhook_PipelineHook(t *testing.T) {
+	// Arrange
+	payload := []byte(`{
+		"object_kind": "pipeline",
+		"object_attributes": {
+			"id": 31,
+			"ref": "main",
+			"status": "failed",
+			"duration": 63,
+			"created_at": "2024-01-01 10:00:00 UTC",
+			"finished_at": "2024-01-01 10:01:03 UTC"
+		},
+		"user": {"username": "alice"},
+		"project": {"id": 123, "web_url": "https://gitlab.com/user/test-project"},
+		"builds": [
+			{"id": 381, "stage": "test", "name": "unit", "status": "failed", "duration": 20.5, "started_at": "2024-01-01 10:00:40 UTC"},
+			{"id": 380, "stage": "build", "name": "compile", "status": "success", "duration": 30, "started_at": "2024-01-01 10:00:05 UTC"}
+		]
+	}`)
+
+	client := NewClient(api.ClientConfig{
+		BaseURL: "https://gitlab.com",
+		Token:   "test-token",
+	}, &mockHTTPClient{})
+
+	// Act
+	events, err := client.ParseWebhook("Pipeline Hook", payload)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(events) != 1 || events[0].Pipeline == nil {
+		t.Fatalf("expected 1 event with pipeline snapshot, got %+v", events)
+	}
+
+	pipeline := events[0].Pipeline
+	if pipeline.ID != "31" || pipeline.ProjectID != "123" || pipeline.Branch != "main" {
+		t.Errorf("unexpected pipeline identity: %+v", pipeline)
+	}
+
+	if pipeline.Status != "failed" {
+		t.Errorf("expected status 'failed', got '%s'", pipeline.Status)
+	}
+
+	if pipeline.WebURL != "https://gitlab.com/user/test-project/-/pipelines/31" {
+		t.Errorf("unexpected web URL '%s'", pipeline.WebURL)
+	}
+
+	if len(pipeline.Builds) != 2 || pipeline.Builds[0].Name != "compile" {
+		t.Fatalf("expected builds in execution order, got %+v", pipeline.Builds)
+	}
+
+	if !pipeline.UpdatedAt.Equal(time.Date(2024, 1, 1, 10, 1, 3, 0, time.UTC)) {
+		t.Errorf("expected finished_at as updated time, got %v", pipeline.UpdatedAt)
+	}
+}
+
+// TestParseWebhook_UnsupportedEvent tests that unsupported hooks yield no events.
+func TestParseWebhook_UnsupportedEvent(t *testing.T) {
+	// Arrange
+	client := NewClient(api.ClientConfig{
+		BaseURL: "https://gitlab.com",
+		Token:   "test-token",
+	}, &mockHTTPClient{})
+
+	// Act
+	events, err := client.ParseWebhook("Note Hook", []byte(`{}`))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(events) != 0 {
+		t.Errorf("expected no events, got %d", len(events))
+	}
+}
+
+// TestGetPipelineJobs_Paging tests that the jobs of pipelines with more jobs than a page are read page by page
+// and returned in execution order.
+func TestGetPipelineJobs_Paging(t *testing.T) {
+	// Arrange
+	total := api.DefaultPageSize + 1
+	jobs := func(from, to int) string {
+		var page []string
+		for id := from; id > to; id-- {
+			page = append(page, fmt.Sprintf(`{"id": %d, "name": "job-%d", "stage": "test", "status": "success"}`, id, id))
+		}
+		return "[" + strings.Join(page, ",") + "]"
+	}
+
+	var pages []string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			page := req.URL.Query().Get("page")
+			pages = append(pages, page)
+
+			// Most recent job first, as GitLab returns them
+			body := jobs(total, 1)
+			if page == "2" {
+				body = jobs(1, 0)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{
+		BaseURL: "https://gitlab.com",
+		Token:   "test-token",
+	}, mockHTTP)
+
+	// Act
+	builds, err := client.GetPipelineJobs(context.Background(), "123", "456")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(builds) != total {
+		t.Fatalf("expected %d builds, got %d", total, len(builds))
+	}
+
+	if builds[0].ID != "1" || builds[total-1].ID != fmt.Sprint(total) {
+		t.Errorf("expected jobs 1 to %d in execution order, got %s to %s", total, builds[0].ID, builds[total-1].ID)
+	}
+
+	if strings.Join(pages, ",") != "1,2" {
+		t.Errorf("expected pages 1 and 2 to be read, got %v", pages)
+	}
+}
+
+// TestGetPipelineJobs_MarksRetried tests that superseded job attempts are included and flagged as retried.
+func TestGetPipelineJobs_MarksRetried(t *testing.T) {
+	// Arrange
+	responseBody := `[
+		{"id": 3, "name": "unit", "stage": "test", "status": "success"},
+		{"id": 2, "name": "unit", "stage": "test", "status": "failed"},
+		{"id": 1, "name": "compile", "stage": "build", "status": "success"}
+	]`
+
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("include_retried") != "true" {
+				t.Errorf("expected include_retried=true, got query %q", req.URL.RawQuery)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			}, nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{
+		BaseURL: "https://gitlab.com",
+		Token:   "test-token",
+	}, mockHTTP)
+
+	// Act
+	builds, err := client.GetPipelineJobs(context.Background(), "123", "456")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(builds) != 3 {
+		t.Fatalf("expected 3 builds, got %d", len(builds))
+	}
+
+	if builds[0].Retried || !builds[1].Retried || builds[2].Retried {
+		t.Errorf("expected only the failed 'unit' attempt to be retried, got %+v", builds)
+	}
+}
@@ -0,0 +1,33 @@
+package gitlab
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// gitlabCoverage is a pipeline's coverage percentage. The REST API sends it as a string
+// (e.g. "87.50"), webhooks as a number; both may be null.
+type gitlabCoverage struct {
+	Percent *float64
+}
+
+// UnmarshalJSON accepts a string, a number or null. Anything else is treated as not reported.
+func (c *gitlabCoverage) UnmarshalJSON(data []byte) error {
+	c.Percent = nil
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	text := string(data)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+
+	// Unparseable coverage is dropped rather than failing the whole pipeline
+	percent, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil
+	}
+	c.Percent = &percent
+	return nil
+}
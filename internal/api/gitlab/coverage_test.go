@@ -0,0 +1,44 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestGitlabCoverage_UnmarshalJSON tests reading coverage sent as a string, a number or null; invalid values are ignored.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGitlabCoverage_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want *float64
+	}{
+		{name: "string", json: `{"coverage": "87.50"}`, want: floatPtr(87.5)},
+		{name: "number", json: `{"coverage": 64.2}`, want: floatPtr(64.2)},
+		{name: "null", json: `{"coverage": null}`},
+		{name: "missing", json: `{}`},
+		{name: "empty string", json: `{"coverage": ""}`},
+		{name: "invalid", json: `{"coverage": "n/a"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			var pipeline gitlabPipeline
+			err := json.Unmarshal([]byte(tt.json), &pipeline)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := pipeline.Coverage.Percent
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
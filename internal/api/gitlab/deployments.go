@@ -0,0 +1,170 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// GetEnvironments retrieves the available environments of a project with their latest deployment.
+// GitLab lists environments without deployments, so the latest deployments are fetched separately
+// and matched by environment name.
+func (c *Client) GetEnvironments(ctx context.Context, projectID string) ([]domain.Environment, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		envURL := fmt.Sprintf("%s/api/v4/projects/%s/environments?states=available&per_page=100", c.BaseURL, projectID)
+
+		var glEnvironments []gitlabEnvironment
+		if err := c.doRequest(ctx, envURL, &glEnvironments); err != nil {
+			return nil, fmt.Errorf("failed to get environments: %w", err)
+		}
+
+		if len(glEnvironments) == 0 {
+			return []domain.Environment{}, nil
+		}
+
+		deployURL := fmt.Sprintf("%s/api/v4/projects/%s/deployments?order_by=id&sort=desc&per_page=100", c.BaseURL, projectID)
+
+		var glDeployments []gitlabDeployment
+		if err := c.doRequest(ctx, deployURL, &glDeployments); err != nil {
+			return nil, fmt.Errorf("failed to get deployments: %w", err)
+		}
+
+		// Newest first - the first deployment of each environment is its latest
+		latest := make(map[string]*domain.Deployment)
+		for _, glDeployment := range glDeployments {
+			name := glDeployment.Environment.Name
+			if _, seen := latest[name]; !seen {
+				latest[name] = convertDeployment(glDeployment, projectID)
+			}
+		}
+
+		environments := make([]domain.Environment, len(glEnvironments))
+		for i, glEnvironment := range glEnvironments {
+			environments[i] = domain.Environment{
+				Name:           glEnvironment.Name,
+				ProjectID:      projectID,
+				Repository:     projectID,
+				URL:            glEnvironment.ExternalURL,
+				LastDeployment: latest[glEnvironment.Name],
+			}
+		}
+
+		sort.Slice(environments, func(i, j int) bool {
+			return environments[i].Name < environments[j].Name
+		})
+		return environments, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Environment), nil
+}
+
+// MaxDeploymentPages is the number of pages of 100 deployments read for the deployment history.
+const MaxDeploymentPages = 10
+
+// GetProductionDeployments retrieves the deployments of a project to production environments created since the given time.
+// Production environments are those of the production tier, or named like production when the tier is unknown.
+func (c *Client) GetProductionDeployments(ctx context.Context, projectID string, since time.Time) ([]domain.Deployment, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		deployments := []domain.Deployment{}
+		perPage := 100
+
+		for page := 1; page <= MaxDeploymentPages; page++ {
+			// created_at >= since implies updated_at >= since, so updated_after narrows the result server-side
+			deployURL := fmt.Sprintf("%s/api/v4/projects/%s/deployments?updated_after=%s&order_by=updated_at&sort=desc&per_page=%d&page=%d",
+				c.BaseURL, projectID, since.UTC().Format(time.RFC3339), perPage, page)
+
+			var glDeployments []gitlabDeployment
+			if err := c.doRequest(ctx, deployURL, &glDeployments); err != nil {
+				return nil, fmt.Errorf("failed to get deployments (page %d): %w", page, err)
+			}
+
+			for _, glDeployment := range glDeployments {
+				deployment := convertDeployment(glDeployment, projectID)
+				if deployment.Production && !deployment.CreatedAt.Before(since) {
+					deployments = append(deployments, *deployment)
+				}
+			}
+
+			if len(glDeployments) < perPage {
+				break
+			}
+		}
+
+		sort.Slice(deployments, func(i, j int) bool {
+			return deployments[i].CreatedAt.After(deployments[j].CreatedAt)
+		})
+		return deployments, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Deployment), nil
+}
+
+// convertDeployment converts a GitLab deployment to a domain deployment.
+func convertDeployment(glDeployment gitlabDeployment, projectID string) *domain.Deployment {
+	deployment := &domain.Deployment{
+		ID:          fmt.Sprintf("%d", glDeployment.ID),
+		ProjectID:   projectID,
+		Environment: glDeployment.Environment.Name,
+		Ref:         glDeployment.Ref,
+		CommitSHA:   glDeployment.SHA,
+		Status:      convertDeploymentStatus(glDeployment.Status),
+		CreatedAt:   glDeployment.CreatedAt,
+		UpdatedAt:   glDeployment.UpdatedAt,
+		Production:  glDeployment.Environment.Tier == "production" || (glDeployment.Environment.Tier == "" && domain.IsProductionEnvironment(glDeployment.Environment.Name)),
+	}
+
+	if glDeployment.User != nil {
+		deployment.Author = glDeployment.User.Username
+	}
+	if glDeployment.Deployable != nil {
+		deployment.WebURL = glDeployment.Deployable.WebURL
+	}
+	return deployment
+}
+
+// convertDeploymentStatus converts a GitLab deployment status to domain status.
+// Created and blocked (waiting for approval or a manual job) deployments have not started yet.
+func convertDeploymentStatus(glStatus string) domain.Status {
+	switch glStatus {
+	case "created", "blocked":
+		return domain.StatusPending
+	default:
+		return convertStatus(glStatus)
+	}
+}
+
+type gitlabEnvironment struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	ExternalURL string `json:"external_url"`
+}
+
+type gitlabDeployment struct {
+	ID          int                   `json:"id"`
+	Ref         string                `json:"ref"`
+	SHA         string                `json:"sha"`
+	Status      string                `json:"status"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+	User        *gitlabUser           `json:"user"`
+	Deployable  *gitlabDeployable     `json:"deployable"`
+	Environment gitlabEnvironmentName `json:"environment"`
+}
+
+type gitlabDeployable struct {
+	WebURL string `json:"web_url"`
+}
+
+type gitlabEnvironmentName struct {
+	Name string `json:"name"`
+	Tier string `json:"tier"` // production, staging, testing, development or other
+}
@@ -0,0 +1,113 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestGetEnvironments tests that each environment gets its latest deployment.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetEnvironments(t *testing.T) {
+	// Arrange
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			body := `[
+				{"id": 1, "name": "staging", "external_url": "https://staging.example.com"},
+				{"id": 2, "name": "production"},
+				{"id": 3, "name": "review"}
+			]`
+			if strings.Contains(req.URL.Path, "/deployments") {
+				body = `[
+					{"id": 30, "ref": "main", "sha": "abcdef1234567890", "status": "running", "user": {"username": "alice"}, "deployable": {"web_url": "https://gitlab.example.com/job/30"}, "environment": {"name": "staging"}},
+					{"id": 20, "ref": "v1.2.0", "sha": "1234567890abcdef", "status": "success", "user": {"username": "bob"}, "environment": {"name": "production"}},
+					{"id": 10, "ref": "main", "sha": "0000000000000000", "status": "success", "environment": {"name": "staging"}}
+				]`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://gitlab.example.com", Token: "token"}, mockHTTP)
+
+	// Act
+	environments, err := client.GetEnvironments(context.Background(), "123")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(environments) != 3 {
+		t.Fatalf("expected 3 environments, got %d", len(environments))
+	}
+
+	production, review, staging := environments[0], environments[1], environments[2]
+	if production.Name != "production" || production.LastDeployment == nil || production.LastDeployment.Ref != "v1.2.0" {
+		t.Errorf("expected production to have v1.2.0 deployed, got %+v", production)
+	}
+	if review.LastDeployment != nil {
+		t.Errorf("expected nothing deployed to review, got %+v", review.LastDeployment)
+	}
+	if staging.URL != "https://staging.example.com" {
+		t.Errorf("expected staging URL, got %q", staging.URL)
+	}
+	deployment := staging.LastDeployment
+	if deployment == nil || deployment.ID != "30" || deployment.Status != domain.StatusRunning || deployment.Author != "alice" {
+		t.Errorf("expected latest staging deployment 30 running by alice, got %+v", deployment)
+	}
+}
+
+// TestGetProductionDeployments tests that only deployments to production environments are returned.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetProductionDeployments(t *testing.T) {
+	// Arrange
+	var requestedURL string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			requestedURL = req.URL.String()
+			body := `[
+				{"id": 40, "status": "failed", "created_at": "2024-03-04T10:00:00Z", "environment": {"name": "eu", "tier": "production"}},
+				{"id": 30, "status": "success", "created_at": "2024-03-03T10:00:00Z", "environment": {"name": "production"}},
+				{"id": 20, "status": "success", "created_at": "2024-03-02T10:00:00Z", "environment": {"name": "staging", "tier": "staging"}},
+				{"id": 10, "status": "success", "created_at": "2024-02-01T10:00:00Z", "environment": {"name": "production"}}
+			]`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://gitlab.example.com", Token: "token"}, mockHTTP)
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	deployments, err := client.GetProductionDeployments(context.Background(), "123", since)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(requestedURL, "updated_after=2024-03-01T00:00:00Z") {
+		t.Errorf("expected deployments updated since the window, got %s", requestedURL)
+	}
+	if len(deployments) != 2 {
+		t.Fatalf("expected 2 production deployments in the window, got %d", len(deployments))
+	}
+	if deployments[0].ID != "40" || deployments[0].Status != domain.StatusFailed || !deployments[0].Production {
+		t.Errorf("expected failed deployment 40 to the production tier first, got %+v", deployments[0])
+	}
+	if deployments[1].ID != "30" || deployments[1].Status != domain.StatusSuccess {
+		t.Errorf("expected successful deployment 30 to production, got %+v", deployments[1])
+	}
+}
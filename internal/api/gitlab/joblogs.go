@@ -0,0 +1,49 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// GetJobLog returns the trace of a job, as it is downloaded.
+// The trace of a running job is what it has logged so far.
+func (c *Client) GetJobLog(ctx context.Context, projectID, jobID string) (io.ReadCloser, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		traceURL := fmt.Sprintf("%s/api/v4/projects/%s/jobs/%s/trace", c.BaseURL, projectID, jobID)
+
+		resp, err := c.DoWithRetry(ctx, c.logRequest(ctx, traceURL))
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		}
+		return resp.Body, nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log of job %s: %w", jobID, err)
+	}
+	return result.(io.ReadCloser), nil
+}
+
+// logRequest returns a builder of GET requests for plain text logs.
+// Logs can be large and grow while a job runs, so they are never stored for conditional requests.
+func (c *Client) logRequest(ctx context.Context, url string) func() (*http.Request, error) {
+	newRequest := c.getRequest(ctx, url)
+	return func() (*http.Request, error) {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Accept", "text/plain")
+		req.Header.Set("Cache-Control", "no-store")
+		return req, nil
+	}
+}
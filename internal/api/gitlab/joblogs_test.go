@@ -0,0 +1,48 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+)
+
+// TestGetJobLog tests that the job trace is returned as downloaded and never stored for conditional requests.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetJobLog(t *testing.T) {
+	// Arrange
+	var request *http.Request
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			request = req
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(bytes.NewBufferString("\x1b[32;1m$ make test\x1b[0;m\nok\n")),
+			}, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://gitlab.example.com", Token: "token"}, mockHTTP)
+
+	// Act
+	logReader, err := client.GetJobLog(context.Background(), "123", "456")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer logReader.Close()
+	jobLog, _ := io.ReadAll(logReader)
+	if string(jobLog) != "\x1b[32;1m$ make test\x1b[0;m\nok\n" {
+		t.Errorf("expected the raw trace, got %q", jobLog)
+	}
+	if request.URL.Path != "/api/v4/projects/123/jobs/456/trace" {
+		t.Errorf("expected the trace endpoint, got %s", request.URL.Path)
+	}
+	if request.Header.Get("Cache-Control") != "no-store" {
+		t.Errorf("expected the trace not to be stored, got Cache-Control %q", request.Header.Get("Cache-Control"))
+	}
+}
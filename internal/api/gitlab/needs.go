@@ -0,0 +1,133 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// jobNeedsQuery reads the jobs of a pipeline with their needs.
+// The REST API doesn't expose needs, so this is the only GraphQL query the client makes.
+const jobNeedsQuery = `query($ids: [ID!], $iid: ID!) {
+  projects(ids: $ids) {
+    nodes {
+      pipeline(iid: $iid) {
+        jobs(retried: false, first: 100) {
+          nodes { name schedulingType needs { nodes { name } } }
+        }
+      }
+    }
+  }
+}`
+
+// GetJobNeeds reads the needs: of a pipeline's jobs through the GraphQL API.
+// Only jobs scheduled by their needs are included - the others wait for the previous stage.
+func (c *Client) GetJobNeeds(ctx context.Context, projectID, pipelineID string) (map[string][]string, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		// GraphQL finds pipelines by their per-project iid
+		pipelineURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s", c.BaseURL, projectID, pipelineID)
+		var pipeline gitlabPipeline
+		if err := c.doRequest(ctx, pipelineURL, &pipeline); err != nil {
+			return nil, fmt.Errorf("failed to get pipeline %s: %w", pipelineID, err)
+		}
+
+		variables := map[string]interface{}{
+			"ids": []string{"gid://gitlab/Project/" + projectID},
+			"iid": fmt.Sprintf("%d", pipeline.IID),
+		}
+		var response gitlabJobNeedsResponse
+		if err := c.doGraphQL(ctx, jobNeedsQuery, variables, &response); err != nil {
+			return nil, fmt.Errorf("failed to get job needs of pipeline %s: %w", pipelineID, err)
+		}
+
+		needs := make(map[string][]string)
+		for _, project := range response.Data.Projects.Nodes {
+			if project.Pipeline == nil {
+				continue
+			}
+			for _, job := range project.Pipeline.Jobs.Nodes {
+				if !strings.EqualFold(job.SchedulingType, "dag") {
+					continue
+				}
+				names := make([]string, 0, len(job.Needs.Nodes))
+				for _, need := range job.Needs.Nodes {
+					names = append(names, need.Name)
+				}
+				needs[job.Name] = names
+			}
+		}
+		return needs, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string][]string), nil
+}
+
+// doGraphQL posts a GraphQL query and decodes the response. Query errors are returned as errors.
+func (c *Client) doGraphQL(ctx context.Context, query string, variables map[string]interface{}, result *gitlabJobNeedsResponse) error {
+	payload, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	resp, err := c.DoWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/api/graphql", bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GraphQL API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("GraphQL query failed: %s", result.Errors[0].Message)
+	}
+	return nil
+}
+
+type gitlabJobNeedsResponse struct {
+	Data struct {
+		Projects struct {
+			Nodes []struct {
+				Pipeline *struct {
+					Jobs struct {
+						Nodes []gitlabGraphQLJob `json:"nodes"`
+					} `json:"jobs"`
+				} `json:"pipeline"`
+			} `json:"nodes"`
+		} `json:"projects"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type gitlabGraphQLJob struct {
+	Name           string `json:"name"`
+	SchedulingType string `json:"schedulingType"` // "stage" or "dag"
+	Needs          struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"needs"`
+}
@@ -0,0 +1,62 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+)
+
+// TestGetJobNeeds tests reading the needs of DAG-scheduled jobs through GraphQL.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetJobNeeds(t *testing.T) {
+	// Arrange
+	var variables map[string]interface{}
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			body := `{"id": 456, "iid": 7, "status": "success"}`
+			if req.URL.Path == "/api/graphql" {
+				var payload struct {
+					Variables map[string]interface{} `json:"variables"`
+				}
+				json.NewDecoder(req.Body).Decode(&payload)
+				variables = payload.Variables
+				body = `{"data": {"projects": {"nodes": [{"pipeline": {"jobs": {"nodes": [
+					{"name": "build", "schedulingType": "stage", "needs": {"nodes": []}},
+					{"name": "lint", "schedulingType": "dag", "needs": {"nodes": []}},
+					{"name": "test", "schedulingType": "dag", "needs": {"nodes": [{"name": "build"}]}}
+				]}}}]}}}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+			}, nil
+		},
+	}
+	client := NewClient(api.ClientConfig{BaseURL: "https://gitlab.example.com", Token: "token"}, mockHTTP)
+
+	// Act
+	needs, err := client.GetJobNeeds(context.Background(), "123", "456")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if variables["iid"] != "7" {
+		t.Errorf("expected the pipeline to be queried by iid 7, got %v", variables)
+	}
+	if _, ok := needs["build"]; ok {
+		t.Errorf("expected stage-scheduled jobs to be left out, got %v", needs)
+	}
+	if lint, ok := needs["lint"]; !ok || len(lint) != 0 {
+		t.Errorf("expected lint to need nothing, got %v", needs)
+	}
+	if test := needs["test"]; len(test) != 1 || test[0] != "build" {
+		t.Errorf("expected test to need build, got %v", needs)
+	}
+}
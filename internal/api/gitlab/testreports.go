@@ -0,0 +1,104 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// GetTestReport retrieves the test report GitLab builds from the JUnit artifacts of a pipeline's jobs.
+// Returns nil if the pipeline has no test results.
+func (c *Client) GetTestReport(ctx context.Context, projectID, pipelineID string) (*domain.TestReport, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		reportURL := fmt.Sprintf("%s/api/v4/projects/%s/pipelines/%s/test_report", c.BaseURL, projectID, pipelineID)
+
+		var glReport gitlabTestReport
+		if err := c.doRequest(ctx, reportURL, &glReport); err != nil {
+			return nil, fmt.Errorf("failed to get test report of pipeline %s: %w", pipelineID, err)
+		}
+
+		if glReport.TotalCount == 0 {
+			return (*domain.TestReport)(nil), nil
+		}
+		return convertTestReport(glReport, projectID, pipelineID), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.TestReport), nil
+}
+
+// convertTestReport converts a GitLab pipeline test report to the domain model.
+func convertTestReport(glReport gitlabTestReport, projectID, pipelineID string) *domain.TestReport {
+	report := &domain.TestReport{
+		ProjectID:  projectID,
+		PipelineID: pipelineID,
+		Suites:     make([]domain.TestSuite, 0, len(glReport.TestSuites)),
+	}
+
+	for _, glSuite := range glReport.TestSuites {
+		suite := domain.TestSuite{
+			Name:     glSuite.Name,
+			Duration: secondsToDuration(glSuite.TotalTime),
+			Cases:    make([]domain.TestCase, 0, len(glSuite.TestCases)),
+		}
+		for _, glCase := range glSuite.TestCases {
+			tc := domain.TestCase{
+				Name:      glCase.Name,
+				ClassName: glCase.ClassName,
+				Suite:     glSuite.Name,
+				Status:    convertTestStatus(glCase.Status),
+				Duration:  secondsToDuration(glCase.ExecutionTime),
+			}
+			if tc.Status.IsFailure() {
+				tc.Message = api.FailureMessage(glCase.SystemOutput, glCase.StackTrace)
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		report.Suites = append(report.Suites, suite)
+	}
+	return report
+}
+
+// convertTestStatus converts a GitLab test case status to domain test status.
+func convertTestStatus(glStatus string) domain.TestStatus {
+	switch glStatus {
+	case "failed":
+		return domain.TestFailed
+	case "error":
+		return domain.TestError
+	case "skipped":
+		return domain.TestSkipped
+	default:
+		return domain.TestPassed
+	}
+}
+
+// secondsToDuration converts a duration in seconds to time.Duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+type gitlabTestReport struct {
+	TotalCount int               `json:"total_count"`
+	TestSuites []gitlabTestSuite `json:"test_suites"`
+}
+
+type gitlabTestSuite struct {
+	Name      string           `json:"name"`
+	TotalTime float64          `json:"total_time"`
+	TestCases []gitlabTestCase `json:"test_cases"`
+}
+
+type gitlabTestCase struct {
+	Status        string  `json:"status"`
+	Name          string  `json:"name"`
+	ClassName     string  `json:"classname"`
+	ExecutionTime float64 `json:"execution_time"`
+	SystemOutput  string  `json:"system_output"`
+	StackTrace    string  `json:"stack_trace"`
+}
@@ -0,0 +1,93 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestGetTestReport tests converting the pipeline test report, and that empty reports yield nil.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetTestReport(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantNil    bool
+		wantFailed []string
+	}{
+		{
+			name: "report with failures",
+			body: `{"total_count": 3, "test_suites": [{"name": "rspec", "total_time": 1.5, "test_cases": [
+				{"status": "success", "name": "creates a user", "classname": "spec.users", "execution_time": 0.5},
+				{"status": "failed", "name": "deletes a user", "classname": "spec.users", "execution_time": 1, "system_output": "expected 204, got 500"},
+				{"status": "error", "name": "lists users", "classname": "spec.users", "stack_trace": "NoMethodError"}
+			]}]}`,
+			wantFailed: []string{"spec.users.deletes a user", "spec.users.lists users"},
+		},
+		{
+			name:    "pipeline without test results",
+			body:    `{"total_count": 0, "test_suites": []}`,
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var path string
+			mockHTTP := &mockHTTPClient{
+				doFunc: func(req *http.Request) (*http.Response, error) {
+					path = req.URL.Path
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     make(http.Header),
+						Body:       io.NopCloser(bytes.NewBufferString(tt.body)),
+					}, nil
+				},
+			}
+			client := NewClient(api.ClientConfig{BaseURL: "https://gitlab.example.com", Token: "token"}, mockHTTP)
+
+			// Act
+			report, err := client.GetTestReport(context.Background(), "123", "456")
+
+			// Assert
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if path != "/api/v4/projects/123/pipelines/456/test_report" {
+				t.Errorf("expected the test report endpoint, got %s", path)
+			}
+			if tt.wantNil {
+				if report != nil {
+					t.Errorf("expected no report, got %+v", report)
+				}
+				return
+			}
+
+			if report == nil || report.Total() != 3 || report.PipelineID != "456" {
+				t.Fatalf("expected a report of 3 tests for pipeline 456, got %+v", report)
+			}
+			failed := report.FailedCases()
+			if len(failed) != len(tt.wantFailed) {
+				t.Fatalf("expected %d failed tests, got %+v", len(tt.wantFailed), failed)
+			}
+			for i, name := range tt.wantFailed {
+				if failed[i].FullName() != name {
+					t.Errorf("expected failed test %q, got %q", name, failed[i].FullName())
+				}
+			}
+			if failed[0].Status != domain.TestFailed || failed[0].Message != "expected 204, got 500" || failed[0].Duration != time.Second {
+				t.Errorf("expected failure details to be converted, got %+v", failed[0])
+			}
+			if failed[1].Status != domain.TestError || failed[1].Message != "NoMethodError" {
+				t.Errorf("expected error details to be converted, got %+v", failed[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// zeroSHA is the commit SHA GitLab reports as "after" when a branch is deleted.
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// ParseWebhook translates a GitLab webhook payload into domain events.
+// eventType is the value of the X-Gitlab-Event header.
+// Supports pipeline, push and merge request hooks; other hooks yield no events.
+func (c *Client) ParseWebhook(eventType string, payload []byte) ([]domain.Event, error) {
+	switch eventType {
+	case "Pipeline Hook":
+		var hook gitlabPipelineHook
+		if err := json.Unmarshal(payload, &hook); err != nil {
+			return nil, fmt.Errorf("failed to decode pipeline hook: %w", err)
+		}
+		return []domain.Event{c.convertPipelineHook(hook)}, nil
+
+	case "Push Hook":
+		var hook gitlabPushHook
+		if err := json.Unmarshal(payload, &hook); err != nil {
+			return nil, fmt.Errorf("failed to decode push hook: %w", err)
+		}
+		if !strings.HasPrefix(hook.Ref, "refs/heads/") {
+			return nil, nil
+		}
+		return []domain.Event{c.convertPushHook(hook)}, nil
+
+	case "Merge Request Hook":
+		var hook gitlabMergeRequestHook
+		if err := json.Unmarshal(payload, &hook); err != nil {
+			return nil, fmt.Errorf("failed to decode merge request hook: %w", err)
+		}
+		return []domain.Event{c.convertMergeRequestHook(hook)}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// convertPipelineHook converts a pipeline hook to an event with a pipeline snapshot (including jobs).
+func (c *Client) convertPipelineHook(hook gitlabPipelineHook) domain.Event {
+	projectID := fmt.Sprintf("%d", hook.Project.ID)
+	attrs := hook.ObjectAttributes
+
+	createdAt := attrs.CreatedAt.Time
+	updatedAt := time.Now()
+	if attrs.FinishedAt != nil && !attrs.FinishedAt.IsZero() {
+		updatedAt = attrs.FinishedAt.Time
+	}
+
+	duration := updatedAt.Sub(createdAt)
+	if attrs.Duration > 0 {
+		duration = time.Duration(attrs.Duration * float64(time.Second))
+	}
+
+	// Builds arrive in no particular order - job IDs follow creation order
+	sort.SliceStable(hook.Builds, func(i, j int) bool {
+		return hook.Builds[i].ID < hook.Builds[j].ID
+	})
+
+	builds := make([]domain.Build, 0, len(hook.Builds))
+	for _, b := range hook.Builds {
+		build := domain.Build{
+			ID:       fmt.Sprintf("%d", b.ID),
+			Name:     b.Name,
+			Status:   convertStatus(b.Status),
+			Stage:    b.Stage,
+			Duration: time.Duration(b.Duration * float64(time.Second)),
+			WebURL:   fmt.Sprintf("%s/-/jobs/%d", hook.Project.WebURL, b.ID),
+		}
+		if b.StartedAt != nil {
+			build.StartedAt = b.StartedAt.Time
+		}
+		builds = append(builds, build)
+	}
+	domain.MarkRetriedBuilds(builds)
+
+	pipeline := &domain.Pipeline{
+		ID:        fmt.Sprintf("%d", attrs.ID),
+		ProjectID: projectID,
+		Branch:    attrs.Ref,
+		CommitSHA: attrs.SHA,
+		Status:    convertStatus(attrs.Status),
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+		Duration:  duration,
+		WebURL:    fmt.Sprintf("%s/-/pipelines/%d", hook.Project.WebURL, attrs.ID),
+		Builds:    builds,
+	}
+
+	return domain.Event{
+		ID:         pipeline.ID,
+		ProjectID:  projectID,
+		Type:       "pipeline",
+		TargetType: "pipeline",
+		TargetID:   pipeline.ID,
+		CreatedAt:  updatedAt,
+		Author:     hook.User.Username,
+		ActionName: attrs.Status,
+		Pipeline:   pipeline,
+	}
+}
+
+// convertPushHook converts a branch push hook to an event with a branch snapshot.
+// The snapshot is omitted when the payload doesn't include the head commit.
+func (c *Client) convertPushHook(hook gitlabPushHook) domain.Event {
+	projectID := fmt.Sprintf("%d", hook.Project.ID)
+	branchName := strings.TrimPrefix(hook.Ref, "refs/heads/")
+
+	event := domain.Event{
+		ID:         hook.After,
+		ProjectID:  projectID,
+		Type:       "push",
+		TargetType: "branch",
+		TargetID:   branchName,
+		CreatedAt:  time.Now(),
+		Author:     hook.UserUsername,
+		ActionName: "updated",
+	}
+
+	switch {
+	case hook.After == zeroSHA:
+		event.ActionName = "deleted"
+		event.Branch = &domain.Branch{Name: branchName, ProjectID: projectID, Platform: "gitlab"}
+		return event
+	case hook.Before == zeroSHA:
+		event.ActionName = "created"
+	}
+
+	// Find the head commit - commits are listed oldest first
+	var head *gitlabHookCommit
+	for i := range hook.Commits {
+		if hook.Commits[i].ID == hook.After {
+			head = &hook.Commits[i]
+		}
+	}
+	if head == nil {
+		return event
+	}
+
+	event.CreatedAt = head.Timestamp.Time
+	event.Branch = &domain.Branch{
+		Name:           branchName,
+		ProjectID:      projectID,
+		Repository:     projectID,
+		LastCommitSHA:  head.ID,
+		LastCommitMsg:  head.Message,
+		LastCommitDate: head.Timestamp.Time,
+		CommitAuthor:   head.Author.Name,
+		AuthorEmail:    head.Author.Email,
+		IsDefault:      branchName == hook.Project.DefaultBranch,
+		WebURL:         fmt.Sprintf("%s/-/tree/%s", hook.Project.WebURL, branchName),
+		Platform:       "gitlab",
+	}
+
+	return event
+}
+
+// convertMergeRequestHook converts a merge request hook to an event with a merge request snapshot.
+func (c *Client) convertMergeRequestHook(hook gitlabMergeRequestHook) domain.Event {
+	projectID := fmt.Sprintf("%d", hook.Project.ID)
+	attrs := hook.ObjectAttributes
+
+	reviewers := make([]string, 0, len(hook.Reviewers))
+	for _, reviewer := range hook.Reviewers {
+		if reviewer.Username != "" {
+			reviewers = append(reviewers, reviewer.Username)
+		}
+	}
+
+	// The hook only identifies the acting user - that's the author when the MR is opened
+	author := ""
+	if attrs.Action == "open" {
+		author = hook.User.Username
+	}
+
+	mr := &domain.MergeRequest{
+		ID:           fmt.Sprintf("%d", attrs.IID),
+		Number:       attrs.IID,
+		Title:        attrs.Title,
+		Description:  attrs.Description,
+		State:        attrs.State,
+		IsDraft:      attrs.Draft || attrs.WorkInProgress,
+		SourceBranch: attrs.SourceBranch,
+		TargetBranch: attrs.TargetBranch,
+		Author:       author,
+		Reviewers:    reviewers,
+		CreatedAt:    attrs.CreatedAt.Time,
+		UpdatedAt:    attrs.UpdatedAt.Time,
+		WebURL:       attrs.URL,
+		ProjectID:    projectID,
+	}
+
+	action := attrs.Action
+	switch action {
+	case "open":
+		action = "opened"
+	case "close":
+		action = "closed"
+	case "reopen":
+		action = "reopened"
+	case "merge":
+		action = "merged"
+	default:
+		action = "updated"
+	}
+
+	return domain.Event{
+		ID:           fmt.Sprintf("%d", attrs.IID),
+		ProjectID:    projectID,
+		Type:         "merge_request",
+		TargetType:   "merge_request",
+		TargetID:     fmt.Sprintf("%d", attrs.IID),
+		CreatedAt:    attrs.UpdatedAt.Time,
+		Author:       hook.User.Username,
+		ActionName:   action,
+		MergeRequest: mr,
+	}
+}
+
+// gitlabHookTime parses webhook timestamps.
+// Depending on hook type and GitLab version, they are RFC 3339 or "2006-01-02 15:04:05 UTC".
+type gitlabHookTime struct {
+	time.Time
+}
+
+func (t *gitlabHookTime) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"} {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported time format: %s", s)
+}
+
+// GitLab webhook payload types (only the fields used for cache updates)
+type gitlabHookProject struct {
+	ID            int    `json:"id"`
+	WebURL        string `json:"web_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+type gitlabHookUser struct {
+	Username string `json:"username"`
+}
+
+type gitlabPipelineHook struct {
+	ObjectAttributes struct {
+		ID         int             `json:"id"`
+		Ref        string          `json:"ref"`
+		SHA        string          `json:"sha"`
+		Status     string          `json:"status"`
+		Duration   float64         `json:"duration"`
+		CreatedAt  gitlabHookTime  `json:"created_at"`
+		FinishedAt *gitlabHookTime `json:"finished_at"`
+	} `json:"object_attributes"`
+	User    gitlabHookUser    `json:"user"`
+	Project gitlabHookProject `json:"project"`
+	Builds  []struct {
+		ID        int             `json:"id"`
+		Stage     string          `json:"stage"`
+		Name      string          `json:"name"`
+		Status    string          `json:"status"`
+		Duration  float64         `json:"duration"`
+		StartedAt *gitlabHookTime `json:"started_at"`
+	} `json:"builds"`
+}
+
+type gitlabPushHook struct {
+	Before       string             `json:"before"`
+	After        string             `json:"after"`
+	Ref          string             `json:"ref"`
+	UserUsername string             `json:"user_username"`
+	Project      gitlabHookProject  `json:"project"`
+	Commits      []gitlabHookCommit `json:"commits"`
+}
+
+type gitlabHookCommit struct {
+	ID        string         `json:"id"`
+	Message   string         `json:"message"`
+	Timestamp gitlabHookTime `json:"timestamp"`
+	Author    struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+}
+
+type gitlabMergeRequestHook struct {
+	User             gitlabHookUser    `json:"user"`
+	Project          gitlabHookProject `json:"project"`
+	Reviewers        []gitlabHookUser  `json:"reviewers"`
+	ObjectAttributes struct {
+		IID            int            `json:"iid"`
+		Title          string         `json:"title"`
+		Description    string         `json:"description"`
+		State          string         `json:"state"`
+		Action         string         `json:"action"`
+		Draft          bool           `json:"draft"`
+		WorkInProgress bool           `json:"work_in_progress"`
+		SourceBranch   string         `json:"source_branch"`
+		TargetBranch   string         `json:"target_branch"`
+		URL            string         `json:"url"`
+		CreatedAt      gitlabHookTime `json:"created_at"`
+		UpdatedAt      gitlabHookTime `json:"updated_at"`
+	} `json:"object_attributes"`
+}
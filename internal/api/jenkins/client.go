@@ -0,0 +1,391 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+const (
+	// buildsPerJob is how many recent builds are read per job (and per branch of a multibranch job)
+	buildsPerJob = 50
+
+	// buildFields are the build fields read from the JSON API
+	buildFields = "number,result,building,timestamp,duration,actions[lastBuiltRevision[SHA1,branch[name]]]"
+)
+
+// errNotFound is returned by doRequest for 404 responses.
+var errNotFound = errors.New("not found")
+
+// Job links a Jenkins job to the repository whose pipelines it runs.
+type Job struct {
+	Path   string // job path with folders separated by "/" (e.g. "team/app")
+	Branch string // branch built by a single-branch job (empty = the git branch recorded on each build)
+}
+
+// Client implements api.Client for Jenkins.
+// Jenkins only runs pipelines: repositories and branches come from the platform the jobs are linked to,
+// and pipelines are looked up by the linked repository's project ID.
+// Follows Single Responsibility Principle - only handles Jenkins API communication.
+type Client struct {
+	*api.BaseClient
+	username string         // API token owner (empty = anonymous read access)
+	jobs     map[string]Job // linked project ID -> job
+}
+
+// NewClient creates a new Jenkins client for jobs linked to repository project IDs.
+// Uses dependency injection for HTTPClient (IoC).
+func NewClient(config api.ClientConfig, jobs map[string]Job, httpClient api.HTTPClient) *Client {
+	return &Client{
+		BaseClient: api.NewBaseClient(strings.TrimSuffix(config.BaseURL, "/"), config.Token, httpClient),
+		username:   config.Username,
+		jobs:       jobs,
+	}
+}
+
+// GetProjects returns no projects - repositories come from the linked platform.
+func (c *Client) GetProjects(ctx context.Context) ([]domain.Project, error) {
+	return []domain.Project{}, nil
+}
+
+// GetProjectCount returns 0 - repositories come from the linked platform.
+func (c *Client) GetProjectCount(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+// GetProjectsPage returns no projects - repositories come from the linked platform.
+func (c *Client) GetProjectsPage(ctx context.Context, page int) ([]domain.Project, bool, error) {
+	return []domain.Project{}, false, nil
+}
+
+// GetBranches returns no branches - branches come from the linked platform.
+func (c *Client) GetBranches(ctx context.Context, projectID string, limit int) ([]domain.Branch, error) {
+	return []domain.Branch{}, nil
+}
+
+// GetBranch is not supported - branches come from the linked platform.
+func (c *Client) GetBranch(ctx context.Context, projectID, branchName string) (*domain.Branch, error) {
+	return nil, fmt.Errorf("branches are not available from Jenkins")
+}
+
+// GetLatestPipeline retrieves the most recent build of a branch of the job linked to a project.
+// Stages are read from the Pipeline Stage View API (wfapi) when the job is a Pipeline job.
+func (c *Client) GetLatestPipeline(ctx context.Context, projectID, branch string) (*domain.Pipeline, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		runs, err := c.fetchRuns(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, run := range runs {
+			if run.branch != branch {
+				continue
+			}
+
+			pipeline := c.convertRun(run, projectID)
+
+			// Stages are best-effort - freestyle jobs have none
+			builds, err := c.fetchStages(ctx, run, pipeline.WebURL)
+			if err != nil && !errors.Is(err, errNotFound) {
+				log.Printf("[Jenkins] Failed to get stages for build %s of %s: %v", pipeline.ID, run.jobURL, err)
+			} else {
+				pipeline.Builds = builds
+			}
+
+			return pipeline, nil
+		}
+
+		return (*domain.Pipeline)(nil), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.Pipeline), nil
+}
+
+// GetPipelines retrieves recent builds of the job linked to a project, across all branches.
+func (c *Client) GetPipelines(ctx context.Context, projectID string, limit int) ([]domain.Pipeline, error) {
+	result, err := c.DoRateLimited(ctx, func() (interface{}, error) {
+		runs, err := c.fetchRuns(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+
+		if limit > 0 && len(runs) > limit {
+			runs = runs[:limit]
+		}
+
+		pipelines := make([]domain.Pipeline, len(runs))
+		for i, run := range runs {
+			pipelines[i] = *c.convertRun(run, projectID)
+		}
+
+		return pipelines, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Pipeline), nil
+}
+
+// fetchRuns fetches the recent builds of the job linked to a project, newest first.
+// A multibranch job is read in the same request: its branches are child jobs named after the branch.
+func (c *Client) fetchRuns(ctx context.Context, projectID string) ([]jenkinsRun, error) {
+	job, ok := c.jobs[projectID]
+	if !ok {
+		return nil, fmt.Errorf("no Jenkins job linked to project %s", projectID)
+	}
+
+	jobURL := c.jobURL(job.Path)
+	builds := fmt.Sprintf("builds[%s]{0,%d}", buildFields, buildsPerJob)
+	query := url.Values{}
+	query.Set("tree", fmt.Sprintf("%s,jobs[name,%s]", builds, builds))
+
+	var response jenkinsJob
+	if err := c.doRequest(ctx, jobURL+"/api/json?"+query.Encode(), &response); err != nil {
+		return nil, fmt.Errorf("failed to get builds of %s: %w", job.Path, err)
+	}
+
+	var runs []jenkinsRun
+	for _, build := range response.Builds {
+		branch := job.Branch
+		if branch == "" {
+			branch = build.gitBranch()
+		}
+		runs = append(runs, jenkinsRun{build: build, branch: branch, jobURL: jobURL})
+	}
+
+	for _, child := range response.Jobs {
+		// Branch job names are URL-encoded branch names (feature/x -> feature%2Fx)
+		branch, err := url.PathUnescape(child.Name)
+		if err != nil {
+			branch = child.Name
+		}
+		childURL := jobURL + "/job/" + url.PathEscape(child.Name)
+		for _, build := range child.Builds {
+			runs = append(runs, jenkinsRun{build: build, branch: branch, jobURL: childURL})
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].build.Timestamp > runs[j].build.Timestamp
+	})
+
+	return runs, nil
+}
+
+// fetchStages fetches the stages of a Pipeline build without acquiring the rate limit semaphore.
+// Returns errNotFound for builds without stages (freestyle jobs, or the Stage View plugin missing).
+func (c *Client) fetchStages(ctx context.Context, run jenkinsRun, buildURL string) ([]domain.Build, error) {
+	var response struct {
+		Stages []jenkinsStage `json:"stages"`
+	}
+	if err := c.doRequest(ctx, fmt.Sprintf("%s/%d/wfapi/describe", run.jobURL, run.build.Number), &response); err != nil {
+		return nil, err
+	}
+
+	builds := make([]domain.Build, len(response.Stages))
+	for i, stage := range response.Stages {
+		builds[i] = convertStage(stage, buildURL)
+	}
+	return builds, nil
+}
+
+// jobURL returns the URL of a job from its folder path (team/app -> /job/team/job/app).
+// URLs are built from the configured base rather than taken from responses, so credentials
+// are only ever sent to the configured host.
+func (c *Client) jobURL(path string) string {
+	var b strings.Builder
+	b.WriteString(c.BaseURL)
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		b.WriteString("/job/")
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String()
+}
+
+// doRequest performs an HTTP request to the Jenkins JSON API.
+// Returns errNotFound for 404 responses.
+// Follows Single Level of Abstraction Principle (SLAP).
+func (c *Client) doRequest(ctx context.Context, url string, result interface{}) error {
+	resp, err := c.DoWithRetry(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.username != "" {
+			req.SetBasicAuth(c.username, c.Token)
+		}
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := api.DecodeJSON(resp, result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// convertRun converts a Jenkins build to domain model.
+func (c *Client) convertRun(run jenkinsRun, projectID string) *domain.Pipeline {
+	build := run.build
+	createdAt := time.UnixMilli(build.Timestamp)
+
+	updatedAt := createdAt.Add(time.Duration(build.Duration) * time.Millisecond)
+	if build.Building {
+		updatedAt = time.Now()
+	}
+
+	status := build.Result
+	if build.Building {
+		status = "IN_PROGRESS"
+	}
+
+	return &domain.Pipeline{
+		ID:         strconv.Itoa(build.Number),
+		ProjectID:  projectID,
+		Repository: projectID,
+		Branch:     run.branch,
+		CommitSHA:  build.commitSHA(),
+		Status:     convertStatus(status),
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+		Duration:   updatedAt.Sub(createdAt),
+		WebURL:     fmt.Sprintf("%s/%d/", run.jobURL, build.Number),
+	}
+}
+
+// convertStage converts a Pipeline stage to domain Build.
+// Jenkins stages are the units of work, so the stage is left empty.
+func convertStage(stage jenkinsStage, buildURL string) domain.Build {
+	return domain.Build{
+		ID:        stage.ID,
+		Name:      stage.Name,
+		Status:    convertStatus(stage.Status),
+		Duration:  time.Duration(stage.DurationMillis) * time.Millisecond,
+		StartedAt: time.UnixMilli(stage.StartTimeMillis),
+		WebURL:    buildURL,
+	}
+}
+
+// convertStatus converts Jenkins build results and stage statuses to domain status.
+// A build without a result has not started yet.
+func convertStatus(status string) domain.Status {
+	switch status {
+	case "", "QUEUED", "PAUSED_PENDING_INPUT", "NOT_EXECUTED_YET":
+		return domain.StatusPending
+	case "IN_PROGRESS":
+		return domain.StatusRunning
+	case "SUCCESS":
+		return domain.StatusSuccess
+	case "FAILURE", "FAILED", "UNSTABLE": // unstable = tests failed
+		return domain.StatusFailed
+	case "ABORTED":
+		return domain.StatusCanceled
+	case "NOT_BUILT", "NOT_EXECUTED":
+		return domain.StatusSkipped
+	default:
+		return domain.Status(strings.ToLower(status))
+	}
+}
+
+// Jenkins API response types
+
+// jenkinsRun is a build with the branch it ran for and the URL of the job it belongs to.
+type jenkinsRun struct {
+	build  jenkinsBuild
+	branch string
+	jobURL string
+}
+
+type jenkinsJob struct {
+	Builds []jenkinsBuild `json:"builds"`
+	Jobs   []struct {
+		Name   string         `json:"name"`
+		Builds []jenkinsBuild `json:"builds"`
+	} `json:"jobs"` // branches of a multibranch job
+}
+
+type jenkinsBuild struct {
+	Number    int    `json:"number"`
+	Result    string `json:"result"` // null while building
+	Building  bool   `json:"building"`
+	Timestamp int64  `json:"timestamp"` // start, in milliseconds
+	Duration  int64  `json:"duration"`  // milliseconds, 0 while building
+	Actions   []struct {
+		LastBuiltRevision *struct {
+			SHA1   string `json:"SHA1"`
+			Branch []struct {
+				Name string `json:"name"` // e.g. refs/remotes/origin/main or origin/main
+			} `json:"branch"`
+		} `json:"lastBuiltRevision"`
+	} `json:"actions"`
+}
+
+// revision returns the git revision recorded by the Git plugin, if any.
+func (b jenkinsBuild) revision() (sha, branch string) {
+	for _, action := range b.Actions {
+		if rev := action.LastBuiltRevision; rev != nil {
+			if len(rev.Branch) > 0 {
+				branch = rev.Branch[0].Name
+			}
+			return rev.SHA1, branch
+		}
+	}
+	return "", ""
+}
+
+// commitSHA returns the commit the build ran on.
+func (b jenkinsBuild) commitSHA() string {
+	sha, _ := b.revision()
+	return sha
+}
+
+// gitBranch returns the branch the build ran on, without the remote (origin/main -> main).
+func (b jenkinsBuild) gitBranch() string {
+	_, branch := b.revision()
+	if name, found := strings.CutPrefix(branch, "refs/heads/"); found {
+		return name
+	}
+	branch = strings.TrimPrefix(branch, "refs/remotes/")
+	if _, name, found := strings.Cut(branch, "/"); found {
+		return name
+	}
+	return branch
+}
+
+type jenkinsStage struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Status          string `json:"status"` // e.g. SUCCESS, FAILED, IN_PROGRESS, NOT_EXECUTED
+	StartTimeMillis int64  `json:"startTimeMillis"`
+	DurationMillis  int64  `json:"durationMillis"`
+}
@@ -0,0 +1,190 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// mockHTTPClient is a test double for HTTPClient.
+// Follows FIRST principles - tests are Fast and Independent.
+type mockHTTPClient struct {
+	doFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *mockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return m.doFunc(req)
+}
+
+// jsonResponse builds a 200 response with a JSON body.
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+// TestGetLatestPipeline_Multibranch tests reading a branch build and its stages from a multibranch job.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetLatestPipeline_Multibranch(t *testing.T) {
+	// Arrange
+	jobBody := `{"jobs": [
+		{"name": "main", "builds": [
+			{"number": 7, "result": "SUCCESS", "building": false, "timestamp": 1767348000000, "duration": 60000}
+		]},
+		{"name": "feature%2Flogin", "builds": [
+			{"number": 3, "result": null, "building": true, "timestamp": 1767351600000, "duration": 0,
+			 "actions": [{}, {"lastBuiltRevision": {"SHA1": "abc123", "branch": [{"name": "feature/login"}]}}]}
+		]}
+	]}`
+	stagesBody := `{"stages": [
+		{"id": "6", "name": "Build", "status": "SUCCESS", "startTimeMillis": 1767351600000, "durationMillis": 30000},
+		{"id": "12", "name": "Test", "status": "IN_PROGRESS", "startTimeMillis": 1767351630000, "durationMillis": 5000}
+	]}`
+
+	var stagesPath string
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if user, pass, ok := req.BasicAuth(); !ok || user != "ci-bot" || pass != "api-token" {
+				t.Errorf("expected API token basic auth, got %q", req.Header.Get("Authorization"))
+			}
+			if strings.HasSuffix(req.URL.Path, "/wfapi/describe") {
+				stagesPath = req.URL.EscapedPath()
+				return jsonResponse(stagesBody), nil
+			}
+			if req.URL.Path != "/job/team/job/app/api/json" {
+				t.Errorf("unexpected job URL %s", req.URL)
+			}
+			return jsonResponse(jobBody), nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{
+		BaseURL:  "https://jenkins.example.com/",
+		Token:    "api-token",
+		Username: "ci-bot",
+	}, map[string]Job{"42": {Path: "team/app"}}, mockHTTP)
+
+	// Act
+	pipeline, err := client.GetLatestPipeline(context.Background(), "42", "feature/login")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pipeline == nil {
+		t.Fatal("expected pipeline, got nil")
+	}
+	if pipeline.ID != "3" || pipeline.ProjectID != "42" || pipeline.Status != domain.StatusRunning || pipeline.CommitSHA != "abc123" {
+		t.Errorf("unexpected pipeline: %+v", pipeline)
+	}
+	if pipeline.WebURL != "https://jenkins.example.com/job/team/job/app/job/feature%252Flogin/3/" {
+		t.Errorf("unexpected web URL %s", pipeline.WebURL)
+	}
+	if stagesPath != "/job/team/job/app/job/feature%252Flogin/3/wfapi/describe" {
+		t.Errorf("unexpected stages URL %s", stagesPath)
+	}
+	if len(pipeline.Builds) != 2 || pipeline.Builds[0].Status != domain.StatusSuccess || pipeline.Builds[1].Status != domain.StatusRunning {
+		t.Errorf("expected 2 stages, got %+v", pipeline.Builds)
+	}
+}
+
+// TestGetPipelines_GitBranches tests attributing builds of a single-branch job to their git branches.
+func TestGetPipelines_GitBranches(t *testing.T) {
+	// Arrange
+	jobBody := `{"builds": [
+		{"number": 11, "result": "UNSTABLE", "timestamp": 1767355200000, "duration": 120000,
+		 "actions": [{"lastBuiltRevision": {"SHA1": "bbb", "branch": [{"name": "refs/remotes/origin/release/1.x"}]}}]},
+		{"number": 10, "result": "ABORTED", "timestamp": 1767351600000, "duration": 60000,
+		 "actions": [{"lastBuiltRevision": {"SHA1": "aaa", "branch": [{"name": "origin/main"}]}}]}
+	]}`
+
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Authorization") != "" {
+				t.Errorf("expected anonymous request, got %q", req.Header.Get("Authorization"))
+			}
+			return jsonResponse(jobBody), nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{BaseURL: "https://jenkins.example.com"}, map[string]Job{"acme/api": {Path: "legacy-api"}}, mockHTTP)
+
+	// Act
+	pipelines, err := client.GetPipelines(context.Background(), "acme/api", 10)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pipelines) != 2 {
+		t.Fatalf("expected 2 pipelines, got %d", len(pipelines))
+	}
+	if pipelines[0].Branch != "release/1.x" || pipelines[0].Status != domain.StatusFailed {
+		t.Errorf("expected failed build on release/1.x, got %s on %s", pipelines[0].Status, pipelines[0].Branch)
+	}
+	if pipelines[1].Branch != "main" || pipelines[1].Status != domain.StatusCanceled {
+		t.Errorf("expected canceled build on main, got %s on %s", pipelines[1].Status, pipelines[1].Branch)
+	}
+	if pipelines[0].Duration.Minutes() != 2 {
+		t.Errorf("expected 2m duration, got %v", pipelines[0].Duration)
+	}
+}
+
+// TestGetPipelines_UnlinkedProject tests that projects without a linked job are rejected without a request.
+func TestGetPipelines_UnlinkedProject(t *testing.T) {
+	// Arrange
+	mockHTTP := &mockHTTPClient{
+		doFunc: func(req *http.Request) (*http.Response, error) {
+			t.Errorf("unexpected request %s", req.URL)
+			return jsonResponse(`{}`), nil
+		},
+	}
+
+	client := NewClient(api.ClientConfig{BaseURL: "https://jenkins.example.com"}, map[string]Job{}, mockHTTP)
+
+	// Act
+	_, err := client.GetPipelines(context.Background(), "99", 10)
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected error for unlinked project")
+	}
+}
+
+// TestConvertStatus tests Jenkins result and stage status conversion.
+func TestConvertStatus(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected domain.Status
+	}{
+		{"", domain.StatusPending},
+		{"PAUSED_PENDING_INPUT", domain.StatusPending},
+		{"IN_PROGRESS", domain.StatusRunning},
+		{"SUCCESS", domain.StatusSuccess},
+		{"FAILURE", domain.StatusFailed},
+		{"FAILED", domain.StatusFailed},
+		{"UNSTABLE", domain.StatusFailed},
+		{"ABORTED", domain.StatusCanceled},
+		{"NOT_BUILT", domain.StatusSkipped},
+		{"NOT_EXECUTED", domain.StatusSkipped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			// Act
+			result := convertStatus(tt.input)
+
+			// Assert
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
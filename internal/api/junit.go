@@ -0,0 +1,143 @@
+package api
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// MaxTestMessageBytes is the longest failure message kept per test case.
+const MaxTestMessageBytes = 2000
+
+// junitSuite is a JUnit <testsuite>, or the <testsuites> root holding them.
+// Suites can nest - some runners group suites by package or file.
+type junitSuite struct {
+	XMLName xml.Name
+	Name    string       `xml:"name,attr"`
+	Time    string       `xml:"time,attr"`
+	Cases   []junitCase  `xml:"testcase"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+// junitCase is a JUnit <testcase>.
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+	Error     *junitFailure `xml:"error"`
+	Skipped   *struct{}     `xml:"skipped"`
+}
+
+// junitFailure is the <failure> or <error> of a JUnit test case.
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnit parses a JUnit XML report with either a <testsuites> or a <testsuite> root.
+// Nested suites are flattened; suites without test cases are dropped.
+func ParseJUnit(r io.Reader) ([]domain.TestSuite, error) {
+	var root junitSuite
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("failed to parse JUnit XML: %w", err)
+	}
+
+	switch root.XMLName.Local {
+	case "testsuites":
+		var suites []domain.TestSuite
+		for _, suite := range root.Suites {
+			suites = appendJUnitSuite(suites, suite)
+		}
+		return suites, nil
+	case "testsuite":
+		return appendJUnitSuite(nil, root), nil
+	default:
+		return nil, fmt.Errorf("not a JUnit report: root element is <%s>", root.XMLName.Local)
+	}
+}
+
+// appendJUnitSuite converts a JUnit suite and its nested suites, appending those with test cases.
+func appendJUnitSuite(suites []domain.TestSuite, js junitSuite) []domain.TestSuite {
+	if len(js.Cases) > 0 {
+		suite := domain.TestSuite{
+			Name:     js.Name,
+			Duration: parseJUnitSeconds(js.Time),
+			Cases:    make([]domain.TestCase, 0, len(js.Cases)),
+		}
+		for _, jc := range js.Cases {
+			suite.Cases = append(suite.Cases, convertJUnitCase(jc, js.Name))
+		}
+		suites = append(suites, suite)
+	}
+
+	for _, nested := range js.Suites {
+		suites = appendJUnitSuite(suites, nested)
+	}
+	return suites
+}
+
+// convertJUnitCase converts a JUnit test case to the domain model.
+func convertJUnitCase(jc junitCase, suiteName string) domain.TestCase {
+	tc := domain.TestCase{
+		Name:      strings.TrimSpace(jc.Name),
+		ClassName: strings.TrimSpace(jc.ClassName),
+		Suite:     suiteName,
+		Status:    domain.TestPassed,
+		Duration:  parseJUnitSeconds(jc.Time),
+	}
+
+	switch {
+	case jc.Failure != nil:
+		tc.Status = domain.TestFailed
+		tc.Message = FailureMessage(jc.Failure.Message, jc.Failure.Text)
+	case jc.Error != nil:
+		tc.Status = domain.TestError
+		tc.Message = FailureMessage(jc.Error.Message, jc.Error.Text)
+	case jc.Skipped != nil:
+		tc.Status = domain.TestSkipped
+	}
+	return tc
+}
+
+// parseJUnitSeconds parses a duration in seconds, e.g. "1.25". Some runners add thousands separators.
+// Returns zero for a missing or invalid duration.
+func parseJUnitSeconds(value string) time.Duration {
+	seconds, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(value), ",", ""), 64)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// FailureMessage combines the message and details of a test failure, shortened to MaxTestMessageBytes.
+// Details repeating the message are left out.
+func FailureMessage(message, details string) string {
+	message = strings.TrimSpace(message)
+	details = strings.TrimSpace(details)
+
+	text := message
+	switch {
+	case text == "":
+		text = details
+	case details != "" && !strings.HasPrefix(details, message):
+		text += "\n" + details
+	case details != "":
+		text = details
+	}
+
+	if len(text) <= MaxTestMessageBytes {
+		return text
+	}
+	cut := MaxTestMessageBytes
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + "…"
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestParseJUnit tests parsing JUnit XML reports with either root element.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestParseJUnit(t *testing.T) {
+	tests := []struct {
+		name       string
+		xml        string
+		wantSuites []string
+		wantCases  map[string]domain.TestStatus
+		wantErr    bool
+	}{
+		{
+			name: "testsuites root with nested suites",
+			xml: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="pkg" time="1,200.5">
+    <testsuite name="pkg.api" time="0.75">
+      <testcase name="TestGet" classname="pkg.api" time="0.5"/>
+      <testcase name="TestPost" classname="pkg.api" time="0.25">
+        <failure message="expected 200">got 500</failure>
+      </testcase>
+    </testsuite>
+  </testsuite>
+  <testsuite name="pkg.db">
+    <testcase name="TestConnect" classname="pkg.db"><error message="connection refused"/></testcase>
+    <testcase name="TestMigrate" classname="pkg.db"><skipped/></testcase>
+  </testsuite>
+</testsuites>`,
+			wantSuites: []string{"pkg.api", "pkg.db"},
+			wantCases: map[string]domain.TestStatus{
+				"pkg.api.TestGet":    domain.TestPassed,
+				"pkg.api.TestPost":   domain.TestFailed,
+				"pkg.db.TestConnect": domain.TestError,
+				"pkg.db.TestMigrate": domain.TestSkipped,
+			},
+		},
+		{
+			name:       "testsuite root",
+			xml:        `<testsuite name="unit"><testcase name="adds numbers" time="0.01"/></testsuite>`,
+			wantSuites: []string{"unit"},
+			wantCases:  map[string]domain.TestStatus{"adds numbers": domain.TestPassed},
+		},
+		{
+			name:    "not a JUnit report",
+			xml:     `<project><modelVersion>4.0.0</modelVersion></project>`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid XML",
+			xml:     `<testsuite name="unit"><testcase`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			suites, err := ParseJUnit(strings.NewReader(tt.xml))
+
+			// Assert
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %d suites", len(suites))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			var names []string
+			cases := make(map[string]domain.TestStatus)
+			for _, suite := range suites {
+				names = append(names, suite.Name)
+				for _, tc := range suite.Cases {
+					cases[tc.FullName()] = tc.Status
+				}
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantSuites, ",") {
+				t.Errorf("expected suites %v, got %v", tt.wantSuites, names)
+			}
+			if len(cases) != len(tt.wantCases) {
+				t.Errorf("expected %d cases, got %v", len(tt.wantCases), cases)
+			}
+			for name, status := range tt.wantCases {
+				if cases[name] != status {
+					t.Errorf("expected %s to be %s, got %q", name, status, cases[name])
+				}
+			}
+		})
+	}
+}
+
+// TestParseJUnit_DurationsAndMessages tests that durations and failure messages are read.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestParseJUnit_DurationsAndMessages(t *testing.T) {
+	// Arrange
+	report := `<testsuite name="unit" time="1.5">
+  <testcase name="TestA" time="1.25"><failure message="boom">boom
+stack line</failure></testcase>
+</testsuite>`
+
+	// Act
+	suites, err := ParseJUnit(strings.NewReader(report))
+
+	// Assert
+	if err != nil || len(suites) != 1 || len(suites[0].Cases) != 1 {
+		t.Fatalf("expected one suite with one case, got %v (err=%v)", suites, err)
+	}
+	if suites[0].Duration != 1500*time.Millisecond {
+		t.Errorf("expected suite duration 1.5s, got %v", suites[0].Duration)
+	}
+
+	tc := suites[0].Cases[0]
+	if tc.Duration != 1250*time.Millisecond {
+		t.Errorf("expected case duration 1.25s, got %v", tc.Duration)
+	}
+	if tc.Message != "boom\nstack line" {
+		t.Errorf("expected details without the repeated message, got %q", tc.Message)
+	}
+}
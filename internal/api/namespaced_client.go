@@ -0,0 +1,367 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// connectionSeparator separates a project ID from the name of its connection (123@gitlab-internal).
+// No platform uses it in project IDs, and it is neither a cache key nor a path separator.
+const connectionSeparator = "@"
+
+// NamespaceProjectID qualifies a platform project ID with the name of its connection.
+func NamespaceProjectID(projectID, connection string) string {
+	return projectID + connectionSeparator + connection
+}
+
+// SplitProjectID splits a qualified project ID into the platform project ID and the connection name.
+// The connection is empty for IDs that are not qualified.
+func SplitProjectID(id string) (projectID, connection string) {
+	if i := strings.LastIndex(id, connectionSeparator); i >= 0 {
+		return id[:i], id[i+len(connectionSeparator):]
+	}
+	return id, ""
+}
+
+// NamespacedClient qualifies the project IDs of a client with a connection name, so projects from
+// several instances of the same platform (e.g. two GitLab hosts) never share an ID.
+// IDs are qualified on the way out and unqualified on the way in, so the wrapped client only sees its own IDs.
+// Follows Decorator Pattern - wraps any Client without modifying it.
+type NamespacedClient struct {
+	client     Client
+	connection string
+}
+
+// NewNamespacedClient creates a client whose project IDs are qualified with connection.
+func NewNamespacedClient(client Client, connection string) *NamespacedClient {
+	return &NamespacedClient{client: client, connection: connection}
+}
+
+// projectID returns the wrapped client's ID for a qualified project ID.
+func (c *NamespacedClient) projectID(id string) string {
+	projectID, connection := SplitProjectID(id)
+	if connection != c.connection {
+		return id
+	}
+	return projectID
+}
+
+// qualify returns the qualified ID of a wrapped client's project ID.
+func (c *NamespacedClient) qualify(projectID string) string {
+	if projectID == "" {
+		return ""
+	}
+	return NamespaceProjectID(projectID, c.connection)
+}
+
+// qualifyRepository qualifies repository names that are placeholders for the project ID.
+func (c *NamespacedClient) qualifyRepository(repository, projectID string) string {
+	if repository == projectID {
+		return c.qualify(repository)
+	}
+	return repository
+}
+
+// GetProjects retrieves all projects with qualified IDs.
+func (c *NamespacedClient) GetProjects(ctx context.Context) ([]domain.Project, error) {
+	projects, err := c.client.GetProjects(ctx)
+	return c.qualifyProjects(projects), err
+}
+
+// GetProjectsPage retrieves a page of projects with qualified IDs.
+func (c *NamespacedClient) GetProjectsPage(ctx context.Context, page int) ([]domain.Project, bool, error) {
+	projects, hasNext, err := c.client.GetProjectsPage(ctx, page)
+	return c.qualifyProjects(projects), hasNext, err
+}
+
+// GetProjectCount returns the total number of projects.
+func (c *NamespacedClient) GetProjectCount(ctx context.Context) (int, error) {
+	return c.client.GetProjectCount(ctx)
+}
+
+// GetLatestPipeline retrieves the most recent pipeline of a branch.
+func (c *NamespacedClient) GetLatestPipeline(ctx context.Context, projectID, branch string) (*domain.Pipeline, error) {
+	pipeline, err := c.client.GetLatestPipeline(ctx, c.projectID(projectID), branch)
+	if pipeline != nil {
+		c.qualifyPipeline(pipeline)
+	}
+	return pipeline, err
+}
+
+// GetPipelines retrieves recent pipelines of a project.
+func (c *NamespacedClient) GetPipelines(ctx context.Context, projectID string, limit int) ([]domain.Pipeline, error) {
+	pipelines, err := c.client.GetPipelines(ctx, c.projectID(projectID), limit)
+	for i := range pipelines {
+		c.qualifyPipeline(&pipelines[i])
+	}
+	return pipelines, err
+}
+
+// GetBranches retrieves branches of a project.
+func (c *NamespacedClient) GetBranches(ctx context.Context, projectID string, limit int) ([]domain.Branch, error) {
+	branches, err := c.client.GetBranches(ctx, c.projectID(projectID), limit)
+	for i := range branches {
+		c.qualifyBranch(&branches[i])
+	}
+	return branches, err
+}
+
+// GetBranch retrieves a single branch by name.
+func (c *NamespacedClient) GetBranch(ctx context.Context, projectID, branchName string) (*domain.Branch, error) {
+	branch, err := c.client.GetBranch(ctx, c.projectID(projectID), branchName)
+	if branch != nil {
+		c.qualifyBranch(branch)
+	}
+	return branch, err
+}
+
+// GetMergeRequests retrieves open merge requests of a project.
+func (c *NamespacedClient) GetMergeRequests(ctx context.Context, projectID string) ([]domain.MergeRequest, error) {
+	extended, ok := c.client.(ExtendedClient)
+	if !ok {
+		return nil, fmt.Errorf("underlying client does not support GetMergeRequests")
+	}
+
+	mrs, err := extended.GetMergeRequests(ctx, c.projectID(projectID))
+	for i := range mrs {
+		c.qualifyMergeRequest(&mrs[i])
+	}
+	return mrs, err
+}
+
+// GetIssues retrieves open issues of a project.
+func (c *NamespacedClient) GetIssues(ctx context.Context, projectID string) ([]domain.Issue, error) {
+	extended, ok := c.client.(ExtendedClient)
+	if !ok {
+		return nil, fmt.Errorf("underlying client does not support GetIssues")
+	}
+
+	issues, err := extended.GetIssues(ctx, c.projectID(projectID))
+	for i := range issues {
+		issues[i].Repository = c.qualifyRepository(issues[i].Repository, issues[i].ProjectID)
+		issues[i].ProjectID = c.qualify(issues[i].ProjectID)
+	}
+	return issues, err
+}
+
+// GetEnvironments retrieves the environments of a project with their latest deployment.
+func (c *NamespacedClient) GetEnvironments(ctx context.Context, projectID string) ([]domain.Environment, error) {
+	deployments, ok := c.client.(DeploymentsClient)
+	if !ok {
+		return nil, fmt.Errorf("underlying client does not support GetEnvironments")
+	}
+
+	environments, err := deployments.GetEnvironments(ctx, c.projectID(projectID))
+	for i := range environments {
+		environments[i].Repository = c.qualifyRepository(environments[i].Repository, environments[i].ProjectID)
+		environments[i].ProjectID = c.qualify(environments[i].ProjectID)
+		if deployment := environments[i].LastDeployment; deployment != nil {
+			deployment.ProjectID = c.qualify(deployment.ProjectID)
+		}
+	}
+	return environments, err
+}
+
+// GetJobLog retrieves the log of a job.
+func (c *NamespacedClient) GetJobLog(ctx context.Context, projectID, jobID string) (io.ReadCloser, error) {
+	logs, ok := c.client.(JobLogClient)
+	if !ok {
+		return nil, fmt.Errorf("underlying client does not support GetJobLog")
+	}
+	return logs.GetJobLog(ctx, c.projectID(projectID), jobID)
+}
+
+// GetPipelineJobs retrieves the jobs of a pipeline.
+func (c *NamespacedClient) GetPipelineJobs(ctx context.Context, projectID, pipelineID string) ([]domain.Build, error) {
+	jobs, ok := c.client.(JobsClient)
+	if !ok {
+		return nil, fmt.Errorf("underlying client does not support GetPipelineJobs")
+	}
+	return jobs.GetPipelineJobs(ctx, c.projectID(projectID), pipelineID)
+}
+
+// GetJobNeeds retrieves the dependencies between the jobs of a pipeline.
+// Returns nil for underlying clients without needs - their jobs wait for the previous stage.
+func (c *NamespacedClient) GetJobNeeds(ctx context.Context, projectID, pipelineID string) (map[string][]string, error) {
+	needs, ok := c.client.(JobNeedsClient)
+	if !ok {
+		return nil, nil
+	}
+	return needs.GetJobNeeds(ctx, c.projectID(projectID), pipelineID)
+}
+
+// GetTestReport retrieves the test results of a pipeline.
+func (c *NamespacedClient) GetTestReport(ctx context.Context, projectID, pipelineID string) (*domain.TestReport, error) {
+	reports, ok := c.client.(TestReportClient)
+	if !ok {
+		return nil, fmt.Errorf("underlying client does not support GetTestReport")
+	}
+
+	report, err := reports.GetTestReport(ctx, c.projectID(projectID), pipelineID)
+	if report != nil {
+		report.ProjectID = c.qualify(report.ProjectID)
+	}
+	return report, err
+}
+
+// GetCoverage retrieves the coverage read from the artifacts of a pipeline.
+// Returns nil for underlying clients without coverage artifacts - their pipelines report coverage themselves, if at all.
+func (c *NamespacedClient) GetCoverage(ctx context.Context, projectID, pipelineID string) (*float64, error) {
+	coverage, ok := c.client.(CoverageClient)
+	if !ok {
+		return nil, nil
+	}
+	return coverage.GetCoverage(ctx, c.projectID(projectID), pipelineID)
+}
+
+// GetMergedMergeRequests retrieves merge requests of a project merged since the given time.
+func (c *NamespacedClient) GetMergedMergeRequests(ctx context.Context, projectID string, since time.Time) ([]domain.MergeRequest, error) {
+	merged, ok := c.client.(MergedMergeRequestsClient)
+	if !ok {
+		return nil, fmt.Errorf("underlying client does not support GetMergedMergeRequests")
+	}
+
+	mrs, err := merged.GetMergedMergeRequests(ctx, c.projectID(projectID), since)
+	for i := range mrs {
+		c.qualifyMergeRequest(&mrs[i])
+	}
+	return mrs, err
+}
+
+// GetProductionDeployments retrieves the deployments of a project to production environments since the given time.
+// Returns nil for underlying clients without deployments.
+func (c *NamespacedClient) GetProductionDeployments(ctx context.Context, projectID string, since time.Time) ([]domain.Deployment, error) {
+	production, ok := c.client.(ProductionDeploymentsClient)
+	if !ok {
+		return nil, nil
+	}
+
+	deployments, err := production.GetProductionDeployments(ctx, c.projectID(projectID), since)
+	for i := range deployments {
+		deployments[i].ProjectID = c.qualify(deployments[i].ProjectID)
+	}
+	return deployments, err
+}
+
+// GetCurrentUser returns the profile of the authenticated user.
+func (c *NamespacedClient) GetCurrentUser(ctx context.Context) (*domain.UserProfile, error) {
+	user, ok := c.client.(UserClient)
+	if !ok {
+		return nil, fmt.Errorf("underlying client does not support GetCurrentUser")
+	}
+	return user.GetCurrentUser(ctx)
+}
+
+// GetEvents retrieves events of a project since the given time.
+func (c *NamespacedClient) GetEvents(ctx context.Context, projectID string, since time.Time) ([]domain.Event, error) {
+	events, ok := c.client.(EventsClient)
+	if !ok {
+		return nil, fmt.Errorf("underlying client does not support GetEvents")
+	}
+
+	result, err := events.GetEvents(ctx, c.projectID(projectID), since)
+	c.qualifyEvents(result)
+	return result, err
+}
+
+// ParseWebhook translates a webhook payload into events with qualified project IDs.
+func (c *NamespacedClient) ParseWebhook(eventType string, payload []byte) ([]domain.Event, error) {
+	webhook, ok := c.client.(WebhookClient)
+	if !ok {
+		return nil, fmt.Errorf("underlying client does not support ParseWebhook")
+	}
+
+	events, err := webhook.ParseWebhook(eventType, payload)
+	c.qualifyEvents(events)
+	return events, err
+}
+
+// PrefetchProjects loads the data of several projects at once.
+func (c *NamespacedClient) PrefetchProjects(ctx context.Context, projectIDs []string) error {
+	batch, ok := c.client.(BatchClient)
+	if !ok {
+		return fmt.Errorf("underlying client does not support PrefetchProjects")
+	}
+
+	ids := make([]string, len(projectIDs))
+	for i, projectID := range projectIDs {
+		ids[i] = c.projectID(projectID)
+	}
+	return batch.PrefetchProjects(ctx, ids)
+}
+
+// qualifyProjects qualifies project IDs in place.
+func (c *NamespacedClient) qualifyProjects(projects []domain.Project) []domain.Project {
+	for i := range projects {
+		projects[i].ID = c.qualify(projects[i].ID)
+	}
+	return projects
+}
+
+func (c *NamespacedClient) qualifyPipeline(pipeline *domain.Pipeline) {
+	pipeline.Repository = c.qualifyRepository(pipeline.Repository, pipeline.ProjectID)
+	pipeline.ProjectID = c.qualify(pipeline.ProjectID)
+}
+
+func (c *NamespacedClient) qualifyBranch(branch *domain.Branch) {
+	branch.Repository = c.qualifyRepository(branch.Repository, branch.ProjectID)
+	branch.ProjectID = c.qualify(branch.ProjectID)
+}
+
+func (c *NamespacedClient) qualifyMergeRequest(mr *domain.MergeRequest) {
+	mr.Repository = c.qualifyRepository(mr.Repository, mr.ProjectID)
+	mr.ProjectID = c.qualify(mr.ProjectID)
+}
+
+// qualifyEvents qualifies the project IDs of events and their snapshots in place.
+func (c *NamespacedClient) qualifyEvents(events []domain.Event) {
+	for i := range events {
+		events[i].ProjectID = c.qualify(events[i].ProjectID)
+		if events[i].Pipeline != nil {
+			c.qualifyPipeline(events[i].Pipeline)
+		}
+		if events[i].Branch != nil {
+			c.qualifyBranch(events[i].Branch)
+		}
+		if events[i].MergeRequest != nil {
+			c.qualifyMergeRequest(events[i].MergeRequest)
+		}
+	}
+}
+
+// NamespacedActionClient is a NamespacedClient performing write actions, for connections with a write token.
+// Follows Decorator Pattern - wraps any ActionClient without modifying it.
+type NamespacedActionClient struct {
+	*NamespacedClient
+	actions ActionClient
+}
+
+// NewNamespacedActionClient creates an action client whose project IDs are qualified with connection.
+func NewNamespacedActionClient(client ActionClient, connection string) *NamespacedActionClient {
+	return &NamespacedActionClient{NamespacedClient: NewNamespacedClient(client, connection), actions: client}
+}
+
+// RetryPipeline runs all jobs of a pipeline again.
+func (c *NamespacedActionClient) RetryPipeline(ctx context.Context, projectID, pipelineID string) error {
+	return c.actions.RetryPipeline(ctx, c.projectID(projectID), pipelineID)
+}
+
+// RerunFailedJobs runs the failed and canceled jobs of a pipeline again.
+func (c *NamespacedActionClient) RerunFailedJobs(ctx context.Context, projectID, pipelineID string) error {
+	return c.actions.RerunFailedJobs(ctx, c.projectID(projectID), pipelineID)
+}
+
+// CancelPipeline cancels a pending or running pipeline.
+func (c *NamespacedActionClient) CancelPipeline(ctx context.Context, projectID, pipelineID string) error {
+	return c.actions.CancelPipeline(ctx, c.projectID(projectID), pipelineID)
+}
+
+// RunPipeline starts a new pipeline for a branch or tag.
+func (c *NamespacedActionClient) RunPipeline(ctx context.Context, projectID, ref, workflowID string) error {
+	return c.actions.RunPipeline(ctx, c.projectID(projectID), ref, workflowID)
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// stubClient is a test double for Client that records the project IDs it is asked for.
+type stubClient struct {
+	requested []string
+}
+
+func (s *stubClient) GetProjects(ctx context.Context) ([]domain.Project, error) {
+	return []domain.Project{{ID: "123", Name: "api"}}, nil
+}
+
+func (s *stubClient) GetProjectsPage(ctx context.Context, page int) ([]domain.Project, bool, error) {
+	projects, err := s.GetProjects(ctx)
+	return projects, false, err
+}
+
+func (s *stubClient) GetProjectCount(ctx context.Context) (int, error) {
+	return 1, nil
+}
+
+func (s *stubClient) GetLatestPipeline(ctx context.Context, projectID, branch string) (*domain.Pipeline, error) {
+	s.requested = append(s.requested, projectID)
+	return &domain.Pipeline{ID: "9", ProjectID: projectID, Repository: projectID, Branch: branch}, nil
+}
+
+func (s *stubClient) GetPipelines(ctx context.Context, projectID string, limit int) ([]domain.Pipeline, error) {
+	s.requested = append(s.requested, projectID)
+	return nil, nil
+}
+
+func (s *stubClient) GetBranches(ctx context.Context, projectID string, limit int) ([]domain.Branch, error) {
+	s.requested = append(s.requested, projectID)
+	return []domain.Branch{{Name: "main", ProjectID: projectID, Repository: "api"}}, nil
+}
+
+func (s *stubClient) GetBranch(ctx context.Context, projectID, branchName string) (*domain.Branch, error) {
+	s.requested = append(s.requested, projectID)
+	return nil, nil
+}
+
+// TestNamespacedClient_QualifiesProjectIDs tests that IDs are qualified on the way out and unqualified on the way in.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestNamespacedClient_QualifiesProjectIDs(t *testing.T) {
+	// Arrange
+	stub := &stubClient{}
+	client := NewNamespacedClient(stub, "gitlab-internal")
+	ctx := context.Background()
+
+	// Act
+	projects, _ := client.GetProjects(ctx)
+	pipeline, _ := client.GetLatestPipeline(ctx, projects[0].ID, "main")
+	branches, _ := client.GetBranches(ctx, projects[0].ID, 200)
+
+	// Assert
+	if projects[0].ID != "123@gitlab-internal" {
+		t.Fatalf("expected qualified project ID, got %q", projects[0].ID)
+	}
+	for _, requested := range stub.requested {
+		if requested != "123" {
+			t.Errorf("expected wrapped client to be asked for 123, got %q", requested)
+		}
+	}
+	if pipeline.ProjectID != "123@gitlab-internal" || pipeline.Repository != "123@gitlab-internal" {
+		t.Errorf("expected qualified pipeline project, got %q (repository %q)", pipeline.ProjectID, pipeline.Repository)
+	}
+	if branches[0].ProjectID != "123@gitlab-internal" || branches[0].Repository != "api" {
+		t.Errorf("expected qualified branch project and unchanged repository name, got %+v", branches[0])
+	}
+}
+
+// stubActionClient is a test double for ActionClient that records the project IDs it acts on.
+type stubActionClient struct {
+	stubClient
+}
+
+func (s *stubActionClient) RetryPipeline(ctx context.Context, projectID, pipelineID string) error {
+	s.requested = append(s.requested, projectID)
+	return nil
+}
+
+func (s *stubActionClient) RerunFailedJobs(ctx context.Context, projectID, pipelineID string) error {
+	s.requested = append(s.requested, projectID)
+	return nil
+}
+
+func (s *stubActionClient) CancelPipeline(ctx context.Context, projectID, pipelineID string) error {
+	s.requested = append(s.requested, projectID)
+	return nil
+}
+
+func (s *stubActionClient) RunPipeline(ctx context.Context, projectID, ref, workflowID string) error {
+	s.requested = append(s.requested, projectID)
+	return nil
+}
+
+// TestNamespacedActionClient_UnqualifiesProjectIDs tests that actions reach the wrapped client with its own project IDs.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestNamespacedActionClient_UnqualifiesProjectIDs(t *testing.T) {
+	// Arrange
+	stub := &stubActionClient{}
+	client := NewNamespacedActionClient(stub, "gitlab-internal")
+	ctx := context.Background()
+
+	// Act
+	client.RetryPipeline(ctx, "123@gitlab-internal", "9")
+	client.RerunFailedJobs(ctx, "123@gitlab-internal", "9")
+	client.CancelPipeline(ctx, "123@gitlab-internal", "9")
+	client.RunPipeline(ctx, "123@gitlab-internal", "main", "")
+
+	// Assert
+	if len(stub.requested) != 4 {
+		t.Fatalf("expected 4 actions, got %d", len(stub.requested))
+	}
+	for _, requested := range stub.requested {
+		if requested != "123" {
+			t.Errorf("expected wrapped client to act on 123, got %q", requested)
+		}
+	}
+}
+
+// TestSplitProjectID tests splitting qualified and unqualified project IDs.
+func TestSplitProjectID(t *testing.T) {
+	tests := []struct {
+		id         string
+		projectID  string
+		connection string
+	}{
+		{"123", "123", ""},
+		{"owner/repo", "owner/repo", ""},
+		{"123@gitlab-internal", "123", "gitlab-internal"},
+		{"owner/repo@ghe", "owner/repo", "ghe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			// Act
+			projectID, connection := SplitProjectID(tt.id)
+
+			// Assert
+			if projectID != tt.projectID || connection != tt.connection {
+				t.Errorf("expected (%q, %q), got (%q, %q)", tt.projectID, tt.connection, projectID, connection)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// unixTimestampThreshold separates Unix timestamps from delays in seconds in RateLimit-Reset headers.
+// GitLab and GitHub send timestamps, the IETF RateLimit header draft sends seconds until the reset.
+const unixTimestampThreshold = 1_000_000_000
+
+// rateLimitHeader returns a rate limit header, accepting the RateLimit-* (GitLab, IETF draft)
+// and X-RateLimit-* (GitHub, Gitea) spellings.
+func rateLimitHeader(headers http.Header, name string) string {
+	if value := headers.Get("RateLimit-" + name); value != "" {
+		return value
+	}
+	return headers.Get("X-RateLimit-" + name)
+}
+
+// parseRateLimitReset extracts the rate limit reset time from headers.
+// Returns the zero time when the header is missing or invalid.
+func parseRateLimitReset(headers http.Header) time.Time {
+	reset, err := strconv.ParseInt(rateLimitHeader(headers, "Reset"), 10, 64)
+	if err != nil || reset < 0 {
+		return time.Time{}
+	}
+
+	if reset < unixTimestampThreshold {
+		return time.Now().Add(time.Duration(reset) * time.Second)
+	}
+	return time.Unix(reset, 0)
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// isRateLimited reports whether a response was rejected by rate limiting.
+// GitHub signals exhausted quotas with 403 rather than 429.
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode == http.StatusForbidden &&
+		(resp.Header.Get("Retry-After") != "" || rateLimitHeader(resp.Header, "Remaining") == "0")
+}
+
+// retryDelay returns how long to wait before retrying a rate limited request, or -1 if the API doesn't say.
+// Retry-After takes precedence over the quota reset time.
+func retryDelay(headers http.Header) time.Duration {
+	if wait, ok := parseRetryAfter(headers.Get("Retry-After")); ok {
+		return wait
+	}
+
+	if reset := parseRateLimitReset(headers); !reset.IsZero() {
+		// Add a second of slack, reset times are rounded down to the second
+		return max(time.Until(reset), 0) + time.Second
+	}
+	return -1
+}
+
+// waitForRateLimit blocks if the quota of a resource is exhausted, waiting until the reset time.
+func (c *BaseClient) waitForRateLimit(ctx context.Context, resource string) error {
+	c.rateLimitMu.RLock()
+	limit, known := c.rateLimits[resource]
+	c.rateLimitMu.RUnlock()
+	remaining, resetTime := limit.remaining, limit.reset
+
+	// Proceed if the rate limit isn't known yet, requests remain, or the reset time is unknown
+	if !known || remaining != 0 || resetTime.IsZero() {
+		return nil
+	}
+
+	waitDuration := time.Until(resetTime)
+	if waitDuration <= 0 {
+		// Reset time has passed, proceed
+		return nil
+	}
+
+	log.Printf("API rate limit exhausted (0 requests remaining). Waiting %v until reset at %v",
+		waitDuration.Round(time.Second), resetTime.Format("15:04:05"))
+
+	if err := sleepContext(ctx, waitDuration); err != nil {
+		return fmt.Errorf("context cancelled while waiting for rate limit reset: %w", err)
+	}
+
+	log.Printf("API rate limit reset, resuming requests")
+	return nil
+}
+
+// updateRateLimit updates the rate limit state of a resource from response headers and logs warnings.
+func (c *BaseClient) updateRateLimit(host, resource string, headers http.Header) {
+	remaining, err := strconv.Atoi(rateLimitHeader(headers, "Remaining"))
+	if err != nil {
+		return
+	}
+
+	resetTime := parseRateLimitReset(headers)
+	if resetTime.IsZero() {
+		return
+	}
+
+	c.rateLimitMu.Lock()
+	c.rateLimits[resource] = rateLimit{remaining: remaining, reset: resetTime}
+	c.rateLimitMu.Unlock()
+
+	if resource != "" {
+		host += " " + resource
+	}
+
+	// Log warning when below 5% of rate limit (but not at 0 - that will trigger blocking message)
+	limit, _ := strconv.Atoi(rateLimitHeader(headers, "Limit"))
+	if limit > 0 && remaining > 0 && remaining < limit/20 {
+		log.Printf("[%s] Rate limit warning - %d/%d requests remaining (resets at %v)",
+			host, remaining, limit, resetTime.Format("15:04:05"))
+	} else if remaining == 0 {
+		log.Printf("[%s] Rate limit exhausted - further requests will block until %v",
+			host, resetTime.Format("15:04:05"))
+	}
+}
+
+// RateLimit returns the remaining requests and reset time of the default quota last reported by the API.
+// remaining is -1 until the API has reported it.
+func (c *BaseClient) RateLimit() (remaining int, reset time.Time) {
+	c.rateLimitMu.RLock()
+	defer c.rateLimitMu.RUnlock()
+	limit, known := c.rateLimits[""]
+	if !known {
+		return -1, time.Time{}
+	}
+	return limit.remaining, limit.reset
+}
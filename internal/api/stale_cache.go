@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,6 +15,12 @@ import (
 	"github.com/vilaca/ci-dashboard/internal/domain"
 )
 
+// MergedMergeRequestsWindow is how far back merged merge requests are fetched and cached.
+const MergedMergeRequestsWindow = 90 * 24 * time.Hour
+
+// ProductionDeploymentsWindow is how far back deployments to production are fetched and cached.
+const ProductionDeploymentsWindow = 90 * 24 * time.Hour
+
 // StaleCache implements stale-while-revalidate caching strategy.
 // Always serves cached data immediately (even if expired), refreshes in background.
 type StaleCache struct {
@@ -20,8 +28,13 @@ type StaleCache struct {
 	mu       sync.RWMutex
 	ttl      time.Duration
 	staleTTL time.Duration // How long to serve stale data before considering it invalid
+	onChange ChangeListener
 }
 
+// ChangeListener is called with the key of a cache entry whose value changed or was removed.
+// It is called synchronously after the cache lock is released, so it must not block.
+type ChangeListener func(key string)
+
 type staleCacheEntry struct {
 	value      interface{}
 	cachedAt   time.Time
@@ -72,9 +85,12 @@ func (c *StaleCache) Get(key string) (interface{}, bool, bool) {
 }
 
 // Set stores a value in cache with TTL.
+// Notifies the change listener if the value differs from the cached one.
 func (c *StaleCache) Set(key string, value interface{}, projectID string, lastCommit time.Time) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+
+	old, exists := c.entries[key]
+	changed := !exists || !reflect.DeepEqual(old.value, value)
 
 	now := time.Now()
 	c.entries[key] = &staleCacheEntry{
@@ -85,39 +101,66 @@ func (c *StaleCache) Set(key string, value interface{}, projectID string, lastCo
 		projectID:  projectID,
 		lastCommit: lastCommit,
 	}
+	listener := c.onChange
+	c.mu.Unlock()
+
+	if changed && listener != nil {
+		listener(key)
+	}
+}
+
+// SetChangeListener registers a listener notified whenever a cached value changes or is removed.
+func (c *StaleCache) SetChangeListener(listener ChangeListener) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onChange = listener
 }
 
 // Invalidate removes entries from cache, forcing them to be expired.
 // Used by event poller when detecting changes in repositories.
 func (c *StaleCache) Invalidate(key string) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
-	if _, exists := c.entries[key]; exists {
+	_, exists := c.entries[key]
+	if exists {
 		delete(c.entries, key)
 		log.Printf("[StaleCache] Invalidated: %s", key)
 	}
+	listener := c.onChange
+	c.mu.Unlock()
+
+	if exists && listener != nil {
+		listener(key)
+	}
 }
 
 // InvalidatePattern removes all cache entries matching a pattern.
 // Example: InvalidatePattern("GetLatestPipeline:123:") invalidates all branches for project 123
 func (c *StaleCache) InvalidatePattern(pattern string) int {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
-	count := 0
+	var removed []string
 	for key := range c.entries {
 		// Simple pattern matching: check if key starts with pattern
 		if len(key) >= len(pattern) && key[:len(pattern)] == pattern {
 			delete(c.entries, key)
-			count++
+			removed = append(removed, key)
 		}
 	}
+	listener := c.onChange
+	c.mu.Unlock()
 
+	count := len(removed)
 	if count > 0 {
 		log.Printf("[StaleCache] Invalidated %d entries matching pattern: %s*", count, pattern)
 	}
 
+	if listener != nil {
+		for _, key := range removed {
+			listener(key)
+		}
+	}
+
 	return count
 }
 
@@ -194,8 +237,18 @@ type CacheStats struct {
 type StaleCachingClient struct {
 	client         Client
 	extendedClient ExtendedClient
+	mergedClient   MergedMergeRequestsClient
 	userClient     UserClient
 	eventsClient   EventsClient
+	webhookClient  WebhookClient
+	batchClient    BatchClient
+	deployClient   DeploymentsClient
+	prodClient     ProductionDeploymentsClient
+	logClient      JobLogClient
+	testClient     TestReportClient
+	coverageClient CoverageClient
+	jobsClient     JobsClient
+	needsClient    JobNeedsClient
 	cache          *StaleCache
 }
 
@@ -206,6 +259,11 @@ func NewStaleCachingClient(client Client, ttl, staleTTL time.Duration) *StaleCac
 		log.Printf("[Cache] Client does not implement ExtendedClient interface (GetMergeRequests/GetIssues not available)")
 	}
 
+	mergedClient, ok := client.(MergedMergeRequestsClient)
+	if !ok {
+		log.Printf("[Cache] Client does not implement MergedMergeRequestsClient interface (GetMergedMergeRequests not available)")
+	}
+
 	userClient, ok := client.(UserClient)
 	if !ok {
 		log.Printf("[Cache] Client does not implement UserClient interface (GetCurrentUser not available)")
@@ -216,11 +274,58 @@ func NewStaleCachingClient(client Client, ttl, staleTTL time.Duration) *StaleCac
 		log.Printf("[Cache] Client does not implement EventsClient interface (GetEvents not available)")
 	}
 
+	webhookClient, ok := client.(WebhookClient)
+	if !ok {
+		log.Printf("[Cache] Client does not implement WebhookClient interface (ParseWebhook not available)")
+	}
+
+	deployClient, ok := client.(DeploymentsClient)
+	if !ok {
+		log.Printf("[Cache] Client does not implement DeploymentsClient interface (GetEnvironments not available)")
+	}
+
+	logClient, ok := client.(JobLogClient)
+	if !ok {
+		log.Printf("[Cache] Client does not implement JobLogClient interface (GetJobLog not available)")
+	}
+
+	testClient, ok := client.(TestReportClient)
+	if !ok {
+		log.Printf("[Cache] Client does not implement TestReportClient interface (GetTestReport not available)")
+	}
+
+	jobsClient, ok := client.(JobsClient)
+	if !ok {
+		log.Printf("[Cache] Client does not implement JobsClient interface (GetPipelineJobs not available)")
+	}
+
+	// Without needs, jobs are assumed to wait for the previous stage
+	needsClient, _ := client.(JobNeedsClient)
+
+	// Only platforms with deployments have a deployment history
+	prodClient, _ := client.(ProductionDeploymentsClient)
+
+	// Only platforms whose pipelines don't report coverage read it from artifacts
+	coverageClient, _ := client.(CoverageClient)
+
+	// Prefetching is an optimization - clients without it are silently refreshed one request at a time
+	batchClient, _ := client.(BatchClient)
+
 	return &StaleCachingClient{
 		client:         client,
 		extendedClient: extendedClient,
+		mergedClient:   mergedClient,
 		userClient:     userClient,
 		eventsClient:   eventsClient,
+		webhookClient:  webhookClient,
+		batchClient:    batchClient,
+		deployClient:   deployClient,
+		prodClient:     prodClient,
+		logClient:      logClient,
+		testClient:     testClient,
+		coverageClient: coverageClient,
+		jobsClient:     jobsClient,
+		needsClient:    needsClient,
 		cache:          NewStaleCache(ttl, staleTTL),
 	}
 }
@@ -323,6 +428,30 @@ func (c *StaleCachingClient) GetMergeRequests(ctx context.Context, projectID str
 	return []domain.MergeRequest{}, nil
 }
 
+// GetMergedMergeRequests returns cached merge requests merged since the given time.
+// CACHE-ONLY: Returns cached data or empty slice. Never triggers API calls.
+// The cache holds the last MergedMergeRequestsWindow of merges.
+func (c *StaleCachingClient) GetMergedMergeRequests(ctx context.Context, projectID string, since time.Time) ([]domain.MergeRequest, error) {
+	if c.mergedClient == nil {
+		return []domain.MergeRequest{}, nil
+	}
+
+	key := fmt.Sprintf("GetMergedMergeRequests:%s", projectID)
+	mrs, found := getCached(c.cache, key, []domain.MergeRequest{})
+	if !found {
+		// Cache miss - return empty slice (background refresher will populate)
+		return []domain.MergeRequest{}, nil
+	}
+
+	result := make([]domain.MergeRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		if !mr.MergedAt.Before(since) {
+			result = append(result, mr)
+		}
+	}
+	return result, nil
+}
+
 // GetIssues with caching
 // CACHE-ONLY: Returns cached data or empty slice. Never triggers API calls.
 func (c *StaleCachingClient) GetIssues(ctx context.Context, projectID string) ([]domain.Issue, error) {
@@ -339,6 +468,156 @@ func (c *StaleCachingClient) GetIssues(ctx context.Context, projectID string) ([
 	return []domain.Issue{}, nil
 }
 
+// GetEnvironments with caching
+// CACHE-ONLY: Returns cached data or empty slice. Never triggers API calls.
+func (c *StaleCachingClient) GetEnvironments(ctx context.Context, projectID string) ([]domain.Environment, error) {
+	if c.deployClient == nil {
+		return []domain.Environment{}, nil
+	}
+
+	key := fmt.Sprintf("GetEnvironments:%s", projectID)
+	environments, found := getCached(c.cache, key, []domain.Environment{})
+	if found {
+		return environments, nil
+	}
+	// Cache miss - return empty slice (background refresher will populate)
+	return []domain.Environment{}, nil
+}
+
+// GetProductionDeployments returns cached deployments to production created since the given time.
+// CACHE-ONLY: Returns cached data or empty slice. Never triggers API calls.
+// The cache holds the last ProductionDeploymentsWindow of deployments.
+func (c *StaleCachingClient) GetProductionDeployments(ctx context.Context, projectID string, since time.Time) ([]domain.Deployment, error) {
+	if c.prodClient == nil {
+		return []domain.Deployment{}, nil
+	}
+
+	key := fmt.Sprintf("GetProductionDeployments:%s", projectID)
+	deployments, found := getCached(c.cache, key, []domain.Deployment{})
+	if !found {
+		// Cache miss - return empty slice (background refresher will populate)
+		return []domain.Deployment{}, nil
+	}
+
+	result := make([]domain.Deployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		if !deployment.CreatedAt.Before(since) {
+			result = append(result, deployment)
+		}
+	}
+	return result, nil
+}
+
+// GetJobLog retrieves the log of a job (NOT cached - logs are large and streamed to the caller).
+func (c *StaleCachingClient) GetJobLog(ctx context.Context, projectID, jobID string) (io.ReadCloser, error) {
+	if c.logClient == nil {
+		return nil, fmt.Errorf("client does not support GetJobLog")
+	}
+	return c.logClient.GetJobLog(ctx, projectID, jobID)
+}
+
+// GetTestReport retrieves the test results of a pipeline with caching.
+// READ-THROUGH: fetched on a cache miss and then cached - callers only ask for reports of
+// finished pipelines, which never change, so they aren't refreshed in the background.
+func (c *StaleCachingClient) GetTestReport(ctx context.Context, projectID, pipelineID string) (*domain.TestReport, error) {
+	if c.testClient == nil {
+		return nil, nil
+	}
+
+	key := fmt.Sprintf("GetTestReport:%s:%s", projectID, pipelineID)
+	report, found := getCached(c.cache, key, (*domain.TestReport)(nil))
+	if found {
+		return report, nil
+	}
+
+	if err := c.ForceRefresh(ctx, key); err != nil {
+		return nil, err
+	}
+	report, _ = getCached(c.cache, key, (*domain.TestReport)(nil))
+	return report, nil
+}
+
+// GetPipelineJobs retrieves the jobs of a pipeline (NOT cached - jobs of running pipelines change,
+// and only the pipeline graph asks for them, on demand).
+func (c *StaleCachingClient) GetPipelineJobs(ctx context.Context, projectID, pipelineID string) ([]domain.Build, error) {
+	if c.jobsClient == nil {
+		return nil, fmt.Errorf("client does not support GetPipelineJobs")
+	}
+	return c.jobsClient.GetPipelineJobs(ctx, projectID, pipelineID)
+}
+
+// GetJobNeeds retrieves the dependencies between the jobs of a pipeline with caching.
+// READ-THROUGH like GetTestReport - a pipeline's needs are fixed when it is created.
+// Returns nil for clients without needs.
+func (c *StaleCachingClient) GetJobNeeds(ctx context.Context, projectID, pipelineID string) (map[string][]string, error) {
+	if c.needsClient == nil {
+		return nil, nil
+	}
+
+	key := fmt.Sprintf("GetJobNeeds:%s:%s", projectID, pipelineID)
+	needs, found := getCached(c.cache, key, map[string][]string(nil))
+	if found {
+		return needs, nil
+	}
+
+	if err := c.ForceRefresh(ctx, key); err != nil {
+		return nil, err
+	}
+	needs, _ = getCached(c.cache, key, map[string][]string(nil))
+	return needs, nil
+}
+
+// GetCoverage retrieves the coverage read from the artifacts of a pipeline with caching.
+// READ-THROUGH like GetTestReport. Returns nil for clients whose pipelines report coverage themselves.
+func (c *StaleCachingClient) GetCoverage(ctx context.Context, projectID, pipelineID string) (*float64, error) {
+	if c.coverageClient == nil {
+		return nil, nil
+	}
+
+	key := fmt.Sprintf("GetCoverage:%s:%s", projectID, pipelineID)
+	coverage, found := getCached(c.cache, key, (*float64)(nil))
+	if found {
+		return coverage, nil
+	}
+
+	if err := c.ForceRefresh(ctx, key); err != nil {
+		return nil, err
+	}
+	coverage, _ = getCached(c.cache, key, (*float64)(nil))
+	return coverage, nil
+}
+
+// SetFailureExcerpt stores the failure excerpt of a branch's cached latest pipeline.
+// Ignored if the cached pipeline is no longer the given one.
+func (c *StaleCachingClient) SetFailureExcerpt(projectID, branch, pipelineID, excerpt string) {
+	c.updateLatestPipeline(projectID, branch, pipelineID, func(pipeline *domain.Pipeline) {
+		pipeline.FailureExcerpt = excerpt
+	})
+}
+
+// SetCoverage stores the coverage of a branch's cached latest pipeline.
+// Ignored if the cached pipeline is no longer the given one.
+func (c *StaleCachingClient) SetCoverage(projectID, branch, pipelineID string, coverage float64) {
+	c.updateLatestPipeline(projectID, branch, pipelineID, func(pipeline *domain.Pipeline) {
+		pipeline.Coverage = &coverage
+	})
+}
+
+// updateLatestPipeline updates a copy of a branch's cached latest pipeline and caches it,
+// if it is still the given pipeline.
+func (c *StaleCachingClient) updateLatestPipeline(projectID, branch, pipelineID string, update func(*domain.Pipeline)) {
+	key := fmt.Sprintf("GetLatestPipeline:%s:%s", projectID, branch)
+	cached, found := getCached(c.cache, key, (*domain.Pipeline)(nil))
+	if !found || cached == nil || cached.ID != pipelineID {
+		return
+	}
+
+	// Copy - readers may hold the cached pipeline
+	pipeline := *cached
+	update(&pipeline)
+	c.cache.Set(key, &pipeline, projectID, pipeline.UpdatedAt)
+}
+
 // GetCurrentUser with caching
 // CACHE-ONLY: Returns cached data or nil. Never triggers API calls.
 func (c *StaleCachingClient) GetCurrentUser(ctx context.Context) (*domain.UserProfile, error) {
@@ -365,6 +644,26 @@ func (c *StaleCachingClient) GetEvents(ctx context.Context, projectID string, si
 	return c.eventsClient.GetEvents(ctx, projectID, since)
 }
 
+// ParseWebhook translates a webhook payload into events (NOT cached - payloads are pushed to us).
+func (c *StaleCachingClient) ParseWebhook(eventType string, payload []byte) ([]domain.Event, error) {
+	if c.webhookClient == nil {
+		return nil, fmt.Errorf("underlying client does not support ParseWebhook")
+	}
+
+	return c.webhookClient.ParseWebhook(eventType, payload)
+}
+
+// PrefetchProjects lets the underlying client load the data of several projects at once (NOT cached).
+// The following ForceRefresh calls for these projects are then answered from the prefetched data.
+// Does nothing if the underlying client doesn't support batching.
+func (c *StaleCachingClient) PrefetchProjects(ctx context.Context, projectIDs []string) error {
+	if c.batchClient == nil {
+		return nil
+	}
+
+	return c.batchClient.PrefetchProjects(ctx, projectIDs)
+}
+
 // PopulateProjects pre-populates the cache with projects data.
 // Used on startup to load from file cache for instant page loads.
 func (c *StaleCachingClient) PopulateProjects(projects []domain.Project) {
@@ -398,12 +697,26 @@ func (c *StaleCachingClient) PopulatePipelines(pipelines []domain.Pipeline) {
 		c.cache.Set(key, pipes, projectID, lastCommit)
 	}
 
-	// Populate GetLatestPipeline cache keys (per branch)
-	for _, pipeline := range pipelines {
-		if pipeline.Branch != "" {
-			key := fmt.Sprintf("GetLatestPipeline:%s:%s", pipeline.ProjectID, pipeline.Branch)
-			c.cache.Set(key, &pipeline, pipeline.ProjectID, pipeline.UpdatedAt)
+	// Populate GetLatestPipeline cache keys (per branch).
+	// Pipelines are ordered most recent first, so only the first one per branch is the latest.
+	seen := make(map[string]bool)
+	for i := range pipelines {
+		pipeline := pipelines[i]
+		if pipeline.Branch == "" {
+			continue
+		}
+		key := fmt.Sprintf("GetLatestPipeline:%s:%s", pipeline.ProjectID, pipeline.Branch)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		// Keep the cached entry if it's the same run - it may carry job details the list lacks
+		if cached, found := getCached(c.cache, key, (*domain.Pipeline)(nil)); found && cached != nil &&
+			cached.ID == pipeline.ID && !cached.UpdatedAt.Before(pipeline.UpdatedAt) {
+			continue
 		}
+		c.cache.Set(key, &pipeline, pipeline.ProjectID, pipeline.UpdatedAt)
 	}
 }
 
@@ -421,7 +734,7 @@ func (c *StaleCachingClient) PopulateBranches(branches []domain.Branch) {
 
 	// Populate GetBranches cache keys
 	for projectID, branchList := range projectBranches {
-		key := fmt.Sprintf("GetBranches:%s:50", projectID)
+		key := fmt.Sprintf("GetBranches:%s:200", projectID)
 		var lastCommit time.Time
 		if len(branchList) > 0 {
 			lastCommit = branchList[0].LastCommitDate
@@ -490,6 +803,21 @@ func (c *StaleCachingClient) PopulateUserProfiles(profiles []domain.UserProfile)
 	}
 }
 
+// SetChangeListener registers a listener notified whenever a cached value changes or is removed.
+func (c *StaleCachingClient) SetChangeListener(listener ChangeListener) {
+	c.cache.SetChangeListener(listener)
+}
+
+// SaveSnapshot writes the cache to disk. Returns the number of entries written.
+func (c *StaleCachingClient) SaveSnapshot(path string) (int, error) {
+	return c.cache.SaveSnapshot(path)
+}
+
+// LoadSnapshot restores the cache from disk. Returns the number of entries restored.
+func (c *StaleCachingClient) LoadSnapshot(path string) (int, error) {
+	return c.cache.LoadSnapshot(path)
+}
+
 // GetExpiredKeys returns cache keys that need refreshing.
 func (c *StaleCachingClient) GetExpiredKeys() []string {
 	return c.cache.GetExpiredKeys()
@@ -542,6 +870,16 @@ func (c *StaleCachingClient) ForceRefresh(ctx context.Context, key string) error
 		var lastCommit time.Time
 		if pipeline != nil {
 			lastCommit = pipeline.UpdatedAt
+			// Keep what was read from the logs and artifacts of the same finished run - they don't change
+			if cached, found := getCached(c.cache, key, (*domain.Pipeline)(nil)); found && cached != nil &&
+				cached.ID == pipeline.ID && cached.Status == pipeline.Status {
+				if pipeline.FailureExcerpt == "" {
+					pipeline.FailureExcerpt = cached.FailureExcerpt
+				}
+				if pipeline.Coverage == nil {
+					pipeline.Coverage = cached.Coverage
+				}
+			}
 		}
 		c.cache.Set(key, pipeline, parts[1], lastCommit)
 
@@ -615,6 +953,88 @@ func (c *StaleCachingClient) ForceRefresh(ctx context.Context, key string) error
 		}
 		c.cache.Set(key, issues, parts[1], lastUpdate)
 
+	case "GetMergedMergeRequests":
+		if c.mergedClient == nil {
+			return fmt.Errorf("client does not support GetMergedMergeRequests")
+		}
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid key format: %s", key)
+		}
+		mrs, fetchErr := c.mergedClient.GetMergedMergeRequests(ctx, parts[1], time.Now().Add(-MergedMergeRequestsWindow))
+		if fetchErr != nil {
+			return fetchErr
+		}
+		var lastUpdate time.Time
+		if len(mrs) > 0 {
+			lastUpdate = mrs[0].UpdatedAt
+		}
+		c.cache.Set(key, mrs, parts[1], lastUpdate)
+
+	case "GetEnvironments":
+		if c.deployClient == nil {
+			return fmt.Errorf("client does not support GetEnvironments")
+		}
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid key format: %s", key)
+		}
+		environments, fetchErr := c.deployClient.GetEnvironments(ctx, parts[1])
+		if fetchErr != nil {
+			return fetchErr
+		}
+		c.cache.Set(key, environments, parts[1], time.Time{})
+
+	case "GetProductionDeployments":
+		if c.prodClient == nil {
+			return fmt.Errorf("client does not support GetProductionDeployments")
+		}
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid key format: %s", key)
+		}
+		deployments, fetchErr := c.prodClient.GetProductionDeployments(ctx, parts[1], time.Now().Add(-ProductionDeploymentsWindow))
+		if fetchErr != nil {
+			return fetchErr
+		}
+		c.cache.Set(key, deployments, parts[1], time.Time{})
+
+	case "GetTestReport":
+		if c.testClient == nil {
+			return fmt.Errorf("client does not support GetTestReport")
+		}
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid key format: %s", key)
+		}
+		report, fetchErr := c.testClient.GetTestReport(ctx, parts[1], parts[2])
+		if fetchErr != nil {
+			return fetchErr
+		}
+		c.cache.Set(key, report, parts[1], time.Time{})
+
+	case "GetJobNeeds":
+		if c.needsClient == nil {
+			return fmt.Errorf("client does not support GetJobNeeds")
+		}
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid key format: %s", key)
+		}
+		needs, fetchErr := c.needsClient.GetJobNeeds(ctx, parts[1], parts[2])
+		if fetchErr != nil {
+			return fetchErr
+		}
+		c.cache.Set(key, needs, parts[1], time.Time{})
+
+	case "GetCoverage":
+		if c.coverageClient == nil {
+			return fmt.Errorf("client does not support GetCoverage")
+		}
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid key format: %s", key)
+		}
+		coverage, fetchErr := c.coverageClient.GetCoverage(ctx, parts[1], parts[2])
+		if fetchErr != nil {
+			return fetchErr
+		}
+		c.cache.Set(key, coverage, parts[1], time.Time{})
+
 	case "GetCurrentUser":
 		if c.userClient == nil {
 			return fmt.Errorf("client does not support GetCurrentUser")
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestStaleCache_ChangeListener tests that the listener fires only when a value actually changes.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestStaleCache_ChangeListener(t *testing.T) {
+	// Arrange
+	cache := NewStaleCache(time.Minute, time.Hour)
+	var changed []string
+	cache.SetChangeListener(func(key string) {
+		changed = append(changed, key)
+	})
+
+	// Act
+	cache.Set("GetBranches:1:200", []domain.Branch{{Name: "main"}}, "1", time.Time{})
+	cache.Set("GetBranches:1:200", []domain.Branch{{Name: "main"}}, "1", time.Time{})
+	cache.Set("GetBranches:1:200", []domain.Branch{{Name: "main"}, {Name: "dev"}}, "1", time.Time{})
+	cache.Invalidate("GetBranches:1:200")
+	cache.Invalidate("GetBranches:1:200")
+
+	// Assert
+	if len(changed) != 3 {
+		t.Fatalf("expected 3 change notifications (set, update, invalidate), got %d: %v", len(changed), changed)
+	}
+}
+
+// TestStaleCachingClient_KeepsFailureExcerpt tests that a refreshed pipeline keeps the failure excerpt of the same run.
+func TestStaleCachingClient_KeepsFailureExcerpt(t *testing.T) {
+	// Arrange
+	client := NewStaleCachingClient(&stubClient{}, time.Minute, time.Hour)
+	ctx := context.Background()
+	key := "GetLatestPipeline:123:main"
+	if err := client.ForceRefresh(ctx, key); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Act
+	client.SetFailureExcerpt("123", "main", "8", "other run")
+	client.SetFailureExcerpt("123", "main", "9", "lint:\nunused variable")
+	refreshErr := client.ForceRefresh(ctx, key)
+	pipeline, _ := client.GetLatestPipeline(ctx, "123", "main")
+
+	// Assert
+	if refreshErr != nil {
+		t.Fatalf("expected no error, got %v", refreshErr)
+	}
+	if pipeline == nil || pipeline.FailureExcerpt != "lint:\nunused variable" {
+		t.Errorf("expected the excerpt of run 9 to survive the refresh, got %+v", pipeline)
+	}
+}
@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileName is the audit log file name inside the data directory.
+const FileName = "audit.jsonl"
+
+// Outcomes of a write action. Each action is recorded twice: once requested, before the platform is
+// called, then with its outcome.
+const (
+	OutcomeRequested = "requested"
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+)
+
+// Entry is a write action as recorded in the audit log (one JSON object per line).
+type Entry struct {
+	Time       time.Time `json:"time"`
+	User       string    `json:"user"` // "anonymous" when authentication is disabled
+	RemoteAddr string    `json:"remote_addr"`
+	Action     string    `json:"action"`
+	Platform   string    `json:"platform"`
+	ProjectID  string    `json:"project_id"`
+	PipelineID string    `json:"pipeline_id,omitempty"`
+	Ref        string    `json:"ref,omitempty"`
+	WorkflowID string    `json:"workflow_id,omitempty"`
+	Outcome    string    `json:"outcome"`         // OutcomeRequested, OutcomeSucceeded or OutcomeFailed
+	Error      string    `json:"error,omitempty"` // empty unless the action failed
+}
+
+// Log is an append-only audit log backed by a JSON Lines file.
+// Entries are never rewritten or compacted - rotate the file externally if needed.
+// Follows Single Responsibility Principle - only persists audit entries.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Open opens the audit log at path, creating the file and its directory if needed.
+// The file is created up front so a misconfigured path fails at startup rather than on the first action.
+func Open(path string) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	return &Log{path: path}, nil
+}
+
+// Record appends an entry to the audit log. A zero Time is set to now.
+func (l *Log) Record(entry Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
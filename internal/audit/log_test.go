@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLog_Record tests that entries are appended as JSON lines across reopens.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestLog_Record(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "audit", FileName)
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Act
+	firstErr := log.Record(Entry{User: "jane", Action: "retry", Platform: "gitlab", ProjectID: "123", PipelineID: "42"})
+	reopened, _ := Open(path)
+	secondErr := reopened.Record(Entry{User: "john", Action: "cancel", Platform: "github", ProjectID: "acme/api", Error: "API returned status 409"})
+
+	// Assert
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("expected no errors, got %v and %v", firstErr, secondErr)
+	}
+
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(lines), data)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("expected JSON line, got %v", err)
+	}
+	if entry.User != "jane" || entry.PipelineID != "42" || entry.Time.IsZero() || entry.Error != "" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	if !strings.Contains(lines[1], `"error":"API returned status 409"`) {
+		t.Errorf("expected failed action to record its error, got %s", lines[1])
+	}
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Supported htpasswd hash prefixes.
+// bcrypt ($2y$) is not supported as it isn't available in the standard library.
+const (
+	htpasswdSHAPrefix  = "{SHA}"
+	htpasswdAPR1Prefix = "$apr1$"
+)
+
+// Htpasswd holds users and password hashes from an htpasswd file.
+// Supports the {SHA} (htpasswd -s) and $apr1$ MD5 (htpasswd -m) formats.
+type Htpasswd struct {
+	users map[string]string // username -> hash
+}
+
+// LoadHtpasswd reads an htpasswd file.
+func LoadHtpasswd(path string) (*Htpasswd, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open htpasswd file: %w", err)
+	}
+	defer f.Close()
+
+	return ParseHtpasswd(f)
+}
+
+// ParseHtpasswd parses "user:hash" lines. Blank lines and # comments are skipped.
+// Returns an error for entries with unsupported hash formats, so they don't silently lock users out.
+func ParseHtpasswd(r io.Reader) (*Htpasswd, error) {
+	h := &Htpasswd{users: make(map[string]string)}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		user, hash, found := strings.Cut(line, ":")
+		if !found || user == "" {
+			return nil, fmt.Errorf("htpasswd line %d: expected user:hash", lineNumber)
+		}
+		if !strings.HasPrefix(hash, htpasswdSHAPrefix) && !strings.HasPrefix(hash, htpasswdAPR1Prefix) {
+			return nil, fmt.Errorf("htpasswd line %d: unsupported hash format for %q (use htpasswd -m or -s)", lineNumber, user)
+		}
+		h.users[user] = hash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read htpasswd file: %w", err)
+	}
+
+	if len(h.users) == 0 {
+		return nil, fmt.Errorf("htpasswd file has no users")
+	}
+	return h, nil
+}
+
+// Verify reports whether password matches the user's hash.
+func (h *Htpasswd) Verify(user, password string) bool {
+	hash, exists := h.users[user]
+	if !exists {
+		return false
+	}
+
+	var computed string
+	switch {
+	case strings.HasPrefix(hash, htpasswdSHAPrefix):
+		sum := sha1.Sum([]byte(password))
+		computed = htpasswdSHAPrefix + base64.StdEncoding.EncodeToString(sum[:])
+	case strings.HasPrefix(hash, htpasswdAPR1Prefix):
+		salt, _, _ := strings.Cut(strings.TrimPrefix(hash, htpasswdAPR1Prefix), "$")
+		computed = apr1(password, salt)
+	}
+
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(hash)) == 1
+}
+
+// apr1 computes the Apache MD5-crypt hash of password with salt.
+func apr1(password, salt string) string {
+	if len(salt) > 8 {
+		salt = salt[:8]
+	}
+	pw := []byte(password)
+
+	alternate := md5.New()
+	alternate.Write(pw)
+	alternate.Write([]byte(salt))
+	alternate.Write(pw)
+	alternateSum := alternate.Sum(nil)
+
+	ctx := md5.New()
+	ctx.Write(pw)
+	ctx.Write([]byte(htpasswdAPR1Prefix + salt))
+	for i := len(pw); i > 0; i -= 16 {
+		ctx.Write(alternateSum[:min(i, 16)])
+	}
+	for i := len(pw); i > 0; i >>= 1 {
+		if i&1 == 1 {
+			ctx.Write([]byte{0})
+		} else {
+			ctx.Write(pw[:1])
+		}
+	}
+	final := ctx.Sum(nil)
+
+	for i := 0; i < 1000; i++ {
+		round := md5.New()
+		if i&1 == 1 {
+			round.Write(pw)
+		} else {
+			round.Write(final)
+		}
+		if i%3 != 0 {
+			round.Write([]byte(salt))
+		}
+		if i%7 != 0 {
+			round.Write(pw)
+		}
+		if i&1 == 1 {
+			round.Write(final)
+		} else {
+			round.Write(pw)
+		}
+		final = round.Sum(nil)
+	}
+
+	const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	var encoded strings.Builder
+	to64 := func(v uint32, n int) {
+		for ; n > 0; n-- {
+			encoded.WriteByte(itoa64[v&0x3f])
+			v >>= 6
+		}
+	}
+	to64(uint32(final[0])<<16|uint32(final[6])<<8|uint32(final[12]), 4)
+	to64(uint32(final[1])<<16|uint32(final[7])<<8|uint32(final[13]), 4)
+	to64(uint32(final[2])<<16|uint32(final[8])<<8|uint32(final[14]), 4)
+	to64(uint32(final[3])<<16|uint32(final[9])<<8|uint32(final[15]), 4)
+	to64(uint32(final[4])<<16|uint32(final[10])<<8|uint32(final[5]), 4)
+	to64(uint32(final[11]), 2)
+
+	return htpasswdAPR1Prefix + salt + "$" + encoded.String()
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestHtpasswd_Verify tests verification of {SHA} and $apr1$ entries.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestHtpasswd_Verify(t *testing.T) {
+	// Arrange - both hashes are of "secret" (htpasswd -s and openssl passwd -apr1 -salt saltsalt)
+	h, err := ParseHtpasswd(strings.NewReader(`
+# dashboard users
+alice:{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=
+bob:$apr1$saltsalt$LrttParrLPdxvgutaSXWJ0
+`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		user, password string
+		want           bool
+	}{
+		{"alice", "secret", true},
+		{"alice", "wrong", false},
+		{"bob", "secret", true},
+		{"bob", "Secret", false},
+		{"carol", "secret", false},
+	}
+
+	for _, tt := range tests {
+		// Act
+		got := h.Verify(tt.user, tt.password)
+
+		// Assert
+		if got != tt.want {
+			t.Errorf("Verify(%q, %q) = %v, want %v", tt.user, tt.password, got, tt.want)
+		}
+	}
+}
+
+// TestParseHtpasswd_UnsupportedHash tests that bcrypt entries are rejected instead of silently never matching.
+func TestParseHtpasswd_UnsupportedHash(t *testing.T) {
+	// Arrange
+	input := "alice:$2y$05$abcdefghijklmnopqrstuv\n"
+
+	// Act
+	_, err := ParseHtpasswd(strings.NewReader(input))
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected error for bcrypt hash, got nil")
+	}
+}
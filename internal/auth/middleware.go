@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// SessionCookieName is the cookie holding the signed user session
+	SessionCookieName = "ci_dashboard_session"
+
+	// loginStateCookieName is the cookie holding the signed state of a login in progress
+	loginStateCookieName = "ci_dashboard_login"
+
+	// loginStateTTL is how long a login may take at the issuer
+	loginStateTTL = 10 * time.Minute
+
+	// Login, callback and logout endpoints served by the OIDC middleware
+	LoginPath    = "/auth/login"
+	CallbackPath = "/auth/callback"
+	LogoutPath   = "/auth/logout"
+)
+
+// userContextKey is the request context key of the authenticated user.
+type userContextKey struct{}
+
+// UserFromContext returns the authenticated user of a request, if any.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(*User)
+	return user, ok
+}
+
+// isPublicPath reports whether a path is served without authentication.
+// Webhook receivers authenticate with their own secrets; the health check is used by probes.
+func isPublicPath(path string) bool {
+	return path == "/api/health" || strings.HasPrefix(path, "/api/webhooks/")
+}
+
+// isAPIPath reports whether a path is requested by scripts rather than navigated to.
+// Unauthenticated API requests get 401 instead of a login redirect.
+func isAPIPath(path string) bool {
+	return strings.HasPrefix(path, "/api/")
+}
+
+// BasicAuth protects a handler with HTTP basic authentication against an htpasswd file.
+type BasicAuth struct {
+	htpasswd *Htpasswd
+	realm    string
+}
+
+// NewBasicAuth creates basic auth middleware.
+func NewBasicAuth(htpasswd *Htpasswd, realm string) *BasicAuth {
+	return &BasicAuth{htpasswd: htpasswd, realm: realm}
+}
+
+// Wrap returns next protected by basic auth.
+func (b *BasicAuth) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isPublicPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		username, password, ok := r.BasicAuth()
+		if !ok || !b.htpasswd.Verify(username, password) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="`+b.realm+`", charset="UTF-8"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		user := &User{Subject: username, Name: username}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey{}, user)))
+	})
+}
+
+// OIDCAuth protects a handler with OIDC login and signed session cookies.
+type OIDCAuth struct {
+	provider   *OIDCProvider
+	sessions   *SessionCodec
+	sessionTTL time.Duration
+	secure     bool // set the Secure flag on cookies
+}
+
+// loginState is kept in a signed cookie between the login redirect and the callback.
+type loginState struct {
+	State string `json:"state"`
+	Nonce string `json:"nonce"`
+	Next  string `json:"next"`
+}
+
+// NewOIDCAuth creates OIDC middleware.
+// Cookies are marked Secure when the redirect URL uses https.
+func NewOIDCAuth(provider *OIDCProvider, sessions *SessionCodec, sessionTTL time.Duration) *OIDCAuth {
+	return &OIDCAuth{
+		provider:   provider,
+		sessions:   sessions,
+		sessionTTL: sessionTTL,
+		secure:     strings.HasPrefix(provider.config.RedirectURL, "https://"),
+	}
+}
+
+// Wrap returns next protected by OIDC login, serving the login, callback and logout endpoints.
+func (o *OIDCAuth) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case LoginPath:
+			o.handleLogin(w, r)
+			return
+		case CallbackPath:
+			o.handleCallback(w, r)
+			return
+		case LogoutPath:
+			o.setCookie(w, SessionCookieName, "", -1)
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+
+		if isPublicPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var user User
+		cookie, err := r.Cookie(SessionCookieName)
+		if err != nil || o.sessions.Decode(cookie.Value, purposeSession, &user) != nil || user.Subject == "" {
+			if isAPIPath(r.URL.Path) {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			http.Redirect(w, r, LoginPath+"?next="+url.QueryEscape(r.URL.RequestURI()), http.StatusFound)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey{}, &user)))
+	})
+}
+
+// handleLogin redirects to the issuer, remembering state, nonce and the page to return to.
+func (o *OIDCAuth) handleLogin(w http.ResponseWriter, r *http.Request) {
+	state := loginState{
+		State: randomToken(),
+		Nonce: randomToken(),
+		Next:  safeNext(r.URL.Query().Get("next")),
+	}
+
+	authURL, err := o.provider.AuthCodeURL(r.Context(), state.State, state.Nonce)
+	if err != nil {
+		log.Printf("[Auth] Login failed: %v", err)
+		http.Error(w, "Identity provider unavailable", http.StatusBadGateway)
+		return
+	}
+
+	value, err := o.sessions.Encode(purposeLoginState, state, time.Now().Add(loginStateTTL))
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	o.setCookie(w, loginStateCookieName, value, int(loginStateTTL.Seconds()))
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// handleCallback completes the login: checks state, exchanges the code and starts a session.
+func (o *OIDCAuth) handleCallback(w http.ResponseWriter, r *http.Request) {
+	var state loginState
+	cookie, err := r.Cookie(loginStateCookieName)
+	if err != nil || o.sessions.Decode(cookie.Value, purposeLoginState, &state) != nil || r.URL.Query().Get("state") != state.State {
+		http.Error(w, "Invalid or expired login, please try again", http.StatusBadRequest)
+		return
+	}
+	o.setCookie(w, loginStateCookieName, "", -1)
+
+	if errCode := r.URL.Query().Get("error"); errCode != "" {
+		log.Printf("[Auth] Identity provider returned error: %s", errCode)
+		http.Error(w, "Login failed", http.StatusForbidden)
+		return
+	}
+
+	user, err := o.provider.Exchange(r.Context(), r.URL.Query().Get("code"), state.Nonce)
+	if err != nil {
+		log.Printf("[Auth] Login rejected: %v", err)
+		http.Error(w, "Login failed", http.StatusForbidden)
+		return
+	}
+
+	value, err := o.sessions.Encode(purposeSession, user, time.Now().Add(o.sessionTTL))
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	o.setCookie(w, SessionCookieName, value, int(o.sessionTTL.Seconds()))
+
+	log.Printf("[Auth] %s logged in", user.DisplayName())
+	http.Redirect(w, r, state.Next, http.StatusFound)
+}
+
+// setCookie sets an HttpOnly cookie on the whole site. A negative maxAge deletes it.
+func (o *OIDCAuth) setCookie(w http.ResponseWriter, name, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   o.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// safeNext returns next if it is a local path, otherwise "/" (prevents open redirects).
+func safeNext(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+	return next
+}
+
+// randomToken returns a random URL-safe token.
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic("crypto/rand failed: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// GenerateSecret returns a random session secret.
+func GenerateSecret() []byte {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic("crypto/rand failed: " + err.Error())
+	}
+	return b
+}
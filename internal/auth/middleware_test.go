@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBasicAuth_Wrap tests that requests need valid credentials except on public paths.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestBasicAuth_Wrap(t *testing.T) {
+	// Arrange - the hash is of "secret"
+	htpasswd, err := ParseHtpasswd(strings.NewReader("alice:{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var seen *User
+	handler := NewBasicAuth(htpasswd, "CI Dashboard").Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = UserFromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name               string
+		path               string
+		username, password string
+		wantStatus         int
+		wantUser           string
+	}{
+		{name: "valid credentials", path: "/", username: "alice", password: "secret", wantStatus: http.StatusOK, wantUser: "alice"},
+		{name: "wrong password", path: "/", username: "alice", password: "wrong", wantStatus: http.StatusUnauthorized},
+		{name: "unknown user", path: "/api/repositories", username: "bob", password: "secret", wantStatus: http.StatusUnauthorized},
+		{name: "no credentials", path: "/api/repositories", wantStatus: http.StatusUnauthorized},
+		{name: "health check", path: "/api/health", wantStatus: http.StatusOK},
+		{name: "webhook", path: "/api/webhooks/gitlab", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = nil
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.username != "" {
+				req.SetBasicAuth(tt.username, tt.password)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if rec.Code == http.StatusUnauthorized && !strings.HasPrefix(rec.Header().Get("WWW-Authenticate"), "Basic ") {
+				t.Errorf("expected basic auth challenge, got %q", rec.Header().Get("WWW-Authenticate"))
+			}
+			if tt.wantUser != "" && (seen == nil || seen.Subject != tt.wantUser) {
+				t.Errorf("expected user %q in context, got %+v", tt.wantUser, seen)
+			}
+		})
+	}
+}
+
+// TestOIDCAuth_RejectsForgedSessions tests that a login state cookie or a session without a subject
+// doesn't grant access.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestOIDCAuth_RejectsForgedSessions(t *testing.T) {
+	// Arrange
+	issuer := newTestIssuer(t, nil)
+	sessions, _ := NewSessionCodec(GenerateSecret())
+	provider := NewOIDCProvider(OIDCConfig{
+		IssuerURL:    issuer.server.URL,
+		ClientID:     "dashboard",
+		ClientSecret: "s3cret",
+		RedirectURL:  "http://localhost/auth/callback",
+	}, http.DefaultClient)
+	handler := NewOIDCAuth(provider, sessions, time.Hour).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	loginRec := httptest.NewRecorder()
+	handler.ServeHTTP(loginRec, httptest.NewRequest(http.MethodGet, LoginPath, nil))
+	var loginCookie string
+	for _, cookie := range loginRec.Result().Cookies() {
+		if cookie.Name == loginStateCookieName {
+			loginCookie = cookie.Value
+		}
+	}
+	if loginCookie == "" {
+		t.Fatal("expected login state cookie")
+	}
+	anonymous, _ := sessions.Encode(purposeSession, User{}, time.Now().Add(time.Hour))
+
+	for name, value := range map[string]string{"login state cookie": loginCookie, "empty subject": anonymous} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/repositories", nil)
+			req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: value})
+			rec := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(rec, req)
+
+			// Assert
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("expected 401, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+// TestIsPublicPath tests which paths are served without authentication.
+func TestIsPublicPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/api/health", true},
+		{"/api/webhooks/github", true},
+		{"/api/webhooks/gitlab", true},
+		{"/api/healthz", false},
+		{"/api/webhooks", false},
+		{"/api/repositories", false},
+		{"/", false},
+		{"/webhooks/github", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPublicPath(tt.path); got != tt.want {
+			t.Errorf("isPublicPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+// TestSafeNext tests that only local paths are redirected to after login.
+func TestSafeNext(t *testing.T) {
+	tests := []struct {
+		next string
+		want string
+	}{
+		{"/repository?id=a/b", "/repository?id=a/b"},
+		{"/", "/"},
+		{"", "/"},
+		{"https://evil.example.com/", "/"},
+		{"//evil.example.com/", "/"},
+		{"/\\evil.example.com/", "/"},
+		{"javascript:alert(1)", "/"},
+	}
+
+	for _, tt := range tests {
+		if got := safeNext(tt.next); got != tt.want {
+			t.Errorf("safeNext(%q) = %q, want %q", tt.next, got, tt.want)
+		}
+	}
+}
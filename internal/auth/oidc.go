@@ -0,0 +1,381 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxOIDCResponseSize bounds discovery, JWKS and token responses.
+const MaxOIDCResponseSize = 1 << 20
+
+// HTTPClient interface for HTTP operations (allows mocking in tests).
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// OIDCConfig holds settings for the OIDC authorization code flow.
+type OIDCConfig struct {
+	IssuerURL      string
+	ClientID       string
+	ClientSecret   string
+	RedirectURL    string   // e.g. https://ci.example.com/auth/callback
+	AllowedDomains []string // allowed email domains (empty = any, unless groups are set)
+	AllowedGroups  []string // allowed groups (empty = any, unless domains are set)
+	GroupsClaim    string   // ID token claim holding the user's groups (default: groups)
+}
+
+// OIDCProvider performs the OIDC authorization code flow against an issuer.
+// Discovery and signing keys are fetched lazily and cached, so an unreachable issuer doesn't block startup.
+type OIDCProvider struct {
+	config     OIDCConfig
+	httpClient HTTPClient
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]*rsa.PublicKey // kid -> key
+}
+
+// oidcDiscovery holds the endpoints from the issuer's discovery document.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// idTokenClaims holds the ID token claims used for authentication.
+type idTokenClaims struct {
+	Issuer            string          `json:"iss"`
+	Subject           string          `json:"sub"`
+	Audience          audience        `json:"aud"`
+	ExpiresAt         int64           `json:"exp"`
+	Nonce             string          `json:"nonce"`
+	Email             string          `json:"email"`
+	EmailVerified     *bool           `json:"email_verified"`
+	Name              string          `json:"name"`
+	PreferredUsername string          `json:"preferred_username"`
+	Raw               json.RawMessage `json:"-"`
+}
+
+// audience accepts the "aud" claim as a string or an array of strings.
+type audience []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// NewOIDCProvider creates an OIDC provider.
+func NewOIDCProvider(config OIDCConfig, httpClient HTTPClient) *OIDCProvider {
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+	config.IssuerURL = strings.TrimSuffix(config.IssuerURL, "/")
+
+	return &OIDCProvider{
+		config:     config,
+		httpClient: httpClient,
+		keys:       make(map[string]*rsa.PublicKey),
+	}
+}
+
+// AuthCodeURL returns the issuer URL to redirect the browser to for login.
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.config.ClientID)
+	params.Set("redirect_uri", p.config.RedirectURL)
+	params.Set("scope", "openid email profile")
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return discovery.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange redeems an authorization code, verifies the ID token and checks the user is allowed.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, nonce string) (*User, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := p.doJSON(req, &token); err != nil {
+		return nil, fmt.Errorf("token exchange failed: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("token response has no id_token")
+	}
+
+	claims, err := p.verifyIDToken(ctx, token.IDToken, discovery.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("ID token nonce mismatch")
+	}
+
+	if err := p.checkAllowed(claims); err != nil {
+		return nil, err
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.PreferredUsername
+	}
+	return &User{Subject: claims.Subject, Email: claims.Email, Name: name}, nil
+}
+
+// verifyIDToken checks an RS256 ID token's signature, issuer, audience and expiry.
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, token, issuer string) (*idTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed ID token")
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed ID token header: %w", err)
+	}
+	if header.Algorithm != "RS256" {
+		return nil, fmt.Errorf("unsupported ID token algorithm %q (only RS256 is supported)", header.Algorithm)
+	}
+
+	key, err := p.getKey(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed ID token signature: %w", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("invalid ID token signature: %w", err)
+	}
+
+	var claims idTokenClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed ID token claims: %w", err)
+	}
+	claims.Raw, _ = base64.RawURLEncoding.DecodeString(parts[1])
+
+	if claims.Issuer != issuer {
+		return nil, fmt.Errorf("ID token issuer %q does not match %q", claims.Issuer, issuer)
+	}
+	if !containsString(claims.Audience, p.config.ClientID) {
+		return nil, fmt.Errorf("ID token audience does not include client ID")
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, fmt.Errorf("ID token expired")
+	}
+
+	return &claims, nil
+}
+
+// checkAllowed enforces the allowed domain and group lists.
+// With both lists set, a user matching either is allowed.
+func (p *OIDCProvider) checkAllowed(claims *idTokenClaims) error {
+	if len(p.config.AllowedDomains) == 0 && len(p.config.AllowedGroups) == 0 {
+		return nil
+	}
+
+	if claims.Email != "" && (claims.EmailVerified == nil || *claims.EmailVerified) {
+		if _, domain, found := strings.Cut(claims.Email, "@"); found {
+			for _, allowed := range p.config.AllowedDomains {
+				if strings.EqualFold(domain, allowed) {
+					return nil
+				}
+			}
+		}
+	}
+
+	if len(p.config.AllowedGroups) > 0 {
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(claims.Raw, &raw); err == nil {
+			var groups []string
+			if err := json.Unmarshal(raw[p.config.GroupsClaim], &groups); err == nil {
+				for _, group := range groups {
+					if containsString(p.config.AllowedGroups, group) {
+						return nil
+					}
+				}
+			}
+		}
+	}
+
+	return fmt.Errorf("user %q is not in an allowed domain or group", claims.Email)
+}
+
+// getDiscovery returns the cached discovery document, fetching it on first use.
+func (p *OIDCProvider) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.IssuerURL+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery request: %w", err)
+	}
+
+	var discovery oidcDiscovery
+	if err := p.doJSON(req, &discovery); err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	if discovery.Issuer != p.config.IssuerURL {
+		return nil, fmt.Errorf("OIDC discovery issuer %q does not match %q", discovery.Issuer, p.config.IssuerURL)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC discovery document is missing endpoints")
+	}
+
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+// getKey returns the signing key with the given ID, refetching the JWKS for unknown IDs (key rotation).
+func (p *OIDCProvider) getKey(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, exists := p.keys[keyID]; exists {
+		return key, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JWKSURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			Use     string `json:"use"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := p.doJSON(req, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.KeyType != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[k.KeyID] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	p.keys = keys
+
+	key, exists := keys[keyID]
+	if !exists {
+		return nil, fmt.Errorf("no RSA signing key %q in JWKS", keyID)
+	}
+	return key, nil
+}
+
+// doJSON performs a request and decodes a successful JSON response into v.
+func (p *OIDCProvider) doJSON(req *http.Request, v interface{}) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxOIDCResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// decodeSegment decodes a base64url JWT segment into v.
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// containsString reports whether values contains value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testIssuer is a minimal OIDC issuer issuing RS256 ID tokens for a fixed user.
+type testIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]interface{}
+	nonce  string // nonce from the last authorization request
+}
+
+func newTestIssuer(t *testing.T, claims map[string]interface{}) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	issuer := &testIssuer{key: key, claims: claims}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA", "kid": "k1", "use": "sig",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "dashboard" || secret != "s3cret" || r.FormValue("code") != "good-code" {
+			http.Error(w, "invalid_grant", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": issuer.sign(t)})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+// sign issues an ID token with the issuer's claims.
+func (i *testIssuer) sign(t *testing.T) string {
+	claims := map[string]interface{}{
+		"iss": i.server.URL, "aud": "dashboard", "sub": "u-1",
+		"exp": time.Now().Add(time.Hour).Unix(), "nonce": i.nonce,
+	}
+	for k, v := range i.claims {
+		claims[k] = v
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "k1"})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// login runs the login flow against the middleware and returns the callback response.
+func login(t *testing.T, issuer *testIssuer, handler http.Handler) *httptest.ResponseRecorder {
+	// Unauthenticated page request redirects to login
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/repository?id=a/b", nil))
+	if rec.Code != http.StatusFound || !strings.HasPrefix(rec.Header().Get("Location"), LoginPath) {
+		t.Fatalf("expected redirect to login, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	// Login redirects to the issuer with state and nonce
+	loginRec := httptest.NewRecorder()
+	handler.ServeHTTP(loginRec, httptest.NewRequest(http.MethodGet, rec.Header().Get("Location"), nil))
+	authURL, err := url.Parse(loginRec.Header().Get("Location"))
+	if err != nil || !strings.HasPrefix(authURL.String(), issuer.server.URL+"/authorize") {
+		t.Fatalf("expected redirect to issuer, got %q", loginRec.Header().Get("Location"))
+	}
+	issuer.nonce = authURL.Query().Get("nonce")
+
+	// Issuer redirects back with the code
+	callback := httptest.NewRequest(http.MethodGet, CallbackPath+"?code=good-code&state="+authURL.Query().Get("state"), nil)
+	for _, cookie := range loginRec.Result().Cookies() {
+		callback.AddCookie(cookie)
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, callback)
+	return rec
+}
+
+// TestOIDCAuth_Login tests the authorization code flow, session cookie and allowed domains.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestOIDCAuth_Login(t *testing.T) {
+	// Arrange
+	issuer := newTestIssuer(t, map[string]interface{}{"email": "dev@example.com", "email_verified": true, "name": "Dev"})
+	sessions, _ := NewSessionCodec(GenerateSecret())
+	provider := NewOIDCProvider(OIDCConfig{
+		IssuerURL:      issuer.server.URL,
+		ClientID:       "dashboard",
+		ClientSecret:   "s3cret",
+		RedirectURL:    "http://localhost/auth/callback",
+		AllowedDomains: []string{"example.com"},
+	}, http.DefaultClient)
+
+	var seen *User
+	handler := NewOIDCAuth(provider, sessions, time.Hour).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = UserFromContext(r.Context())
+	}))
+
+	// Act
+	callback := login(t, issuer, handler)
+
+	apiRec := httptest.NewRecorder()
+	handler.ServeHTTP(apiRec, httptest.NewRequest(http.MethodGet, "/api/repositories", nil))
+
+	authed := httptest.NewRequest(http.MethodGet, "/api/repositories", nil)
+	for _, cookie := range callback.Result().Cookies() {
+		authed.AddCookie(cookie)
+	}
+	authedRec := httptest.NewRecorder()
+	handler.ServeHTTP(authedRec, authed)
+
+	// Assert
+	if callback.Code != http.StatusFound || callback.Header().Get("Location") != "/repository?id=a/b" {
+		t.Fatalf("expected redirect back to the original page, got %d %q", callback.Code, callback.Header().Get("Location"))
+	}
+
+	if apiRec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for unauthenticated API request, got %d", apiRec.Code)
+	}
+
+	if authedRec.Code != http.StatusOK || seen == nil || seen.Email != "dev@example.com" || seen.Name != "Dev" {
+		t.Errorf("expected authenticated request with user in context, got %d %+v", authedRec.Code, seen)
+	}
+}
+
+// TestOIDCAuth_DisallowedDomain tests that users outside the allowed domains and groups are rejected.
+func TestOIDCAuth_DisallowedDomain(t *testing.T) {
+	// Arrange
+	issuer := newTestIssuer(t, map[string]interface{}{"email": "someone@other.org", "groups": []string{"guests"}})
+	sessions, _ := NewSessionCodec(GenerateSecret())
+	provider := NewOIDCProvider(OIDCConfig{
+		IssuerURL:      issuer.server.URL,
+		ClientID:       "dashboard",
+		ClientSecret:   "s3cret",
+		RedirectURL:    "http://localhost/auth/callback",
+		AllowedDomains: []string{"example.com"},
+		AllowedGroups:  []string{"engineering"},
+	}, http.DefaultClient)
+	handler := NewOIDCAuth(provider, sessions, time.Hour).Wrap(http.NotFoundHandler())
+
+	// Act
+	callback := login(t, issuer, handler)
+
+	// Assert
+	if callback.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for disallowed user, got %d", callback.Code)
+	}
+
+	for _, cookie := range callback.Result().Cookies() {
+		if cookie.Name == SessionCookieName && cookie.Value != "" {
+			t.Errorf("expected no session cookie for disallowed user")
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSessionCodec_RoundTrip tests that an encoded session decodes to the same user.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestSessionCodec_RoundTrip(t *testing.T) {
+	// Arrange
+	codec, err := NewSessionCodec(GenerateSecret())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	user := User{Subject: "u-1", Email: "dev@example.com", Name: "Dev"}
+
+	// Act
+	value, err := codec.Encode(purposeSession, user, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var got User
+	err = codec.Decode(value, purposeSession, &got)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got != user {
+		t.Errorf("expected %+v, got %+v", user, got)
+	}
+}
+
+// TestSessionCodec_Invalid tests that tampered, expired, foreign and repurposed values are rejected.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestSessionCodec_Invalid(t *testing.T) {
+	// Arrange
+	codec, _ := NewSessionCodec(GenerateSecret())
+	other, _ := NewSessionCodec(GenerateSecret())
+	user := User{Subject: "u-1"}
+
+	valid, _ := codec.Encode(purposeSession, user, time.Now().Add(time.Hour))
+	payload, signature, _ := strings.Cut(valid, ".")
+	tamperedPayload, _ := codec.Encode(purposeSession, User{Subject: "admin"}, time.Now().Add(time.Hour))
+	tamperedPayload, _, _ = strings.Cut(tamperedPayload, ".")
+	expired, _ := codec.Encode(purposeSession, user, time.Now().Add(-time.Second))
+	foreign, _ := other.Encode(purposeSession, user, time.Now().Add(time.Hour))
+	loginState, _ := codec.Encode(purposeLoginState, loginState{State: "s", Nonce: "n", Next: "/"}, time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name   string
+		cookie string
+	}{
+		{"empty", ""},
+		{"no signature", payload},
+		{"signature not base64", payload + ".!!!"},
+		{"tampered payload", tamperedPayload + "." + signature},
+		{"tampered signature", payload + "." + strings.Repeat("A", len(signature))},
+		{"expired", expired},
+		{"signed with another secret", foreign},
+		{"login state used as session", loginState},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			var got User
+			err := codec.Decode(tt.cookie, purposeSession, &got)
+
+			// Assert
+			if !errors.Is(err, ErrInvalidSession) {
+				t.Errorf("expected ErrInvalidSession, got %v (%+v)", err, got)
+			}
+		})
+	}
+}
+
+// TestNewSessionCodec_ShortSecret tests that secrets shorter than 32 bytes are rejected.
+func TestNewSessionCodec_ShortSecret(t *testing.T) {
+	// Act
+	_, err := NewSessionCodec([]byte("too short"))
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected error for short secret, got nil")
+	}
+}
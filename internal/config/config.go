@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -16,9 +18,22 @@ const (
 	DefaultCacheDurationSeconds         = 1800  // 30 minutes
 	DefaultStaleCacheTTLSeconds         = 86400 // 24 hours
 	DefaultBackgroundRefreshSeconds     = 300   // 5 minutes
+	DefaultEventPollIntervalSeconds     = 60    // 1 minute
+	DefaultCacheSnapshotSeconds         = 300   // 5 minutes
+	DefaultDataDir                      = "data"
+	DefaultHistoryRetentionDays         = 90
 	DefaultUIRefreshIntervalSeconds     = 5
+	DefaultSMTPPort                     = 587
+	DefaultSessionTTLHours              = 12
 	DefaultGitLabURL                    = "https://gitlab.com"
 	DefaultGitHubURL                    = "https://api.github.com"
+	DefaultBitbucketURL                 = "https://api.bitbucket.org/2.0"
+)
+
+// GitHub API flavours
+const (
+	GitHubAPIREST    = "rest"
+	GitHubAPIGraphQL = "graphql"
 )
 
 // Config holds application configuration.
@@ -27,40 +42,269 @@ type Config struct {
 	Port int
 
 	// GitLab configuration
-	GitLabURL   string
-	GitLabToken string
+	GitLabURL           string
+	GitLabToken         string
+	GitLabWebhookSecret string // Secret token for /api/webhooks/gitlab (empty = endpoint disabled)
+	GitLabWriteToken    string // Token with the api scope for write actions (empty = actions disabled)
 
 	// GitHub configuration
-	GitHubURL   string
-	GitHubToken string
+	GitHubURL           string
+	GitHubToken         string
+	GitHubWebhookSecret string // HMAC secret for /api/webhooks/github (empty = endpoint disabled)
+	GitHubAPI           string // "rest" (default) or "graphql" (batches repository reads)
+	GitHubWriteToken    string // Token with Actions: Read and write for write actions (empty = actions disabled)
+
+	// GitHub App authentication (replaces GitHubToken with auto-refreshed installation tokens)
+	GitHubAppID             string
+	GitHubAppPrivateKeyFile string // PEM private key downloaded from the app settings
+	GitHubAppInstallationID string // optional when the app is installed on a single account
+
+	// Gitea/Forgejo configuration (no default URL - self-hosted only)
+	GiteaURL   string
+	GiteaToken string
+
+	// Bitbucket Cloud configuration
+	BitbucketURL       string
+	BitbucketToken     string // access token, or app password when BitbucketUsername is set
+	BitbucketUsername  string // app password username (empty = token is a bearer access token)
+	BitbucketWorkspace string // limit repositories to one workspace (required for workspace access tokens)
+
+	// Jenkins configuration (pipelines only - jobs are linked to repositories of the other platforms)
+	JenkinsURL      string
+	JenkinsUsername string       // API token owner (empty = anonymous read access)
+	JenkinsToken    string       // API token of JenkinsUsername
+	JenkinsJobs     []JenkinsJob // YAML only
+
+	// Additional named instances of the platforms above (YAML only)
+	Connections []Connection
 
 	// Watched repositories (comma-separated list of project IDs)
 	// Format for GitLab: project-id (e.g., "123,456")
 	GitLabWatchedRepos string
 	// Format for GitHub: owner/repo (e.g., "facebook/react,golang/go")
 	GitHubWatchedRepos string
+	// Format for Gitea: owner/repo (e.g., "infra/deploy,web/site")
+	GiteaWatchedRepos string
+	// Format for Bitbucket: workspace/repo (e.g., "acme/api,acme/web")
+	BitbucketWatchedRepos string
 
 	// Display configuration
 	RunsPerRepository    int // Number of recent runs to show per repository
 	RecentPipelinesLimit int // Total number of pipelines to show in recent view
 
 	// Cache configuration
-	GitLabCacheDurationSeconds int // Duration to cache GitLab API responses (default: 1800 = 30 minutes)
-	GitHubCacheDurationSeconds int // Duration to cache GitHub API responses (default: 1800 = 30 minutes)
-	StaleCacheTTLSeconds       int // How long to serve stale cache data (default: 86400 = 24 hours)
+	GitLabCacheDurationSeconds    int // Duration to cache GitLab API responses (default: 1800 = 30 minutes)
+	GitHubCacheDurationSeconds    int // Duration to cache GitHub API responses (default: 1800 = 30 minutes)
+	GiteaCacheDurationSeconds     int // Duration to cache Gitea API responses (default: 1800 = 30 minutes)
+	BitbucketCacheDurationSeconds int // Duration to cache Bitbucket API responses (default: 1800 = 30 minutes)
+	JenkinsCacheDurationSeconds   int // Duration to cache Jenkins API responses (default: 1800 = 30 minutes)
+	StaleCacheTTLSeconds          int // How long to serve stale cache data (default: 86400 = 24 hours)
+
+	// Persistence configuration
+	DataDir                      string // Directory for on-disk state such as cache snapshots (default: "data")
+	CacheSnapshotIntervalSeconds int    // How often to snapshot the cache to disk (default: 300, 0 = disabled)
+	HistoryRetentionDays         int    // How long to keep pipeline history for trends (default: 90, 0 = disabled)
 
 	// Background refresh configuration
 	BackgroundRefreshIntervalSeconds int // How often to refresh all caches in background (default: 300 = 5 minutes)
 
+	// Event polling configuration
+	EventPollIntervalSeconds int // How often to poll project events for targeted cache refresh (default: 60, 0 = disabled)
+
 	// UI configuration
 	UIRefreshIntervalSeconds int // How often UI auto-refreshes data in seconds (default: 5)
 
 	// Current user configuration (for filtering "your branches")
-	GitLabCurrentUser string // GitLab username for filtering branches (from GITLAB_USER)
-	GitHubCurrentUser string // GitHub username for filtering branches (from GITHUB_USER)
+	GitLabCurrentUser    string // GitLab username for filtering branches (from GITLAB_USER)
+	GitHubCurrentUser    string // GitHub username for filtering branches (from GITHUB_USER)
+	GiteaCurrentUser     string // Gitea username for filtering branches (from GITEA_USER)
+	BitbucketCurrentUser string // Bitbucket nickname for filtering branches (from BITBUCKET_USER)
+
+	// Write actions (retry, cancel and run pipelines from the repository detail page)
+	ActionsEnabled              bool   // Requires a write token per platform (default: false)
+	ActionsAllowUnauthenticated bool   // Allow actions with AUTH_MODE unset (default: false - the server refuses to start)
+	AuditLogFile                string // JSON Lines log of write actions (default: DATA_DIR/audit.jsonl)
 
 	// Repository filtering
 	FilterUserRepos bool // If true, only fetch repositories where user has membership (default: false - disabled until permissions API is fully working)
+
+	// Notifications (YAML only - no rules = disabled)
+	Notifications NotificationsConfig
+
+	// Authentication configuration
+	AuthMode           string   // "" (disabled), "basic" or "oidc"
+	AuthHtpasswdFile   string   // htpasswd file for basic mode
+	SessionSecret      string   // HMAC key for session cookies (empty = random per process, sessions end on restart)
+	SessionTTLHours    int      // How long OIDC sessions last (default: 12)
+	OIDCIssuerURL      string   // OIDC issuer, e.g. https://accounts.google.com
+	OIDCClientID       string   // OIDC client ID
+	OIDCClientSecret   string   // OIDC client secret
+	OIDCRedirectURL    string   // Public callback URL, e.g. https://ci.example.com/auth/callback
+	OIDCAllowedDomains []string // Allowed email domains (empty with no groups = any user of the issuer)
+	OIDCAllowedGroups  []string // Allowed groups from the groups claim
+	OIDCGroupsClaim    string   // ID token claim holding groups (default: groups)
+}
+
+// Connection is an additional named instance of a platform, e.g. a self-managed GitLab next to
+// gitlab.com or GitHub Enterprise Server next to github.com.
+// Project IDs of a connection are qualified with its name (123@gitlab-internal) so they never collide.
+// Token, WriteToken and WebhookSecret support ${ENV_VAR} expansion so secrets can stay out of the file.
+type Connection struct {
+	Name                 string   `yaml:"name"`                   // letters, digits, "-" and "_"
+	Platform             string   `yaml:"platform"`               // gitlab, github, gitea or bitbucket
+	URL                  string   `yaml:"url"`                    // API URL (default: the platform's public service; required for gitea)
+	Token                string   `yaml:"token"`                  // access token
+	WriteToken           string   `yaml:"write_token"`            // gitlab, github: token for write actions (empty = actions disabled)
+	WebhookSecret        string   `yaml:"webhook_secret"`         // gitlab, github: secret of /api/webhooks/<name> (empty = endpoint disabled)
+	Username             string   `yaml:"username"`               // bitbucket: app password username
+	Workspace            string   `yaml:"workspace"`              // bitbucket: limit repositories to one workspace
+	API                  string   `yaml:"api"`                    // github: "rest" (default) or "graphql"
+	AppID                string   `yaml:"app_id"`                 // github: authenticate as a GitHub App instead of a token
+	AppPrivateKeyFile    string   `yaml:"app_private_key_file"`   // github: PEM private key of the app
+	AppInstallationID    string   `yaml:"app_installation_id"`    // github: optional with a single installation
+	WatchedRepos         []string `yaml:"watched_repos"`          // whitelist of the platform's project IDs (empty = all)
+	CacheDurationSeconds int      `yaml:"cache_duration_seconds"` // default: 1800 = 30 minutes
+}
+
+// connectionNamePattern restricts connection names to characters that are safe in project IDs, cache keys and file names.
+var connectionNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// defaultPlatformURLs are the API URLs of connections without a URL (gitea is self-hosted only).
+var defaultPlatformURLs = map[string]string{
+	"gitlab":    DefaultGitLabURL,
+	"github":    DefaultGitHubURL,
+	"gitea":     "",
+	"bitbucket": DefaultBitbucketURL,
+}
+
+// loadConnections validates connections and fills in defaults.
+// Names must be unique and cannot be a platform name, which is reserved for the platform's default connection.
+func loadConnections(connections []Connection) ([]Connection, error) {
+	seen := make(map[string]bool)
+	for i := range connections {
+		conn := &connections[i]
+
+		if !connectionNamePattern.MatchString(conn.Name) {
+			return nil, fmt.Errorf("connection %d: invalid name %q (use letters, digits, \"-\" and \"_\")", i+1, conn.Name)
+		}
+		if _, reserved := defaultPlatformURLs[conn.Name]; reserved || seen[conn.Name] {
+			return nil, fmt.Errorf("connection %s: name is already in use", conn.Name)
+		}
+		seen[conn.Name] = true
+
+		defaultURL, ok := defaultPlatformURLs[conn.Platform]
+		if !ok {
+			return nil, fmt.Errorf("connection %s: unknown platform %q", conn.Name, conn.Platform)
+		}
+		if conn.URL == "" {
+			conn.URL = defaultURL
+		}
+		if conn.URL == "" {
+			return nil, fmt.Errorf("connection %s: url is required for %s", conn.Name, conn.Platform)
+		}
+		conn.URL = strings.TrimSuffix(conn.URL, "/")
+
+		api, err := loadGitHubAPI(conn.API)
+		if err != nil {
+			return nil, fmt.Errorf("connection %s: %w", conn.Name, err)
+		}
+		conn.API = api
+
+		if conn.AppID != "" || conn.AppPrivateKeyFile != "" {
+			if conn.Platform != "github" {
+				return nil, fmt.Errorf("connection %s: app authentication is only supported for github", conn.Name)
+			}
+			if err := validateGitHubApp(conn.AppID, conn.AppPrivateKeyFile); err != nil {
+				return nil, fmt.Errorf("connection %s: %w", conn.Name, err)
+			}
+		}
+
+		conn.Token = os.ExpandEnv(conn.Token)
+		if conn.Token == "" && conn.AppID == "" {
+			return nil, fmt.Errorf("connection %s: token is required", conn.Name)
+		}
+
+		conn.WriteToken = os.ExpandEnv(conn.WriteToken)
+		if conn.WriteToken != "" && conn.Platform != "gitlab" && conn.Platform != "github" {
+			return nil, fmt.Errorf("connection %s: write actions are only supported for gitlab and github", conn.Name)
+		}
+
+		conn.WebhookSecret = os.ExpandEnv(conn.WebhookSecret)
+		if conn.WebhookSecret != "" && conn.Platform != "gitlab" && conn.Platform != "github" {
+			return nil, fmt.Errorf("connection %s: webhooks are only supported for gitlab and github", conn.Name)
+		}
+
+		if conn.CacheDurationSeconds <= 0 {
+			conn.CacheDurationSeconds = DefaultCacheDurationSeconds
+		}
+	}
+	return connections, nil
+}
+
+// loadGitHubAPI validates the GitHub API flavour, defaulting to REST.
+func loadGitHubAPI(value string) (string, error) {
+	switch api := strings.ToLower(value); api {
+	case "", GitHubAPIREST:
+		return GitHubAPIREST, nil
+	case GitHubAPIGraphQL:
+		return api, nil
+	default:
+		return "", fmt.Errorf("invalid GitHub API %q (want %s or %s)", value, GitHubAPIREST, GitHubAPIGraphQL)
+	}
+}
+
+// validateGitHubApp checks that a GitHub App is configured with both its ID and private key.
+func validateGitHubApp(appID, privateKeyFile string) error {
+	if appID == "" || privateKeyFile == "" {
+		return fmt.Errorf("GitHub App authentication requires both app_id and app_private_key_file")
+	}
+	return nil
+}
+
+// JenkinsJob links a Jenkins job to the repository whose pipelines it runs.
+type JenkinsJob struct {
+	Job        string `yaml:"job"`        // job path with folders separated by "/" (e.g. "team/app")
+	Platform   string `yaml:"platform"`   // platform hosting the repository (e.g. gitlab)
+	Connection string `yaml:"connection"` // named connection hosting the repository (empty = the platform's default connection)
+	Project    string `yaml:"project"`    // repository project ID on that platform or connection (e.g. "123" or "owner/repo")
+	Branch     string `yaml:"branch"`     // branch built by a single-branch job (empty = the git branch of each build)
+}
+
+// NotificationsConfig holds notification sinks and the rules that route default branch status changes to them.
+type NotificationsConfig struct {
+	Sinks []NotificationSink `yaml:"sinks"`
+	Rules []NotificationRule `yaml:"rules"`
+}
+
+// NotificationSink configures a notification destination.
+// URL and Password support ${ENV_VAR} expansion so secrets can stay out of the file.
+type NotificationSink struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"` // webhook, slack or email
+	URL  string `yaml:"url"`  // webhook and slack
+
+	// Email (SMTP) settings
+	SMTPHost string   `yaml:"smtp_host"`
+	SMTPPort int      `yaml:"smtp_port"` // default: 587
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+// NotificationRule selects default branch status changes to notify on.
+type NotificationRule struct {
+	Name             string   `yaml:"name"`
+	Repositories     []string `yaml:"repositories"`       // globs (empty = all)
+	Branches         []string `yaml:"branches"`           // globs (empty = all default branches)
+	Status           string   `yaml:"status"`             // failed or success (recovered)
+	MinFailedMinutes int      `yaml:"min_failed_minutes"` // only notify once failing this long
+	Sinks            []string `yaml:"sinks"`              // sink names (empty = all)
+}
+
+// HasNotifications returns true if any notification rule is configured.
+func (c *Config) HasNotifications() bool {
+	return len(c.Notifications.Rules) > 0
 }
 
 // yamlConfig represents the YAML file structure.
@@ -72,6 +316,8 @@ type yamlConfig struct {
 		WatchedRepos         []string `yaml:"watched_repos"`
 		CacheDurationSeconds int      `yaml:"cache_duration_seconds"`
 		CurrentUser          string   `yaml:"current_user"`
+		WebhookSecret        string   `yaml:"webhook_secret"`
+		WriteToken           string   `yaml:"write_token"`
 	} `yaml:"gitlab"`
 	GitHub struct {
 		URL                  string   `yaml:"url"`
@@ -79,23 +325,82 @@ type yamlConfig struct {
 		WatchedRepos         []string `yaml:"watched_repos"`
 		CacheDurationSeconds int      `yaml:"cache_duration_seconds"`
 		CurrentUser          string   `yaml:"current_user"`
+		WebhookSecret        string   `yaml:"webhook_secret"`
+		API                  string   `yaml:"api"`
+		WriteToken           string   `yaml:"write_token"`
+		AppID                string   `yaml:"app_id"`
+		AppPrivateKeyFile    string   `yaml:"app_private_key_file"`
+		AppInstallationID    string   `yaml:"app_installation_id"`
 	} `yaml:"github"`
+	Gitea struct {
+		URL                  string   `yaml:"url"`
+		Token                string   `yaml:"token"`
+		WatchedRepos         []string `yaml:"watched_repos"`
+		CacheDurationSeconds int      `yaml:"cache_duration_seconds"`
+		CurrentUser          string   `yaml:"current_user"`
+	} `yaml:"gitea"`
+	Bitbucket struct {
+		URL                  string   `yaml:"url"`
+		Token                string   `yaml:"token"`
+		Username             string   `yaml:"username"`
+		Workspace            string   `yaml:"workspace"`
+		WatchedRepos         []string `yaml:"watched_repos"`
+		CacheDurationSeconds int      `yaml:"cache_duration_seconds"`
+		CurrentUser          string   `yaml:"current_user"`
+	} `yaml:"bitbucket"`
+	Jenkins struct {
+		URL                  string       `yaml:"url"`
+		Username             string       `yaml:"username"`
+		Token                string       `yaml:"token"`
+		CacheDurationSeconds int          `yaml:"cache_duration_seconds"`
+		Jobs                 []JenkinsJob `yaml:"jobs"`
+	} `yaml:"jenkins"`
 	Display struct {
 		RunsPerRepository    int `yaml:"runs_per_repository"`
 		RecentPipelinesLimit int `yaml:"recent_pipelines_limit"`
 	} `yaml:"display"`
 	Cache struct {
-		StaleTTLSeconds int `yaml:"stale_ttl_seconds"`
+		StaleTTLSeconds         int    `yaml:"stale_ttl_seconds"`
+		DataDir                 string `yaml:"data_dir"`
+		SnapshotIntervalSeconds *int   `yaml:"snapshot_interval_seconds"`
 	} `yaml:"cache"`
+	History struct {
+		RetentionDays *int `yaml:"retention_days"`
+	} `yaml:"history"`
 	Background struct {
 		RefreshIntervalSeconds int `yaml:"refresh_interval_seconds"`
 	} `yaml:"background"`
+	Events struct {
+		PollIntervalSeconds *int `yaml:"poll_interval_seconds"`
+	} `yaml:"events"`
 	UI struct {
 		RefreshIntervalSeconds int `yaml:"refresh_interval_seconds"`
 	} `yaml:"ui"`
 	Filter struct {
 		UserRepos bool `yaml:"user_repos"`
 	} `yaml:"filter"`
+	Actions struct {
+		Enabled              bool   `yaml:"enabled"`
+		AllowUnauthenticated bool   `yaml:"allow_unauthenticated"`
+		AuditLog             string `yaml:"audit_log"`
+	} `yaml:"actions"`
+	Connections   []Connection        `yaml:"connections"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Auth          struct {
+		Mode            string `yaml:"mode"`
+		HtpasswdFile    string `yaml:"htpasswd_file"`
+		SessionSecret   string `yaml:"session_secret"`
+		SessionTTLHours int    `yaml:"session_ttl_hours"`
+		OIDC            struct {
+			IssuerURL      string   `yaml:"issuer_url"`
+			ClientID       string   `yaml:"client_id"`
+			ClientSecret   string   `yaml:"client_secret"`
+			RedirectURL    string   `yaml:"redirect_url"`
+			AllowedDomains []string `yaml:"allowed_domains"`
+			AllowedGroups  []string `yaml:"allowed_groups"`
+			GroupsClaim    string   `yaml:"groups_claim"`
+		} `yaml:"oidc"`
+	} `yaml:"auth"`
 }
 
 // loadIntConfig loads an integer configuration value with fallback priority:
@@ -185,16 +490,78 @@ func Load() (*Config, error) {
 		githubToken = yc.GitHub.Token
 	}
 
+	giteaURL := strings.TrimSuffix(getEnvOrDefault("GITEA_URL", yc.Gitea.URL), "/")
+
+	giteaToken := os.Getenv("GITEA_TOKEN")
+	if giteaToken == "" {
+		giteaToken = yc.Gitea.Token
+	}
+
+	bitbucketURL := getEnvOrDefault("BITBUCKET_URL", "")
+	if bitbucketURL == "" {
+		if yc.Bitbucket.URL != "" {
+			bitbucketURL = yc.Bitbucket.URL
+		} else {
+			bitbucketURL = DefaultBitbucketURL
+		}
+	}
+
+	bitbucketToken := os.Getenv("BITBUCKET_TOKEN")
+	if bitbucketToken == "" {
+		bitbucketToken = yc.Bitbucket.Token
+	}
+
+	jenkinsURL := strings.TrimSuffix(getEnvOrDefault("JENKINS_URL", yc.Jenkins.URL), "/")
+
+	jenkinsToken := os.Getenv("JENKINS_TOKEN")
+	if jenkinsToken == "" {
+		jenkinsToken = yc.Jenkins.Token
+	}
+
+	gitlabWebhookSecret := os.Getenv("GITLAB_WEBHOOK_SECRET")
+	if gitlabWebhookSecret == "" {
+		gitlabWebhookSecret = yc.GitLab.WebhookSecret
+	}
+
+	githubWebhookSecret := os.Getenv("GITHUB_WEBHOOK_SECRET")
+	if githubWebhookSecret == "" {
+		githubWebhookSecret = yc.GitHub.WebhookSecret
+	}
+
 	gitlabWatchedRepos := os.Getenv("GITLAB_WATCHED_REPOS")
 	if gitlabWatchedRepos == "" {
 		gitlabWatchedRepos = strings.Join(yc.GitLab.WatchedRepos, ",")
 	}
 
+	githubAPI, err := loadGitHubAPI(getEnvOrDefault("GITHUB_API", yc.GitHub.API))
+	if err != nil {
+		return nil, err
+	}
+
+	githubAppID := getEnvOrDefault("GITHUB_APP_ID", yc.GitHub.AppID)
+	githubAppPrivateKeyFile := getEnvOrDefault("GITHUB_APP_PRIVATE_KEY_FILE", yc.GitHub.AppPrivateKeyFile)
+	githubAppInstallationID := getEnvOrDefault("GITHUB_APP_INSTALLATION_ID", yc.GitHub.AppInstallationID)
+	if githubAppID != "" || githubAppPrivateKeyFile != "" {
+		if err := validateGitHubApp(githubAppID, githubAppPrivateKeyFile); err != nil {
+			return nil, err
+		}
+	}
+
 	githubWatchedRepos := os.Getenv("GITHUB_WATCHED_REPOS")
 	if githubWatchedRepos == "" {
 		githubWatchedRepos = strings.Join(yc.GitHub.WatchedRepos, ",")
 	}
 
+	giteaWatchedRepos := os.Getenv("GITEA_WATCHED_REPOS")
+	if giteaWatchedRepos == "" {
+		giteaWatchedRepos = strings.Join(yc.Gitea.WatchedRepos, ",")
+	}
+
+	bitbucketWatchedRepos := os.Getenv("BITBUCKET_WATCHED_REPOS")
+	if bitbucketWatchedRepos == "" {
+		bitbucketWatchedRepos = strings.Join(yc.Bitbucket.WatchedRepos, ",")
+	}
+
 	runsPerRepo := loadIntConfig("RUNS_PER_REPOSITORY", yc.Display.RunsPerRepository, DefaultRunsPerRepository, func(v int) bool { return v > 0 })
 
 	recentLimit := loadIntConfig("RECENT_PIPELINES_LIMIT", yc.Display.RecentPipelinesLimit, DefaultRecentPipelinesLimit, func(v int) bool { return v > 0 })
@@ -203,6 +570,12 @@ func Load() (*Config, error) {
 
 	githubCacheDuration := loadIntConfig("GITHUB_CACHE_DURATION_SECONDS", yc.GitHub.CacheDurationSeconds, DefaultCacheDurationSeconds, func(v int) bool { return v >= 0 })
 
+	giteaCacheDuration := loadIntConfig("GITEA_CACHE_DURATION_SECONDS", yc.Gitea.CacheDurationSeconds, DefaultCacheDurationSeconds, func(v int) bool { return v >= 0 })
+
+	bitbucketCacheDuration := loadIntConfig("BITBUCKET_CACHE_DURATION_SECONDS", yc.Bitbucket.CacheDurationSeconds, DefaultCacheDurationSeconds, func(v int) bool { return v >= 0 })
+
+	jenkinsCacheDuration := loadIntConfig("JENKINS_CACHE_DURATION_SECONDS", yc.Jenkins.CacheDurationSeconds, DefaultCacheDurationSeconds, func(v int) bool { return v >= 0 })
+
 	// Load current user configuration with fallback
 	currentUser := os.Getenv("CURRENT_USER") // Common fallback
 
@@ -224,12 +597,60 @@ func Load() (*Config, error) {
 		}
 	}
 
+	giteaCurrentUser := os.Getenv("GITEA_USER")
+	if giteaCurrentUser == "" {
+		if yc.Gitea.CurrentUser != "" {
+			giteaCurrentUser = yc.Gitea.CurrentUser
+		} else {
+			giteaCurrentUser = currentUser
+		}
+	}
+
+	bitbucketCurrentUser := os.Getenv("BITBUCKET_USER")
+	if bitbucketCurrentUser == "" {
+		if yc.Bitbucket.CurrentUser != "" {
+			bitbucketCurrentUser = yc.Bitbucket.CurrentUser
+		} else {
+			bitbucketCurrentUser = currentUser
+		}
+	}
+
 	uiRefreshInterval := loadIntConfig("UI_REFRESH_INTERVAL_SECONDS", yc.UI.RefreshIntervalSeconds, DefaultUIRefreshIntervalSeconds, func(v int) bool { return v > 0 })
 
 	staleCacheTTL := loadIntConfig("STALE_CACHE_TTL_SECONDS", yc.Cache.StaleTTLSeconds, DefaultStaleCacheTTLSeconds, func(v int) bool { return v > 0 })
 
 	backgroundRefreshInterval := loadIntConfig("BACKGROUND_REFRESH_INTERVAL_SECONDS", yc.Background.RefreshIntervalSeconds, DefaultBackgroundRefreshSeconds, func(v int) bool { return v > 0 })
 
+	// Event polling can be disabled explicitly with 0, so a YAML zero is not treated as "unset"
+	eventPollInterval := DefaultEventPollIntervalSeconds
+	if yc.Events.PollIntervalSeconds != nil && *yc.Events.PollIntervalSeconds >= 0 {
+		eventPollInterval = *yc.Events.PollIntervalSeconds
+	}
+	eventPollInterval = loadIntConfig("EVENT_POLL_INTERVAL_SECONDS", 0, eventPollInterval, func(v int) bool { return v >= 0 })
+
+	dataDir := getEnvOrDefault("DATA_DIR", "")
+	if dataDir == "" {
+		if yc.Cache.DataDir != "" {
+			dataDir = yc.Cache.DataDir
+		} else {
+			dataDir = DefaultDataDir
+		}
+	}
+
+	// Snapshots can be disabled explicitly with 0, so a YAML zero is not treated as "unset"
+	cacheSnapshotInterval := DefaultCacheSnapshotSeconds
+	if yc.Cache.SnapshotIntervalSeconds != nil && *yc.Cache.SnapshotIntervalSeconds >= 0 {
+		cacheSnapshotInterval = *yc.Cache.SnapshotIntervalSeconds
+	}
+	cacheSnapshotInterval = loadIntConfig("CACHE_SNAPSHOT_INTERVAL_SECONDS", 0, cacheSnapshotInterval, func(v int) bool { return v >= 0 })
+
+	// Pipeline history can be disabled explicitly with 0, so a YAML zero is not treated as "unset"
+	historyRetention := DefaultHistoryRetentionDays
+	if yc.History.RetentionDays != nil && *yc.History.RetentionDays >= 0 {
+		historyRetention = *yc.History.RetentionDays
+	}
+	historyRetention = loadIntConfig("HISTORY_RETENTION_DAYS", 0, historyRetention, func(v int) bool { return v >= 0 })
+
 	// Load filter configuration (DISABLED by default until permissions are properly populated)
 	// Priority: Environment variable -> Default (false)
 	// To enable, set FILTER_USER_REPOS=true or FILTER_USER_REPOS=1
@@ -238,25 +659,108 @@ func Load() (*Config, error) {
 		filterUserRepos = envFilter == "true" || envFilter == "1"
 	}
 
-	return &Config{
+	// Write actions: the environment overrides the file
+	actionsEnabled := yc.Actions.Enabled
+	if envActions := os.Getenv("ACTIONS_ENABLED"); envActions != "" {
+		actionsEnabled = envActions == "true" || envActions == "1"
+	}
+	allowUnauthenticated := yc.Actions.AllowUnauthenticated
+	if envAllow := os.Getenv("ACTIONS_ALLOW_UNAUTHENTICATED"); envAllow != "" {
+		allowUnauthenticated = envAllow == "true" || envAllow == "1"
+	}
+
+	// Expand ${ENV_VAR} references in notification secrets
+	notifications := yc.Notifications
+	for i := range notifications.Sinks {
+		notifications.Sinks[i].URL = os.ExpandEnv(notifications.Sinks[i].URL)
+		notifications.Sinks[i].Password = os.ExpandEnv(notifications.Sinks[i].Password)
+		if notifications.Sinks[i].SMTPPort == 0 {
+			notifications.Sinks[i].SMTPPort = DefaultSMTPPort
+		}
+	}
+
+	connections, err := loadConnections(yc.Connections)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authentication: secrets from the environment win over the file
+	authMode := strings.ToLower(getEnvOrDefault("AUTH_MODE", yc.Auth.Mode))
+	if authMode == "none" {
+		authMode = ""
+	}
+	sessionTTL := loadIntConfig("SESSION_TTL_HOURS", yc.Auth.SessionTTLHours, DefaultSessionTTLHours, func(v int) bool { return v > 0 })
+
+	cfg := &Config{
 		Port:                             port,
 		GitLabURL:                        gitlabURL,
 		GitLabToken:                      gitlabToken,
+		GitLabWebhookSecret:              gitlabWebhookSecret,
+		GitLabWriteToken:                 getEnvOrDefault("GITLAB_WRITE_TOKEN", yc.GitLab.WriteToken),
 		GitHubURL:                        githubURL,
 		GitHubToken:                      githubToken,
+		GitHubWebhookSecret:              githubWebhookSecret,
+		GitHubAPI:                        githubAPI,
+		GitHubWriteToken:                 getEnvOrDefault("GITHUB_WRITE_TOKEN", yc.GitHub.WriteToken),
+		GitHubAppID:                      githubAppID,
+		GitHubAppPrivateKeyFile:          githubAppPrivateKeyFile,
+		GitHubAppInstallationID:          githubAppInstallationID,
+		GiteaURL:                         giteaURL,
+		GiteaToken:                       giteaToken,
+		BitbucketURL:                     bitbucketURL,
+		BitbucketToken:                   bitbucketToken,
+		BitbucketUsername:                getEnvOrDefault("BITBUCKET_USERNAME", yc.Bitbucket.Username),
+		BitbucketWorkspace:               getEnvOrDefault("BITBUCKET_WORKSPACE", yc.Bitbucket.Workspace),
+		JenkinsURL:                       jenkinsURL,
+		JenkinsUsername:                  getEnvOrDefault("JENKINS_USERNAME", yc.Jenkins.Username),
+		JenkinsToken:                     jenkinsToken,
+		JenkinsJobs:                      yc.Jenkins.Jobs,
+		Connections:                      connections,
 		GitLabWatchedRepos:               gitlabWatchedRepos,
 		GitHubWatchedRepos:               githubWatchedRepos,
+		GiteaWatchedRepos:                giteaWatchedRepos,
+		BitbucketWatchedRepos:            bitbucketWatchedRepos,
 		RunsPerRepository:                runsPerRepo,
 		RecentPipelinesLimit:             recentLimit,
 		GitLabCacheDurationSeconds:       gitlabCacheDuration,
 		GitHubCacheDurationSeconds:       githubCacheDuration,
+		GiteaCacheDurationSeconds:        giteaCacheDuration,
+		BitbucketCacheDurationSeconds:    bitbucketCacheDuration,
+		JenkinsCacheDurationSeconds:      jenkinsCacheDuration,
 		StaleCacheTTLSeconds:             staleCacheTTL,
+		DataDir:                          dataDir,
+		CacheSnapshotIntervalSeconds:     cacheSnapshotInterval,
+		HistoryRetentionDays:             historyRetention,
 		BackgroundRefreshIntervalSeconds: backgroundRefreshInterval,
+		EventPollIntervalSeconds:         eventPollInterval,
 		UIRefreshIntervalSeconds:         uiRefreshInterval,
 		GitLabCurrentUser:                gitlabCurrentUser,
 		GitHubCurrentUser:                githubCurrentUser,
+		GiteaCurrentUser:                 giteaCurrentUser,
+		BitbucketCurrentUser:             bitbucketCurrentUser,
 		FilterUserRepos:                  filterUserRepos,
-	}, nil
+		ActionsEnabled:                   actionsEnabled,
+		ActionsAllowUnauthenticated:      allowUnauthenticated,
+		AuditLogFile:                     getEnvOrDefault("ACTIONS_AUDIT_LOG", yc.Actions.AuditLog),
+		Notifications:                    notifications,
+		AuthMode:                         authMode,
+		AuthHtpasswdFile:                 getEnvOrDefault("AUTH_HTPASSWD_FILE", yc.Auth.HtpasswdFile),
+		SessionSecret:                    getEnvOrDefault("SESSION_SECRET", yc.Auth.SessionSecret),
+		SessionTTLHours:                  sessionTTL,
+		OIDCIssuerURL:                    getEnvOrDefault("OIDC_ISSUER_URL", yc.Auth.OIDC.IssuerURL),
+		OIDCClientID:                     getEnvOrDefault("OIDC_CLIENT_ID", yc.Auth.OIDC.ClientID),
+		OIDCClientSecret:                 getEnvOrDefault("OIDC_CLIENT_SECRET", yc.Auth.OIDC.ClientSecret),
+		OIDCRedirectURL:                  getEnvOrDefault("OIDC_REDIRECT_URL", yc.Auth.OIDC.RedirectURL),
+		OIDCAllowedDomains:               getEnvListOrDefault("OIDC_ALLOWED_DOMAINS", yc.Auth.OIDC.AllowedDomains),
+		OIDCAllowedGroups:                getEnvListOrDefault("OIDC_ALLOWED_GROUPS", yc.Auth.OIDC.AllowedGroups),
+		OIDCGroupsClaim:                  getEnvOrDefault("OIDC_GROUPS_CLAIM", yc.Auth.OIDC.GroupsClaim),
+	}
+
+	// Anyone reaching an unauthenticated dashboard could run pipelines with the write tokens
+	if cfg.HasActions() && cfg.AuthMode == "" && !cfg.ActionsAllowUnauthenticated {
+		return nil, fmt.Errorf("write actions require authentication: set AUTH_MODE, or ACTIONS_ALLOW_UNAUTHENTICATED=true to accept anonymous actions")
+	}
+	return cfg, nil
 }
 
 // GetGitLabWatchedRepos returns the list of watched GitLab repository IDs.
@@ -293,6 +797,40 @@ func (c *Config) GetGitHubWatchedRepos() []string {
 	return result
 }
 
+// GetGiteaWatchedRepos returns the list of watched Gitea repository IDs.
+func (c *Config) GetGiteaWatchedRepos() []string {
+	if c.GiteaWatchedRepos == "" {
+		return nil
+	}
+
+	repos := strings.Split(c.GiteaWatchedRepos, ",")
+	result := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		repo = strings.TrimSpace(repo)
+		if repo != "" {
+			result = append(result, repo)
+		}
+	}
+	return result
+}
+
+// GetBitbucketWatchedRepos returns the list of watched Bitbucket repository IDs.
+func (c *Config) GetBitbucketWatchedRepos() []string {
+	if c.BitbucketWatchedRepos == "" {
+		return nil
+	}
+
+	repos := strings.Split(c.BitbucketWatchedRepos, ",")
+	result := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		repo = strings.TrimSpace(repo)
+		if repo != "" {
+			result = append(result, repo)
+		}
+	}
+	return result
+}
+
 // HasGitLabConfig returns true if GitLab is configured.
 func (c *Config) HasGitLabConfig() bool {
 	return c.GitLabToken != ""
@@ -300,7 +838,73 @@ func (c *Config) HasGitLabConfig() bool {
 
 // HasGitHubConfig returns true if GitHub is configured.
 func (c *Config) HasGitHubConfig() bool {
-	return c.GitHubToken != ""
+	return c.GitHubToken != "" || c.HasGitHubApp()
+}
+
+// HasGitLabActions returns true if write actions are enabled for GitLab.
+func (c *Config) HasGitLabActions() bool {
+	return c.ActionsEnabled && c.HasGitLabConfig() && c.GitLabWriteToken != ""
+}
+
+// HasGitHubActions returns true if write actions are enabled for GitHub.
+func (c *Config) HasGitHubActions() bool {
+	return c.ActionsEnabled && c.HasGitHubConfig() && c.GitHubWriteToken != ""
+}
+
+// HasActions returns true if write actions are enabled for any platform or connection.
+func (c *Config) HasActions() bool {
+	if c.HasGitLabActions() || c.HasGitHubActions() {
+		return true
+	}
+	for _, conn := range c.Connections {
+		if c.HasConnectionActions(conn) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasConnectionActions returns true if write actions are enabled for a named connection.
+func (c *Config) HasConnectionActions(conn Connection) bool {
+	return c.ActionsEnabled && conn.WriteToken != ""
+}
+
+// HasGitHubApp returns true if GitHub authenticates as a GitHub App.
+func (c *Config) HasGitHubApp() bool {
+	return c.GitHubAppID != ""
+}
+
+// HasBitbucketConfig returns true if Bitbucket is configured.
+func (c *Config) HasBitbucketConfig() bool {
+	return c.BitbucketToken != ""
+}
+
+// HasGiteaConfig returns true if Gitea is configured.
+// Gitea is self-hosted, so the URL is required as well as the token.
+func (c *Config) HasGiteaConfig() bool {
+	return c.GiteaURL != "" && c.GiteaToken != ""
+}
+
+// HasJenkinsConfig returns true if Jenkins is configured.
+// Jenkins is self-hosted and only useful with jobs linked to repositories, so both are required.
+func (c *Config) HasJenkinsConfig() bool {
+	return c.JenkinsURL != "" && len(c.JenkinsJobs) > 0
+}
+
+// getEnvListOrDefault returns the comma-separated list in an environment variable, or defaultValue when unset.
+func getEnvListOrDefault(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
 }
 
 func getEnvOrDefault(key, defaultValue string) string {
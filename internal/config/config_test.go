@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -61,3 +62,423 @@ func TestLoad_InvalidPort(t *testing.T) {
 		t.Errorf("expected default port 8080 for invalid input, got %d", cfg.Port)
 	}
 }
+
+// TestLoad_CacheSnapshotDisabled tests that a zero snapshot interval disables cache snapshots.
+func TestLoad_CacheSnapshotDisabled(t *testing.T) {
+	// Arrange
+	os.Setenv("CACHE_SNAPSHOT_INTERVAL_SECONDS", "0")
+	defer os.Unsetenv("CACHE_SNAPSHOT_INTERVAL_SECONDS")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if cfg.CacheSnapshotIntervalSeconds != 0 {
+		t.Errorf("expected snapshots disabled (0), got %d", cfg.CacheSnapshotIntervalSeconds)
+	}
+
+	if cfg.DataDir != DefaultDataDir {
+		t.Errorf("expected default data dir %q, got %q", DefaultDataDir, cfg.DataDir)
+	}
+}
+
+// TestLoad_HistoryRetention tests the pipeline history retention default and explicit disabling.
+func TestLoad_HistoryRetention(t *testing.T) {
+	// Arrange
+	defaultCfg, defaultErr := Load()
+	os.Setenv("HISTORY_RETENTION_DAYS", "0")
+	defer os.Unsetenv("HISTORY_RETENTION_DAYS")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if defaultErr != nil || err != nil {
+		t.Fatalf("expected no errors, got %v and %v", defaultErr, err)
+	}
+
+	if defaultCfg.HistoryRetentionDays != DefaultHistoryRetentionDays {
+		t.Errorf("expected default retention %d, got %d", DefaultHistoryRetentionDays, defaultCfg.HistoryRetentionDays)
+	}
+
+	if cfg.HistoryRetentionDays != 0 {
+		t.Errorf("expected history disabled (0), got %d", cfg.HistoryRetentionDays)
+	}
+}
+
+// TestLoad_Notifications tests loading notification sinks and rules from YAML with secret expansion.
+func TestLoad_Notifications(t *testing.T) {
+	// Arrange
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	yamlConfig := `
+notifications:
+  sinks:
+    - name: team
+      type: slack
+      url: ${TEST_SLACK_URL}
+    - name: oncall
+      type: email
+      smtp_host: smtp.example.com
+      from: ci@example.com
+      to: [oncall@example.com]
+  rules:
+    - name: main-broken
+      repositories: ["platform/*"]
+      status: failed
+      min_failed_minutes: 15
+      sinks: [team]
+`
+	if err := os.WriteFile(configFile, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	os.Setenv("CONFIG_FILE", configFile)
+	os.Setenv("TEST_SLACK_URL", "https://hooks.example.com/secret")
+	defer os.Unsetenv("CONFIG_FILE")
+	defer os.Unsetenv("TEST_SLACK_URL")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !cfg.HasNotifications() || len(cfg.Notifications.Sinks) != 2 {
+		t.Fatalf("expected 2 sinks and a rule, got %+v", cfg.Notifications)
+	}
+
+	if cfg.Notifications.Sinks[0].URL != "https://hooks.example.com/secret" {
+		t.Errorf("expected expanded sink URL, got %q", cfg.Notifications.Sinks[0].URL)
+	}
+
+	if cfg.Notifications.Sinks[1].SMTPPort != DefaultSMTPPort {
+		t.Errorf("expected default SMTP port %d, got %d", DefaultSMTPPort, cfg.Notifications.Sinks[1].SMTPPort)
+	}
+
+	rule := cfg.Notifications.Rules[0]
+	if rule.Status != "failed" || rule.MinFailedMinutes != 15 || len(rule.Sinks) != 1 || rule.Repositories[0] != "platform/*" {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+}
+
+// TestLoad_Connections tests loading named connections with defaults and secret expansion.
+func TestLoad_Connections(t *testing.T) {
+	// Arrange
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	yamlConfig := `
+connections:
+  - name: gitlab-internal
+    platform: gitlab
+    url: https://gitlab.example.com/
+    token: ${TEST_GITLAB_INTERNAL_TOKEN}
+    watched_repos: ["123"]
+  - name: ghe
+    platform: github
+    url: https://github.example.com/api/v3
+    token: ghe-token
+    cache_duration_seconds: 60
+`
+	if err := os.WriteFile(configFile, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	os.Setenv("CONFIG_FILE", configFile)
+	os.Setenv("TEST_GITLAB_INTERNAL_TOKEN", "glpat-secret")
+	defer os.Unsetenv("CONFIG_FILE")
+	defer os.Unsetenv("TEST_GITLAB_INTERNAL_TOKEN")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(cfg.Connections) != 2 {
+		t.Fatalf("expected 2 connections, got %d", len(cfg.Connections))
+	}
+
+	internal := cfg.Connections[0]
+	if internal.URL != "https://gitlab.example.com" || internal.Token != "glpat-secret" || internal.CacheDurationSeconds != DefaultCacheDurationSeconds {
+		t.Errorf("unexpected connection: %+v", internal)
+	}
+
+	if cfg.Connections[1].CacheDurationSeconds != 60 {
+		t.Errorf("expected cache duration 60, got %d", cfg.Connections[1].CacheDurationSeconds)
+	}
+}
+
+// TestLoad_ConnectionNameReserved tests that a connection cannot take a platform's name.
+func TestLoad_ConnectionNameReserved(t *testing.T) {
+	// Arrange
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	yamlConfig := `
+connections:
+  - name: gitlab
+    platform: gitlab
+    token: token
+`
+	if err := os.WriteFile(configFile, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	os.Setenv("CONFIG_FILE", configFile)
+	defer os.Unsetenv("CONFIG_FILE")
+
+	// Act
+	_, err := Load()
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected error for reserved connection name")
+	}
+}
+
+// TestLoad_GitHubAPI tests selecting the GitHub API flavour.
+func TestLoad_GitHubAPI(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{"", GitHubAPIREST, false},
+		{"GraphQL", GitHubAPIGraphQL, false},
+		{"soap", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			// Arrange
+			os.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+			os.Setenv("GITHUB_API", tt.value)
+			defer os.Unsetenv("CONFIG_FILE")
+			defer os.Unsetenv("GITHUB_API")
+
+			// Act
+			cfg, err := Load()
+
+			// Assert
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for invalid GitHub API")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if cfg.GitHubAPI != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, cfg.GitHubAPI)
+			}
+		})
+	}
+}
+
+// TestLoad_GitHubApp tests that GitHub App authentication enables GitHub without a token.
+func TestLoad_GitHubApp(t *testing.T) {
+	// Arrange
+	os.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+	os.Setenv("GITHUB_APP_ID", "123")
+	os.Setenv("GITHUB_APP_PRIVATE_KEY_FILE", "/etc/ci-dashboard/app.pem")
+	defer os.Unsetenv("CONFIG_FILE")
+	defer os.Unsetenv("GITHUB_APP_ID")
+	defer os.Unsetenv("GITHUB_APP_PRIVATE_KEY_FILE")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !cfg.HasGitHubConfig() || !cfg.HasGitHubApp() {
+		t.Error("expected GitHub to be configured with a GitHub App")
+	}
+
+	// A private key without an app ID is rejected
+	os.Unsetenv("GITHUB_APP_ID")
+	if _, err := Load(); err == nil {
+		t.Error("expected error for GitHub App without an app ID")
+	}
+}
+
+// TestLoad_Actions tests that write actions need both the flag and a write token.
+func TestLoad_Actions(t *testing.T) {
+	// Arrange
+	os.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+	os.Setenv("ACTIONS_ENABLED", "true")
+	os.Setenv("GITLAB_TOKEN", "read-token")
+	os.Setenv("GITLAB_WRITE_TOKEN", "write-token")
+	os.Setenv("GITHUB_TOKEN", "read-token")
+	os.Setenv("AUTH_MODE", "basic")
+	defer os.Unsetenv("CONFIG_FILE")
+	defer os.Unsetenv("ACTIONS_ENABLED")
+	defer os.Unsetenv("AUTH_MODE")
+	defer os.Unsetenv("GITLAB_TOKEN")
+	defer os.Unsetenv("GITLAB_WRITE_TOKEN")
+	defer os.Unsetenv("GITHUB_TOKEN")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !cfg.HasGitLabActions() {
+		t.Error("expected GitLab actions with flag and write token")
+	}
+	if cfg.HasGitHubActions() {
+		t.Error("expected no GitHub actions without a write token")
+	}
+
+	cfg.ActionsEnabled = false
+	if cfg.HasGitLabActions() {
+		t.Error("expected no actions without the flag")
+	}
+}
+
+// TestLoad_ActionsRequireAuthentication tests that actions without authentication are refused unless allowed explicitly.
+func TestLoad_ActionsRequireAuthentication(t *testing.T) {
+	// Arrange
+	os.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+	os.Setenv("ACTIONS_ENABLED", "true")
+	os.Setenv("GITLAB_TOKEN", "read-token")
+	os.Setenv("GITLAB_WRITE_TOKEN", "write-token")
+	defer os.Unsetenv("CONFIG_FILE")
+	defer os.Unsetenv("ACTIONS_ENABLED")
+	defer os.Unsetenv("GITLAB_TOKEN")
+	defer os.Unsetenv("GITLAB_WRITE_TOKEN")
+
+	// Act
+	_, err := Load()
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected error for actions without authentication")
+	}
+
+	os.Setenv("ACTIONS_ALLOW_UNAUTHENTICATED", "true")
+	defer os.Unsetenv("ACTIONS_ALLOW_UNAUTHENTICATED")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected no error with the explicit opt-in, got %v", err)
+	}
+	if !cfg.HasActions() {
+		t.Error("expected actions with the explicit opt-in")
+	}
+}
+
+// TestLoad_ConnectionActions tests write tokens of named connections.
+func TestLoad_ConnectionActions(t *testing.T) {
+	// Arrange
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	yamlConfig := `
+actions:
+  enabled: true
+auth:
+  mode: basic
+connections:
+  - name: gitlab-internal
+    platform: gitlab
+    url: https://gitlab.example.com
+    token: read-token
+    write_token: ${TEST_GITLAB_INTERNAL_WRITE_TOKEN}
+  - name: ghe
+    platform: github
+    url: https://github.example.com/api/v3
+    token: ghe-token
+`
+	if err := os.WriteFile(configFile, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	os.Setenv("CONFIG_FILE", configFile)
+	os.Setenv("TEST_GITLAB_INTERNAL_WRITE_TOKEN", "glpat-write")
+	defer os.Unsetenv("CONFIG_FILE")
+	defer os.Unsetenv("TEST_GITLAB_INTERNAL_WRITE_TOKEN")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Connections[0].WriteToken != "glpat-write" || !cfg.HasConnectionActions(cfg.Connections[0]) {
+		t.Errorf("expected actions for gitlab-internal, got %+v", cfg.Connections[0])
+	}
+	if cfg.HasConnectionActions(cfg.Connections[1]) {
+		t.Error("expected no actions for a connection without a write token")
+	}
+	if !cfg.HasActions() {
+		t.Error("expected actions enabled by a connection")
+	}
+
+	// Platforms without actions reject write tokens
+	yamlConfig = `
+connections:
+  - name: forgejo
+    platform: gitea
+    url: https://forgejo.example.com
+    token: read-token
+    write_token: write-token
+`
+	if err := os.WriteFile(configFile, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a write token on a gitea connection")
+	}
+}
+
+// TestLoad_ConnectionWebhooks tests webhook secrets of named connections.
+func TestLoad_ConnectionWebhooks(t *testing.T) {
+	// Arrange
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	yamlConfig := `
+connections:
+  - name: gitlab-internal
+    platform: gitlab
+    url: https://gitlab.example.com
+    token: read-token
+    webhook_secret: ${TEST_GITLAB_INTERNAL_WEBHOOK_SECRET}
+`
+	if err := os.WriteFile(configFile, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	os.Setenv("CONFIG_FILE", configFile)
+	os.Setenv("TEST_GITLAB_INTERNAL_WEBHOOK_SECRET", "hook-secret")
+	defer os.Unsetenv("CONFIG_FILE")
+	defer os.Unsetenv("TEST_GITLAB_INTERNAL_WEBHOOK_SECRET")
+
+	// Act
+	cfg, err := Load()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.Connections[0].WebhookSecret != "hook-secret" {
+		t.Errorf("expected the expanded webhook secret, got %q", cfg.Connections[0].WebhookSecret)
+	}
+
+	// Platforms without webhooks reject webhook secrets
+	yamlConfig = `
+connections:
+  - name: bb
+    platform: bitbucket
+    username: user
+    token: read-token
+    webhook_secret: hook-secret
+`
+	if err := os.WriteFile(configFile, []byte(yamlConfig), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	if _, err := Load(); err == nil {
+		t.Error("expected error for a webhook secret on a bitbucket connection")
+	}
+}
@@ -0,0 +1,152 @@
+package dashboard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/audit"
+	"github.com/vilaca/ci-dashboard/internal/auth"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// CSRFHeader is the request header carrying the CSRF token of write actions.
+const CSRFHeader = "X-CSRF-Token"
+
+// AuditLog records write actions (Interface Segregation Principle).
+type AuditLog interface {
+	Record(entry audit.Entry) error
+}
+
+// handlePipelineAction performs a write action (retry, rerun-failed, cancel or run) on a project's pipelines.
+// POST form fields: id, action, pipeline, ref and workflow. Requires the CSRF token of the detail page.
+// Every action is recorded in the audit log before the platform is called, and again with its outcome.
+// Actions that can't be recorded are not performed.
+func (h *Handler) handlePipelineAction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.validCSRFRequest(r) {
+		h.logger.Printf("[Actions] Rejected action from %s: invalid CSRF token", r.RemoteAddr)
+		http.Error(w, "Invalid CSRF token, reload the page", http.StatusForbidden)
+		return
+	}
+
+	project := h.findProject(r, r.PostFormValue("id"))
+	if project == nil || !h.pipelineService.HasPipelineActions(*project) {
+		http.Error(w, "Actions not enabled for this repository", http.StatusNotFound)
+		return
+	}
+
+	req := service.PipelineActionRequest{
+		Action:     service.PipelineAction(r.PostFormValue("action")),
+		PipelineID: r.PostFormValue("pipeline"),
+		Ref:        r.PostFormValue("ref"),
+		WorkflowID: r.PostFormValue("workflow"),
+	}
+	entry := audit.Entry{
+		User:       requestUser(r),
+		RemoteAddr: r.RemoteAddr,
+		Action:     string(req.Action),
+		Platform:   project.Platform,
+		ProjectID:  project.ID,
+		PipelineID: req.PipelineID,
+		Ref:        req.Ref,
+		WorkflowID: req.WorkflowID,
+		Outcome:    audit.OutcomeRequested,
+	}
+	if err := h.recordAction(entry); err != nil {
+		h.logger.Printf("[Actions] Refused %s %s on %s: failed to record audit entry: %v", entry.User, req.Action, project.ID, err)
+		http.Error(w, "Action not performed: the audit log is unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	err := h.pipelineService.RunPipelineAction(r.Context(), *project, req)
+
+	entry.Time = time.Time{}
+	entry.Outcome = audit.OutcomeSucceeded
+	if err != nil {
+		entry.Outcome = audit.OutcomeFailed
+		entry.Error = err.Error()
+	}
+	if auditErr := h.recordAction(entry); auditErr != nil {
+		h.logger.Printf("[Actions] Failed to record audit entry: %v", auditErr)
+	}
+
+	if err != nil {
+		h.logger.Printf("[Actions] %s %s on %s (pipeline %q, ref %q) failed: %v", entry.User, req.Action, project.ID, req.PipelineID, req.Ref, err)
+		http.Error(w, "Action failed, see the server log for details", http.StatusBadGateway)
+		return
+	}
+
+	h.logger.Printf("[Actions] %s %s on %s (pipeline %q, ref %q)", entry.User, req.Action, project.ID, req.PipelineID, req.Ref)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+// recordAction appends an entry to the audit log, if there is one.
+func (h *Handler) recordAction(entry audit.Entry) error {
+	if h.auditLog == nil {
+		return nil
+	}
+	return h.auditLog.Record(entry)
+}
+
+// findProject returns the cached project with the given ID, or nil if there is none.
+func (h *Handler) findProject(r *http.Request, projectID string) *domain.Project {
+	projects, err := h.pipelineService.GetAllProjects(r.Context())
+	if err != nil {
+		h.logger.Printf("failed to get projects: %v", err)
+		return nil
+	}
+
+	for i := range projects {
+		if projects[i].ID == projectID {
+			return &projects[i]
+		}
+	}
+	return nil
+}
+
+// requestUser returns the name of the authenticated user of a request, or "anonymous" without authentication.
+func requestUser(r *http.Request) string {
+	if user, ok := auth.UserFromContext(r.Context()); ok {
+		return user.DisplayName()
+	}
+	return "anonymous"
+}
+
+// csrfToken returns the CSRF token of the requesting user: an HMAC of the user's subject
+// under a per-process key. Tokens are stateless and end when the process restarts.
+func (h *Handler) csrfToken(r *http.Request) string {
+	subject := ""
+	if user, ok := auth.UserFromContext(r.Context()); ok {
+		subject = user.Subject
+	}
+
+	mac := hmac.New(sha256.New, h.csrfKey)
+	mac.Write([]byte(subject))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// validCSRFRequest checks the CSRF token header and, when the browser sends one, that the Origin is this host.
+// Cross-site pages can't read the token from the detail page, so they can't forge actions.
+func (h *Handler) validCSRFRequest(r *http.Request) bool {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		originURL, err := url.Parse(origin)
+		if err != nil || originURL.Host != r.Host {
+			return false
+		}
+	}
+
+	return hmac.Equal([]byte(r.Header.Get(CSRFHeader)), []byte(h.csrfToken(r)))
+}
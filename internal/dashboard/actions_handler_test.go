@@ -0,0 +1,119 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/audit"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// fakeActionService is a test double for the pipeline service that appends performed actions to a shared trace.
+// Only the methods write actions use are implemented; other methods panic if called.
+type fakeActionService struct {
+	PipelineService
+	trace *[]string
+	err   error
+}
+
+func (f *fakeActionService) GetAllProjects(ctx context.Context) ([]domain.Project, error) {
+	return []domain.Project{{ID: "123", Name: "api", Platform: "gitlab"}}, nil
+}
+
+func (f *fakeActionService) HasPipelineActions(project domain.Project) bool {
+	return true
+}
+
+func (f *fakeActionService) RunPipelineAction(ctx context.Context, project domain.Project, req service.PipelineActionRequest) error {
+	*f.trace = append(*f.trace, "action "+string(req.Action))
+	return f.err
+}
+
+// fakeAuditLog is a test double for the audit log that appends recorded outcomes to a shared trace.
+type fakeAuditLog struct {
+	trace   *[]string
+	entries []audit.Entry
+	err     error
+}
+
+func (f *fakeAuditLog) Record(entry audit.Entry) error {
+	if f.err != nil {
+		return f.err
+	}
+	*f.trace = append(*f.trace, "audit "+entry.Outcome)
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+// TestHandlePipelineAction tests request checks of write actions and that they are audited before they run.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestHandlePipelineAction(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		origin         string
+		validToken     bool
+		auditErr       error
+		actionErr      error
+		expectedStatus int
+		expectedTrace  []string
+	}{
+		{"performed", http.MethodPost, "", true, nil, nil, http.StatusOK, []string{"audit requested", "action retry", "audit succeeded"}},
+		{"same origin", http.MethodPost, "http://example.com", true, nil, nil, http.StatusOK, []string{"audit requested", "action retry", "audit succeeded"}},
+		{"GET", http.MethodGet, "", true, nil, nil, http.StatusMethodNotAllowed, nil},
+		{"missing CSRF token", http.MethodPost, "", false, nil, nil, http.StatusForbidden, nil},
+		{"cross-site origin", http.MethodPost, "https://evil.example", true, nil, nil, http.StatusForbidden, nil},
+		{"malformed origin", http.MethodPost, "://", true, nil, nil, http.StatusForbidden, nil},
+		{"audit log unavailable", http.MethodPost, "", true, errors.New("disk full"), nil, http.StatusInternalServerError, nil},
+		{"platform error", http.MethodPost, "", true, nil, errors.New("token expired for https://gitlab.example.com"), http.StatusBadGateway, []string{"audit requested", "action retry", "audit failed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var trace []string
+			auditLog := &fakeAuditLog{trace: &trace, err: tt.auditErr}
+			h := NewHandler(HandlerConfig{
+				Logger:          nopLogger{},
+				PipelineService: &fakeActionService{trace: &trace, err: tt.actionErr},
+				AuditLog:        auditLog,
+			})
+
+			form := url.Values{"id": {"123"}, "action": {"retry"}, "pipeline": {"9"}}
+			req := httptest.NewRequest(tt.method, "http://example.com/api/actions", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.validToken {
+				req.Header.Set(CSRFHeader, h.csrfToken(req))
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			h.handlePipelineAction(rec, req)
+
+			// Assert
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if strings.Join(trace, ", ") != strings.Join(tt.expectedTrace, ", ") {
+				t.Errorf("expected %v, got %v", tt.expectedTrace, trace)
+			}
+			if tt.actionErr != nil {
+				if strings.Contains(rec.Body.String(), "gitlab.example.com") {
+					t.Errorf("expected a generic error message, got %q", rec.Body.String())
+				}
+				if last := auditLog.entries[len(auditLog.entries)-1]; last.Error != tt.actionErr.Error() {
+					t.Errorf("expected the error in the audit log, got %q", last.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package dashboard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	ansiStandardColors   = 8
+	ansiPaletteColors    = 16
+	ansiExtendedColorArg = 5 // 38;5;n / 48;5;n select one of 256 colours
+	ansiTrueColorArg     = 2 // 38;2;r;g;b / 48;2;r;g;b select an RGB colour
+)
+
+var (
+	ansiSequencePattern = regexp.MustCompile(`\x1b\[([0-9;?]*)([A-Za-z])`)
+	logSectionPattern   = regexp.MustCompile(`section_(?:start|end):\d+:\S*`)
+)
+
+// ansiStyle is the text style set by ANSI SGR (Select Graphic Rendition) sequences.
+// Colours are palette indexes (0-15), -1 for the default colour.
+type ansiStyle struct {
+	fg, bg                  int
+	bold, italic, underline bool
+}
+
+// newANSIStyle returns the default style.
+func newANSIStyle() ansiStyle {
+	return ansiStyle{fg: -1, bg: -1}
+}
+
+// classes returns the CSS classes of the style, or an empty string for the default style.
+func (s ansiStyle) classes() string {
+	var classes []string
+	if s.fg >= 0 {
+		classes = append(classes, fmt.Sprintf("ansi-fg-%d", s.fg))
+	}
+	if s.bg >= 0 {
+		classes = append(classes, fmt.Sprintf("ansi-bg-%d", s.bg))
+	}
+	if s.bold {
+		classes = append(classes, "ansi-bold")
+	}
+	if s.italic {
+		classes = append(classes, "ansi-italic")
+	}
+	if s.underline {
+		classes = append(classes, "ansi-underline")
+	}
+	return strings.Join(classes, " ")
+}
+
+// apply updates the style with the parameters of an SGR sequence, e.g. "1;31".
+// Colours outside the 16 colour palette are ignored.
+func (s *ansiStyle) apply(params string) {
+	if params == "" {
+		params = "0"
+	}
+
+	args := strings.Split(params, ";")
+	for i := 0; i < len(args); i++ {
+		code, err := strconv.Atoi(args[i])
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case code == 0:
+			*s = newANSIStyle()
+		case code == 1:
+			s.bold = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 22:
+			s.bold = false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code >= 30 && code <= 37:
+			s.fg = code - 30
+		case code >= 90 && code <= 97:
+			s.fg = code - 90 + ansiStandardColors
+		case code == 39:
+			s.fg = -1
+		case code >= 40 && code <= 47:
+			s.bg = code - 40
+		case code >= 100 && code <= 107:
+			s.bg = code - 100 + ansiStandardColors
+		case code == 49:
+			s.bg = -1
+		case code == 38 || code == 48:
+			color, consumed := extendedColor(args[i+1:])
+			i += consumed
+			if color < 0 {
+				continue
+			}
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+}
+
+// extendedColor parses the arguments following 38 or 48. Returns the palette index of the
+// colour (-1 if it is outside the palette) and the number of arguments consumed.
+func extendedColor(args []string) (int, int) {
+	if len(args) == 0 {
+		return -1, 0
+	}
+
+	mode, _ := strconv.Atoi(args[0])
+	switch {
+	case mode == ansiExtendedColorArg && len(args) >= 2:
+		color, err := strconv.Atoi(args[1])
+		if err != nil || color >= ansiPaletteColors {
+			return -1, 2
+		}
+		return color, 2
+	case mode == ansiTrueColorArg:
+		return -1, min(4, len(args))
+	default:
+		return -1, 1
+	}
+}
+
+// renderANSILine converts a log line to HTML, escaping its text and turning colours into spans.
+// The style carries over from line to line, but every line closes its spans so it stands alone.
+// Carriage returns overwrite the line like a terminal, and GitLab section markers are dropped.
+func renderANSILine(line string, style *ansiStyle) string {
+	line = strings.TrimRight(line, "\r")
+	if i := strings.LastIndexByte(line, '\r'); i >= 0 {
+		line = line[i+1:]
+	}
+	line = logSectionPattern.ReplaceAllString(line, "")
+
+	var sb strings.Builder
+	writeText := func(text string) {
+		if text == "" {
+			return
+		}
+		if classes := style.classes(); classes != "" {
+			sb.WriteString(`<span class="` + classes + `">` + escapeHTML(text) + `</span>`)
+		} else {
+			sb.WriteString(escapeHTML(text))
+		}
+	}
+
+	pos := 0
+	for _, match := range ansiSequencePattern.FindAllStringSubmatchIndex(line, -1) {
+		writeText(line[pos:match[0]])
+		pos = match[1]
+
+		// Only SGR (colour) sequences matter - cursor movement and erasing are dropped
+		if line[match[4]:match[5]] == "m" {
+			style.apply(line[match[2]:match[3]])
+		}
+	}
+	writeText(line[pos:])
+
+	return sb.String()
+}
+
+// writeANSIHTML converts a log to HTML line by line as it is read, one output line per log line.
+// flush is called whenever all data received so far has been written, so logs are streamed.
+func writeANSIHTML(w io.Writer, r io.Reader, flush func()) error {
+	reader := bufio.NewReader(r)
+	style := newANSIStyle()
+
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			if _, writeErr := io.WriteString(w, renderANSILine(strings.TrimSuffix(line, "\n"), &style)+"\n"); writeErr != nil {
+				return writeErr
+			}
+			if reader.Buffered() == 0 {
+				flush()
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/vilaca/ci-dashboard/internal/metrics"
+)
+
+// handleDORA serves the DORA metrics page.
+// Query params: optional ?days=7|30|90 (default 30), &id=owner/repo, &group=name
+func (h *Handler) handleDORA(w http.ResponseWriter, r *http.Request) {
+	var report *metrics.Report
+	if h.metricsService != nil {
+		var status int
+		report, status = h.getDORAReport(r)
+		if status != http.StatusOK {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := h.renderer.RenderDORA(w, report); err != nil {
+		h.logger.Printf("failed to render DORA metrics: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleDORAAPI serves DORA metrics per project and per group as JSON.
+// Query params: optional ?days=7|30|90 (default 30), &id=owner/repo, &group=name
+func (h *Handler) handleDORAAPI(w http.ResponseWriter, r *http.Request) {
+	if h.metricsService == nil {
+		http.Error(w, "Pipeline history not enabled", http.StatusNotFound)
+		return
+	}
+
+	report, status := h.getDORAReport(r)
+	if status != http.StatusOK {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		h.logger.Printf("[DORA] failed to encode response: %v", err)
+	}
+}
+
+// getDORAReport computes the DORA report for the window and filters given in the query.
+// Returns the HTTP status to respond with on failure.
+func (h *Handler) getDORAReport(r *http.Request) (*metrics.Report, int) {
+	query := r.URL.Query()
+
+	days := metrics.DefaultWindowDays
+	if value := query.Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > metrics.MaxWindowDays {
+			return nil, http.StatusBadRequest
+		}
+		days = parsed
+	}
+
+	report, err := h.metricsService.GetDORAReport(r.Context(), days)
+	if err != nil {
+		h.logger.Printf("[DORA] failed to compute metrics: %v", err)
+		return nil, http.StatusInternalServerError
+	}
+
+	projectID, group := query.Get("id"), query.Get("group")
+	if projectID == "" && group == "" {
+		return report, http.StatusOK
+	}
+
+	filtered := report.Filter(projectID, group)
+	if len(filtered.Projects) == 0 && projectID != "" {
+		return nil, http.StatusNotFound
+	}
+	return filtered, http.StatusOK
+}
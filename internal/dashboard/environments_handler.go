@@ -0,0 +1,70 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// handleEnvironments serves the environments page: what is deployed where, per repository.
+// Query param: optional ?id=owner/repo or ?id=123 to show a single repository
+func (h *Handler) handleEnvironments(w http.ResponseWriter, r *http.Request) {
+	projects, status := h.getEnvironments(r)
+	if status != http.StatusOK {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := h.renderer.RenderEnvironments(w, projects); err != nil {
+		h.logger.Printf("failed to render environments: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleEnvironmentsAPI serves the environments of repositories with their latest deployment as JSON.
+// Query param: optional ?id=owner/repo or ?id=123 to return a single repository
+func (h *Handler) handleEnvironmentsAPI(w http.ResponseWriter, r *http.Request) {
+	projects, status := h.getEnvironments(r)
+	if status != http.StatusOK {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if projects == nil {
+		projects = []service.ProjectEnvironments{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(projects); err != nil {
+		h.logger.Printf("[Environments] failed to encode response: %v", err)
+	}
+}
+
+// getEnvironments returns the environments of all repositories, or of the repository given by ?id=.
+// Returns the HTTP status to respond with on failure.
+func (h *Handler) getEnvironments(r *http.Request) ([]service.ProjectEnvironments, int) {
+	repositoryID := r.URL.Query().Get("id")
+	if repositoryID == "" {
+		projects, err := h.pipelineService.GetAllEnvironments(r.Context())
+		if err != nil {
+			h.logger.Printf("[Environments] failed to get environments: %v", err)
+			return nil, http.StatusInternalServerError
+		}
+		return projects, http.StatusOK
+	}
+
+	project := h.findProject(r, repositoryID)
+	if project == nil {
+		return nil, http.StatusNotFound
+	}
+
+	environments, err := h.pipelineService.GetEnvironmentsForProject(r.Context(), *project)
+	if err != nil {
+		h.logger.Printf("[Environments] failed to get environments of %s: %v", project.ID, err)
+		return nil, http.StatusInternalServerError
+	}
+	return []service.ProjectEnvironments{{Project: *project, Environments: environments}}, http.StatusOK
+}
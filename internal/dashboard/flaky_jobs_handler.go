@@ -0,0 +1,77 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// handleFlakyJobs serves the flaky jobs page.
+// Query param: optional ?id=owner/repo or ?id=123 to show a single repository
+func (h *Handler) handleFlakyJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, status := h.getFlakyJobs(r)
+	if status != http.StatusOK {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := h.renderer.RenderFlakyJobs(w, jobs, h.pipelineService.HasPipelineHistory()); err != nil {
+		h.logger.Printf("failed to render flaky jobs: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleFlakyJobsAPI serves flaky jobs with their flakiness scores as JSON.
+// Query param: optional ?id=owner/repo or ?id=123 to return a single repository
+func (h *Handler) handleFlakyJobsAPI(w http.ResponseWriter, r *http.Request) {
+	if !h.pipelineService.HasPipelineHistory() {
+		http.Error(w, "Pipeline history not enabled", http.StatusNotFound)
+		return
+	}
+
+	jobs, status := h.getFlakyJobs(r)
+	if status != http.StatusOK {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if jobs == nil {
+		jobs = []service.FlakyJob{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(jobs); err != nil {
+		h.logger.Printf("[FlakyJobs] failed to encode response: %v", err)
+	}
+}
+
+// getFlakyJobs returns flaky jobs of all repositories, or of the repository given by ?id=.
+// Returns the HTTP status to respond with on failure.
+func (h *Handler) getFlakyJobs(r *http.Request) ([]service.FlakyJob, int) {
+	repositoryID := r.URL.Query().Get("id")
+	if repositoryID == "" {
+		jobs, err := h.pipelineService.GetFlakyJobs(r.Context())
+		if err != nil {
+			h.logger.Printf("[FlakyJobs] failed to detect flaky jobs: %v", err)
+			return nil, http.StatusInternalServerError
+		}
+		return jobs, http.StatusOK
+	}
+
+	projects, err := h.pipelineService.GetAllProjects(r.Context())
+	if err != nil {
+		h.logger.Printf("[FlakyJobs] failed to get projects: %v", err)
+		return nil, http.StatusInternalServerError
+	}
+
+	for _, project := range projects {
+		if project.ID == repositoryID {
+			return h.pipelineService.GetFlakyJobsForProject(project), http.StatusOK
+		}
+	}
+
+	return nil, http.StatusNotFound
+}
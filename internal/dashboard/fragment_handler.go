@@ -0,0 +1,44 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// serveFragment serves data about a pipeline of a repository as an HTML fragment in JSON ({"html": ...}).
+// Query params: id (repository) and pipeline. load reads the data from the pipeline service and
+// render turns it into HTML. Errors are logged under name and answered with generic messages.
+func serveFragment[T any](h *Handler, w http.ResponseWriter, r *http.Request, name string,
+	load func(ctx context.Context, project domain.Project, pipelineID string) (*T, error),
+	render func(w io.Writer, data T) error) {
+	pipelineID := r.URL.Query().Get("pipeline")
+	project := h.findProject(r, r.URL.Query().Get("id"))
+	if project == nil || pipelineID == "" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	data, err := load(r.Context(), *project, pipelineID)
+	if err != nil {
+		h.logger.Printf("[%s] failed to load pipeline %s of %s: %v", name, pipelineID, project.ID, err)
+		http.Error(w, "Failed to load pipeline data", http.StatusBadGateway)
+		return
+	}
+
+	var buf strings.Builder
+	if err := render(&buf, *data); err != nil {
+		h.logger.Printf("[%s] failed to render: %v", name, err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]string{"html": buf.String()}); err != nil {
+		h.logger.Printf("[%s] failed to encode response: %v", name, err)
+	}
+}
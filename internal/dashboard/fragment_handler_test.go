@@ -0,0 +1,73 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestServeFragment tests the responses of pipeline fragments, and that load errors stay in the log.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestServeFragment(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		loadErr        error
+		renderErr      error
+		expectedStatus int
+		expectedHTML   string
+	}{
+		{"rendered", "?id=123&pipeline=9", nil, nil, http.StatusOK, "<p>pipeline 9 of 123</p>"},
+		{"unknown repository", "?id=456&pipeline=9", nil, nil, http.StatusNotFound, ""},
+		{"missing pipeline", "?id=123", nil, nil, http.StatusNotFound, ""},
+		{"load error", "?id=123&pipeline=9", errors.New("GET https://gitlab.example.com/api/v4: 401"), nil, http.StatusBadGateway, ""},
+		{"render error", "?id=123&pipeline=9", nil, errors.New("broken template"), http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			h := NewHandler(HandlerConfig{
+				Logger:          nopLogger{},
+				PipelineService: &fakeStreamService{projects: []domain.Project{{ID: "123", Platform: "gitlab"}}},
+			})
+			load := func(ctx context.Context, project domain.Project, pipelineID string) (*string, error) {
+				data := fmt.Sprintf("pipeline %s of %s", pipelineID, project.ID)
+				return &data, tt.loadErr
+			}
+			render := func(w io.Writer, data string) error {
+				if tt.renderErr != nil {
+					return tt.renderErr
+				}
+				_, err := fmt.Fprintf(w, "<p>%s</p>", data)
+				return err
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			serveFragment(h, rec, httptest.NewRequest(http.MethodGet, "/api/fragment"+tt.query, nil), "Fragment", load, render)
+
+			// Assert
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if strings.Contains(rec.Body.String(), "gitlab.example.com") {
+				t.Errorf("expected a generic error message, got %q", rec.Body.String())
+			}
+			if tt.expectedStatus == http.StatusOK {
+				var response map[string]string
+				if err := json.NewDecoder(rec.Body).Decode(&response); err != nil || response["html"] != tt.expectedHTML {
+					t.Errorf("expected html %q, got %v (%v)", tt.expectedHTML, response, err)
+				}
+			}
+		})
+	}
+}
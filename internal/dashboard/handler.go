@@ -13,7 +13,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/vilaca/ci-dashboard/internal/auth"
 	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/metrics"
 	"github.com/vilaca/ci-dashboard/internal/service"
 )
 
@@ -43,18 +45,25 @@ type avatarCacheEntry struct {
 // Handler handles HTTP requests for the dashboard.
 // Each handler method has a Single Responsibility (SRP).
 type Handler struct {
-	renderer            Renderer
-	logger              Logger
-	pipelineService     PipelineService
-	runsPerRepo         int
-	recentLimit         int
-	uiRefreshInterval   int
-	gitlabCurrentUser   string
-	githubCurrentUser   string
-	httpClient          *http.Client // reused HTTP client for avatar downloads
-	avatarCache         map[string]*avatarCacheEntry // platform:username -> cached data with TTL
-	avatarCacheMu       sync.RWMutex
-	stopAvatarCleanup   chan struct{} // channel to stop avatar cache cleanup goroutine
+	renderer             Renderer
+	logger               Logger
+	pipelineService      PipelineService
+	metricsService       MetricsService // nil = DORA metrics disabled
+	auditLog             AuditLog       // records write actions (nil when actions are disabled)
+	csrfKey              []byte         // per-process key of CSRF tokens for write actions
+	runsPerRepo          int
+	recentLimit          int
+	uiRefreshInterval    int
+	gitlabCurrentUser    string
+	githubCurrentUser    string
+	giteaCurrentUser     string
+	bitbucketCurrentUser string
+	webhooks             map[string]Webhook           // connection -> webhook endpoint (missing = disabled)
+	httpClient           *http.Client                 // reused HTTP client for avatar downloads
+	avatarCache          map[string]*avatarCacheEntry // platform:username -> cached data with TTL
+	avatarCacheMu        sync.RWMutex
+	stopAvatarCleanup    chan struct{} // channel to stop avatar cache cleanup goroutine
+	stopStreams          chan struct{} // closed on Stop to end open event streams
 }
 
 // Logger interface for logging operations (Interface Segregation Principle).
@@ -82,6 +91,27 @@ type PipelineService interface {
 	GetUserProfiles(ctx context.Context) ([]domain.UserProfile, error)
 	GetProjectsPageByPlatform(ctx context.Context, platform string, page int) ([]domain.Project, bool, error)
 	GetTotalProjectCount(ctx context.Context) (int, error)
+	HandleWebhook(ctx context.Context, connection, eventType string, payload []byte) (int, error)
+	SubscribeRepositoryChanges() (<-chan service.RepositoryChange, func())
+	GetRepositoryStats(project domain.Project, branch string) *service.RepositoryStats
+	GetCoverageTrend(project domain.Project, branch string) []service.CoveragePoint
+	GetCoverageDelta(project domain.Project, pipeline *domain.Pipeline) *float64
+	HasPipelineHistory() bool
+	GetFlakyJobs(ctx context.Context) ([]service.FlakyJob, error)
+	GetFlakyJobsForProject(project domain.Project) []service.FlakyJob
+	GetAllEnvironments(ctx context.Context) ([]service.ProjectEnvironments, error)
+	GetEnvironmentsForProject(ctx context.Context, project domain.Project) ([]domain.Environment, error)
+	GetJobLog(ctx context.Context, project domain.Project, jobID string) (io.ReadCloser, error)
+	GetPipelineTestResults(ctx context.Context, project domain.Project, pipelineID string) (*service.PipelineTestResults, error)
+	GetPipelineGraph(ctx context.Context, project domain.Project, pipelineID string) (*service.PipelineGraph, error)
+	GetPipelineTimeline(ctx context.Context, project domain.Project, pipelineID string) (*service.PipelineTimeline, error)
+	HasPipelineActions(project domain.Project) bool
+	RunPipelineAction(ctx context.Context, project domain.Project, req service.PipelineActionRequest) error
+}
+
+// MetricsService interface for delivery metrics (Dependency Inversion Principle).
+type MetricsService interface {
+	GetDORAReport(ctx context.Context, windowDays int) (*metrics.Report, error)
 }
 
 // RepositoryWithRuns is imported from service package
@@ -95,6 +125,11 @@ type PersonalizedRepositoryDetail struct {
 	ReviewingMRs    []domain.MergeRequest
 	MyMRs           []domain.MergeRequest
 	RecentPipelines []domain.Pipeline
+	DefaultPipeline *domain.Pipeline         // Latest default branch pipeline, with job breakdown when available
+	Stats           *service.RepositoryStats // Pipeline trends from history (nil = history disabled)
+	CoverageTrend   []service.CoveragePoint  // Default branch coverage from history, oldest first
+	ActionsEnabled  bool                     // Show retry, cancel and run buttons
+	CSRFToken       string                   // Token the buttons send with write actions
 }
 
 // HandlerConfig holds configuration for creating a new Handler
@@ -102,25 +137,38 @@ type HandlerConfig struct {
 	Renderer          Renderer
 	Logger            Logger
 	PipelineService   PipelineService
+	MetricsService    MetricsService // optional
+	AuditLog          AuditLog       // optional
 	RunsPerRepo       int
 	RecentLimit       int
 	UIRefreshInterval int
 	GitLabUser        string
 	GitHubUser        string
+	GiteaUser         string
+	BitbucketUser     string
+
+	// Webhook endpoints by connection name (the platform name for a platform's default connection)
+	Webhooks map[string]Webhook
 }
 
 // NewHandler creates a new Handler with injected dependencies (Dependency Inversion Principle).
 // This follows IoC (Inversion of Control) by accepting dependencies rather than creating them.
 func NewHandler(cfg HandlerConfig) *Handler {
 	h := &Handler{
-		renderer:          cfg.Renderer,
-		logger:            cfg.Logger,
-		pipelineService:   cfg.PipelineService,
-		runsPerRepo:       cfg.RunsPerRepo,
-		recentLimit:       cfg.RecentLimit,
-		uiRefreshInterval: cfg.UIRefreshInterval,
-		gitlabCurrentUser: cfg.GitLabUser,
-		githubCurrentUser: cfg.GitHubUser,
+		renderer:             cfg.Renderer,
+		logger:               cfg.Logger,
+		pipelineService:      cfg.PipelineService,
+		metricsService:       cfg.MetricsService,
+		auditLog:             cfg.AuditLog,
+		csrfKey:              auth.GenerateSecret(),
+		runsPerRepo:          cfg.RunsPerRepo,
+		recentLimit:          cfg.RecentLimit,
+		uiRefreshInterval:    cfg.UIRefreshInterval,
+		gitlabCurrentUser:    cfg.GitLabUser,
+		githubCurrentUser:    cfg.GitHubUser,
+		giteaCurrentUser:     cfg.GiteaUser,
+		bitbucketCurrentUser: cfg.BitbucketUser,
+		webhooks:             cfg.Webhooks,
 		httpClient: &http.Client{
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				// Follow redirects but limit to prevent infinite loops
@@ -132,6 +180,7 @@ func NewHandler(cfg HandlerConfig) *Handler {
 		},
 		avatarCache:       make(map[string]*avatarCacheEntry),
 		stopAvatarCleanup: make(chan struct{}),
+		stopStreams:       make(chan struct{}),
 	}
 
 	// Start background cleanup goroutine for avatar cache (runs every hour)
@@ -143,6 +192,7 @@ func NewHandler(cfg HandlerConfig) *Handler {
 // Stop gracefully stops the handler's background goroutines
 func (h *Handler) Stop() {
 	close(h.stopAvatarCleanup)
+	close(h.stopStreams)
 }
 
 // RegisterRoutes registers all HTTP routes.
@@ -150,9 +200,23 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/", h.handleRepositories)
 	mux.HandleFunc("/api/health", h.handleHealth)
 	mux.HandleFunc("/api/repositories", h.handleRepositoriesBulk)
+	mux.HandleFunc("/api/stream", h.handleStream)
 	mux.HandleFunc("/api/repository-detail", h.handleRepositoryDetailAPI)
+	mux.HandleFunc("/api/stats", h.handleStats)
 	mux.HandleFunc("/api/avatar/", h.handleAvatar)
 	mux.HandleFunc("/repository", h.handleRepositoryDetail)
+	mux.HandleFunc("/flaky-jobs", h.handleFlakyJobs)
+	mux.HandleFunc("/api/flaky-jobs", h.handleFlakyJobsAPI)
+	mux.HandleFunc("/environments", h.handleEnvironments)
+	mux.HandleFunc("/api/environments", h.handleEnvironmentsAPI)
+	mux.HandleFunc("/dora", h.handleDORA)
+	mux.HandleFunc("/api/dora", h.handleDORAAPI)
+	mux.HandleFunc("/api/webhooks/{connection}", h.handleWebhook)
+	mux.HandleFunc("/api/actions", h.handlePipelineAction)
+	mux.HandleFunc("/api/job-log", h.handleJobLog)
+	mux.HandleFunc("/api/test-results", h.handleTestResults)
+	mux.HandleFunc("/api/pipeline-graph", h.handlePipelineGraph)
+	mux.HandleFunc("/api/pipeline-timeline", h.handlePipelineTimeline)
 }
 
 // handleIndex serves the main dashboard page.
@@ -207,6 +271,7 @@ type RepositoryDefaultBranch struct {
 	OpenMRCount    int              `json:"OpenMRCount"`    // Count of open MRs/PRs
 	DraftMRCount   int              `json:"DraftMRCount"`   // Count of draft MRs/PRs (subset of OpenMRCount)
 	ReviewingCount int              `json:"ReviewingCount"` // Count of MRs where current user is reviewer
+	CoverageDelta  *float64         `json:"CoverageDelta"`  // Coverage change since the previous default branch pipeline (nil = unknown)
 }
 
 // handleRepositoriesBulk returns repositories as paginated JSON (cache only, no API calls).
@@ -244,52 +309,7 @@ func (h *Handler) handleRepositoriesBulk(w http.ResponseWriter, r *http.Request)
 
 	results := make([]RepositoryDefaultBranch, 0, len(projects))
 	for _, project := range projects {
-		// Fetch cached data for this project
-		defaultBranch, pipeline, branchCount, err := h.pipelineService.GetDefaultBranchForProject(ctx, project)
-		if err != nil {
-			h.logger.Printf("Failed to get default branch for project %s: %v", project.Name, err)
-		}
-
-		// Debug logging for missing commit data
-		if defaultBranch == nil {
-			h.logger.Printf("[DEBUG] %s: defaultBranch is nil", project.Name)
-		} else if defaultBranch.CommitAuthor == "" || defaultBranch.LastCommitDate.IsZero() {
-			h.logger.Printf("[DEBUG] %s: Missing data - Author: %q, Date: %v, Branch: %s",
-				project.Name, defaultBranch.CommitAuthor, defaultBranch.LastCommitDate, defaultBranch.Name)
-		}
-
-		// Fetch cached MRs for this project (only OPEN MRs are fetched)
-		mrs, err := h.pipelineService.GetMergeRequestsForProject(ctx, project)
-		if err != nil {
-			h.logger.Printf("Failed to get merge requests for project %s: %v", project.Name, err)
-		}
-
-		// Count MRs (all are open since we only fetch open ones)
-		openMRCount := len(mrs)
-		draftMRCount := 0
-		reviewingCount := 0
-		for _, mr := range mrs {
-			if mr.IsDraft {
-				draftMRCount++
-			}
-			// Check if current user is a reviewer
-			for _, reviewer := range mr.Reviewers {
-				if reviewer == h.gitlabCurrentUser || reviewer == h.githubCurrentUser {
-					reviewingCount++
-					break
-				}
-			}
-		}
-
-		results = append(results, RepositoryDefaultBranch{
-			Project:        project,
-			DefaultBranch:  defaultBranch,
-			Pipeline:       pipeline,
-			BranchCount:    branchCount,
-			OpenMRCount:    openMRCount,
-			DraftMRCount:   draftMRCount,
-			ReviewingCount: reviewingCount,
-		})
+		results = append(results, h.buildRepositoryRow(ctx, project))
 	}
 
 	startIndex := (page - 1) * limit
@@ -322,6 +342,58 @@ func (h *Handler) handleRepositoriesBulk(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// buildRepositoryRow assembles a repositories table row for a project from cached data (no API calls).
+// Shared by the bulk endpoint and the change stream so both send identical rows.
+func (h *Handler) buildRepositoryRow(ctx context.Context, project domain.Project) RepositoryDefaultBranch {
+	// Fetch cached data for this project
+	defaultBranch, pipeline, branchCount, err := h.pipelineService.GetDefaultBranchForProject(ctx, project)
+	if err != nil {
+		h.logger.Printf("Failed to get default branch for project %s: %v", project.Name, err)
+	}
+
+	// Debug logging for missing commit data
+	if defaultBranch == nil {
+		h.logger.Printf("[DEBUG] %s: defaultBranch is nil", project.Name)
+	} else if defaultBranch.CommitAuthor == "" || defaultBranch.LastCommitDate.IsZero() {
+		h.logger.Printf("[DEBUG] %s: Missing data - Author: %q, Date: %v, Branch: %s",
+			project.Name, defaultBranch.CommitAuthor, defaultBranch.LastCommitDate, defaultBranch.Name)
+	}
+
+	// Fetch cached MRs for this project (only OPEN MRs are fetched)
+	mrs, err := h.pipelineService.GetMergeRequestsForProject(ctx, project)
+	if err != nil {
+		h.logger.Printf("Failed to get merge requests for project %s: %v", project.Name, err)
+	}
+
+	// Count MRs (all are open since we only fetch open ones)
+	openMRCount := len(mrs)
+	draftMRCount := 0
+	reviewingCount := 0
+	for _, mr := range mrs {
+		if mr.IsDraft {
+			draftMRCount++
+		}
+		// Check if current user is a reviewer
+		for _, reviewer := range mr.Reviewers {
+			if reviewer == h.gitlabCurrentUser || reviewer == h.githubCurrentUser || reviewer == h.giteaCurrentUser || reviewer == h.bitbucketCurrentUser {
+				reviewingCount++
+				break
+			}
+		}
+	}
+
+	return RepositoryDefaultBranch{
+		Project:        project,
+		DefaultBranch:  defaultBranch,
+		Pipeline:       pipeline,
+		BranchCount:    branchCount,
+		OpenMRCount:    openMRCount,
+		DraftMRCount:   draftMRCount,
+		ReviewingCount: reviewingCount,
+		CoverageDelta:  h.pipelineService.GetCoverageDelta(project, pipeline),
+	}
+}
+
 // handleRepositoryDetail serves the repository detail page with progressive loading.
 // Query param: ?id=owner/repo or ?id=123
 func (h *Handler) handleRepositoryDetail(w http.ResponseWriter, r *http.Request) {
@@ -404,6 +476,16 @@ func (h *Handler) handleRepositoryDetailAPI(w http.ResponseWriter, r *http.Reque
 
 	h.logger.Printf("[RepositoryDetail] Found %d pipelines and %d branches for %s", len(pipelines), len(branches), repositoryID)
 
+	// Get latest default branch pipeline (includes jobs, from cache)
+	defaultBranch, defaultPipeline, _, err := h.pipelineService.GetDefaultBranchForProject(r.Context(), *project)
+	if err != nil {
+		h.logger.Printf("[RepositoryDetail] failed to get default branch pipeline for %s: %v", repositoryID, err)
+	}
+	defaultBranchName := project.DefaultBranch
+	if defaultBranch != nil {
+		defaultBranchName = defaultBranch.Name
+	}
+
 	// Get MRs for this repository (from cache)
 	allMRs, err := h.pipelineService.GetAllMergeRequests(r.Context())
 	if err != nil {
@@ -425,6 +507,10 @@ func (h *Handler) handleRepositoryDetailAPI(w http.ResponseWriter, r *http.Reque
 	currentUser := h.gitlabCurrentUser
 	if project.Platform == domain.PlatformGitHub {
 		currentUser = h.githubCurrentUser
+	} else if project.Platform == domain.PlatformGitea {
+		currentUser = h.giteaCurrentUser
+	} else if project.Platform == domain.PlatformBitbucket {
+		currentUser = h.bitbucketCurrentUser
 	}
 
 	h.logger.Printf("[RepositoryDetail] Filtering for current user: %q (platform: %s)", currentUser, project.Platform)
@@ -516,6 +602,11 @@ func (h *Handler) handleRepositoryDetailAPI(w http.ResponseWriter, r *http.Reque
 		ReviewingMRs:    reviewingMRs,
 		MyMRs:           myMRs,
 		RecentPipelines: pipelines,
+		DefaultPipeline: defaultPipeline,
+		Stats:           h.pipelineService.GetRepositoryStats(*project, ""),
+		CoverageTrend:   h.pipelineService.GetCoverageTrend(*project, defaultBranchName),
+		ActionsEnabled:  h.pipelineService.HasPipelineActions(*project),
+		CSRFToken:       h.csrfToken(r),
 	}
 
 	// Render to a buffer to get HTML string
@@ -774,8 +865,8 @@ func (h *Handler) getUserRole(project *domain.Project) string {
 		}
 	}
 
-	if project.Platform == domain.PlatformGitHub {
-		// GitHub permissions
+	if project.Platform == domain.PlatformGitHub || project.Platform == domain.PlatformGitea {
+		// GitHub/Gitea permissions
 		if project.Permissions.Admin {
 			return "Admin"
 		}
@@ -793,6 +884,7 @@ func (h *Handler) getUserRole(project *domain.Project) string {
 
 // isBranchAuthor checks if the branch was authored by the current user.
 // For GitHub: matches by username in CommitAuthor
+// For Gitea and Bitbucket: matches by username in CommitAuthor, or by email when the commit isn't linked to an account
 // For GitLab: matches by email in AuthorEmail (since GitLab branches only return author name, not username)
 func (h *Handler) isBranchAuthor(branch domain.Branch, currentUser string, platform string) bool {
 	if currentUser == "" {
@@ -804,6 +896,15 @@ func (h *Handler) isBranchAuthor(branch domain.Branch, currentUser string, platf
 		return branch.CommitAuthor == currentUser
 	}
 
+	if platform == domain.PlatformGitea || platform == domain.PlatformBitbucket {
+		// Gitea and Bitbucket provide the username when the commit email belongs to an account
+		if branch.CommitAuthor == currentUser || branch.AuthorEmail == currentUser {
+			return true
+		}
+		emailUsername, _, _ := strings.Cut(branch.AuthorEmail, "@")
+		return emailUsername != "" && emailUsername == currentUser
+	}
+
 	if platform == domain.PlatformGitLab {
 		// GitLab only provides author name and email in branches endpoint
 		// Match by email (e.g., GITLAB_USER=john.doe, AuthorEmail=john.doe@company.com)
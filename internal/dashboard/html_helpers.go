@@ -755,6 +755,9 @@ func buildNavigationWithProfiles(userProfiles []domain.UserProfile) string {
 
 	sb.WriteString(`<div class="nav">
 			<a href="/">Repositories</a>
+			<a href="/environments">Environments</a>
+			<a href="/flaky-jobs">Flaky Jobs</a>
+			<a href="/dora">DORA</a>
 		</div>
 		<div class="action-buttons">
 			<button class="refresh-btn" onclick="location.reload()" aria-label="Refresh page">🔄 Refresh</button>
@@ -802,6 +805,10 @@ func externalLink(url, text string) string {
 			linkText = "Open on GitHub →"
 		} else if strings.Contains(url, "gitlab.com") || strings.Contains(url, "gitlab.") {
 			linkText = "Open on GitLab →"
+		} else if strings.Contains(url, "gitea.") || strings.Contains(url, "forgejo.") || strings.Contains(url, "codeberg.org") {
+			linkText = "Open on Gitea →"
+		} else if strings.Contains(url, "bitbucket.org") {
+			linkText = "Open on Bitbucket →"
 		}
 	}
 
@@ -0,0 +1,37 @@
+package dashboard
+
+import (
+	"net/http"
+)
+
+// handleJobLog streams the log of a job as HTML, with ANSI colours turned into spans.
+// Query params: id (repository) and job. Each output line is one log line, sent as soon as it is read.
+func (h *Handler) handleJobLog(w http.ResponseWriter, r *http.Request) {
+	jobID := r.URL.Query().Get("job")
+	project := h.findProject(r, r.URL.Query().Get("id"))
+	if project == nil || jobID == "" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	logReader, err := h.pipelineService.GetJobLog(r.Context(), *project, jobID)
+	if err != nil {
+		h.logger.Printf("[JobLog] failed to get log of job %s of %s: %v", jobID, project.ID, err)
+		http.Error(w, "Failed to load the job log", http.StatusBadGateway)
+		return
+	}
+	defer logReader.Close()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // disable proxy buffering (nginx)
+
+	flush := func() {}
+	if flusher, ok := w.(http.Flusher); ok {
+		flush = flusher.Flush
+	}
+
+	if err := writeANSIHTML(w, logReader, flush); err != nil {
+		h.logger.Printf("[JobLog] failed to stream log of job %s of %s: %v", jobID, project.ID, err)
+	}
+}
@@ -0,0 +1,11 @@
+package dashboard
+
+import (
+	"net/http"
+)
+
+// handlePipelineGraph serves the job graph of a pipeline as an HTML fragment in JSON.
+// Query params: id (repository) and pipeline.
+func (h *Handler) handlePipelineGraph(w http.ResponseWriter, r *http.Request) {
+	serveFragment(h, w, r, "PipelineGraph", h.pipelineService.GetPipelineGraph, h.renderer.RenderPipelineGraph)
+}
@@ -0,0 +1,11 @@
+package dashboard
+
+import (
+	"net/http"
+)
+
+// handlePipelineTimeline serves the Gantt chart of a pipeline's jobs as an HTML fragment in JSON.
+// Query params: id (repository) and pipeline.
+func (h *Handler) handlePipelineTimeline(w http.ResponseWriter, r *http.Request) {
+	serveFragment(h, w, r, "PipelineTimeline", h.pipelineService.GetPipelineTimeline, h.renderer.RenderPipelineTimeline)
+}
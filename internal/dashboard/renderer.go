@@ -4,6 +4,8 @@ import (
 	"io"
 
 	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/metrics"
+	"github.com/vilaca/ci-dashboard/internal/service"
 )
 
 // Renderer handles rendering responses to HTTP clients.
@@ -13,6 +15,12 @@ type Renderer interface {
 	RenderRepositoriesSkeleton(w io.Writer, userProfiles []domain.UserProfile, refreshInterval int) error
 	RenderRepositoryDetail(w io.Writer, detail PersonalizedRepositoryDetail) error
 	RenderRepositoryDetailSkeleton(w io.Writer, repositoryID string) error
+	RenderFlakyJobs(w io.Writer, jobs []service.FlakyJob, historyEnabled bool) error
+	RenderDORA(w io.Writer, report *metrics.Report) error
+	RenderEnvironments(w io.Writer, projects []service.ProjectEnvironments) error
+	RenderTestResults(w io.Writer, results service.PipelineTestResults) error
+	RenderPipelineGraph(w io.Writer, graph service.PipelineGraph) error
+	RenderPipelineTimeline(w io.Writer, timeline service.PipelineTimeline) error
 }
 
 // HTMLRenderer implements Renderer for HTML responses.
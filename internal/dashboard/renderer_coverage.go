@@ -0,0 +1,74 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// Size of the coverage trend chart, in SVG user units
+const (
+	coverageChartWidth  = 600
+	coverageChartHeight = 80
+)
+
+// writeCoverageTrend writes the coverage of a branch's recorded pipelines as a line chart.
+// Each point shows its pipeline and coverage on hover.
+func writeCoverageTrend(sb *strings.Builder, branch string, points []service.CoveragePoint) {
+	latest := points[len(points)-1]
+	change := ""
+	if len(points) > 1 {
+		change = " " + formatCoverageDelta(latest.Coverage-points[0].Coverage) + " over the period"
+	}
+
+	sb.WriteString(fmt.Sprintf(`				<div class="trends-block">
+					<h3>Coverage • %s</h3>
+					<p class="coverage-summary">%.1f%% now%s • %d pipelines</p>
+					<svg class="coverage-chart" viewBox="0 0 %d %d" preserveAspectRatio="none" role="img" aria-label="Coverage trend">
+`, escapeHTML(branch), latest.Coverage, change, len(points), coverageChartWidth, coverageChartHeight))
+
+	// Scale to the range covered, so small changes stay visible
+	low, high := points[0].Coverage, points[0].Coverage
+	for _, p := range points {
+		low = min(low, p.Coverage)
+		high = max(high, p.Coverage)
+	}
+	if high-low < 1 {
+		low, high = max(low-0.5, 0), min(high+0.5, 100)
+	}
+
+	const margin = 6
+	var line []string
+	var dots strings.Builder
+	for i, p := range points {
+		x := float64(coverageChartWidth) / 2
+		if len(points) > 1 {
+			x = margin + float64(i)*float64(coverageChartWidth-2*margin)/float64(len(points)-1)
+		}
+		y := margin + (high-p.Coverage)/(high-low)*float64(coverageChartHeight-2*margin)
+		line = append(line, fmt.Sprintf("%.1f,%.1f", x, y))
+		dots.WriteString(fmt.Sprintf(`						<circle cx="%.1f" cy="%.1f" r="3"><title>#%s • %s • %.1f%%</title></circle>
+`, x, y, escapeHTML(p.PipelineID), p.CreatedAt.Format("2006-01-02 15:04"), p.Coverage))
+	}
+
+	sb.WriteString(`						<polyline points="` + strings.Join(line, " ") + `"/>
+`)
+	sb.WriteString(dots.String())
+	sb.WriteString(fmt.Sprintf(`					</svg>
+					<div class="coverage-range"><span>%.1f%%</span><span>%.1f%%</span></div>
+				</div>
+`, low, high))
+}
+
+// formatCoverageDelta formats a change in coverage as an arrow and signed percentage points.
+func formatCoverageDelta(delta float64) string {
+	switch {
+	case delta >= 0.05:
+		return fmt.Sprintf(`<span class="coverage-delta up">▲ +%.1f</span>`, delta)
+	case delta <= -0.05:
+		return fmt.Sprintf(`<span class="coverage-delta down">▼ %.1f</span>`, delta)
+	default:
+		return `<span class="coverage-delta">± 0.0</span>`
+	}
+}
@@ -0,0 +1,128 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/metrics"
+)
+
+// doraWindowDays are the reporting periods offered on the DORA page.
+var doraWindowDays = []int{7, 30, 90}
+
+// RenderDORA renders the DORA metrics page with a group table followed by a project table.
+// A nil report means metrics are disabled.
+func (r *HTMLRenderer) RenderDORA(w io.Writer, report *metrics.Report) error {
+	var sb strings.Builder
+
+	sb.WriteString(htmlHead("DORA Metrics - CI Dashboard", "Deployment frequency, lead time, change failure rate and time to restore"))
+	sb.WriteString(pageCSS(`
+		.dora-table { width: 100%; border-collapse: collapse; font-size: 14px; background: var(--bg-secondary); border-radius: 8px; box-shadow: 0 2px 4px var(--shadow); margin-bottom: 30px; }
+		.dora-table th, .dora-table td { padding: 10px 12px; text-align: right; border-bottom: 1px solid var(--border); white-space: nowrap; }
+		.dora-table th:first-child, .dora-table td:first-child { text-align: left; }
+		.dora-table th { color: var(--text-secondary); font-weight: 600; }
+		.dora-samples { font-size: 12px; color: var(--text-secondary); }
+		.dora-help { color: var(--text-secondary); margin-bottom: 20px; }
+		.dora-windows { margin-bottom: 20px; }
+		.dora-windows a { margin-right: 12px; }
+		.dora-windows a.active { font-weight: 600; }
+	`))
+	sb.WriteString(`<body>
+	<div class="container">
+`)
+	sb.WriteString(buildNavigationWithProfiles(nil))
+	sb.WriteString(`
+		<h1>DORA Metrics</h1>
+		<p class="dora-help">Computed from deployments to production environments: a successful deployment counts as a deployment, a failed one as a failed change.
+		Projects without production deployments fall back to default branch pipelines; the source column tells which was used.
+		Lead time runs from merge request creation to the first successful deployment started after the merge.
+		Time to restore runs from the first failure to the next success.</p>
+`)
+
+	if report == nil {
+		sb.WriteString(`		<p class="dora-help">Pipeline history is disabled - set HISTORY_RETENTION_DAYS to enable DORA metrics.</p>
+`)
+	} else {
+		r.writeDORAWindows(&sb, report.WindowDays)
+
+		if len(report.Projects) == 0 {
+			sb.WriteString(`		<p class="dora-help">No deployments or default branch pipelines recorded in this period.</p>
+`)
+		} else {
+			sb.WriteString(`		<h2>Groups</h2>
+		<table class="dora-table">
+			<thead><tr><th>Group</th><th>Projects</th><th>Source</th><th>Deployments / day</th><th>Lead time</th><th>Change failure rate</th><th>Time to restore</th></tr></thead>
+			<tbody>
+`)
+			for _, group := range report.Groups {
+				name := fmt.Sprintf(`<a href="/dora?days=%d&group=%s">%s</a> <span class="dora-samples">(%s)</span>`,
+					report.WindowDays, url.QueryEscape(group.Group), escapeHTML(group.Group), escapeHTML(group.Platform))
+				r.writeDORARow(&sb, name, fmt.Sprintf("%d", group.Projects), group.DORA)
+			}
+			sb.WriteString(`			</tbody>
+		</table>
+		<h2>Projects</h2>
+		<table class="dora-table">
+			<thead><tr><th>Repository</th><th>Branch</th><th>Source</th><th>Deployments / day</th><th>Lead time</th><th>Change failure rate</th><th>Time to restore</th></tr></thead>
+			<tbody>
+`)
+			for _, project := range report.Projects {
+				name := fmt.Sprintf(`<a href="/repository?id=%s">%s</a>`, url.QueryEscape(project.ProjectID), escapeHTML(project.Name))
+				r.writeDORARow(&sb, name, escapeHTML(project.DefaultBranch), project.DORA)
+			}
+			sb.WriteString(`			</tbody>
+		</table>
+`)
+		}
+	}
+
+	sb.WriteString(`	</div>
+`)
+	sb.WriteString(themeToggleScript())
+	sb.WriteString(`</body>
+</html>
+`)
+
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
+
+// writeDORAWindows writes the reporting period selector.
+func (r *HTMLRenderer) writeDORAWindows(sb *strings.Builder, current int) {
+	sb.WriteString(`		<div class="dora-windows">Period:`)
+	for _, days := range doraWindowDays {
+		class := ""
+		if days == current {
+			class = ` class="active"`
+		}
+		sb.WriteString(fmt.Sprintf(` <a href="/dora?days=%d"%s>%d days</a>`, days, class, days))
+	}
+	sb.WriteString(`</div>
+`)
+}
+
+// writeDORARow writes a DORA metrics table row. name and second are pre-escaped HTML.
+func (r *HTMLRenderer) writeDORARow(sb *strings.Builder, name, second string, d metrics.DORA) {
+	leadTime := "-"
+	if d.LeadTimeChanges > 0 {
+		leadTime = fmt.Sprintf(`%s <span class="dora-samples">(%d MRs)</span>`,
+			formatDuration(time.Duration(d.LeadTimeSeconds)*time.Second), d.LeadTimeChanges)
+	}
+
+	failureRate := "-"
+	if d.Deployments+d.FailedChanges > 0 {
+		failureRate = fmt.Sprintf(`%.0f%% <span class="dora-samples">(%d)</span>`, d.ChangeFailureRate, d.FailedChanges)
+	}
+
+	restore := "-"
+	if d.Restores > 0 {
+		restore = fmt.Sprintf(`%s <span class="dora-samples">(%d)</span>`,
+			formatDuration(time.Duration(d.TimeToRestoreSeconds)*time.Second), d.Restores)
+	}
+
+	sb.WriteString(fmt.Sprintf(`				<tr><td>%s</td><td>%s</td><td>%s</td><td>%.2f <span class="dora-samples">(%d)</span></td><td>%s</td><td>%s</td><td>%s</td></tr>
+`, name, second, escapeHTML(d.Source), d.DeploymentsPerDay, d.Deployments, leadTime, failureRate, restore))
+}
@@ -0,0 +1,108 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// RenderEnvironments renders the environments page: the latest deployment of each environment, per repository.
+func (r *HTMLRenderer) RenderEnvironments(w io.Writer, projects []service.ProjectEnvironments) error {
+	var sb strings.Builder
+
+	sb.WriteString(htmlHead("Environments - CI Dashboard", "What is deployed to each environment"))
+	sb.WriteString(pageCSS(`
+		.env-table { width: 100%; border-collapse: collapse; font-size: 14px; background: var(--bg-secondary); border-radius: 8px; box-shadow: 0 2px 4px var(--shadow); margin-bottom: 30px; }
+		.env-table th, .env-table td { padding: 10px 12px; text-align: left; border-bottom: 1px solid var(--border); white-space: nowrap; }
+		.env-table th { color: var(--text-secondary); font-weight: 600; }
+		.env-table th:first-child, .env-table td:first-child { width: 20%; }
+		.env-commit { font-family: monospace; }
+		.env-none { color: var(--text-secondary); }
+		.env-help { color: var(--text-secondary); margin-bottom: 20px; }
+		.env-repository { margin-bottom: 10px; }
+	`))
+	sb.WriteString(`<body>
+	<div class="container">
+`)
+	sb.WriteString(buildNavigationWithProfiles(nil))
+	sb.WriteString(`
+		<h1>Environments</h1>
+		<p class="env-help">The latest deployment to each environment: which ref and commit, when, by whom and with what status.</p>
+`)
+
+	if len(projects) == 0 {
+		sb.WriteString(`		<p class="env-help">No environments found.</p>
+`)
+	}
+
+	for _, project := range projects {
+		r.writeProjectEnvironments(&sb, project)
+	}
+
+	sb.WriteString(`	</div>
+`)
+	sb.WriteString(themeToggleScript())
+	sb.WriteString(`</body>
+</html>
+`)
+
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
+
+// writeProjectEnvironments writes the environments table of a single repository.
+func (r *HTMLRenderer) writeProjectEnvironments(sb *strings.Builder, project service.ProjectEnvironments) {
+	sb.WriteString(fmt.Sprintf(`		<h2 class="env-repository"><a href="/repository?id=%s">%s</a></h2>
+`, url.QueryEscape(project.Project.ID), escapeHTML(project.Project.Name)))
+
+	if len(project.Environments) == 0 {
+		sb.WriteString(`		<p class="env-help">No environments found.</p>
+`)
+		return
+	}
+
+	sb.WriteString(`		<table class="env-table">
+			<thead><tr><th>Environment</th><th>Ref</th><th>Commit</th><th>Status</th><th>Deployed</th><th>By</th></tr></thead>
+			<tbody>
+`)
+	for _, environment := range project.Environments {
+		r.writeEnvironmentRow(sb, environment)
+	}
+	sb.WriteString(`			</tbody>
+		</table>
+`)
+}
+
+// writeEnvironmentRow writes a single environment table row.
+func (r *HTMLRenderer) writeEnvironmentRow(sb *strings.Builder, environment domain.Environment) {
+	name := escapeHTML(environment.Name)
+	if environment.URL != "" {
+		name = fmt.Sprintf(`<a href="%s" target="_blank" rel="noopener noreferrer">%s</a>`, escapeHTML(environment.URL), name)
+	}
+
+	deployment := environment.LastDeployment
+	if deployment == nil {
+		sb.WriteString(fmt.Sprintf(`				<tr><td>%s</td><td colspan="5" class="env-none">Nothing deployed yet</td></tr>
+`, name))
+		return
+	}
+
+	commit := deployment.CommitSHA
+	if len(commit) > 8 {
+		commit = commit[:8]
+	}
+
+	deployed := formatTimeAgo(deployment.CreatedAt)
+	if deployment.WebURL != "" {
+		deployed = fmt.Sprintf(`<a href="%s" target="_blank" rel="noopener noreferrer">%s</a>`, escapeHTML(deployment.WebURL), deployed)
+	}
+
+	statusClass := strings.ToLower(string(deployment.Status))
+	sb.WriteString(fmt.Sprintf(`				<tr><td>%s</td><td>%s</td><td class="env-commit">%s</td><td><span class="status-badge %s">%s</span></td><td>%s</td><td>%s</td></tr>
+`, name, escapeHTML(deployment.Ref), escapeHTML(commit), statusClass, strings.ToUpper(string(deployment.Status)),
+		deployed, escapeHTML(deployment.Author)))
+}
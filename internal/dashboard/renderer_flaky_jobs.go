@@ -0,0 +1,88 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// RenderFlakyJobs renders the flaky jobs page, most flaky first.
+func (r *HTMLRenderer) RenderFlakyJobs(w io.Writer, jobs []service.FlakyJob, historyEnabled bool) error {
+	var sb strings.Builder
+
+	sb.WriteString(htmlHead("Flaky Jobs - CI Dashboard", "Jobs that fail and pass without code changes"))
+	sb.WriteString(pageCSS(`
+		.flaky-table { width: 100%; border-collapse: collapse; font-size: 14px; background: var(--bg-secondary); border-radius: 8px; box-shadow: 0 2px 4px var(--shadow); }
+		.flaky-table th, .flaky-table td { padding: 10px 12px; text-align: right; border-bottom: 1px solid var(--border); white-space: nowrap; }
+		.flaky-table th:nth-child(-n+2), .flaky-table td:nth-child(-n+2) { text-align: left; }
+		.flaky-table th { color: var(--text-secondary); font-weight: 600; }
+		.flaky-stage { font-size: 12px; color: var(--text-secondary); }
+		.flaky-score { font-weight: 600; }
+		.flaky-score.high { color: var(--failed-text); }
+		.flaky-help { color: var(--text-secondary); margin-bottom: 20px; }
+	`))
+	sb.WriteString(`<body>
+	<div class="container">
+`)
+	sb.WriteString(buildNavigationWithProfiles(nil))
+	sb.WriteString(fmt.Sprintf(`
+		<h1>Flaky Jobs</h1>
+		<p class="flaky-help">Jobs that failed and then passed on the same commit (retried or re-run), or that flipped status at least %d times on the default branch, over the last %d days.
+		Score = (retry passes + same-commit flakes + flips) / runs.</p>
+`, service.FlakyMinFlips, service.FlakyJobsWindowDays))
+
+	switch {
+	case !historyEnabled:
+		sb.WriteString(`		<p class="flaky-help">Pipeline history is disabled - set HISTORY_RETENTION_DAYS to enable flaky job detection.</p>
+`)
+	case len(jobs) == 0:
+		sb.WriteString(`		<p class="flaky-help">No flaky jobs detected.</p>
+`)
+	default:
+		sb.WriteString(`		<table class="flaky-table">
+			<thead><tr><th>Repository</th><th>Job</th><th>Score</th><th>Runs</th><th>Failures</th><th>Retry passes</th><th>Same-commit flakes</th><th>Flips</th><th>Last flaky</th></tr></thead>
+			<tbody>
+`)
+		for _, job := range jobs {
+			r.writeFlakyJobRow(&sb, job)
+		}
+		sb.WriteString(`			</tbody>
+		</table>
+`)
+	}
+
+	sb.WriteString(`	</div>
+`)
+	sb.WriteString(themeToggleScript())
+	sb.WriteString(`</body>
+</html>
+`)
+
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
+
+// writeFlakyJobRow writes a single flaky job table row.
+func (r *HTMLRenderer) writeFlakyJobRow(sb *strings.Builder, job service.FlakyJob) {
+	scoreClass := "flaky-score"
+	if job.Score >= 0.2 {
+		scoreClass += " high"
+	}
+
+	stage := ""
+	if job.Stage != "" {
+		stage = fmt.Sprintf(` <span class="flaky-stage">(%s)</span>`, escapeHTML(job.Stage))
+	}
+
+	lastFlaky := "-"
+	if !job.LastFlakyAt.IsZero() {
+		lastFlaky = formatTimeAgo(job.LastFlakyAt)
+	}
+
+	sb.WriteString(fmt.Sprintf(`				<tr><td><a href="/repository?id=%s">%s</a></td><td>%s%s</td><td class="%s">%.0f%%</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%s</td></tr>
+`, url.QueryEscape(job.ProjectID), escapeHTML(job.Repository), escapeHTML(job.Name), stage,
+		scoreClass, job.Score*100, job.Runs, job.Failures, job.RetryPasses, job.SameCommitFlakes, job.Flips, lastFlaky))
+}
@@ -3,10 +3,13 @@ package dashboard
 import (
 	"fmt"
 	"io"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+	"github.com/vilaca/ci-dashboard/internal/service"
 )
 
 // formatDuration formats a duration into human-readable format.
@@ -85,6 +88,91 @@ func (r *HTMLRenderer) RenderRepositoryDetailSkeleton(w io.Writer, repositoryID
 		.run-meta { display: flex; gap: 15px; align-items: center; flex-wrap: wrap; }
 		.mr-title, .issue-title { font-size: 18px; font-weight: 600; color: var(--text-primary); margin-bottom: 8px; }
 		.mr-meta, .issue-meta { font-size: 14px; color: var(--text-secondary); }
+		.jobs-section { background: var(--bg-secondary); padding: 20px; border-radius: 8px; margin-bottom: 30px; box-shadow: 0 2px 4px var(--shadow); }
+		.jobs-header { display: flex; justify-content: space-between; align-items: center; margin-bottom: 15px; gap: 15px; flex-wrap: wrap; }
+		.stages { display: flex; gap: 15px; overflow-x: auto; padding-bottom: 5px; }
+		.stage-column { min-width: 180px; flex: 1; }
+		.stage-name { font-size: 13px; font-weight: 600; text-transform: uppercase; color: var(--text-secondary); margin-bottom: 8px; }
+		.job-item { display: flex; justify-content: space-between; align-items: center; gap: 8px; padding: 6px 10px; border-radius: 4px; margin-bottom: 6px; border: 1px solid var(--border); font-size: 14px; }
+		.job-item.failed { border-color: var(--failed-text); background: var(--failed-bg); }
+		.job-name { overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+		.job-duration { font-size: 12px; color: var(--text-secondary); white-space: nowrap; }
+		.failed-jobs { font-size: 13px; color: var(--failed-text); margin-top: 4px; }
+		.trends-block { background: var(--bg-secondary); padding: 20px; border-radius: 8px; margin-bottom: 15px; box-shadow: 0 2px 4px var(--shadow); overflow-x: auto; }
+		.trends-block h3 { margin: 0 0 10px 0; font-size: 16px; }
+		.trends-table { width: 100%; border-collapse: collapse; font-size: 14px; }
+		.trends-table th, .trends-table td { padding: 8px 12px; text-align: right; border-bottom: 1px solid var(--border); white-space: nowrap; }
+		.trends-table th:first-child, .trends-table td:first-child { text-align: left; }
+		.trends-table th { color: var(--text-secondary); font-weight: 600; }
+		.trends-table td.streak-failed { color: var(--failed-text); font-weight: 600; }
+		.coverage-summary { margin: 0 0 10px 0; color: var(--text-secondary); font-size: 14px; }
+		.coverage-chart { width: 100%; height: 80px; display: block; }
+		.coverage-chart polyline { fill: none; stroke: var(--success-text); stroke-width: 2; vector-effect: non-scaling-stroke; }
+		.coverage-chart circle { fill: var(--success-text); }
+		.coverage-range { display: flex; justify-content: space-between; flex-direction: row-reverse; color: var(--text-secondary); font-size: 12px; }
+		.coverage-delta.up { color: var(--success-text); }
+		.coverage-delta.down { color: var(--failed-text); }
+		.pipeline-action { padding: 4px 10px; background: var(--button-bg); color: white; border: none; border-radius: 4px; cursor: pointer; font-size: 13px; }
+		.pipeline-action:hover { background: var(--button-hover); }
+		.pipeline-action:disabled { opacity: 0.6; cursor: wait; }
+		.failure-excerpt { font-size: 12px; color: var(--failed-text); background: var(--failed-bg); padding: 8px 10px; border-radius: 4px; margin: 8px 0 12px 0; white-space: pre-wrap; word-break: break-word; }
+		.job-log-button { padding: 1px 6px; background: none; color: var(--link-color); border: 1px solid var(--border); border-radius: 4px; cursor: pointer; font-size: 11px; }
+		.job-log-button:hover { background: var(--border); }
+		.test-results-button { padding: 4px 10px; background: none; color: var(--link-color); border: 1px solid var(--border); border-radius: 4px; cursor: pointer; font-size: 13px; }
+		.test-results-button:hover { background: var(--border); }
+		.test-results, .pipeline-graph, .pipeline-timeline { background: var(--bg-secondary); padding: 15px 20px; border-radius: 8px; margin: -10px 0 15px 0; box-shadow: 0 2px 4px var(--shadow); }
+		.jobs-section .test-results, .jobs-section .pipeline-graph, .jobs-section .pipeline-timeline { box-shadow: none; padding: 0 0 15px 0; margin: 0; }
+		.graph-summary { font-size: 14px; color: var(--text-secondary); margin-bottom: 10px; }
+		.graph-scroll { overflow-x: auto; }
+		.graph-stage { font-size: 12px; font-weight: 600; fill: var(--text-secondary); }
+		.graph-edge { fill: none; stroke: var(--text-secondary); stroke-width: 1.5; opacity: 0.6; }
+		.graph-edge.critical { stroke: var(--link-color); stroke-width: 2.5; opacity: 1; }
+		.graph-node rect { fill: var(--canceled-bg); stroke: var(--canceled-text); stroke-width: 1; }
+		.graph-node.success rect { fill: var(--success-bg); stroke: var(--success-text); }
+		.graph-node.failed rect { fill: var(--failed-bg); stroke: var(--failed-text); }
+		.graph-node.running rect { fill: var(--running-bg); stroke: var(--running-text); }
+		.graph-node.pending rect { fill: var(--pending-bg); stroke: var(--pending-text); }
+		.graph-node.critical rect { stroke: var(--link-color); stroke-width: 2.5; }
+		.graph-job-name { font-size: 13px; font-weight: 600; fill: var(--text-primary); }
+		.graph-job-meta { font-size: 11px; fill: var(--text-secondary); }
+		.gantt-tick { stroke: var(--border-color); stroke-width: 1; }
+		.gantt-tick-label { font-size: 11px; fill: var(--text-secondary); }
+		.gantt-label { font-size: 12px; fill: var(--text-primary); }
+		.gantt-label a { fill: var(--link-color); }
+		.gantt-row.critical .gantt-label { font-weight: 600; }
+		.gantt-queue { fill: var(--pending-bg); stroke: var(--pending-text); stroke-width: 0.5; stroke-dasharray: 2 2; }
+		.gantt-run { fill: var(--canceled-text); }
+		.gantt-run.success { fill: var(--success-text); }
+		.gantt-run.failed { fill: var(--failed-text); }
+		.gantt-run.running { fill: var(--running-text); }
+		.gantt-row.critical .gantt-run { stroke: var(--link-color); stroke-width: 2; }
+		.gantt-row:not(.critical) .gantt-run { opacity: 0.55; }
+		.test-summary { font-size: 14px; color: var(--text-secondary); margin-bottom: 10px; }
+		.test-failed-count { color: var(--failed-text); font-weight: 600; }
+		.test-results-empty { font-size: 14px; color: var(--text-secondary); margin: 5px 0; }
+		.test-failure { border-top: 1px solid var(--border); padding: 8px 0; }
+		.test-failure-header { display: flex; gap: 10px; align-items: center; flex-wrap: wrap; font-size: 14px; }
+		.test-name { font-weight: 600; font-family: monospace; }
+		.test-suite, .test-rate { font-size: 12px; color: var(--text-secondary); }
+		.test-history { font-size: 11px; font-weight: 600; padding: 2px 6px; border-radius: 4px; }
+		.test-history.new { color: var(--failed-text); background: var(--failed-bg); }
+		.test-history.long-standing { color: var(--text-secondary); border: 1px solid var(--border); }
+		.test-failure .failure-excerpt { margin: 6px 0 0 0; }
+		.job-log-panel { background: var(--bg-secondary); padding: 15px 20px; border-radius: 8px; margin-top: 20px; box-shadow: 0 2px 4px var(--shadow); }
+		.job-log-header { display: flex; justify-content: space-between; align-items: center; margin-bottom: 10px; font-weight: 600; }
+		.job-log-close { background: none; border: none; color: var(--text-secondary); cursor: pointer; font-size: 16px; }
+		.job-log { background: #1e1e1e; color: #d4d4d4; font-size: 12px; line-height: 1.4; padding: 12px; border-radius: 4px; max-height: 70vh; overflow: auto; white-space: pre-wrap; word-break: break-all; margin: 0; }
+		.ansi-bold { font-weight: bold; }
+		.ansi-italic { font-style: italic; }
+		.ansi-underline { text-decoration: underline; }
+		.ansi-fg-0 { color: #3b3b3b; } .ansi-fg-1 { color: #cd3131; } .ansi-fg-2 { color: #0dbc79; } .ansi-fg-3 { color: #e5e510; }
+		.ansi-fg-4 { color: #2472c8; } .ansi-fg-5 { color: #bc3fbc; } .ansi-fg-6 { color: #11a8cd; } .ansi-fg-7 { color: #e5e5e5; }
+		.ansi-fg-8 { color: #666666; } .ansi-fg-9 { color: #f14c4c; } .ansi-fg-10 { color: #23d18b; } .ansi-fg-11 { color: #f5f543; }
+		.ansi-fg-12 { color: #3b8eea; } .ansi-fg-13 { color: #d670d6; } .ansi-fg-14 { color: #29b8db; } .ansi-fg-15 { color: #ffffff; }
+		.ansi-bg-0 { background: #3b3b3b; } .ansi-bg-1 { background: #cd3131; } .ansi-bg-2 { background: #0dbc79; } .ansi-bg-3 { background: #e5e510; }
+		.ansi-bg-4 { background: #2472c8; } .ansi-bg-5 { background: #bc3fbc; } .ansi-bg-6 { background: #11a8cd; } .ansi-bg-7 { background: #e5e5e5; }
+		.ansi-bg-8 { background: #666666; } .ansi-bg-9 { background: #f14c4c; } .ansi-bg-10 { background: #23d18b; } .ansi-bg-11 { background: #f5f543; }
+		.ansi-bg-12 { background: #3b8eea; } .ansi-bg-13 { background: #d670d6; } .ansi-bg-14 { background: #29b8db; } .ansi-bg-15 { background: #ffffff; }
 	`))
 	sb.WriteString(`<body>
 	<div class="container">
@@ -100,6 +188,15 @@ func (r *HTMLRenderer) RenderRepositoryDetailSkeleton(w io.Writer, repositoryID
 		<div id="content" style="display: none;">
 			<!-- Content will be populated by JavaScript -->
 		</div>
+
+		<!-- Outside the content so reloading the details keeps the log open -->
+		<div id="job-log-panel" class="job-log-panel" hidden>
+			<div class="job-log-header">
+				<span id="job-log-title"></span>
+				<button class="job-log-close" onclick="closeJobLog()" aria-label="Close log">✕</button>
+			</div>
+			<pre id="job-log" class="job-log"></pre>
+		</div>
 	</div>
 	<script>
 		const repositoryID = ` + fmt.Sprintf("%q", repositoryID) + `;
@@ -148,6 +245,138 @@ func (r *HTMLRenderer) RenderRepositoryDetailSkeleton(w io.Writer, repositoryID
 			}
 		}
 
+		const pipelineActionPrompts = {
+			'retry': 'Retry this pipeline?',
+			'rerun-failed': 'Re-run the failed jobs of this pipeline?',
+			'cancel': 'Cancel this pipeline?',
+			'run': 'Run a new pipeline?'
+		};
+
+		async function runPipelineAction(button) {
+			if (!confirm(pipelineActionPrompts[button.dataset.action])) {
+				return;
+			}
+
+			button.disabled = true;
+			try {
+				const response = await fetch('/api/actions', {
+					method: 'POST',
+					headers: { 'X-CSRF-Token': document.getElementById('pipeline-actions').dataset.csrfToken },
+					body: new URLSearchParams({
+						id: repositoryID,
+						action: button.dataset.action,
+						pipeline: button.dataset.pipeline,
+						ref: button.dataset.ref,
+						workflow: button.dataset.workflow
+					})
+				});
+				if (!response.ok) {
+					throw new Error(await response.text());
+				}
+				loadRepositoryDetail();
+			} catch (error) {
+				alert('Action failed: ' + error.message);
+				button.disabled = false;
+			}
+		}
+
+		let jobLogController = null;
+
+		// Streams a job log into the log panel. The server sends one complete HTML line per log line,
+		// so each chunk is appended up to its last line break.
+		async function openJobLog(button) {
+			if (jobLogController) {
+				jobLogController.abort();
+			}
+			jobLogController = new AbortController();
+
+			const panel = document.getElementById('job-log-panel');
+			const output = document.getElementById('job-log');
+			document.getElementById('job-log-title').textContent = button.dataset.name;
+			output.innerHTML = '';
+			panel.hidden = false;
+			panel.scrollIntoView({ behavior: 'smooth' });
+
+			try {
+				const response = await fetch('/api/job-log?id=' + encodeURIComponent(repositoryID) + '&job=' + encodeURIComponent(button.dataset.job),
+					{ signal: jobLogController.signal });
+				if (!response.ok) {
+					throw new Error(await response.text());
+				}
+
+				const reader = response.body.getReader();
+				const decoder = new TextDecoder();
+				let pending = '';
+				while (true) {
+					const { done, value } = await reader.read();
+					if (done) {
+						break;
+					}
+					pending += decoder.decode(value, { stream: true });
+					const end = pending.lastIndexOf('\n');
+					if (end >= 0) {
+						output.insertAdjacentHTML('beforeend', pending.slice(0, end + 1));
+						pending = pending.slice(end + 1);
+						output.scrollTop = output.scrollHeight;
+					}
+				}
+				output.insertAdjacentHTML('beforeend', pending);
+				output.scrollTop = output.scrollHeight;
+			} catch (error) {
+				if (error.name !== 'AbortError') {
+					output.textContent = 'Failed to load log: ' + error.message;
+				}
+			}
+		}
+
+		// Loads the test results of a pipeline into the element after its row on the first click,
+		// and shows or hides them afterwards.
+		function toggleTestResults(button) {
+			togglePipelinePanel(button, 'test-results', '/api/test-results', 'test results');
+		}
+
+		function togglePipelineGraph(button) {
+			togglePipelinePanel(button, 'pipeline-graph', '/api/pipeline-graph', 'pipeline graph');
+		}
+
+		function togglePipelineTimeline(button) {
+			togglePipelinePanel(button, 'pipeline-timeline', '/api/pipeline-timeline', 'pipeline timeline');
+		}
+
+		// Shows or hides the panel with the given class below a pipeline, loading it on first use
+		async function togglePipelinePanel(button, panelClass, url, label) {
+			let container = button.closest('.run-item, .jobs-header').nextElementSibling;
+			while (!container.classList.contains(panelClass)) {
+				container = container.nextElementSibling;
+			}
+			if (container.dataset.loaded) {
+				container.hidden = !container.hidden;
+				return;
+			}
+
+			container.hidden = false;
+			container.innerHTML = '<p class="test-results-empty">Loading ' + label + '...</p>';
+			try {
+				const response = await fetch(url + '?id=' + encodeURIComponent(repositoryID) + '&pipeline=' + encodeURIComponent(button.dataset.pipeline));
+				if (!response.ok) {
+					throw new Error(await response.text());
+				}
+				const data = await response.json();
+				container.innerHTML = data.html;
+				container.dataset.loaded = 'true';
+			} catch (error) {
+				container.textContent = 'Failed to load ' + label + ': ' + error.message;
+			}
+		}
+
+		function closeJobLog() {
+			if (jobLogController) {
+				jobLogController.abort();
+				jobLogController = null;
+			}
+			document.getElementById('job-log-panel').hidden = true;
+		}
+
 		// Load data when page is ready
 		if (document.readyState === 'loading') {
 			document.addEventListener('DOMContentLoaded', loadRepositoryDetail);
@@ -191,9 +420,20 @@ func (r *HTMLRenderer) RenderRepositoryDetail(w io.Writer, detail PersonalizedRe
 	// Render header with repository name, link, and user role
 	sb.WriteString(fmt.Sprintf(`<h1>%s</h1>
 		<div class="repo-url">
-			%s <span style="margin-left: 20px; color: var(--text-secondary);">Your role: <strong>%s</strong></span>
+			%s <a href="/environments?id=%s" style="margin-left: 20px;">Environments →</a> <span style="margin-left: 20px; color: var(--text-secondary);">Your role: <strong>%s</strong></span>
 		</div>
-`, detail.Project.Name, externalLink(detail.Project.WebURL, "View →"), detail.UserRole))
+`, detail.Project.Name, externalLink(detail.Project.WebURL, "View →"), url.QueryEscape(detail.Project.ID), detail.UserRole))
+
+	// CSRF token read by the action buttons
+	if detail.ActionsEnabled {
+		sb.WriteString(fmt.Sprintf(`<div id="pipeline-actions" data-csrf-token="%s" hidden></div>
+`, escapeHTML(detail.CSRFToken)))
+	}
+
+	// Default branch pipeline with per-stage job breakdown
+	if detail.DefaultPipeline != nil {
+		r.writePipelineJobs(&sb, *detail.DefaultPipeline, detail.ActionsEnabled)
+	}
 
 	// Stats cards
 	sb.WriteString(`
@@ -225,7 +465,12 @@ func (r *HTMLRenderer) RenderRepositoryDetail(w io.Writer, detail PersonalizedRe
 			<button class="tab-button active" data-tab="mybranches" onclick="switchTab('mybranches', this)">My Branches (` + fmt.Sprintf("%d", len(detail.MyBranches)) + `)</button>
 			<button class="tab-button" data-tab="reviewing" onclick="switchTab('reviewing', this)">Reviewing (` + fmt.Sprintf("%d", len(detail.ReviewingMRs)) + `)</button>
 			<button class="tab-button" data-tab="mymrs" onclick="switchTab('mymrs', this)">My MRs/PRs (` + fmt.Sprintf("%d", len(detail.MyMRs)) + `)</button>
-			<button class="tab-button" data-tab="activity" onclick="switchTab('activity', this)">Recent Activity (` + fmt.Sprintf("%d", totalRuns) + `)</button>
+			<button class="tab-button" data-tab="activity" onclick="switchTab('activity', this)">Recent Activity (` + fmt.Sprintf("%d", totalRuns) + `)</button>`)
+	if detail.Stats != nil {
+		sb.WriteString(`
+			<button class="tab-button" data-tab="trends" onclick="switchTab('trends', this)">Trends</button>`)
+	}
+	sb.WriteString(`
 		</div>
 
 		<!-- My Branches Tab -->
@@ -293,7 +538,7 @@ func (r *HTMLRenderer) RenderRepositoryDetail(w io.Writer, detail PersonalizedRe
 		sb.WriteString(`				<p style="color: var(--text-secondary); text-align: center; padding: 40px 0;">No recent pipeline runs found.</p>`)
 	} else {
 		for _, run := range detail.RecentPipelines {
-			r.writeRepositoryDetailRun(&sb, run)
+			r.writeRepositoryDetailRun(&sb, run, detail.ActionsEnabled)
 		}
 	}
 
@@ -301,10 +546,90 @@ func (r *HTMLRenderer) RenderRepositoryDetail(w io.Writer, detail PersonalizedRe
 		</div>
 `)
 
+	if detail.Stats != nil {
+		r.writeRepositoryTrends(&sb, detail.Project, *detail.Stats, detail.CoverageTrend)
+	}
+
 	_, err := w.Write([]byte(sb.String()))
 	return err
 }
 
+// writeRepositoryTrends writes the trends tab: the default branch's coverage, then pipeline stats per window
+// for all branches, the default branch first, then every other branch with recorded history.
+func (r *HTMLRenderer) writeRepositoryTrends(sb *strings.Builder, project domain.Project, stats service.RepositoryStats, coverage []service.CoveragePoint) {
+	sb.WriteString(`
+		<!-- Trends Tab -->
+		<div id="trends-tab" class="tab-content">
+			<div class="runs-section">
+				<h2>Pipeline Trends</h2>
+				<p style="color: var(--text-secondary); margin-bottom: 20px;">Finished pipelines recorded by the dashboard (canceled runs are excluded from success rate, durations and streaks) • <a href="/flaky-jobs?id=` + url.QueryEscape(project.ID) + `">Flaky jobs →</a></p>
+`)
+
+	if len(stats.Branches) == 0 {
+		sb.WriteString(`				<p style="color: var(--text-secondary); text-align: center; padding: 40px 0;">No pipeline history recorded yet.</p>
+			</div>
+		</div>
+`)
+		return
+	}
+
+	if len(coverage) > 0 {
+		writeCoverageTrend(sb, project.DefaultBranch, coverage)
+	}
+
+	writeTrendsTable(sb, "All branches", stats.Windows)
+
+	// Default branch first, then the rest in name order
+	for _, branch := range stats.Branches {
+		if branch.Branch == project.DefaultBranch {
+			writeTrendsTable(sb, branch.Branch+" (default)", branch.Windows)
+		}
+	}
+	for _, branch := range stats.Branches {
+		if branch.Branch != project.DefaultBranch {
+			writeTrendsTable(sb, branch.Branch, branch.Windows)
+		}
+	}
+
+	sb.WriteString(`			</div>
+		</div>
+`)
+}
+
+// writeTrendsTable writes one table row per stats window.
+func writeTrendsTable(sb *strings.Builder, title string, windows []history.Stats) {
+	sb.WriteString(fmt.Sprintf(`				<div class="trends-block">
+					<h3>%s</h3>
+					<table class="trends-table">
+						<thead><tr><th>Window</th><th>Runs</th><th>Success rate</th><th>p50 duration</th><th>p95 duration</th><th>Failure streak</th><th>Longest streak</th></tr></thead>
+						<tbody>
+`, escapeHTML(title)))
+
+	for _, window := range windows {
+		successRate, p50, p95 := "—", "—", "—"
+		if window.Succeeded+window.Failed > 0 {
+			successRate = fmt.Sprintf("%.1f%%", window.SuccessRate)
+		}
+		if window.P50DurationSeconds > 0 {
+			p50 = formatDuration(time.Duration(window.P50DurationSeconds * float64(time.Second)))
+			p95 = formatDuration(time.Duration(window.P95DurationSeconds * float64(time.Second)))
+		}
+
+		streakClass := ""
+		if window.CurrentFailureStreak > 0 {
+			streakClass = ` class="streak-failed"`
+		}
+
+		sb.WriteString(fmt.Sprintf(`							<tr><td>%d days</td><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td%s>%d</td><td>%d</td></tr>
+`, window.WindowDays, window.Total, successRate, p50, p95, streakClass, window.CurrentFailureStreak, window.LongestFailureStreak))
+	}
+
+	sb.WriteString(`						</tbody>
+					</table>
+				</div>
+`)
+}
+
 // writeRepositoryDetailBranch writes a single branch item with pipeline status.
 func (r *HTMLRenderer) writeRepositoryDetailBranch(sb *strings.Builder, branch domain.BranchWithPipeline) {
 	statusBadge := `<span class="status-badge" style="background: var(--border); color: var(--text-secondary);">NO PIPELINE</span>`
@@ -322,6 +647,11 @@ func (r *HTMLRenderer) writeRepositoryDetailBranch(sb *strings.Builder, branch d
 			externalLink(branch.Pipeline.WebURL, "Pipeline →"))
 	}
 
+	failedJobs := ""
+	if branch.Pipeline != nil && branch.Pipeline.Status == domain.StatusFailed {
+		failedJobs = formatFailedJobs(branch.Pipeline.FailedBuilds())
+	}
+
 	commitMsg := branch.Branch.LastCommitMsg
 	if len(commitMsg) > 60 {
 		commitMsg = commitMsg[:60] + "..."
@@ -331,6 +661,7 @@ func (r *HTMLRenderer) writeRepositoryDetailBranch(sb *strings.Builder, branch d
 				<div class="run-left">
 					<div class="run-name">%s</div>
 					<div class="run-branch">%s • Last commit %s</div>
+					%s
 				</div>
 				<div class="run-meta">
 					%s
@@ -341,13 +672,133 @@ func (r *HTMLRenderer) writeRepositoryDetailBranch(sb *strings.Builder, branch d
 `, branch.Branch.Name,
 		escapeHTML(commitMsg),
 		formatTimeAgo(branch.Branch.LastCommitDate),
+		failedJobs,
 		pipelineInfo,
 		externalLink(branch.Branch.WebURL, "View Branch →"),
 		statusBadge))
 }
 
+// writePipelineJobs writes the job breakdown of a pipeline, grouped by stage in execution order.
+func (r *HTMLRenderer) writePipelineJobs(sb *strings.Builder, pipeline domain.Pipeline, actionsEnabled bool) {
+	statusClass := strings.ToLower(string(pipeline.Status))
+
+	actions := ""
+	if actionsEnabled {
+		actions = pipelineActionButtons(pipeline, true)
+	}
+
+	sb.WriteString(fmt.Sprintf(`		<div class="jobs-section">
+			<div class="jobs-header">
+				<h2>Default Branch Pipeline <span style="font-size: 14px; color: var(--text-secondary); font-weight: normal;">%s • %s</span></h2>
+				<div class="run-meta">
+					%s
+					%s
+					<span>⏱️ %s</span>
+					<span>⏰ %s</span>
+					%s
+					<span class="status-badge %s">%s</span>
+				</div>
+			</div>
+			<div class="test-results" hidden></div>
+			<div class="pipeline-graph" hidden></div>
+			<div class="pipeline-timeline" hidden></div>
+`, escapeHTML(pipeline.Branch), escapeHTML(pipeline.ID),
+		actions,
+		pipelineGraphButton(pipeline)+pipelineTimelineButton(pipeline)+testResultsButton(pipeline),
+		formatDuration(pipeline.Duration),
+		formatTimeAgo(pipeline.UpdatedAt),
+		externalLink(pipeline.WebURL, "Pipeline →"),
+		statusClass, strings.ToUpper(string(pipeline.Status))))
+
+	if len(pipeline.Builds) == 0 {
+		sb.WriteString(`			<p style="color: var(--text-secondary);">No job details available for this pipeline.</p>
+		</div>
+`)
+		return
+	}
+
+	if failed := pipeline.FailedBuilds(); len(failed) > 0 {
+		sb.WriteString(`			` + formatFailedJobs(failed) + `
+`)
+	}
+	if pipeline.FailureExcerpt != "" {
+		sb.WriteString(`			<pre class="failure-excerpt">` + escapeHTML(pipeline.FailureExcerpt) + `</pre>
+`)
+	}
+
+	// Group builds by stage, keeping stages in order of first appearance
+	// Retried attempts are hidden - the final attempt of each job is shown
+	var stages []string
+	stageBuilds := make(map[string][]domain.Build)
+	for _, build := range pipeline.Builds {
+		if build.Retried {
+			continue
+		}
+		stage := build.Stage
+		if stage == "" {
+			stage = "jobs"
+		}
+		if _, exists := stageBuilds[stage]; !exists {
+			stages = append(stages, stage)
+		}
+		stageBuilds[stage] = append(stageBuilds[stage], build)
+	}
+
+	sb.WriteString(`			<div class="stages">
+`)
+	for _, stage := range stages {
+		sb.WriteString(fmt.Sprintf(`				<div class="stage-column">
+					<div class="stage-name">%s</div>
+`, escapeHTML(stage)))
+		for _, build := range stageBuilds[stage] {
+			buildStatus := strings.ToLower(string(build.Status))
+			duration := "-"
+			if build.Duration > 0 {
+				duration = formatDuration(build.Duration)
+			}
+			logButton := ""
+			if build.ID != "" {
+				logButton = fmt.Sprintf(`<button class="job-log-button" data-job="%s" data-name="%s" onclick="openJobLog(this)" title="Show log" aria-label="Show log of %s">log</button>`,
+					escapeHTML(build.ID), escapeHTML(build.Name), escapeHTML(build.Name))
+			}
+			sb.WriteString(fmt.Sprintf(`					<div class="job-item %s">
+						<a class="job-name" href="%s" target="_blank" rel="noopener noreferrer" title="%s">%s</a>
+						<span class="job-duration">%s</span>
+						%s
+						<span class="status-badge %s">%s</span>
+					</div>
+`, buildStatus, escapeHTML(build.WebURL), escapeHTML(build.Name), escapeHTML(build.Name),
+				duration, logButton, buildStatus, strings.ToUpper(string(build.Status))))
+		}
+		sb.WriteString(`				</div>
+`)
+	}
+	sb.WriteString(`			</div>
+		</div>
+`)
+}
+
+// formatFailedJobs renders a one-line summary of failed jobs, e.g. "Failed: lint (test)".
+// Returns an empty string when there are no failed jobs.
+func formatFailedJobs(builds []domain.Build) string {
+	if len(builds) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(builds))
+	for _, build := range builds {
+		if build.Stage != "" {
+			names = append(names, fmt.Sprintf("%s (%s)", escapeHTML(build.Name), escapeHTML(build.Stage)))
+		} else {
+			names = append(names, escapeHTML(build.Name))
+		}
+	}
+
+	return `<div class="failed-jobs">✗ Failed: ` + strings.Join(names, ", ") + `</div>`
+}
+
 // writeRepositoryDetailRun writes a single run item for the repository detail page.
-func (r *HTMLRenderer) writeRepositoryDetailRun(sb *strings.Builder, run domain.Pipeline) {
+func (r *HTMLRenderer) writeRepositoryDetailRun(sb *strings.Builder, run domain.Pipeline, actionsEnabled bool) {
 	name := run.Repository
 	if run.WorkflowName != nil && *run.WorkflowName != "" {
 		name = *run.WorkflowName
@@ -355,25 +806,68 @@ func (r *HTMLRenderer) writeRepositoryDetailRun(sb *strings.Builder, run domain.
 
 	statusClass := strings.ToLower(string(run.Status))
 
+	actions := ""
+	if actionsEnabled {
+		actions = pipelineActionButtons(run, false)
+	}
+
 	sb.WriteString(fmt.Sprintf(`			<div class="run-item">
 				<div class="run-left">
 					<div class="run-name">%s</div>
 					<div class="run-branch">Branch: %s</div>
 				</div>
 				<div class="run-meta">
+					%s
+					%s
 					<span>⏱️ %s</span>
 					<span>⏰ %s</span>
 					%s
 					<span class="status-badge %s">%s</span>
 				</div>
 			</div>
+			<div class="test-results" hidden></div>
+			<div class="pipeline-graph" hidden></div>
+			<div class="pipeline-timeline" hidden></div>
 `, name, run.Branch,
+		actions,
+		pipelineGraphButton(run)+pipelineTimelineButton(run)+testResultsButton(run),
 		formatDuration(run.Duration),
 		formatTimeAgo(run.UpdatedAt),
 		externalLink(run.WebURL, "View Details →"),
 		statusClass, strings.ToUpper(string(run.Status))))
 }
 
+// pipelineActionButtons renders the write action buttons that apply to a pipeline's status:
+// cancel while it is pending or running, retry once finished and re-run failed jobs after a failure.
+// withRun adds a button starting a new pipeline on the pipeline's branch.
+func pipelineActionButtons(pipeline domain.Pipeline, withRun bool) string {
+	workflowID := ""
+	if pipeline.WorkflowID != nil {
+		workflowID = *pipeline.WorkflowID
+	}
+
+	button := func(action, label string) string {
+		return fmt.Sprintf(`<button class="pipeline-action" data-action="%s" data-pipeline="%s" data-ref="%s" data-workflow="%s" onclick="runPipelineAction(this)">%s</button>`,
+			action, escapeHTML(pipeline.ID), escapeHTML(pipeline.Branch), escapeHTML(workflowID), label)
+	}
+
+	var buttons []string
+	switch {
+	case pipeline.Status == domain.StatusPending || pipeline.Status == domain.StatusRunning:
+		buttons = append(buttons, button(string(service.ActionCancel), "✕ Cancel"))
+	case pipeline.Status.IsTerminal():
+		buttons = append(buttons, button(string(service.ActionRetry), "↻ Retry"))
+		if pipeline.Status == domain.StatusFailed {
+			buttons = append(buttons, button(string(service.ActionRerunFailed), "↻ Re-run failed"))
+		}
+	}
+	if withRun {
+		buttons = append(buttons, button(string(service.ActionRun), "▶ Run pipeline"))
+	}
+
+	return strings.Join(buttons, "\n")
+}
+
 // writeRepositoryDetailMR writes a single MR item in the repository detail view.
 func (r *HTMLRenderer) writeRepositoryDetailMR(sb *strings.Builder, mr domain.MergeRequest) {
 	sb.WriteString(fmt.Sprintf(`			<div class="mr-item">
@@ -0,0 +1,151 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// Layout of the pipeline graph, in SVG user units
+const (
+	graphNodeWidth    = 190
+	graphNodeHeight   = 44
+	graphColumnGap    = 56
+	graphRowGap       = 12
+	graphHeaderHeight = 24
+	graphPadding      = 8
+	graphMaxNameChars = 24
+)
+
+// RenderPipelineGraph renders the jobs of a pipeline as columns of nodes linked by their needs.
+// Jobs on the critical path are highlighted. This renders only the fragment shown below the pipeline.
+func (r *HTMLRenderer) RenderPipelineGraph(w io.Writer, graph service.PipelineGraph) error {
+	var sb strings.Builder
+
+	if len(graph.Nodes) == 0 {
+		sb.WriteString(`<p class="test-results-empty">No jobs for this pipeline.</p>
+`)
+		_, err := w.Write([]byte(sb.String()))
+		return err
+	}
+
+	rows := 0
+	for _, column := range graph.Columns {
+		rows = max(rows, len(column))
+	}
+	width := 2*graphPadding + len(graph.Columns)*graphNodeWidth + (len(graph.Columns)-1)*graphColumnGap
+	height := 2*graphPadding + graphHeaderHeight + rows*graphNodeHeight + (rows-1)*graphRowGap
+
+	sb.WriteString(fmt.Sprintf(`<div class="graph-summary">%d jobs • critical path %s</div>
+<div class="graph-scroll">
+<svg class="pipeline-graph-svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="Pipeline job graph">
+`, len(graph.Nodes), formatDuration(graph.CriticalPath), width, height, width, height))
+
+	// Position of each node: its column and row within the column
+	type position struct{ x, y int }
+	positions := make([]position, len(graph.Nodes))
+	for c, column := range graph.Columns {
+		x := graphPadding + c*(graphNodeWidth+graphColumnGap)
+		sb.WriteString(fmt.Sprintf(`	<text class="graph-stage" x="%d" y="%d">%s</text>
+`, x, graphPadding+14, escapeHTML(truncateGraphLabel(columnStages(graph, column)))))
+		for row, i := range column {
+			positions[i] = position{x, graphPadding + graphHeaderHeight + row*(graphNodeHeight+graphRowGap)}
+		}
+	}
+
+	// Edges first, so nodes are drawn over them. Stage order is shown by the columns, so only
+	// needs and the critical path are drawn.
+	for i, node := range graph.Nodes {
+		for _, j := range node.Needs {
+			critical := node.Critical && graph.Nodes[j].Critical
+			if !node.Explicit && !critical {
+				continue
+			}
+			from, to := positions[j], positions[i]
+			x1, y1 := from.x+graphNodeWidth, from.y+graphNodeHeight/2
+			x2, y2 := to.x, to.y+graphNodeHeight/2
+			mid := (x1 + x2) / 2
+			class := "graph-edge"
+			if critical {
+				class += " critical"
+			}
+			sb.WriteString(fmt.Sprintf(`	<path class="%s" d="M %d %d C %d %d, %d %d, %d %d"/>
+`, class, x1, y1, mid, y1, mid, y2, x2, y2))
+		}
+	}
+
+	for i, node := range graph.Nodes {
+		pos := positions[i]
+		build := node.Build
+		class := "graph-node " + strings.ToLower(string(build.Status))
+		if node.Critical {
+			class += " critical"
+		}
+
+		duration := "—"
+		if build.Duration > 0 {
+			duration = formatDuration(build.Duration)
+		}
+
+		// Nodes link to their job, when the platform has a page for it
+		linkOpen, linkClose := "<g>", "</g>"
+		if build.WebURL != "" {
+			linkOpen = fmt.Sprintf(`<a href="%s" target="_blank" rel="noopener noreferrer">`, escapeHTML(build.WebURL))
+			linkClose = "</a>"
+		}
+
+		sb.WriteString(fmt.Sprintf(`	%s
+		<g class="%s">
+			<title>%s (%s) • %s • %s</title>
+			<rect x="%d" y="%d" width="%d" height="%d" rx="6"/>
+			<text class="graph-job-name" x="%d" y="%d">%s</text>
+			<text class="graph-job-meta" x="%d" y="%d">%s • %s</text>
+		</g>
+	%s
+`, linkOpen, class,
+			escapeHTML(build.Name), escapeHTML(build.Stage), strings.ToUpper(string(build.Status)), duration,
+			pos.x, pos.y, graphNodeWidth, graphNodeHeight,
+			pos.x+10, pos.y+18, escapeHTML(truncateGraphLabel(build.Name)),
+			pos.x+10, pos.y+35, strings.ToUpper(string(build.Status)), duration,
+			linkClose))
+	}
+
+	sb.WriteString(`</svg>
+</div>
+`)
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
+
+// columnStages returns the distinct stages of the jobs of a graph column, in job order.
+func columnStages(graph service.PipelineGraph, column []int) string {
+	var stages []string
+	seen := make(map[string]bool)
+	for _, i := range column {
+		stage := graph.Nodes[i].Build.Stage
+		if !seen[stage] {
+			seen[stage] = true
+			stages = append(stages, stage)
+		}
+	}
+	return strings.Join(stages, ", ")
+}
+
+// truncateGraphLabel shortens a label to fit a graph node; the full name is in the node's tooltip.
+func truncateGraphLabel(label string) string {
+	runes := []rune(label)
+	if len(runes) <= graphMaxNameChars {
+		return label
+	}
+	return string(runes[:graphMaxNameChars-1]) + "…"
+}
+
+// pipelineGraphButton renders the button showing the job graph of a pipeline.
+// The graph is loaded into the pipeline-graph element following the button's row.
+func pipelineGraphButton(pipeline domain.Pipeline) string {
+	return fmt.Sprintf(`<button class="test-results-button" data-pipeline="%s" onclick="togglePipelineGraph(this)">Graph</button>`,
+		escapeHTML(pipeline.ID))
+}
@@ -0,0 +1,137 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// Layout of the pipeline timeline, in SVG user units
+const (
+	timelineLabelWidth = 200
+	timelineChartWidth = 700
+	timelineRowHeight  = 22
+	timelineBarHeight  = 14
+	timelineAxisHeight = 20
+	timelineMaxTicks   = 8
+)
+
+// timelineTickSteps are the candidate intervals between time axis ticks, shortest first.
+var timelineTickSteps = []time.Duration{
+	10 * time.Second, 30 * time.Second, time.Minute, 2 * time.Minute, 5 * time.Minute,
+	10 * time.Minute, 15 * time.Minute, 30 * time.Minute, time.Hour, 2 * time.Hour,
+}
+
+// RenderPipelineTimeline renders the jobs of a pipeline as a Gantt chart: time waiting for a runner
+// and time running per job, with the chain of jobs that determined the pipeline's duration highlighted.
+// This renders only the fragment shown below the pipeline.
+func (r *HTMLRenderer) RenderPipelineTimeline(w io.Writer, timeline service.PipelineTimeline) error {
+	var sb strings.Builder
+
+	if len(timeline.Jobs) == 0 {
+		sb.WriteString(`<p class="test-results-empty">No job of this pipeline has started.</p>
+`)
+		_, err := w.Write([]byte(sb.String()))
+		return err
+	}
+
+	notStarted := ""
+	if timeline.NotStarted > 0 {
+		notStarted = fmt.Sprintf(" • %d jobs not started", timeline.NotStarted)
+	}
+	sb.WriteString(fmt.Sprintf(`<div class="graph-summary">Wall clock %s • critical chain: %s running, %s waiting for runners%s</div>
+`, formatDuration(timeline.End.Sub(timeline.Start)), formatDuration(timeline.CriticalRun), formatDuration(timeline.CriticalQueue), notStarted))
+
+	total := timeline.End.Sub(timeline.Start)
+	if total <= 0 {
+		total = time.Second
+	}
+	x := func(t time.Time) float64 {
+		return timelineLabelWidth + float64(t.Sub(timeline.Start))/float64(total)*timelineChartWidth
+	}
+
+	width := timelineLabelWidth + timelineChartWidth + 10
+	height := timelineAxisHeight + len(timeline.Jobs)*timelineRowHeight
+	sb.WriteString(fmt.Sprintf(`<div class="graph-scroll">
+<svg class="pipeline-timeline-svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="Pipeline timeline">
+`, width, height, width, height))
+
+	// Time axis: elapsed time since the first job was queued
+	step := timelineTickSteps[len(timelineTickSteps)-1]
+	for _, candidate := range timelineTickSteps {
+		if total/candidate < timelineMaxTicks {
+			step = candidate
+			break
+		}
+	}
+	for offset := time.Duration(0); offset <= total; offset += step {
+		tx := x(timeline.Start.Add(offset))
+		sb.WriteString(fmt.Sprintf(`	<line class="gantt-tick" x1="%.1f" y1="%d" x2="%.1f" y2="%d"/>
+	<text class="gantt-tick-label" x="%.1f" y="12">%s</text>
+`, tx, timelineAxisHeight-4, tx, height, tx+3, formatTimelineOffset(offset)))
+	}
+
+	for row, job := range timeline.Jobs {
+		writeTimelineJob(&sb, job, timelineAxisHeight+row*timelineRowHeight, x)
+	}
+
+	sb.WriteString(`</svg>
+</div>
+`)
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
+
+// writeTimelineJob writes the row of a job: its name, then its queue and run bars.
+func writeTimelineJob(sb *strings.Builder, job service.TimelineJob, y int, x func(time.Time) float64) {
+	build := job.Build
+	class := "gantt-row"
+	if job.Critical {
+		class += " critical"
+	}
+
+	name := escapeHTML(truncateGraphLabel(build.Name))
+	if build.WebURL != "" {
+		name = fmt.Sprintf(`<a href="%s" target="_blank" rel="noopener noreferrer">%s</a>`, escapeHTML(build.WebURL), name)
+	}
+
+	queued := build.StartedAt.Sub(job.QueuedAt)
+	ran := job.EndedAt.Sub(build.StartedAt)
+	barY := y + (timelineRowHeight-timelineBarHeight)/2
+
+	sb.WriteString(fmt.Sprintf(`	<g class="%s">
+		<title>%s (%s) • %s • waited %s, ran %s</title>
+		<text class="gantt-label" x="0" y="%d">%s</text>
+		<rect class="gantt-queue" x="%.1f" y="%d" width="%.1f" height="%d"/>
+		<rect class="gantt-run %s" x="%.1f" y="%d" width="%.1f" height="%d" rx="2"/>
+	</g>
+`, class,
+		escapeHTML(build.Name), escapeHTML(build.Stage), strings.ToUpper(string(build.Status)),
+		formatTestDuration(queued), formatTestDuration(ran),
+		y+timelineRowHeight/2+4, name,
+		x(job.QueuedAt), barY, x(build.StartedAt)-x(job.QueuedAt), timelineBarHeight,
+		strings.ToLower(string(build.Status)), x(build.StartedAt), barY, max(x(job.EndedAt)-x(build.StartedAt), 1), timelineBarHeight))
+}
+
+// formatTimelineOffset formats a time axis offset, e.g. "+90s" or "+12m".
+func formatTimelineOffset(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("+%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("+%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("+%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// pipelineTimelineButton renders the button showing the timeline of a pipeline's jobs.
+// The timeline is loaded into the pipeline-timeline element following the button's row.
+func pipelineTimelineButton(pipeline domain.Pipeline) string {
+	return fmt.Sprintf(`<button class="test-results-button" data-pipeline="%s" onclick="togglePipelineTimeline(this)">Timeline</button>`,
+		escapeHTML(pipeline.ID))
+}
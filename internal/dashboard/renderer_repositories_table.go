@@ -30,6 +30,8 @@ func (r *HTMLRenderer) RenderRepositoriesSkeleton(w io.Writer, userProfiles []do
 				<option value="">All Platforms</option>
 				<option value="gitlab">GitLab</option>
 				<option value="github">GitHub</option>
+				<option value="gitea">Gitea</option>
+				<option value="bitbucket">Bitbucket</option>
 			</select>
 			<select id="statusFilter" class="filter-select">
 				<option value="">All Statuses</option>
@@ -54,6 +56,7 @@ func (r *HTMLRenderer) RenderRepositoriesSkeleton(w io.Writer, userProfiles []do
 					<th style="text-align: center;">Platform</th>
 					<th style="text-align: center;">Role</th>
 					<th style="text-align: center;">Status</th>
+					<th style="text-align: center;">Coverage</th>
 					<th style="text-align: center;">Branches</th>
 					<th style="text-align: center;">MRs/PRs</th>
 					<th style="text-align: center;">Last Commit Author</th>
@@ -61,7 +64,7 @@ func (r *HTMLRenderer) RenderRepositoriesSkeleton(w io.Writer, userProfiles []do
 				</tr>
 			</thead>
 			<tbody id="repositories-tbody">
-				<tr><td colspan="8" class="loading-cell">Loading repositories...</td></tr>
+				<tr><td colspan="9" class="loading-cell">Loading repositories...</td></tr>
 			</tbody>
 		</table>
 	</div>
@@ -162,6 +165,12 @@ const repositoriesTablePageCSS = `
 	.platform-github {
 		background: #24292e;
 	}
+	.platform-gitea {
+		background: #609926;
+	}
+	.platform-bitbucket {
+		background: #0052cc;
+	}
 	.fork-badge {
 		padding: 2px 8px;
 		border-radius: 3px;
@@ -201,6 +210,9 @@ const repositoriesTablePageCSS = `
 	.status-cell {
 		text-align: center;
 	}
+	.status-badge.has-excerpt {
+		cursor: help;
+	}
 	.commit-cell {
 		text-align: right;
 	}
@@ -210,6 +222,20 @@ const repositoriesTablePageCSS = `
 	.count-cell {
 		text-align: center;
 	}
+	.coverage-cell {
+		text-align: center;
+		white-space: nowrap;
+	}
+	.coverage-delta {
+		font-size: 11px;
+		color: var(--text-secondary);
+	}
+	.coverage-delta.up {
+		color: var(--success-text);
+	}
+	.coverage-delta.down {
+		color: var(--failed-text);
+	}
 `
 
 func repositoriesTableScript() string {
@@ -339,23 +365,7 @@ func repositoriesTableScript() string {
 				loadedCount = allRepositories.length;
 				allLoaded = data.pagination ? !data.pagination.hasNext : true;
 
-				allRepositories.sort((a, b) => {
-					const aFav = isFavorite(a.Project.ID);
-					const bFav = isFavorite(b.Project.ID);
-					if (aFav && !bFav) return -1;
-					if (!aFav && bFav) return 1;
-
-					if (!a.DefaultBranch || !a.DefaultBranch.LastCommitDate) return 1;
-					if (!b.DefaultBranch || !b.DefaultBranch.LastCommitDate) return -1;
-
-					const dateA = new Date(a.DefaultBranch.LastCommitDate);
-					const dateB = new Date(b.DefaultBranch.LastCommitDate);
-
-					if (dateA.getFullYear() < 1970) return 1;
-					if (dateB.getFullYear() < 1970) return -1;
-
-					return dateB - dateA;
-				});
+				sortRepositories();
 
 				renderAllRepositories();
 
@@ -403,86 +413,155 @@ func repositoriesTableScript() string {
 			return '-';
 		}
 
+		// Favorites first, then most recent commit on the default branch
+		function sortRepositories() {
+			allRepositories.sort((a, b) => {
+				const aFav = isFavorite(a.Project.ID);
+				const bFav = isFavorite(b.Project.ID);
+				if (aFav && !bFav) return -1;
+				if (!aFav && bFav) return 1;
+
+				if (!a.DefaultBranch || !a.DefaultBranch.LastCommitDate) return 1;
+				if (!b.DefaultBranch || !b.DefaultBranch.LastCommitDate) return -1;
+
+				const dateA = new Date(a.DefaultBranch.LastCommitDate);
+				const dateB = new Date(b.DefaultBranch.LastCommitDate);
+
+				if (dateA.getFullYear() < 1970) return 1;
+				if (dateB.getFullYear() < 1970) return -1;
+
+				return dateB - dateA;
+			});
+		}
+
 		function renderAllRepositories() {
 			tbody.innerHTML = '';
 
 			allRepositories.forEach(repo => {
-				const row = document.createElement('tr');
-				row.className = 'filterable';
-				row.setAttribute('data-repository', repo.Project.Name);
-				row.setAttribute('data-platform', repo.Project.Platform);
-				row.setAttribute('data-is-fork', repo.Project.IsFork ? 'true' : 'false');
-
-				let status = 'none';
-				let statusDisplay = '<span class="status-badge canceled">NONE</span>';
-				if (repo.Pipeline) {
-					status = repo.Pipeline.Status;
-					statusDisplay = '<span class="status-badge ' + status + '">' + status.toUpperCase() + '</span>';
-				}
-				row.setAttribute('data-status', status);
+				tbody.appendChild(buildRepositoryRow(repo));
+			});
 
-				let lastCommit = '-';
-				if (repo.DefaultBranch && repo.DefaultBranch.LastCommitDate) {
-					const commitDate = new Date(repo.DefaultBranch.LastCommitDate);
-					lastCommit = formatTimeAgo(commitDate);
-				}
+			if (typeof applyFilters === 'function') {
+				applyFilters();
+			}
+		}
 
-				let committer = '-';
-				if (repo.DefaultBranch && repo.DefaultBranch.CommitAuthor) {
-					committer = escapeHtml(repo.DefaultBranch.CommitAuthor);
+		function buildRepositoryRow(repo) {
+			const row = document.createElement('tr');
+			row.className = 'filterable';
+			row.setAttribute('data-project-id', repo.Project.ID);
+			row.setAttribute('data-repository', repo.Project.Name);
+			row.setAttribute('data-platform', repo.Project.Platform);
+			row.setAttribute('data-is-fork', repo.Project.IsFork ? 'true' : 'false');
+
+			let status = 'none';
+			let statusDisplay = '<span class="status-badge canceled">NONE</span>';
+			if (repo.Pipeline) {
+				status = repo.Pipeline.Status;
+				statusDisplay = '<span class="status-badge ' + status + '">' + status.toUpperCase() + '</span>';
+			}
+			row.setAttribute('data-status', status);
+
+			// Coverage with its change since the previous default branch pipeline
+			let coverageDisplay = '-';
+			if (repo.Pipeline && repo.Pipeline.Coverage != null) {
+				coverageDisplay = repo.Pipeline.Coverage.toFixed(1) + '%';
+				const delta = repo.CoverageDelta;
+				if (delta != null && delta >= 0.05) {
+					coverageDisplay += ' <span class="coverage-delta up" title="Since the previous pipeline">▲ +' + delta.toFixed(1) + '</span>';
+				} else if (delta != null && delta <= -0.05) {
+					coverageDisplay += ' <span class="coverage-delta down" title="Since the previous pipeline">▼ ' + delta.toFixed(1) + '</span>';
 				}
+			}
 
-				const branchCount = repo.BranchCount || 0;
-				const openMRs = repo.OpenMRCount || 0;
-				const draftMRs = repo.DraftMRCount || 0;
+			let lastCommit = '-';
+			if (repo.DefaultBranch && repo.DefaultBranch.LastCommitDate) {
+				const commitDate = new Date(repo.DefaultBranch.LastCommitDate);
+				lastCommit = formatTimeAgo(commitDate);
+			}
 
-				// Display open MRs count with draft indication
-				let mrDisplay = '-';
-				if (openMRs > 0) {
-					if (draftMRs > 0) {
-						mrDisplay = openMRs + ' <span style="font-size: 11px; color: var(--text-secondary);">(' + draftMRs + ' draft)</span>';
-					} else {
-						mrDisplay = openMRs.toString();
-					}
+			let committer = '-';
+			if (repo.DefaultBranch && repo.DefaultBranch.CommitAuthor) {
+				committer = escapeHtml(repo.DefaultBranch.CommitAuthor);
+			}
+
+			const branchCount = repo.BranchCount || 0;
+			const openMRs = repo.OpenMRCount || 0;
+			const draftMRs = repo.DraftMRCount || 0;
+
+			// Display open MRs count with draft indication
+			let mrDisplay = '-';
+			if (openMRs > 0) {
+				if (draftMRs > 0) {
+					mrDisplay = openMRs + ' <span style="font-size: 11px; color: var(--text-secondary);">(' + draftMRs + ' draft)</span>';
+				} else {
+					mrDisplay = openMRs.toString();
 				}
+			}
 
-				const repoDetailLink = '/repository?id=' + encodeURIComponent(repo.Project.ID);
-				const platformLink = repo.Project.WebURL || '#';
-				const platformBadge = '<a href="' + platformLink + '" target="_blank" rel="noopener noreferrer" class="platform-badge platform-' + repo.Project.Platform + '" title="Open on ' + repo.Project.Platform + '">' + repo.Project.Platform + '</a>';
-				const forkBadge = repo.Project.IsFork ? '<span class="fork-badge" title="This is a forked repository">FORK</span>' : '';
-
-				const favIcon = isFavorite(repo.Project.ID) ? '★' : '☆';
-				const favClass = isFavorite(repo.Project.ID) ? 'favorite-star favorited' : 'favorite-star';
-				const favTitle = isFavorite(repo.Project.ID) ? 'Remove from favorites' : 'Add to favorites';
-
-				const roleName = getRoleName(repo.Project.Permissions);
-				const roleDisplay = '<span style="font-size: 12px; color: var(--text-secondary);">' + roleName + '</span>';
-
-				row.innerHTML = '<td>' +
-					'<span class="' + favClass + '" data-repo-id="' + escapeHtml(repo.Project.ID) + '" title="' + favTitle + '" style="cursor: pointer; margin-right: 8px;">' + favIcon + '</span>' +
-					'<a href="' + repoDetailLink + '" style="color: var(--text-primary); text-decoration: none; font-weight: 600;" class="repo-link"><strong>' + escapeHtml(repo.Project.Name) + '</strong></a> ' +
-					forkBadge +
-					'</td>' +
-					'<td class="platform-cell">' + platformBadge + '</td>' +
-					'<td class="count-cell">' + roleDisplay + '</td>' +
-					'<td class="status-cell">' + statusDisplay + '</td>' +
-					'<td class="count-cell">' + branchCount + '</td>' +
-					'<td class="count-cell">' + mrDisplay + '</td>' +
-					'<td class="committer-cell">' + committer + '</td>' +
-					'<td class="commit-cell">' + lastCommit + '</td>';
-
-				tbody.appendChild(row);
-			});
+			const repoDetailLink = '/repository?id=' + encodeURIComponent(repo.Project.ID);
+			const platformLink = repo.Project.WebURL || '#';
+			const platformBadge = '<a href="' + platformLink + '" target="_blank" rel="noopener noreferrer" class="platform-badge platform-' + repo.Project.Platform + '" title="Open on ' + repo.Project.Platform + '">' + repo.Project.Platform + '</a>';
+			const forkBadge = repo.Project.IsFork ? '<span class="fork-badge" title="This is a forked repository">FORK</span>' : '';
+
+			const favIcon = isFavorite(repo.Project.ID) ? '★' : '☆';
+			const favClass = isFavorite(repo.Project.ID) ? 'favorite-star favorited' : 'favorite-star';
+			const favTitle = isFavorite(repo.Project.ID) ? 'Remove from favorites' : 'Add to favorites';
+
+			const roleName = getRoleName(repo.Project.Permissions);
+			const roleDisplay = '<span style="font-size: 12px; color: var(--text-secondary);">' + roleName + '</span>';
+
+			row.innerHTML = '<td>' +
+				'<span class="' + favClass + '" data-repo-id="' + escapeHtml(repo.Project.ID) + '" title="' + favTitle + '" style="cursor: pointer; margin-right: 8px;">' + favIcon + '</span>' +
+				'<a href="' + repoDetailLink + '" style="color: var(--text-primary); text-decoration: none; font-weight: 600;" class="repo-link"><strong>' + escapeHtml(repo.Project.Name) + '</strong></a> ' +
+				forkBadge +
+				'</td>' +
+				'<td class="platform-cell">' + platformBadge + '</td>' +
+				'<td class="count-cell">' + roleDisplay + '</td>' +
+				'<td class="status-cell">' + statusDisplay + '</td>' +
+				'<td class="coverage-cell">' + coverageDisplay + '</td>' +
+				'<td class="count-cell">' + branchCount + '</td>' +
+				'<td class="count-cell">' + mrDisplay + '</td>' +
+				'<td class="committer-cell">' + committer + '</td>' +
+				'<td class="commit-cell">' + lastCommit + '</td>';
+
+			// Show why the pipeline failed on hover, when the job logs explained it
+			if (repo.Pipeline && repo.Pipeline.FailureExcerpt) {
+				const badge = row.querySelector('.status-cell .status-badge');
+				badge.title = repo.Pipeline.FailureExcerpt;
+				badge.classList.add('has-excerpt');
+			}
 
-			tbody.querySelectorAll('.favorite-star').forEach(star => {
-				star.addEventListener('click', (e) => {
-					e.preventDefault();
-					e.stopPropagation();
-					const repoId = star.getAttribute('data-repo-id');
-					toggleFavorite(repoId);
-				});
+			const star = row.querySelector('.favorite-star');
+			star.addEventListener('click', (e) => {
+				e.preventDefault();
+				e.stopPropagation();
+				toggleFavorite(star.getAttribute('data-repo-id'));
 			});
 
+			return row;
+		}
+
+		// Replace a single repository row in place (used by the change stream)
+		function patchRepository(repo) {
+			const index = allRepositories.findIndex(r => r.Project.ID === repo.Project.ID);
+			if (index === -1) {
+				allRepositories.push(repo);
+				sortRepositories();
+				renderAllRepositories();
+				return;
+			}
+			allRepositories[index] = repo;
+
+			const newRow = buildRepositoryRow(repo);
+			const oldRow = Array.from(tbody.querySelectorAll('tr.filterable'))
+				.find(row => row.getAttribute('data-project-id') === repo.Project.ID);
+			if (oldRow) {
+				tbody.replaceChild(newRow, oldRow);
+			} else {
+				tbody.appendChild(newRow);
+			}
+
 			if (typeof applyFilters === 'function') {
 				applyFilters();
 			}
@@ -521,106 +600,127 @@ func repositoriesTableScript() string {
 			return years + ' year' + (years !== 1 ? 's' : '') + ' ago';
 		}
 
-		// Auto-refresh functionality
-		if (REFRESH_INTERVAL_SECONDS > 0) {
-			setInterval(() => {
-				fetch('/api/repositories?limit=10000')
-					.then(response => {
-						if (!response.ok) {
-							throw new Error('Failed to fetch repositories');
-						}
-						return response.json();
-					})
-					.then(data => {
-						const newRepositories = data.repositories || [];
-						const newTotalCount = data.pagination ? data.pagination.total : newRepositories.length;
-						const newAllLoaded = data.pagination ? !data.pagination.hasNext : true;
-
-						// Create a map of current repositories by ID for quick lookup
-						const currentRepoMap = new Map();
-						allRepositories.forEach(repo => {
-							currentRepoMap.set(repo.Project.ID, repo);
-						});
-
-						// Create a map of new repositories by ID
-						const newRepoMap = new Map();
-						newRepositories.forEach(repo => {
-							newRepoMap.set(repo.Project.ID, repo);
-						});
-
-						// Find added and removed repositories
-						const addedRepos = newRepositories.filter(repo => !currentRepoMap.has(repo.Project.ID));
-						const removedRepoIDs = Array.from(currentRepoMap.keys()).filter(id => !newRepoMap.has(id));
-
-						// Update existing repositories and track changes
-						let hasChanges = false;
-						newRepositories.forEach(newRepo => {
-							const existingRepo = currentRepoMap.get(newRepo.Project.ID);
-							if (existingRepo) {
-								// Check if data has changed
-								if (JSON.stringify(existingRepo) !== JSON.stringify(newRepo)) {
-									hasChanges = true;
-									// Update the existing repo in allRepositories
-									const index = allRepositories.findIndex(r => r.Project.ID === newRepo.Project.ID);
-									if (index !== -1) {
-										allRepositories[index] = newRepo;
-									}
+		// Re-fetch the full repository list and re-render if anything changed
+		function refreshRepositories() {
+			fetch('/api/repositories?limit=10000')
+				.then(response => {
+					if (!response.ok) {
+						throw new Error('Failed to fetch repositories');
+					}
+					return response.json();
+				})
+				.then(data => {
+					const newRepositories = data.repositories || [];
+					const newTotalCount = data.pagination ? data.pagination.total : newRepositories.length;
+					const newAllLoaded = data.pagination ? !data.pagination.hasNext : true;
+
+					// Create a map of current repositories by ID for quick lookup
+					const currentRepoMap = new Map();
+					allRepositories.forEach(repo => {
+						currentRepoMap.set(repo.Project.ID, repo);
+					});
+
+					// Create a map of new repositories by ID
+					const newRepoMap = new Map();
+					newRepositories.forEach(repo => {
+						newRepoMap.set(repo.Project.ID, repo);
+					});
+
+					// Find added and removed repositories
+					const addedRepos = newRepositories.filter(repo => !currentRepoMap.has(repo.Project.ID));
+					const removedRepoIDs = Array.from(currentRepoMap.keys()).filter(id => !newRepoMap.has(id));
+
+					// Update existing repositories and track changes
+					let hasChanges = false;
+					newRepositories.forEach(newRepo => {
+						const existingRepo = currentRepoMap.get(newRepo.Project.ID);
+						if (existingRepo) {
+							// Check if data has changed
+							if (JSON.stringify(existingRepo) !== JSON.stringify(newRepo)) {
+								hasChanges = true;
+								// Update the existing repo in allRepositories
+								const index = allRepositories.findIndex(r => r.Project.ID === newRepo.Project.ID);
+								if (index !== -1) {
+									allRepositories[index] = newRepo;
 								}
 							}
-						});
-
-						// Add new repositories
-						if (addedRepos.length > 0) {
-							hasChanges = true;
-							allRepositories = allRepositories.concat(addedRepos);
 						}
+					});
 
-						// Remove deleted repositories
-						if (removedRepoIDs.length > 0) {
-							hasChanges = true;
-							allRepositories = allRepositories.filter(repo => !removedRepoIDs.includes(repo.Project.ID));
-						}
+					// Add new repositories
+					if (addedRepos.length > 0) {
+						hasChanges = true;
+						allRepositories = allRepositories.concat(addedRepos);
+					}
 
-						// Update the progress info with current count
-						totalCount = newTotalCount;
-						loadedCount = allRepositories.length;
-						allLoaded = newAllLoaded;
-
-						if (allLoaded) {
-							progressInfo.textContent = '✓ Loaded ' + loadedCount + ' repositories';
-							progressInfo.style.color = 'var(--status-success)';
-						} else {
-							progressInfo.textContent = loadedCount + '+ repositories (loading...)';
-							progressInfo.style.color = 'var(--text-secondary)';
-						}
+					// Remove deleted repositories
+					if (removedRepoIDs.length > 0) {
+						hasChanges = true;
+						allRepositories = allRepositories.filter(repo => !removedRepoIDs.includes(repo.Project.ID));
+					}
 
-						// Re-sort and re-render if there were any changes
-						if (hasChanges || addedRepos.length > 0 || removedRepoIDs.length > 0) {
-							allRepositories.sort((a, b) => {
-								const aFav = isFavorite(a.Project.ID);
-								const bFav = isFavorite(b.Project.ID);
-								if (aFav && !bFav) return -1;
-								if (!aFav && bFav) return 1;
+					// Update the progress info with current count
+					totalCount = newTotalCount;
+					loadedCount = allRepositories.length;
+					allLoaded = newAllLoaded;
 
-								if (!a.DefaultBranch || !a.DefaultBranch.LastCommitDate) return 1;
-								if (!b.DefaultBranch || !b.DefaultBranch.LastCommitDate) return -1;
+					if (allLoaded) {
+						progressInfo.textContent = '✓ Loaded ' + loadedCount + ' repositories';
+						progressInfo.style.color = 'var(--status-success)';
+					} else {
+						progressInfo.textContent = loadedCount + '+ repositories (loading...)';
+						progressInfo.style.color = 'var(--text-secondary)';
+					}
 
-								const dateA = new Date(a.DefaultBranch.LastCommitDate);
-								const dateB = new Date(b.DefaultBranch.LastCommitDate);
+					// Re-sort and re-render if there were any changes
+					if (hasChanges || addedRepos.length > 0 || removedRepoIDs.length > 0) {
+						sortRepositories();
+						renderAllRepositories();
+					}
+				})
+				.catch(error => {
+					console.error('Auto-refresh error:', error);
+				});
+		}
 
-								if (dateA.getFullYear() < 1970) return 1;
-								if (dateB.getFullYear() < 1970) return -1;
+		// Live updates: the change stream patches changed rows as they happen.
+		// Falls back to polling the full list while the stream is unavailable.
+		let pollTimer = null;
 
-								return dateB - dateA;
-							});
+		function startPolling() {
+			if (pollTimer === null && REFRESH_INTERVAL_SECONDS > 0) {
+				pollTimer = setInterval(refreshRepositories, REFRESH_INTERVAL_SECONDS * 1000);
+			}
+		}
 
-							renderAllRepositories();
-						}
-					})
-					.catch(error => {
-						console.error('Auto-refresh error:', error);
-					});
-			}, REFRESH_INTERVAL_SECONDS * 1000);
+		function stopPolling() {
+			if (pollTimer !== null) {
+				clearInterval(pollTimer);
+				pollTimer = null;
+			}
+		}
+
+		if (window.EventSource) {
+			const stream = new EventSource('/api/stream');
+			let streamConnected = false;
+
+			stream.addEventListener('repository', (e) => {
+				patchRepository(JSON.parse(e.data));
+			});
+			stream.addEventListener('reload', refreshRepositories);
+
+			stream.onopen = () => {
+				// Catch up on changes missed while disconnected
+				if (streamConnected) {
+					refreshRepositories();
+				}
+				streamConnected = true;
+				stopPolling();
+			};
+			// EventSource reconnects on its own - poll in the meantime
+			stream.onerror = startPolling;
+		} else {
+			startPolling();
 		}
 	</script>
 `
@@ -0,0 +1,117 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// maxRenderedTestFailures is the number of failed tests listed per pipeline.
+const maxRenderedTestFailures = 50
+
+// RenderTestResults renders the test results of a pipeline: a summary and its failed tests with their history.
+// This renders only the fragment shown below the pipeline on the repository detail page.
+func (r *HTMLRenderer) RenderTestResults(w io.Writer, results service.PipelineTestResults) error {
+	var sb strings.Builder
+
+	report := results.Report
+	if report == nil {
+		sb.WriteString(`<p class="test-results-empty">No test results for this pipeline. GitLab reads them from JUnit report artifacts; GitHub from uploaded artifacts whose name contains "test" or "junit".</p>
+`)
+		_, err := w.Write([]byte(sb.String()))
+		return err
+	}
+
+	var duration time.Duration
+	for _, suite := range report.Suites {
+		duration += suite.Duration
+	}
+	sb.WriteString(fmt.Sprintf(`<div class="test-summary">%d tests • <span class="test-failed-count">%d failed</span> • %d skipped • %s</div>
+`, report.Total(), len(report.FailedCases()), report.Count(domain.TestSkipped), formatTestDuration(duration)))
+
+	if len(results.Failures) == 0 {
+		sb.WriteString(`<p class="test-results-empty">All tests passed.</p>
+`)
+	}
+
+	for i, failure := range results.Failures {
+		if i == maxRenderedTestFailures {
+			sb.WriteString(fmt.Sprintf(`<p class="test-results-empty">and %d more failed tests</p>
+`, len(results.Failures)-maxRenderedTestFailures))
+			break
+		}
+		writeTestFailure(&sb, failure)
+	}
+
+	if !results.HistoryEnabled && len(results.Failures) > 0 {
+		sb.WriteString(`<p class="test-results-empty">Enable pipeline history (DATA_DIR) to tell new failures from long-standing ones.</p>
+`)
+	}
+
+	_, err := w.Write([]byte(sb.String()))
+	return err
+}
+
+// writeTestFailure writes a failed test with whether it is a new failure and how often it failed before.
+func writeTestFailure(sb *strings.Builder, failure service.TestFailure) {
+	tc := failure.Test
+
+	badge := ""
+	switch {
+	case failure.IsNew():
+		badge = `<span class="test-history new">NEW</span>`
+	case failure.FailingRuns > 1:
+		badge = fmt.Sprintf(`<span class="test-history long-standing">failing for %d runs, since %s</span>`,
+			failure.FailingRuns, formatTimeAgo(failure.FailingSince))
+	}
+
+	rate := ""
+	if failure.Runs > 1 {
+		rate = fmt.Sprintf(`<span class="test-rate">failed %d of the last %d runs</span>`, failure.Failures, failure.Runs)
+	}
+
+	status := "FAILED"
+	if tc.Status == domain.TestError {
+		status = "ERROR"
+	}
+
+	sb.WriteString(fmt.Sprintf(`<div class="test-failure">
+	<div class="test-failure-header">
+		<span class="status-badge failed">%s</span>
+		<span class="test-name" title="%s">%s</span>
+		%s
+		%s
+		<span class="test-suite">%s • %s</span>
+	</div>
+`, status, escapeHTML(tc.FullName()), escapeHTML(tc.Name), badge, rate,
+		escapeHTML(tc.ClassName), formatTestDuration(tc.Duration)))
+
+	if tc.Message != "" {
+		sb.WriteString(`	<pre class="failure-excerpt">` + escapeHTML(tc.Message) + `</pre>
+`)
+	}
+	sb.WriteString(`</div>
+`)
+}
+
+// formatTestDuration formats a test duration, keeping milliseconds for fast tests.
+func formatTestDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return formatDuration(d)
+}
+
+// testResultsButton renders the button showing the test results of a finished pipeline.
+// The results are loaded into the element following the button's row.
+func testResultsButton(pipeline domain.Pipeline) string {
+	if !pipeline.Status.IsTerminal() {
+		return ""
+	}
+	return fmt.Sprintf(`<button class="test-results-button" data-pipeline="%s" onclick="toggleTestResults(this)">Tests</button>`,
+		escapeHTML(pipeline.ID))
+}
@@ -0,0 +1,49 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// handleStats serves pipeline trends (success rate, p50/p95 duration, failure streaks) for a repository as JSON.
+// Query params: ?id=owner/repo or ?id=123, optional &branch=name (default: all branches, with per-branch trends)
+func (h *Handler) handleStats(w http.ResponseWriter, r *http.Request) {
+	repositoryID := r.URL.Query().Get("id")
+	if repositoryID == "" {
+		http.Error(w, "Missing repository id parameter", http.StatusBadRequest)
+		return
+	}
+
+	projects, err := h.pipelineService.GetAllProjects(r.Context())
+	if err != nil {
+		h.logger.Printf("[Stats] failed to get projects: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	var project *domain.Project
+	for i := range projects {
+		if projects[i].ID == repositoryID {
+			project = &projects[i]
+			break
+		}
+	}
+
+	if project == nil {
+		http.Error(w, "Repository not found", http.StatusNotFound)
+		return
+	}
+
+	stats := h.pipelineService.GetRepositoryStats(*project, r.URL.Query().Get("branch"))
+	if stats == nil {
+		http.Error(w, "Pipeline history not enabled", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		h.logger.Printf("[Stats] failed to encode response: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+const (
+	// StreamBatchInterval is how long changes are collected before rows are sent.
+	// Background refreshes update many cache keys in bursts; batching sends each row once per burst.
+	StreamBatchInterval = 1 * time.Second
+	// StreamHeartbeatInterval is how often a comment is sent to keep idle connections open through proxies
+	StreamHeartbeatInterval = 30 * time.Second
+)
+
+// handleStream serves repository table deltas as Server-Sent Events.
+// Events:
+//   - "repository": a changed row, same shape as the rows of /api/repositories
+//   - "reload": the project list changed, clients should re-fetch /api/repositories
+func (h *Handler) handleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // disable proxy buffering (nginx)
+
+	changes, unsubscribe := h.pipelineService.SubscribeRepositoryChanges()
+	defer unsubscribe()
+
+	// Tell the browser to wait 5 seconds before reconnecting
+	fmt.Fprint(w, "retry: 5000\n\n")
+	flusher.Flush()
+
+	batchTicker := time.NewTicker(StreamBatchInterval)
+	defer batchTicker.Stop()
+	heartbeatTicker := time.NewTicker(StreamHeartbeatInterval)
+	defer heartbeatTicker.Stop()
+
+	pending := make(map[string]bool) // platform:projectID -> changed
+	reload := false
+	lastSent := make(map[string]string) // platform:projectID -> last row JSON sent on this connection
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.stopStreams:
+			return
+
+		case change, ok := <-changes:
+			if !ok {
+				return
+			}
+			if change.ProjectID == "" {
+				reload = true
+			} else {
+				pending[change.Platform+":"+change.ProjectID] = true
+			}
+
+		case <-batchTicker.C:
+			if !reload && len(pending) == 0 {
+				continue
+			}
+			if err := h.writeStreamBatch(r.Context(), w, pending, reload, lastSent); err != nil {
+				h.logger.Printf("[Stream] Client disconnected: %v", err)
+				return
+			}
+			flusher.Flush()
+			pending = make(map[string]bool)
+			reload = false
+
+		case <-heartbeatTicker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeStreamBatch writes the events for a batch of changes.
+// Rows identical to the last one sent on this connection are skipped.
+func (h *Handler) writeStreamBatch(ctx context.Context, w http.ResponseWriter, pending map[string]bool, reload bool, lastSent map[string]string) error {
+	if reload {
+		if _, err := fmt.Fprint(w, "event: reload\ndata: {}\n\n"); err != nil {
+			return err
+		}
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, HTTPRequestTimeout)
+	defer cancel()
+
+	// Projects come from cache, so this doesn't cost API calls
+	projects, err := h.pipelineService.GetAllProjects(ctx)
+	if err != nil {
+		h.logger.Printf("[Stream] failed to get projects: %v", err)
+		return nil
+	}
+
+	projectsByKey := make(map[string]domain.Project, len(projects))
+	for _, project := range projects {
+		projectsByKey[project.Platform+":"+project.ID] = project
+	}
+
+	for key := range pending {
+		project, found := projectsByKey[key]
+		if !found {
+			continue // filtered out or not loaded yet
+		}
+
+		data, err := json.Marshal(h.buildRepositoryRow(ctx, project))
+		if err != nil {
+			h.logger.Printf("[Stream] failed to encode row for %s: %v", project.Name, err)
+			continue
+		}
+
+		row := string(data)
+		if lastSent[key] == row {
+			continue
+		}
+		lastSent[key] = row
+
+		if _, err := fmt.Fprintf(w, "event: repository\ndata: %s\n\n", row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/service"
+)
+
+// fakeStreamService is a test double for the pipeline service serving cached rows and a change stream.
+// Only the methods the stream uses are implemented; other methods panic if called.
+type fakeStreamService struct {
+	PipelineService
+	projects []domain.Project
+	pipeline *domain.Pipeline
+	changes  chan service.RepositoryChange
+}
+
+func (f *fakeStreamService) GetAllProjects(ctx context.Context) ([]domain.Project, error) {
+	return f.projects, nil
+}
+
+func (f *fakeStreamService) GetDefaultBranchForProject(ctx context.Context, project domain.Project) (*domain.Branch, *domain.Pipeline, int, error) {
+	return &domain.Branch{Name: "main"}, f.pipeline, 0, nil
+}
+
+func (f *fakeStreamService) GetMergeRequestsForProject(ctx context.Context, project domain.Project) ([]domain.MergeRequest, error) {
+	return nil, nil
+}
+
+func (f *fakeStreamService) GetCoverageDelta(project domain.Project, pipeline *domain.Pipeline) *float64 {
+	return nil
+}
+
+func (f *fakeStreamService) SubscribeRepositoryChanges() (<-chan service.RepositoryChange, func()) {
+	return f.changes, func() {}
+}
+
+// TestWriteStreamBatch tests the events written for a batch of changes.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestWriteStreamBatch(t *testing.T) {
+	// Arrange
+	fake := &fakeStreamService{
+		projects: []domain.Project{{ID: "123", Name: "api", Platform: "gitlab"}},
+		pipeline: &domain.Pipeline{ID: "9", Status: domain.StatusSuccess},
+	}
+	h := NewHandler(HandlerConfig{Logger: nopLogger{}, PipelineService: fake})
+	lastSent := make(map[string]string)
+	pending := map[string]bool{"gitlab:123": true, "gitlab:unknown": true}
+
+	// Act
+	first := httptest.NewRecorder()
+	firstErr := h.writeStreamBatch(context.Background(), first, pending, true, lastSent)
+	unchanged := httptest.NewRecorder()
+	unchangedErr := h.writeStreamBatch(context.Background(), unchanged, pending, false, lastSent)
+	fake.pipeline = &domain.Pipeline{ID: "10", Status: domain.StatusFailed}
+	changed := httptest.NewRecorder()
+	changedErr := h.writeStreamBatch(context.Background(), changed, pending, false, lastSent)
+
+	// Assert
+	if firstErr != nil || unchangedErr != nil || changedErr != nil {
+		t.Fatalf("expected no errors, got %v, %v, %v", firstErr, unchangedErr, changedErr)
+	}
+	body := first.Body.String()
+	if !strings.HasPrefix(body, "event: reload\ndata: {}\n\n") {
+		t.Errorf("expected a reload event first, got %q", body)
+	}
+	if strings.Count(body, "event: repository\n") != 1 || !strings.Contains(body, `"ID":"123"`) {
+		t.Errorf("expected one row for the known project, got %q", body)
+	}
+	if unchanged.Body.Len() != 0 {
+		t.Errorf("expected unchanged rows to be skipped, got %q", unchanged.Body.String())
+	}
+	if !strings.Contains(changed.Body.String(), `"ID":"10"`) {
+		t.Errorf("expected the changed row to be sent again, got %q", changed.Body.String())
+	}
+}
+
+// TestHandleStream tests the event stream headers and that it ends when the handler stops.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestHandleStream(t *testing.T) {
+	// Arrange
+	fake := &fakeStreamService{changes: make(chan service.RepositoryChange)}
+	h := NewHandler(HandlerConfig{Logger: nopLogger{}, PipelineService: fake})
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+
+	// Act
+	go func() {
+		h.handleStream(rec, httptest.NewRequest(http.MethodGet, "/api/stream", nil))
+		close(done)
+	}()
+	h.Stop()
+	<-done
+
+	// Assert
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("expected text/event-stream, got %q", got)
+	}
+	if !strings.HasPrefix(rec.Body.String(), "retry: 5000\n\n") {
+		t.Errorf("expected the reconnect delay first, got %q", rec.Body.String())
+	}
+}
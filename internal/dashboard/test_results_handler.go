@@ -0,0 +1,11 @@
+package dashboard
+
+import (
+	"net/http"
+)
+
+// handleTestResults serves the test results of a finished pipeline as an HTML fragment in JSON.
+// Query params: id (repository) and pipeline.
+func (h *Handler) handleTestResults(w http.ResponseWriter, r *http.Request) {
+	serveFragment(h, w, r, "TestResults", h.pipelineService.GetPipelineTestResults, h.renderer.RenderTestResults)
+}
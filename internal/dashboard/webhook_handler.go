@@ -0,0 +1,123 @@
+package dashboard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MaxWebhookPayloadSize is the largest webhook payload accepted (GitHub caps payloads at 25 MB)
+const MaxWebhookPayloadSize = 25 << 20
+
+// Webhook is the webhook endpoint of a connection.
+type Webhook struct {
+	Platform string // gitlab or github, selects how requests are authenticated
+	Secret   string // empty = endpoint disabled
+}
+
+// handleWebhook receives webhooks at /api/webhooks/{connection} and applies them to that connection's cache.
+// GitLab requests are authenticated with the secret token sent in X-Gitlab-Token,
+// GitHub requests with the HMAC-SHA256 signature sent in X-Hub-Signature-256.
+func (h *Handler) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	connection := r.PathValue("connection")
+	webhook := h.webhooks[connection]
+	if !h.checkWebhookRequest(w, r, webhook.Secret) {
+		return
+	}
+
+	switch webhook.Platform {
+	case "gitlab":
+		token := r.Header.Get("X-Gitlab-Token")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(webhook.Secret)) != 1 {
+			h.logger.Printf("[Webhook] Rejected %s webhook from %s: invalid token", connection, r.RemoteAddr)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		payload, ok := h.readWebhookPayload(w, r)
+		if !ok {
+			return
+		}
+		h.applyWebhook(w, r, connection, r.Header.Get("X-Gitlab-Event"), payload)
+
+	case "github":
+		payload, ok := h.readWebhookPayload(w, r)
+		if !ok {
+			return
+		}
+
+		if !validGitHubSignature(payload, r.Header.Get("X-Hub-Signature-256"), webhook.Secret) {
+			h.logger.Printf("[Webhook] Rejected %s webhook from %s: invalid signature", connection, r.RemoteAddr)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.applyWebhook(w, r, connection, r.Header.Get("X-GitHub-Event"), payload)
+
+	default:
+		http.Error(w, "Webhook not configured", http.StatusNotFound)
+	}
+}
+
+// checkWebhookRequest rejects non-POST requests and requests to endpoints without a configured secret.
+// Webhooks fail closed: an endpoint without a secret is disabled.
+func (h *Handler) checkWebhookRequest(w http.ResponseWriter, r *http.Request, secret string) bool {
+	if secret == "" {
+		http.Error(w, "Webhook not configured", http.StatusNotFound)
+		return false
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+
+	return true
+}
+
+// readWebhookPayload reads the request body, enforcing MaxWebhookPayloadSize.
+func (h *Handler) readWebhookPayload(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxWebhookPayloadSize))
+	if err != nil {
+		h.logger.Printf("[Webhook] Failed to read payload: %v", err)
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return nil, false
+	}
+	return payload, true
+}
+
+// applyWebhook hands an authenticated payload to the pipeline service and reports how many events were applied.
+func (h *Handler) applyWebhook(w http.ResponseWriter, r *http.Request, connection, eventType string, payload []byte) {
+	applied, err := h.pipelineService.HandleWebhook(r.Context(), connection, eventType, payload)
+	if err != nil {
+		h.logger.Printf("[Webhook] Failed to handle %s %q webhook: %v", connection, eventType, err)
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Printf("[Webhook] %s %q: applied %d event(s)", connection, eventType, applied)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"applied": applied})
+}
+
+// validGitHubSignature checks a "sha256=<hex>" signature against the HMAC-SHA256 of the payload.
+func validGitHubSignature(payload []byte, signature, secret string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,146 @@
+package dashboard
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeWebhookService is a test double for the pipeline service that records applied webhooks.
+// Only HandleWebhook is implemented; other methods panic if called.
+type fakeWebhookService struct {
+	PipelineService
+	connection string
+	payload    string
+}
+
+func (f *fakeWebhookService) HandleWebhook(ctx context.Context, connection, eventType string, payload []byte) (int, error) {
+	f.connection, f.payload = connection, string(payload)
+	return 1, nil
+}
+
+// nopLogger discards log output.
+type nopLogger struct{}
+
+func (nopLogger) Printf(format string, v ...interface{}) {}
+
+// sign returns the X-Hub-Signature-256 value of a payload.
+func sign(payload, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// TestValidGitHubSignature tests the HMAC check of GitHub webhook signatures.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestValidGitHubSignature(t *testing.T) {
+	payload := `{"action": "completed"}`
+	valid := sign(payload, "secret")
+
+	tests := []struct {
+		name      string
+		signature string
+		expected  bool
+	}{
+		{"valid", valid, true},
+		{"missing prefix", strings.TrimPrefix(valid, "sha256="), false},
+		{"sha1 prefix", "sha1=" + strings.TrimPrefix(valid, "sha256="), false},
+		{"bad hex", "sha256=not-hex", false},
+		{"wrong MAC", sign(payload, "other-secret"), false},
+		{"truncated MAC", valid[:len(valid)-2], false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := validGitHubSignature([]byte(payload), tt.signature, "secret")
+
+			// Assert
+			if got != tt.expected {
+				t.Errorf("expected %v for signature %q, got %v", tt.expected, tt.signature, got)
+			}
+		})
+	}
+}
+
+// TestHandleWebhook tests authentication and request checks of the per-connection webhook endpoints.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestHandleWebhook(t *testing.T) {
+	payload := `{"object_kind": "pipeline"}`
+	oversize := strings.Repeat("x", MaxWebhookPayloadSize+1)
+	webhooks := map[string]Webhook{
+		"gitlab":          {Platform: "gitlab", Secret: "secret"},
+		"github":          {Platform: "github", Secret: "secret"},
+		"gitlab-internal": {Platform: "gitlab", Secret: "internal-secret"},
+		"ghe":             {Platform: "github", Secret: "ghe-secret"},
+		"disabled":        {Platform: "gitlab"},
+	}
+
+	tests := []struct {
+		name           string
+		connection     string
+		method         string
+		body           string
+		headers        map[string]string
+		expectedStatus int
+		expectApplied  bool
+	}{
+		{"gitlab valid token", "gitlab", http.MethodPost, payload, map[string]string{"X-Gitlab-Token": "secret"}, http.StatusOK, true},
+		{"gitlab no secret", "disabled", http.MethodPost, payload, map[string]string{"X-Gitlab-Token": ""}, http.StatusNotFound, false},
+		{"unknown connection", "gitea", http.MethodPost, payload, map[string]string{"X-Gitlab-Token": ""}, http.StatusNotFound, false},
+		{"gitlab wrong token", "gitlab", http.MethodPost, payload, map[string]string{"X-Gitlab-Token": "guess"}, http.StatusUnauthorized, false},
+		{"gitlab missing token", "gitlab", http.MethodPost, payload, nil, http.StatusUnauthorized, false},
+		{"gitlab GET", "gitlab", http.MethodGet, "", map[string]string{"X-Gitlab-Token": "secret"}, http.StatusMethodNotAllowed, false},
+		{"gitlab oversize body", "gitlab", http.MethodPost, oversize, map[string]string{"X-Gitlab-Token": "secret"}, http.StatusBadRequest, false},
+		{"named gitlab connection", "gitlab-internal", http.MethodPost, payload, map[string]string{"X-Gitlab-Token": "internal-secret"}, http.StatusOK, true},
+		{"named gitlab connection with default secret", "gitlab-internal", http.MethodPost, payload, map[string]string{"X-Gitlab-Token": "secret"}, http.StatusUnauthorized, false},
+		{"github valid signature", "github", http.MethodPost, payload, map[string]string{"X-Hub-Signature-256": sign(payload, "secret")}, http.StatusOK, true},
+		{"github wrong signature", "github", http.MethodPost, payload, map[string]string{"X-Hub-Signature-256": sign(payload, "guess")}, http.StatusUnauthorized, false},
+		{"github PUT", "github", http.MethodPut, payload, map[string]string{"X-Hub-Signature-256": sign(payload, "secret")}, http.StatusMethodNotAllowed, false},
+		{"github oversize body", "github", http.MethodPost, oversize, map[string]string{"X-Hub-Signature-256": sign(oversize, "secret")}, http.StatusBadRequest, false},
+		{"named github connection", "ghe", http.MethodPost, payload, map[string]string{"X-Hub-Signature-256": sign(payload, "ghe-secret")}, http.StatusOK, true},
+		{"named github connection with default secret", "ghe", http.MethodPost, payload, map[string]string{"X-Hub-Signature-256": sign(payload, "secret")}, http.StatusUnauthorized, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := &fakeWebhookService{}
+			h := NewHandler(HandlerConfig{
+				Logger:          nopLogger{},
+				PipelineService: service,
+				Webhooks:        webhooks,
+			})
+
+			req := httptest.NewRequest(tt.method, "/api/webhooks/"+tt.connection, strings.NewReader(tt.body))
+			req.SetPathValue("connection", tt.connection)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+
+			// Act
+			h.handleWebhook(rec, req)
+
+			// Assert
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if applied := service.connection != ""; applied != tt.expectApplied {
+				t.Errorf("expected webhook applied = %v, got %v", tt.expectApplied, applied)
+			}
+			if tt.expectApplied && (service.connection != tt.connection || service.payload != tt.body) {
+				t.Errorf("expected the %s payload to be applied, got %s %q", tt.connection, service.connection, service.payload)
+			}
+			if tt.expectedStatus == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != http.MethodPost {
+				t.Errorf("expected Allow: POST, got %q", rec.Header().Get("Allow"))
+			}
+		})
+	}
+}
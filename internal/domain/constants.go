@@ -6,4 +6,8 @@ const (
 	PlatformGitLab = "gitlab"
 	// PlatformGitHub represents the GitHub Actions platform
 	PlatformGitHub = "github"
+	// PlatformGitea represents Gitea and Forgejo with Gitea Actions
+	PlatformGitea = "gitea"
+	// PlatformBitbucket represents Bitbucket Cloud with Bitbucket Pipelines
+	PlatformBitbucket = "bitbucket"
 )
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Environment represents a deployment target of a project, e.g. staging or production.
+type Environment struct {
+	Name           string
+	ProjectID      string
+	Repository     string
+	URL            string      // URL of the deployed application (optional)
+	LastDeployment *Deployment // nil = nothing deployed yet
+}
+
+// Deployment represents a deployment of a commit to an environment.
+type Deployment struct {
+	ID          string
+	ProjectID   string
+	Environment string
+	Ref         string // branch or tag that was deployed
+	CommitSHA   string
+	Status      Status
+	Author      string // user who triggered the deployment
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	WebURL      string // deployment job or log
+	Production  bool   // deployed to a production environment
+}
+
+// IsProductionEnvironment returns true if an environment's name marks it as production,
+// e.g. "production", "prod", "live" or "production-eu".
+func IsProductionEnvironment(name string) bool {
+	name = strings.ToLower(name)
+	for _, prefix := range []string{"production", "prod", "live"} {
+		rest, found := strings.CutPrefix(name, prefix)
+		if found && (rest == "" || strings.ContainsAny(rest[:1], "-_/. ")) {
+			return true
+		}
+	}
+	return false
+}
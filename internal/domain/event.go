@@ -13,4 +13,10 @@ type Event struct {
 	CreatedAt  time.Time // When the event occurred
 	Author     string    // Username who triggered the event
 	ActionName string    // Specific action: "created", "updated", "closed", "merged", etc.
+
+	// Optional snapshots of the target's new state (set by webhook payloads).
+	// When present, caches can be updated directly without re-fetching from the API.
+	Pipeline     *Pipeline
+	Branch       *Branch
+	MergeRequest *MergeRequest
 }
@@ -16,6 +16,7 @@ type MergeRequest struct {
 	Reviewers    []string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	MergedAt     time.Time // zero unless merged
 	WebURL       string
 	ProjectID    string
 	Repository   string
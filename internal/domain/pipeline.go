@@ -9,6 +9,7 @@ type Pipeline struct {
 	ProjectID  string
 	Repository string
 	Branch     string
+	CommitSHA  string // commit the pipeline ran on
 	Status     Status
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
@@ -16,6 +17,12 @@ type Pipeline struct {
 	WebURL     string
 	Builds     []Build
 
+	// Why the pipeline failed, extracted from the logs of its failed jobs (empty until extracted)
+	FailureExcerpt string
+
+	// Percentage of lines covered by tests (nil = not reported)
+	Coverage *float64
+
 	// Optional workflow fields for GitHub Actions (nil for GitLab)
 	WorkflowName *string
 	WorkflowID   *string
@@ -30,6 +37,53 @@ type Build struct {
 	Duration  time.Duration
 	StartedAt time.Time
 	WebURL    string
+	Retried   bool          // superseded by a later attempt of the same job in this pipeline
+	Queued    time.Duration // time the job waited for a runner before StartedAt
+	Coverage  *float64      // percentage of lines covered, parsed from the job's log by GitLab (nil = not reported)
+	Needs     []string      // names of the jobs this job waits for (nil = every job of the previous stage)
+}
+
+// FailedBuilds returns the builds of the pipeline that failed.
+// Retried attempts are ignored - only the final attempt of each job counts.
+func (p *Pipeline) FailedBuilds() []Build {
+	var failed []Build
+	for _, build := range p.Builds {
+		if build.Status == StatusFailed && !build.Retried {
+			failed = append(failed, build)
+		}
+	}
+	return failed
+}
+
+// JobCoverage returns the average coverage of the builds that report it, as GitLab computes a pipeline's
+// coverage. Retried attempts are ignored. Returns nil if no build reports coverage.
+func (p *Pipeline) JobCoverage() *float64 {
+	sum, count := 0.0, 0
+	for _, build := range p.Builds {
+		if build.Coverage != nil && !build.Retried {
+			sum += *build.Coverage
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+
+	coverage := sum / float64(count)
+	return &coverage
+}
+
+// MarkRetriedBuilds flags every build superseded by a later build with the same stage and name.
+// Builds must be in execution order.
+func MarkRetriedBuilds(builds []Build) {
+	latest := make(map[string]int, len(builds))
+	for i, build := range builds {
+		key := build.Stage + "\x00" + build.Name
+		if prev, exists := latest[key]; exists {
+			builds[prev].Retried = true
+		}
+		latest[key] = i
+	}
 }
 
 // Status represents the state of a pipeline or build.
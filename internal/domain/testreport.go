@@ -0,0 +1,85 @@
+package domain
+
+import "time"
+
+// TestReport holds the test results of a pipeline, from the platform's test report or JUnit XML artifacts.
+type TestReport struct {
+	ProjectID  string
+	PipelineID string
+	Suites     []TestSuite
+}
+
+// TestSuite represents a group of test cases, e.g. a JUnit <testsuite>.
+type TestSuite struct {
+	Name     string
+	Duration time.Duration
+	Cases    []TestCase
+}
+
+// TestCase represents the result of a single test.
+type TestCase struct {
+	Name      string
+	ClassName string // class, package or file the test belongs to (optional)
+	Suite     string
+	Status    TestStatus
+	Duration  time.Duration
+	Message   string // failure or error message, with the start of its details
+}
+
+// TestStatus represents the outcome of a test case.
+type TestStatus string
+
+const (
+	TestPassed  TestStatus = "passed"
+	TestFailed  TestStatus = "failed"
+	TestError   TestStatus = "error" // the test crashed or could not run, as opposed to a failed assertion
+	TestSkipped TestStatus = "skipped"
+)
+
+// IsFailure returns true if the test failed or errored.
+func (s TestStatus) IsFailure() bool {
+	return s == TestFailed || s == TestError
+}
+
+// FullName returns the name identifying a test across pipelines: its class name and name.
+func (c TestCase) FullName() string {
+	if c.ClassName == "" {
+		return c.Name
+	}
+	return c.ClassName + "." + c.Name
+}
+
+// Total returns the number of test cases in the report.
+func (r *TestReport) Total() int {
+	total := 0
+	for _, suite := range r.Suites {
+		total += len(suite.Cases)
+	}
+	return total
+}
+
+// Count returns the number of test cases with the given status.
+func (r *TestReport) Count(status TestStatus) int {
+	count := 0
+	for _, suite := range r.Suites {
+		for _, tc := range suite.Cases {
+			if tc.Status == status {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// FailedCases returns the test cases that failed or errored, in report order.
+func (r *TestReport) FailedCases() []TestCase {
+	var failed []TestCase
+	for _, suite := range r.Suites {
+		for _, tc := range suite.Cases {
+			if tc.Status.IsFailure() {
+				failed = append(failed, tc)
+			}
+		}
+	}
+	return failed
+}
@@ -0,0 +1,95 @@
+package history
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// StatsWindowDays are the periods (in days) trends are reported over.
+var StatsWindowDays = []int{7, 30, 90}
+
+// Stats summarizes the finished pipelines of a repository or branch over a time window.
+type Stats struct {
+	WindowDays           int     `json:"window_days"`
+	Total                int     `json:"total"`
+	Succeeded            int     `json:"succeeded"`
+	Failed               int     `json:"failed"`
+	Canceled             int     `json:"canceled"`
+	SuccessRate          float64 `json:"success_rate"`         // percent of succeeded out of succeeded + failed
+	P50DurationSeconds   float64 `json:"p50_duration_seconds"` // median duration of succeeded and failed runs
+	P95DurationSeconds   float64 `json:"p95_duration_seconds"`
+	CurrentFailureStreak int     `json:"current_failure_streak"` // consecutive failures up to the latest run
+	LongestFailureStreak int     `json:"longest_failure_streak"`
+}
+
+// ComputeStats summarizes records for a window. Records must be ordered oldest first.
+// Canceled runs are counted but neither break nor extend failure streaks.
+func ComputeStats(windowDays int, records []Record) Stats {
+	stats := Stats{WindowDays: windowDays, Total: len(records)}
+
+	var durations []float64
+	streak := 0
+	for _, r := range records {
+		switch r.Status {
+		case domain.StatusSuccess:
+			stats.Succeeded++
+			streak = 0
+		case domain.StatusFailed:
+			stats.Failed++
+			streak++
+			if streak > stats.LongestFailureStreak {
+				stats.LongestFailureStreak = streak
+			}
+		case domain.StatusCanceled:
+			stats.Canceled++
+			continue
+		}
+
+		if r.DurationSeconds > 0 {
+			durations = append(durations, r.DurationSeconds)
+		}
+	}
+	stats.CurrentFailureStreak = streak
+
+	if finished := stats.Succeeded + stats.Failed; finished > 0 {
+		stats.SuccessRate = float64(stats.Succeeded) / float64(finished) * 100
+	}
+
+	sort.Float64s(durations)
+	stats.P50DurationSeconds = percentile(durations, 50)
+	stats.P95DurationSeconds = percentile(durations, 95)
+
+	return stats
+}
+
+// Stats computes stats for a project over every window in StatsWindowDays.
+// An empty branch covers all branches.
+func (s *Store) Stats(platform, projectID, branch string, now time.Time) []Stats {
+	longest := StatsWindowDays[len(StatsWindowDays)-1]
+	records := s.Records(platform, projectID, branch, now.AddDate(0, 0, -longest))
+
+	result := make([]Stats, 0, len(StatsWindowDays))
+	for _, days := range StatsWindowDays {
+		since := now.AddDate(0, 0, -days)
+		start := sort.Search(len(records), func(i int) bool {
+			return !records[i].CreatedAt.Before(since)
+		})
+		result = append(result, ComputeStats(days, records[start:]))
+	}
+	return result
+}
+
+// percentile returns the nearest-rank percentile of sorted values (0 when empty).
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
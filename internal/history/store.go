@@ -0,0 +1,461 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// FileName is the history file name inside the data directory.
+const FileName = "pipeline-history.jsonl"
+
+// MaxRecordLineSize is the longest history line accepted when loading the store.
+const MaxRecordLineSize = 1 << 20
+
+// compactionInterval is how often in-memory records past the retention period are dropped.
+const compactionInterval = 24 * time.Hour
+
+// Record is a finished pipeline as stored in the history file (one JSON object per line).
+type Record struct {
+	Platform        string        `json:"platform"`
+	ProjectID       string        `json:"project_id"`
+	PipelineID      string        `json:"pipeline_id"`
+	Branch          string        `json:"branch"`
+	CommitSHA       string        `json:"commit_sha,omitempty"`
+	Status          domain.Status `json:"status"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	DurationSeconds float64       `json:"duration_seconds"`
+	Jobs            []JobRecord   `json:"jobs,omitempty"`     // empty when the pipeline was observed without jobs
+	Tests           *TestRecord   `json:"tests,omitempty"`    // nil when the pipeline's test results weren't read
+	Coverage        *float64      `json:"coverage,omitempty"` // percentage of lines covered, nil when not reported
+}
+
+// TestRecord is the outcome of the tests of a recorded pipeline.
+type TestRecord struct {
+	Total  int      `json:"total"`
+	Failed []string `json:"failed,omitempty"` // full names of the failed tests
+}
+
+// JobRecord is the outcome of a single job attempt within a recorded pipeline.
+type JobRecord struct {
+	Name    string        `json:"name"`
+	Stage   string        `json:"stage,omitempty"`
+	Status  domain.Status `json:"status"`
+	Retried bool          `json:"retried,omitempty"`
+}
+
+// Duration returns the pipeline duration.
+func (r Record) Duration() time.Duration {
+	return time.Duration(r.DurationSeconds * float64(time.Second))
+}
+
+// sameAs reports whether two records describe the same pipeline state.
+func (r Record) sameAs(other Record) bool {
+	return r.key() == other.key() &&
+		r.Branch == other.Branch &&
+		r.CommitSHA == other.CommitSHA &&
+		r.Status == other.Status &&
+		r.UpdatedAt.Equal(other.UpdatedAt) &&
+		r.DurationSeconds == other.DurationSeconds &&
+		len(r.Jobs) == len(other.Jobs) &&
+		(r.Tests == nil) == (other.Tests == nil) &&
+		(r.Coverage == nil) == (other.Coverage == nil)
+}
+
+// key identifies a pipeline across platforms and projects.
+func (r Record) key() string {
+	return r.Platform + "|" + r.ProjectID + "|" + r.PipelineID
+}
+
+// projectKey identifies a project across platforms.
+func projectKey(platform, projectID string) string {
+	return platform + "|" + projectID
+}
+
+// branchKey identifies a branch of a project across platforms.
+func branchKey(platform, projectID, branch string) string {
+	return platform + "|" + projectID + "|" + branch
+}
+
+// Store is an append-only pipeline history backed by a JSON Lines file.
+// Every change is appended; on open, later lines for the same pipeline replace earlier ones
+// and records past the retention period are dropped, rewriting the file when anything was dropped.
+// Follows Single Responsibility Principle - only persists and queries pipeline history.
+type Store struct {
+	path          string
+	retention     time.Duration
+	records       []Record         // ordered by CreatedAt (oldest first) after load; appended afterwards
+	index         map[string]int   // record key -> position in records
+	projects      map[string][]int // project key -> positions of the project's records
+	branches      map[string][]int // branch key -> positions of the branch's records
+	lastCompacted time.Time
+	mu            sync.RWMutex
+}
+
+// Open loads the history file at path, creating its directory if needed.
+// A missing file yields an empty store. Records older than retention are discarded.
+func Open(path string, retention time.Duration) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	s := &Store{
+		path:      path,
+		retention: retention,
+		index:     make(map[string]int),
+		projects:  make(map[string][]int),
+		branches:  make(map[string][]int),
+	}
+
+	lines, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	dropped := s.compactLocked(time.Now())
+	if dropped > 0 || lines != len(s.records) {
+		if err := s.rewriteLocked(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// load reads every record from the history file, later lines replacing earlier ones.
+// Returns the number of lines read.
+func (s *Store) load() (int, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxRecordLineSize)
+
+	lines := 0
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		lines++
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// A crash mid-append can leave a partial last line - skip it, the rewrite drops it
+			continue
+		}
+		s.put(record)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	sort.SliceStable(s.records, func(i, j int) bool {
+		return s.records[i].CreatedAt.Before(s.records[j].CreatedAt)
+	})
+	s.reindex()
+
+	return lines, nil
+}
+
+// Add records the finished pipelines of a platform and appends new or changed ones to the file.
+// Pipelines that haven't finished are skipped - they are recorded once they reach a terminal status.
+// Returns the number of records written.
+func (s *Store) Add(platform string, pipelines []domain.Pipeline) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-s.retention)
+
+	var changed []Record
+	for _, p := range pipelines {
+		if !p.Status.IsTerminal() || p.CreatedAt.Before(cutoff) {
+			continue
+		}
+
+		record := Record{
+			Platform:        platform,
+			ProjectID:       p.ProjectID,
+			PipelineID:      p.ID,
+			Branch:          p.Branch,
+			CommitSHA:       p.CommitSHA,
+			Status:          p.Status,
+			CreatedAt:       p.CreatedAt,
+			UpdatedAt:       p.UpdatedAt,
+			DurationSeconds: p.Duration.Seconds(),
+			Jobs:            jobRecords(p.Builds),
+			Coverage:        p.Coverage,
+		}
+
+		if i, exists := s.index[record.key()]; exists {
+			// Pipeline lists don't carry jobs - keep the jobs seen earlier
+			if len(record.Jobs) == 0 {
+				record.Jobs = s.records[i].Jobs
+			}
+			record.Tests = s.records[i].Tests
+			if record.CommitSHA == "" {
+				record.CommitSHA = s.records[i].CommitSHA
+			}
+			// Coverage is only known for the latest pipeline of a branch - keep it once seen
+			if record.Coverage == nil {
+				record.Coverage = s.records[i].Coverage
+			}
+			if s.records[i].sameAs(record) {
+				continue
+			}
+		}
+
+		s.put(record)
+		changed = append(changed, record)
+	}
+
+	if now.Sub(s.lastCompacted) >= compactionInterval && s.compactLocked(now) > 0 {
+		// Compaction rewrites the whole file, including the records added above
+		if err := s.rewriteLocked(); err != nil {
+			return 0, err
+		}
+		return len(changed), nil
+	}
+
+	if len(changed) == 0 {
+		return 0, nil
+	}
+
+	if err := s.appendLocked(changed); err != nil {
+		return 0, err
+	}
+	return len(changed), nil
+}
+
+// SetTestResults records the test results of a pipeline that is already in the history.
+// Returns false if the pipeline isn't recorded or already has test results.
+func (s *Store) SetTestResults(platform, projectID, pipelineID string, tests TestRecord) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i, exists := s.index[Record{Platform: platform, ProjectID: projectID, PipelineID: pipelineID}.key()]
+	if !exists || s.records[i].Tests != nil {
+		return false, nil
+	}
+
+	record := s.records[i]
+	record.Tests = &tests
+	s.records[i] = record
+
+	if err := s.appendLocked([]Record{record}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// jobRecords converts builds to job records, or nil when there are none.
+func jobRecords(builds []domain.Build) []JobRecord {
+	if len(builds) == 0 {
+		return nil
+	}
+
+	jobs := make([]JobRecord, 0, len(builds))
+	for _, b := range builds {
+		jobs = append(jobs, JobRecord{Name: b.Name, Stage: b.Stage, Status: b.Status, Retried: b.Retried})
+	}
+	return jobs
+}
+
+// Records returns the records of a project created at or after since, oldest first.
+// An empty branch matches all branches.
+func (s *Store) Records(platform, projectID, branch string, since time.Time) []Record {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	positions := s.projects[projectKey(platform, projectID)]
+	if branch != "" {
+		positions = s.branches[branchKey(platform, projectID, branch)]
+	}
+
+	var result []Record
+	for _, i := range positions {
+		if s.records[i].CreatedAt.Before(since) {
+			continue
+		}
+		result = append(result, s.records[i])
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// PreviousCoverage returns the coverage of the latest record of a project's branch with coverage
+// created before a pipeline, other than the pipeline itself. Returns false when there is none.
+// Reads the branch index without copying records, as it runs for every repository row.
+func (s *Store) PreviousCoverage(platform, projectID, branch, pipelineID string, before time.Time) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var previous *Record
+	for _, i := range s.branches[branchKey(platform, projectID, branch)] {
+		r := &s.records[i]
+		if r.Coverage == nil || r.PipelineID == pipelineID || !r.CreatedAt.Before(before) {
+			continue
+		}
+		if previous == nil || r.CreatedAt.After(previous.CreatedAt) {
+			previous = r
+		}
+	}
+
+	if previous == nil {
+		return 0, false
+	}
+	return *previous.Coverage, true
+}
+
+// Branches returns the branches with recorded pipelines for a project, sorted by name.
+func (s *Store) Branches(platform, projectID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var branches []string
+	for _, i := range s.projects[projectKey(platform, projectID)] {
+		branch := s.records[i].Branch
+		if seen[branch] {
+			continue
+		}
+		seen[branch] = true
+		branches = append(branches, branch)
+	}
+
+	sort.Strings(branches)
+	return branches
+}
+
+// Len returns the number of stored records.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.records)
+}
+
+// put inserts or replaces a record in memory.
+func (s *Store) put(record Record) {
+	if i, exists := s.index[record.key()]; exists {
+		moved := s.records[i].Branch != record.Branch
+		s.records[i] = record
+		if moved {
+			s.reindex()
+		}
+		return
+	}
+	s.indexRecord(record, len(s.records))
+	s.records = append(s.records, record)
+}
+
+// indexRecord adds a record at position i to the indexes.
+func (s *Store) indexRecord(record Record, i int) {
+	s.index[record.key()] = i
+	project := projectKey(record.Platform, record.ProjectID)
+	s.projects[project] = append(s.projects[project], i)
+	branch := branchKey(record.Platform, record.ProjectID, record.Branch)
+	s.branches[branch] = append(s.branches[branch], i)
+}
+
+// reindex rebuilds the indexes after records were reordered or removed.
+func (s *Store) reindex() {
+	s.index = make(map[string]int, len(s.records))
+	s.projects = make(map[string][]int)
+	s.branches = make(map[string][]int)
+	for i, r := range s.records {
+		s.indexRecord(r, i)
+	}
+}
+
+// compactLocked drops records created before the retention period.
+// Returns the number of records dropped. Caller must hold the write lock.
+func (s *Store) compactLocked(now time.Time) int {
+	s.lastCompacted = now
+	cutoff := now.Add(-s.retention)
+
+	kept := s.records[:0]
+	for _, r := range s.records {
+		if !r.CreatedAt.Before(cutoff) {
+			kept = append(kept, r)
+		}
+	}
+
+	dropped := len(s.records) - len(kept)
+	if dropped > 0 {
+		s.records = kept
+		s.reindex()
+	}
+	return dropped
+}
+
+// appendLocked appends records to the history file. Caller must hold the write lock.
+func (s *Store) appendLocked(records []Record) error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+
+	if err := writeRecords(f, records); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to append history: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to append history: %w", err)
+	}
+	return nil
+}
+
+// rewriteLocked replaces the history file with the in-memory records.
+// The file is written atomically (temp file + rename). Caller must hold the write lock.
+func (s *Store) rewriteLocked() error {
+	tmpPath := s.path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create history: %w", err)
+	}
+
+	if err := writeRecords(f, s.records); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace history: %w", err)
+	}
+	return nil
+}
+
+// writeRecords writes records as JSON Lines.
+func writeRecords(f *os.File, records []Record) error {
+	w := bufio.NewWriter(f)
+	encoder := json.NewEncoder(w)
+	for _, r := range records {
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,201 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestStore_AddAndReopen tests that finished pipelines are persisted, de-duplicated and restored.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestStore_AddAndReopen(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	store, err := Open(path, 90*24*time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	now := time.Now()
+	pipelines := []domain.Pipeline{
+		{ID: "1", ProjectID: "123", Branch: "main", Status: domain.StatusFailed, CreatedAt: now.Add(-2 * time.Hour), Duration: time.Minute},
+		{ID: "2", ProjectID: "123", Branch: "main", Status: domain.StatusRunning, CreatedAt: now.Add(-time.Hour)},
+		{ID: "3", ProjectID: "123", Branch: "main", Status: domain.StatusSuccess, CreatedAt: now.AddDate(0, 0, -100)},
+	}
+
+	// Act
+	added, addErr := store.Add("gitlab", pipelines)
+	pipelines[0].Status = domain.StatusSuccess // retried to success
+	readded, readdErr := store.Add("gitlab", pipelines)
+	unchanged, _ := store.Add("gitlab", pipelines)
+	reopened, openErr := Open(path, 90*24*time.Hour)
+
+	// Assert
+	if addErr != nil || readdErr != nil || openErr != nil {
+		t.Fatalf("expected no errors, got add=%v readd=%v open=%v", addErr, readdErr, openErr)
+	}
+
+	if added != 1 || readded != 1 || unchanged != 0 {
+		t.Errorf("expected 1, 1 and 0 records written, got %d, %d and %d", added, readded, unchanged)
+	}
+
+	records := reopened.Records("gitlab", "123", "", now.AddDate(0, 0, -90))
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record after reopening, got %d", len(records))
+	}
+
+	if records[0].Status != domain.StatusSuccess || records[0].Duration() != time.Minute {
+		t.Errorf("expected latest status and duration to be kept, got %+v", records[0])
+	}
+}
+
+// TestStore_SetTestResults tests that test results are persisted and kept when the pipeline is seen again.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestStore_SetTestResults(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	store, err := Open(path, 90*24*time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	pipeline := domain.Pipeline{ID: "1", ProjectID: "123", Branch: "main", Status: domain.StatusFailed, CreatedAt: time.Now().Add(-time.Hour)}
+	store.Add("gitlab", []domain.Pipeline{pipeline})
+
+	// Act
+	set, setErr := store.SetTestResults("gitlab", "123", "1", TestRecord{Total: 10, Failed: []string{"pkg.TestA"}})
+	setAgain, _ := store.SetTestResults("gitlab", "123", "1", TestRecord{Total: 10})
+	unknown, _ := store.SetTestResults("gitlab", "123", "2", TestRecord{Total: 10})
+	readded, _ := store.Add("gitlab", []domain.Pipeline{pipeline})
+	reopened, openErr := Open(path, 90*24*time.Hour)
+
+	// Assert
+	if setErr != nil || openErr != nil {
+		t.Fatalf("expected no errors, got set=%v open=%v", setErr, openErr)
+	}
+	if !set || setAgain || unknown {
+		t.Errorf("expected only the first results of a recorded pipeline to be set, got %v, %v and %v", set, setAgain, unknown)
+	}
+	if readded != 0 {
+		t.Errorf("expected the unchanged pipeline not to be written again, got %d records", readded)
+	}
+
+	records := reopened.Records("gitlab", "123", "", time.Now().AddDate(0, 0, -1))
+	if len(records) != 1 || records[0].Tests == nil || records[0].Tests.Total != 10 || len(records[0].Tests.Failed) != 1 {
+		t.Fatalf("expected the test results to be restored, got %+v", records)
+	}
+}
+
+// TestStore_AddKeepsCoverage tests that a pipeline's coverage is kept when it is recorded again without it.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestStore_AddKeepsCoverage(t *testing.T) {
+	// Arrange
+	store, err := Open(filepath.Join(t.TempDir(), "history.jsonl"), 90*24*time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	coverage := 81.5
+	pipeline := domain.Pipeline{ID: "1", ProjectID: "123", Branch: "main", Status: domain.StatusSuccess, CreatedAt: time.Now().Add(-time.Hour)}
+	store.Add("gitlab", []domain.Pipeline{pipeline})
+
+	// Act
+	withCoverage := pipeline
+	withCoverage.Coverage = &coverage
+	added, _ := store.Add("gitlab", []domain.Pipeline{withCoverage})
+	readded, _ := store.Add("gitlab", []domain.Pipeline{pipeline})
+
+	// Assert
+	if added != 1 || readded != 0 {
+		t.Errorf("expected only the coverage to be written, got %d and %d records", added, readded)
+	}
+	records := store.Records("gitlab", "123", "main", time.Now().AddDate(0, 0, -1))
+	if len(records) != 1 || records[0].Coverage == nil || *records[0].Coverage != coverage {
+		t.Fatalf("expected the coverage to be kept, got %+v", records)
+	}
+}
+
+// TestStore_RecordsByProjectAndBranch tests that records are found per project and branch,
+// including after records were dropped by compaction.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestStore_RecordsByProjectAndBranch(t *testing.T) {
+	// Arrange
+	store, err := Open(filepath.Join(t.TempDir(), "history.jsonl"), 90*24*time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	now := time.Now()
+	store.Add("gitlab", []domain.Pipeline{
+		{ID: "1", ProjectID: "123", Branch: "main", Status: domain.StatusSuccess, CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "2", ProjectID: "123", Branch: "feature", Status: domain.StatusFailed, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "3", ProjectID: "456", Branch: "main", Status: domain.StatusSuccess, CreatedAt: now.Add(-time.Hour)},
+	})
+	store.Add("github", []domain.Pipeline{
+		{ID: "1", ProjectID: "123", Branch: "main", Status: domain.StatusSuccess, CreatedAt: now.Add(-time.Hour)},
+	})
+	store.Add("gitlab", []domain.Pipeline{
+		{ID: "4", ProjectID: "123", Branch: "main", Status: domain.StatusFailed, CreatedAt: now.Add(-30 * time.Minute)},
+	})
+
+	// Act
+	all := store.Records("gitlab", "123", "", time.Time{})
+	main := store.Records("gitlab", "123", "main", time.Time{})
+	recent := store.Records("gitlab", "123", "main", now.Add(-time.Hour))
+	branches := store.Branches("gitlab", "123")
+	store.mu.Lock()
+	store.retention = 150 * time.Minute
+	store.compactLocked(now)
+	store.mu.Unlock()
+	compacted := store.Records("gitlab", "123", "main", time.Time{})
+
+	// Assert
+	if len(all) != 3 || all[0].PipelineID != "1" || all[2].PipelineID != "4" {
+		t.Errorf("expected pipelines 1, 2 and 4 oldest first, got %+v", all)
+	}
+	if len(main) != 2 || main[0].PipelineID != "1" || main[1].PipelineID != "4" {
+		t.Errorf("expected pipelines 1 and 4 on main, got %+v", main)
+	}
+	if len(recent) != 1 || recent[0].PipelineID != "4" {
+		t.Errorf("expected only pipeline 4 since an hour ago, got %+v", recent)
+	}
+	if len(branches) != 2 || branches[0] != "feature" || branches[1] != "main" {
+		t.Errorf("expected branches feature and main, got %v", branches)
+	}
+	if len(compacted) != 1 || compacted[0].PipelineID != "4" {
+		t.Errorf("expected only pipeline 4 on main after compaction, got %+v", compacted)
+	}
+}
+
+// TestComputeStats tests success rate, duration percentiles and failure streaks.
+func TestComputeStats(t *testing.T) {
+	// Arrange
+	statuses := []domain.Status{
+		domain.StatusFailed, domain.StatusFailed, domain.StatusSuccess, domain.StatusCanceled,
+		domain.StatusFailed, domain.StatusFailed, domain.StatusFailed, domain.StatusSuccess,
+		domain.StatusFailed, domain.StatusCanceled, domain.StatusFailed,
+	}
+	records := make([]Record, 0, len(statuses))
+	for i, status := range statuses {
+		records = append(records, Record{Status: status, DurationSeconds: float64(i + 1)})
+	}
+
+	// Act
+	stats := ComputeStats(7, records)
+
+	// Assert
+	if stats.Total != 11 || stats.Succeeded != 2 || stats.Failed != 7 || stats.Canceled != 2 {
+		t.Errorf("unexpected counts: %+v", stats)
+	}
+
+	if want := 2.0 / 9.0 * 100; stats.SuccessRate != want {
+		t.Errorf("expected success rate %.2f, got %.2f", want, stats.SuccessRate)
+	}
+
+	// Canceled runs (durations 4 and 10) are excluded from the percentiles
+	if stats.P50DurationSeconds != 6 || stats.P95DurationSeconds != 11 {
+		t.Errorf("expected p50=6 and p95=11, got p50=%v p95=%v", stats.P50DurationSeconds, stats.P95DurationSeconds)
+	}
+
+	if stats.CurrentFailureStreak != 2 || stats.LongestFailureStreak != 3 {
+		t.Errorf("expected current streak 2 and longest 3, got %d and %d", stats.CurrentFailureStreak, stats.LongestFailureStreak)
+	}
+}
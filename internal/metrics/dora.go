@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+)
+
+// Sources DORA metrics are computed from.
+const (
+	SourceDeployments = "deployments" // deployments to production environments
+	SourcePipelines   = "pipelines"   // default branch pipelines, for projects without production deployments
+	SourceMixed       = "mixed"       // groups with projects of both sources
+)
+
+// DORA holds the four DORA metrics over a time window.
+// Deployments are successful production deployments, or successful default branch pipelines
+// for projects without any; Source tells which.
+type DORA struct {
+	Source               string  `json:"source"`
+	Deployments          int     `json:"deployments"`
+	DeploymentsPerDay    float64 `json:"deployments_per_day"`
+	LeadTimeSeconds      float64 `json:"lead_time_seconds"`   // median time from MR creation to the first deployment after merge
+	LeadTimeChanges      int     `json:"lead_time_changes"`   // merged MRs with a deployment after merge
+	ChangeFailureRate    float64 `json:"change_failure_rate"` // percent of finished deployments that failed
+	FailedChanges        int     `json:"failed_changes"`
+	TimeToRestoreSeconds float64 `json:"time_to_restore_seconds"` // median time from a failure to the next success
+	Restores             int     `json:"restores"`
+}
+
+// samples holds the raw observations behind DORA metrics, so projects can be aggregated into groups.
+type samples struct {
+	source      string
+	deployments int
+	finished    int
+	failures    int
+	leadTimes   []float64 // seconds
+	restores    []float64 // seconds
+}
+
+// delivery is a finished production deployment, or a finished default branch pipeline standing in for one.
+type delivery struct {
+	status    domain.Status
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// collect gathers samples from production deployments and merged merge requests, falling back to
+// default branch pipeline records (oldest first) when there are no finished production deployments.
+// Only merge requests targeting the default branch count towards lead time.
+func collect(records []history.Record, productionDeployments []domain.Deployment, mrs []domain.MergeRequest, defaultBranch string) samples {
+	s := samples{source: SourceDeployments}
+	deliveries := deploymentDeliveries(productionDeployments)
+	if len(deliveries) == 0 {
+		s.source = SourcePipelines
+		deliveries = pipelineDeliveries(records)
+	}
+
+	var deployments []delivery
+	var failingSince time.Time
+
+	for _, d := range deliveries {
+		s.finished++
+		if d.status == domain.StatusFailed {
+			s.failures++
+			if failingSince.IsZero() {
+				failingSince = d.updatedAt
+			}
+			continue
+		}
+
+		s.deployments++
+		deployments = append(deployments, d)
+		if !failingSince.IsZero() {
+			s.restores = append(s.restores, d.updatedAt.Sub(failingSince).Seconds())
+			failingSince = time.Time{}
+		}
+	}
+
+	for _, mr := range mrs {
+		if mr.MergedAt.IsZero() || mr.TargetBranch != defaultBranch {
+			continue
+		}
+
+		// The first deployment started after the merge ships the change
+		i := sort.Search(len(deployments), func(i int) bool {
+			return !deployments[i].createdAt.Before(mr.MergedAt)
+		})
+		if i == len(deployments) {
+			continue
+		}
+		s.leadTimes = append(s.leadTimes, deployments[i].updatedAt.Sub(mr.CreatedAt).Seconds())
+	}
+
+	return s
+}
+
+// deploymentDeliveries returns the successful and failed deployments, oldest first.
+func deploymentDeliveries(deployments []domain.Deployment) []delivery {
+	var deliveries []delivery
+	for _, d := range deployments {
+		if d.Status == domain.StatusSuccess || d.Status == domain.StatusFailed {
+			deliveries = append(deliveries, delivery{status: d.Status, createdAt: d.CreatedAt, updatedAt: d.UpdatedAt})
+		}
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].createdAt.Before(deliveries[j].createdAt)
+	})
+	return deliveries
+}
+
+// pipelineDeliveries returns the successful and failed pipeline records, keeping their order.
+func pipelineDeliveries(records []history.Record) []delivery {
+	var deliveries []delivery
+	for _, r := range records {
+		if r.Status == domain.StatusSuccess || r.Status == domain.StatusFailed {
+			deliveries = append(deliveries, delivery{status: r.Status, createdAt: r.CreatedAt, updatedAt: r.UpdatedAt})
+		}
+	}
+	return deliveries
+}
+
+// add merges another project's samples into s.
+func (s *samples) add(other samples) {
+	switch s.source {
+	case "":
+		s.source = other.source
+	case other.source:
+	default:
+		s.source = SourceMixed
+	}
+	s.deployments += other.deployments
+	s.finished += other.finished
+	s.failures += other.failures
+	s.leadTimes = append(s.leadTimes, other.leadTimes...)
+	s.restores = append(s.restores, other.restores...)
+}
+
+// dora computes the DORA metrics from samples over a window of days.
+func (s samples) dora(windowDays int) DORA {
+	d := DORA{
+		Source:          s.source,
+		Deployments:     s.deployments,
+		LeadTimeChanges: len(s.leadTimes),
+		FailedChanges:   s.failures,
+		Restores:        len(s.restores),
+	}
+
+	if windowDays > 0 {
+		d.DeploymentsPerDay = float64(s.deployments) / float64(windowDays)
+	}
+	if s.finished > 0 {
+		d.ChangeFailureRate = float64(s.failures) / float64(s.finished) * 100
+	}
+	d.LeadTimeSeconds = median(s.leadTimes)
+	d.TimeToRestoreSeconds = median(s.restores)
+
+	return d
+}
+
+// median returns the median of values (0 when empty).
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
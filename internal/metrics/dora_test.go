@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+)
+
+// TestCollect_DORA tests deployment frequency, lead time, change failure rate and time to restore.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestCollect_DORA(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return start.Add(time.Duration(hours) * time.Hour) }
+	pipeline := func(hour int, status domain.Status) history.Record {
+		// Each pipeline takes 10 minutes
+		return history.Record{Branch: "main", Status: status, CreatedAt: at(hour), UpdatedAt: at(hour).Add(10 * time.Minute)}
+	}
+
+	records := []history.Record{
+		pipeline(0, domain.StatusSuccess),
+		pipeline(1, domain.StatusFailed),
+		pipeline(2, domain.StatusCanceled), // ignored
+		pipeline(3, domain.StatusFailed),
+		pipeline(4, domain.StatusSuccess), // restores 3h after the first failure
+		pipeline(6, domain.StatusSuccess),
+	}
+	mrs := []domain.MergeRequest{
+		// Merged between pipelines 4 and 6: deployed by pipeline 6
+		{TargetBranch: "main", CreatedAt: at(3), MergedAt: at(5)},
+		// Merged right before pipeline 0 started
+		{TargetBranch: "main", CreatedAt: at(-1), MergedAt: at(0)},
+		// Not yet deployed, targets another branch or not merged - ignored
+		{TargetBranch: "main", CreatedAt: at(6), MergedAt: at(7)},
+		{TargetBranch: "develop", CreatedAt: at(0), MergedAt: at(1)},
+		{TargetBranch: "main", CreatedAt: at(0)},
+	}
+
+	// Act
+	dora := collect(records, nil, mrs, "main").dora(2)
+
+	// Assert
+	if dora.Source != SourcePipelines {
+		t.Errorf("expected source %q without deployments, got %q", SourcePipelines, dora.Source)
+	}
+
+	if dora.Deployments != 3 || dora.DeploymentsPerDay != 1.5 {
+		t.Errorf("expected 3 deployments (1.5/day), got %d (%.2f/day)", dora.Deployments, dora.DeploymentsPerDay)
+	}
+
+	if dora.FailedChanges != 2 || dora.ChangeFailureRate != 40 {
+		t.Errorf("expected 2 failed changes (40%%), got %d (%.1f%%)", dora.FailedChanges, dora.ChangeFailureRate)
+	}
+
+	if dora.Restores != 1 || dora.TimeToRestoreSeconds != (3*time.Hour).Seconds() {
+		t.Errorf("expected 1 restore after 3h, got %d after %.0fs", dora.Restores, dora.TimeToRestoreSeconds)
+	}
+
+	// Lead times: 1h10m and 3h10m, median 2h10m
+	expectedLeadTime := (2*time.Hour + 10*time.Minute).Seconds()
+	if dora.LeadTimeChanges != 2 || dora.LeadTimeSeconds != expectedLeadTime {
+		t.Errorf("expected 2 lead time samples with median %.0fs, got %d with %.0fs", expectedLeadTime, dora.LeadTimeChanges, dora.LeadTimeSeconds)
+	}
+}
+
+// TestCollect_ProductionDeployments tests that production deployments take precedence over pipelines.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestCollect_ProductionDeployments(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return start.Add(time.Duration(hours) * time.Hour) }
+	deployment := func(hour int, status domain.Status) domain.Deployment {
+		// Each deployment takes 5 minutes
+		return domain.Deployment{Environment: "production", Production: true, Status: status, CreatedAt: at(hour), UpdatedAt: at(hour).Add(5 * time.Minute)}
+	}
+
+	records := []history.Record{
+		{Branch: "main", Status: domain.StatusFailed, CreatedAt: at(0), UpdatedAt: at(0)},
+		{Branch: "main", Status: domain.StatusSuccess, CreatedAt: at(1), UpdatedAt: at(1)},
+	}
+	// Newest first, as the platforms return them
+	deployments := []domain.Deployment{
+		deployment(8, domain.StatusRunning), // ignored
+		deployment(6, domain.StatusSuccess),
+		deployment(4, domain.StatusFailed),
+		deployment(2, domain.StatusSuccess),
+	}
+	mrs := []domain.MergeRequest{
+		// Merged after the deployment at 2, shipped by the one at 6
+		{TargetBranch: "main", CreatedAt: at(1), MergedAt: at(3)},
+	}
+
+	// Act
+	dora := collect(records, deployments, mrs, "main").dora(1)
+
+	// Assert
+	if dora.Source != SourceDeployments {
+		t.Errorf("expected source %q, got %q", SourceDeployments, dora.Source)
+	}
+
+	if dora.Deployments != 2 || dora.FailedChanges != 1 {
+		t.Errorf("expected 2 deployments and 1 failed change, got %d and %d", dora.Deployments, dora.FailedChanges)
+	}
+
+	if dora.Restores != 1 || dora.TimeToRestoreSeconds != (2*time.Hour).Seconds() {
+		t.Errorf("expected 1 restore after 2h, got %d after %.0fs", dora.Restores, dora.TimeToRestoreSeconds)
+	}
+
+	expectedLeadTime := (5*time.Hour + 5*time.Minute).Seconds()
+	if dora.LeadTimeChanges != 1 || dora.LeadTimeSeconds != expectedLeadTime {
+		t.Errorf("expected 1 lead time sample of %.0fs, got %d of %.0fs", expectedLeadTime, dora.LeadTimeChanges, dora.LeadTimeSeconds)
+	}
+}
+
+// TestSamplesAdd_Source tests that groups mixing sources report a mixed source.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestSamplesAdd_Source(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  []string
+		expected string
+	}{
+		{"single source", []string{SourceDeployments, SourceDeployments}, SourceDeployments},
+		{"mixed sources", []string{SourcePipelines, SourceDeployments}, SourceMixed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var group samples
+
+			// Act
+			for _, source := range tt.sources {
+				group.add(samples{source: source})
+			}
+
+			// Assert
+			if got := group.dora(1).Source; got != tt.expected {
+				t.Errorf("expected source %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
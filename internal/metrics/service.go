@@ -0,0 +1,203 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+)
+
+const (
+	// DefaultWindowDays is the default DORA reporting period
+	DefaultWindowDays = 30
+
+	// MaxWindowDays is the longest DORA reporting period (bounded by pipeline history and merged MR retention)
+	MaxWindowDays = 90
+)
+
+// Source provides the data DORA metrics are computed from (Dependency Inversion Principle).
+// Implemented by service.PipelineService.
+type Source interface {
+	GetAllProjects(ctx context.Context) ([]domain.Project, error)
+	HasPipelineHistory() bool
+	GetPipelineHistory(project domain.Project, branch string, since time.Time) []history.Record
+	GetMergedMergeRequestsForProject(ctx context.Context, project domain.Project, since time.Time) ([]domain.MergeRequest, error)
+	GetProductionDeploymentsForProject(ctx context.Context, project domain.Project, since time.Time) ([]domain.Deployment, error)
+}
+
+// ProjectMetrics holds DORA metrics for a project's default branch.
+type ProjectMetrics struct {
+	ProjectID     string `json:"project_id"`
+	Platform      string `json:"platform"`
+	Name          string `json:"name"`
+	Group         string `json:"group"`
+	DefaultBranch string `json:"default_branch"`
+	DORA
+}
+
+// GroupMetrics holds DORA metrics aggregated over the projects of a group (GitLab namespace or GitHub owner).
+type GroupMetrics struct {
+	Platform string `json:"platform"`
+	Group    string `json:"group"`
+	Projects int    `json:"projects"`
+	DORA
+}
+
+// Report holds DORA metrics for every project and group over a window.
+type Report struct {
+	WindowDays int              `json:"window_days"`
+	Since      time.Time        `json:"since"`
+	Until      time.Time        `json:"until"`
+	Projects   []ProjectMetrics `json:"projects"`
+	Groups     []GroupMetrics   `json:"groups"`
+}
+
+// Service computes DORA metrics on top of the pipeline service.
+// Follows Single Responsibility Principle - only computes delivery metrics.
+type Service struct {
+	source Source
+}
+
+// NewService creates a new metrics service.
+// Follows Dependency Injection - accepts dependencies via constructor.
+func NewService(source Source) *Service {
+	return &Service{source: source}
+}
+
+// Enabled reports whether metrics can be computed (pipeline history is required).
+func (s *Service) Enabled() bool {
+	return s.source.HasPipelineHistory()
+}
+
+// GetDORAReport computes DORA metrics over the last windowDays for every project and group.
+// Projects without any deployment or default branch activity in the window are omitted.
+func (s *Service) GetDORAReport(ctx context.Context, windowDays int) (*Report, error) {
+	if windowDays <= 0 || windowDays > MaxWindowDays {
+		return nil, fmt.Errorf("window must be between 1 and %d days, got %d", MaxWindowDays, windowDays)
+	}
+
+	projects, err := s.source.GetAllProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	until := time.Now()
+	since := until.AddDate(0, 0, -windowDays)
+	report := &Report{
+		WindowDays: windowDays,
+		Since:      since,
+		Until:      until,
+		Projects:   []ProjectMetrics{},
+		Groups:     []GroupMetrics{},
+	}
+
+	groups := make(map[string]*GroupMetrics)
+	groupSamples := make(map[string]*samples)
+
+	for _, project := range projects {
+		if project.DefaultBranch == "" {
+			continue
+		}
+
+		records := s.source.GetPipelineHistory(project, project.DefaultBranch, since)
+		mrs, err := s.source.GetMergedMergeRequestsForProject(ctx, project, since)
+		if err != nil {
+			// Lead time is best-effort - the other metrics only need pipelines
+			log.Printf("[Metrics] Failed to get merged merge requests for %s: %v", project.ID, err)
+		}
+
+		deployments, err := s.source.GetProductionDeploymentsForProject(ctx, project, since)
+		if err != nil {
+			// Pipelines stand in for deployments when they can't be fetched
+			log.Printf("[Metrics] Failed to get production deployments for %s: %v", project.ID, err)
+		}
+
+		projectSamples := collect(records, deployments, mrs, project.DefaultBranch)
+		if projectSamples.finished == 0 && len(projectSamples.leadTimes) == 0 {
+			continue
+		}
+
+		group := projectGroup(project)
+		report.Projects = append(report.Projects, ProjectMetrics{
+			ProjectID:     project.ID,
+			Platform:      project.Platform,
+			Name:          project.Name,
+			Group:         group,
+			DefaultBranch: project.DefaultBranch,
+			DORA:          projectSamples.dora(windowDays),
+		})
+
+		key := project.Platform + ":" + group
+		if _, exists := groups[key]; !exists {
+			groups[key] = &GroupMetrics{Platform: project.Platform, Group: group}
+			groupSamples[key] = &samples{}
+		}
+		groups[key].Projects++
+		groupSamples[key].add(projectSamples)
+	}
+
+	for key, group := range groups {
+		group.DORA = groupSamples[key].dora(windowDays)
+		report.Groups = append(report.Groups, *group)
+	}
+
+	// Most deployments first, then by name
+	sort.SliceStable(report.Projects, func(i, j int) bool {
+		if report.Projects[i].Deployments != report.Projects[j].Deployments {
+			return report.Projects[i].Deployments > report.Projects[j].Deployments
+		}
+		return report.Projects[i].Name < report.Projects[j].Name
+	})
+	sort.SliceStable(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Platform != report.Groups[j].Platform {
+			return report.Groups[i].Platform < report.Groups[j].Platform
+		}
+		return report.Groups[i].Group < report.Groups[j].Group
+	})
+
+	return report, nil
+}
+
+// projectGroup returns the group a project belongs to: its GitLab namespace or GitHub owner.
+func projectGroup(project domain.Project) string {
+	if project.Namespace != nil && project.Namespace.Path != "" {
+		return project.Namespace.Path
+	}
+	if project.Owner != nil && project.Owner.Username != "" {
+		return project.Owner.Username
+	}
+	if owner, _, found := strings.Cut(project.ID, "/"); found {
+		return owner
+	}
+	return ""
+}
+
+// Filter returns a copy of the report narrowed to a project (by ID) and/or a group.
+// Empty values don't filter. Filtering by project keeps only that project's group.
+func (r *Report) Filter(projectID, group string) *Report {
+	filtered := *r
+	filtered.Projects = []ProjectMetrics{}
+	filtered.Groups = []GroupMetrics{}
+
+	groupKeys := make(map[string]bool)
+	for _, p := range r.Projects {
+		if (projectID != "" && p.ProjectID != projectID) || (group != "" && p.Group != group) {
+			continue
+		}
+		filtered.Projects = append(filtered.Projects, p)
+		groupKeys[p.Platform+":"+p.Group] = true
+	}
+
+	for _, g := range r.Groups {
+		if groupKeys[g.Platform+":"+g.Group] {
+			filtered.Groups = append(filtered.Groups, g)
+		}
+	}
+
+	return &filtered
+}
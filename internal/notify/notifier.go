@@ -0,0 +1,283 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// SendTimeout bounds the delivery of a single event to a single sink.
+const SendTimeout = 30 * time.Second
+
+// Event describes a default branch status transition, or a failure that has lasted long enough to alert on.
+type Event struct {
+	Rule           string        `json:"rule"`
+	Platform       string        `json:"platform"`
+	ProjectID      string        `json:"project_id"`
+	Repository     string        `json:"repository"`
+	Branch         string        `json:"branch"`
+	Status         domain.Status `json:"status"`          // current status: failed or success (recovered)
+	PreviousStatus domain.Status `json:"previous_status"` // status before the transition
+	PipelineID     string        `json:"pipeline_id"`
+	PipelineURL    string        `json:"pipeline_url"`
+	FailedSince    time.Time     `json:"failed_since"`
+	FailedSeconds  int64         `json:"failed_seconds"` // how long the branch has been (or was) failing
+	Time           time.Time     `json:"time"`
+}
+
+// Summary returns a one-line human-readable description of the event.
+func (e Event) Summary() string {
+	failedFor := time.Duration(e.FailedSeconds) * time.Second
+	if e.Status == domain.StatusSuccess {
+		return fmt.Sprintf("%s: %s recovered after %s", e.Repository, e.Branch, failedFor)
+	}
+	if failedFor > 0 {
+		return fmt.Sprintf("%s: %s has been failing for %s", e.Repository, e.Branch, failedFor)
+	}
+	return fmt.Sprintf("%s: %s is failing", e.Repository, e.Branch)
+}
+
+// Sink delivers events to an external system (Interface Segregation Principle).
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, event Event) error
+}
+
+// Rule selects which events are delivered, and to which sinks.
+type Rule struct {
+	Name              string
+	Repositories      []string      // globs matched against the project ID, name and namespace/name (empty = all)
+	Branches          []string      // globs matched against the default branch name (empty = all)
+	Status            domain.Status // failed (alert) or success (recovery)
+	MinFailedDuration time.Duration // only alert once the branch has been failing this long
+	Sinks             []string      // sink names (empty = all sinks)
+}
+
+// matches reports whether the rule applies to a project's branch.
+func (r Rule) matches(project domain.Project, branch string) bool {
+	return matchAny(r.Branches, branch) && matchAny(r.Repositories, repositoryNames(project)...)
+}
+
+// branchState tracks the last known status of a watched branch.
+type branchState struct {
+	status       domain.Status
+	updatedAt    time.Time
+	failedSince  time.Time       // zero unless failing
+	failedAlerts map[string]bool // rule name -> alerted during the current failure
+}
+
+// Notifier watches default branch status transitions and delivers matching events to sinks.
+// Follows Single Responsibility Principle - only evaluates rules and dispatches events.
+type Notifier struct {
+	rules  []Rule
+	sinks  map[string]Sink
+	states map[string]*branchState // platform:projectID:branch -> state
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+}
+
+// New creates a notifier.
+// Returns an error if a rule has an unsupported status or references an unknown sink.
+func New(rules []Rule, sinks []Sink) (*Notifier, error) {
+	n := &Notifier{
+		rules:  rules,
+		sinks:  make(map[string]Sink),
+		states: make(map[string]*branchState),
+	}
+
+	for _, sink := range sinks {
+		if _, exists := n.sinks[sink.Name()]; exists {
+			return nil, fmt.Errorf("duplicate sink name %q", sink.Name())
+		}
+		n.sinks[sink.Name()] = sink
+	}
+
+	for _, rule := range rules {
+		if rule.Status != domain.StatusFailed && rule.Status != domain.StatusSuccess {
+			return nil, fmt.Errorf("rule %q: status must be %q or %q, got %q", rule.Name, domain.StatusFailed, domain.StatusSuccess, rule.Status)
+		}
+		for _, pattern := range append(append([]string{}, rule.Repositories...), rule.Branches...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("rule %q: invalid glob %q: %w", rule.Name, pattern, err)
+			}
+		}
+		for _, name := range rule.Sinks {
+			if _, exists := n.sinks[name]; !exists {
+				return nil, fmt.Errorf("rule %q: unknown sink %q", rule.Name, name)
+			}
+		}
+	}
+
+	return n, nil
+}
+
+// Observe evaluates a finished pipeline of a project's default branch.
+// The first pipeline seen for a branch only establishes its status, so restarts don't re-send alerts.
+// Events are delivered asynchronously.
+func (n *Notifier) Observe(project domain.Project, pipeline domain.Pipeline) {
+	if pipeline.Status != domain.StatusSuccess && pipeline.Status != domain.StatusFailed {
+		return
+	}
+
+	now := time.Now()
+	events := n.evaluate(project, pipeline, now)
+
+	for _, event := range events {
+		for _, sink := range n.sinksFor(event.Rule) {
+			n.wg.Add(1)
+			go n.deliver(sink, event)
+		}
+	}
+}
+
+// evaluate updates the branch state and returns the events of every matching rule.
+func (n *Notifier) evaluate(project domain.Project, pipeline domain.Pipeline, now time.Time) []Event {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	key := project.Platform + ":" + project.ID + ":" + pipeline.Branch
+	state, known := n.states[key]
+	if !known {
+		state = &branchState{status: pipeline.Status, updatedAt: pipeline.UpdatedAt, failedAlerts: make(map[string]bool)}
+		if pipeline.Status == domain.StatusFailed {
+			state.failedSince = pipeline.UpdatedAt
+			// Don't alert again for a failure that predates this process
+			for _, rule := range n.rules {
+				state.failedAlerts[rule.Name] = true
+			}
+		}
+		n.states[key] = state
+		return nil
+	}
+
+	// Ignore pipelines older than the one that set the current status (e.g. out-of-order webhooks)
+	if pipeline.UpdatedAt.Before(state.updatedAt) {
+		return nil
+	}
+
+	previous := state.status
+	failedSince := state.failedSince
+	state.status = pipeline.Status
+	state.updatedAt = pipeline.UpdatedAt
+
+	event := Event{
+		Platform:       project.Platform,
+		ProjectID:      project.ID,
+		Repository:     project.Name,
+		Branch:         pipeline.Branch,
+		Status:         pipeline.Status,
+		PreviousStatus: previous,
+		PipelineID:     pipeline.ID,
+		PipelineURL:    pipeline.WebURL,
+		Time:           now,
+	}
+
+	var events []Event
+	switch {
+	case pipeline.Status == domain.StatusSuccess && previous == domain.StatusFailed:
+		// Recovery: alert rules on success with a long enough failure
+		state.failedSince = time.Time{}
+		state.failedAlerts = make(map[string]bool)
+
+		event.FailedSince = failedSince
+		failedFor := pipeline.UpdatedAt.Sub(failedSince)
+		event.FailedSeconds = int64(failedFor.Seconds())
+		for _, rule := range n.rules {
+			if rule.Status == domain.StatusSuccess && failedFor >= rule.MinFailedDuration && rule.matches(project, pipeline.Branch) {
+				event.Rule = rule.Name
+				events = append(events, event)
+			}
+		}
+
+	case pipeline.Status == domain.StatusFailed:
+		// Breakage or still failing: alert each rule on failure once per failure, after its minimum duration
+		if previous != domain.StatusFailed {
+			state.failedSince = pipeline.UpdatedAt
+		}
+
+		event.FailedSince = state.failedSince
+		failedFor := now.Sub(state.failedSince)
+		event.FailedSeconds = int64(failedFor.Seconds())
+		for _, rule := range n.rules {
+			if rule.Status != domain.StatusFailed || state.failedAlerts[rule.Name] || failedFor < rule.MinFailedDuration || !rule.matches(project, pipeline.Branch) {
+				continue
+			}
+			state.failedAlerts[rule.Name] = true
+			event.Rule = rule.Name
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// sinksFor returns the sinks a rule delivers to.
+func (n *Notifier) sinksFor(ruleName string) []Sink {
+	for _, rule := range n.rules {
+		if rule.Name != ruleName {
+			continue
+		}
+		if len(rule.Sinks) == 0 {
+			sinks := make([]Sink, 0, len(n.sinks))
+			for _, sink := range n.sinks {
+				sinks = append(sinks, sink)
+			}
+			return sinks
+		}
+
+		sinks := make([]Sink, 0, len(rule.Sinks))
+		for _, name := range rule.Sinks {
+			sinks = append(sinks, n.sinks[name])
+		}
+		return sinks
+	}
+	return nil
+}
+
+// deliver sends an event to a sink, logging failures.
+func (n *Notifier) deliver(sink Sink, event Event) {
+	defer n.wg.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), SendTimeout)
+	defer cancel()
+
+	if err := sink.Send(ctx, event); err != nil {
+		log.Printf("[Notify] Failed to send %q event for %s to %s: %v", event.Rule, event.Repository, sink.Name(), err)
+		return
+	}
+	log.Printf("[Notify] Sent %q event for %s to %s: %s", event.Rule, event.Repository, sink.Name(), event.Summary())
+}
+
+// Wait blocks until all pending deliveries finish.
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+// repositoryNames returns the names a repository glob is matched against.
+func repositoryNames(project domain.Project) []string {
+	names := []string{project.ID, project.Name}
+	if project.Namespace != nil && project.Namespace.Path != "" {
+		names = append(names, project.Namespace.Path+"/"+project.Name)
+	}
+	return names
+}
+
+// matchAny reports whether any value matches any pattern. No patterns match everything.
+func matchAny(patterns []string, values ...string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		for _, value := range values {
+			if matched, _ := path.Match(pattern, value); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// recordingSink collects delivered events.
+type recordingSink struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (s *recordingSink) Name() string { return "recorder" }
+
+func (s *recordingSink) Send(ctx context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+// TestNotifier_Observe tests alerts on breakage, long failures and recovery, and that baselines don't alert.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestNotifier_Observe(t *testing.T) {
+	// Arrange
+	sink := &recordingSink{}
+	notifier, err := New([]Rule{
+		{Name: "broken", Repositories: []string{"platform/*"}, Status: domain.StatusFailed},
+		{Name: "still-broken", Status: domain.StatusFailed, MinFailedDuration: time.Hour},
+		{Name: "fixed", Branches: []string{"main"}, Status: domain.StatusSuccess},
+	}, []Sink{sink})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	api := domain.Project{ID: "platform/api", Name: "api", Platform: "github", DefaultBranch: "main"}
+	web := domain.Project{ID: "frontend/web", Name: "web", Platform: "github", DefaultBranch: "main"}
+	start := time.Now().Add(-3 * time.Hour)
+	pipeline := func(id string, status domain.Status, minutes int) domain.Pipeline {
+		return domain.Pipeline{ID: id, Branch: "main", Status: status, UpdatedAt: start.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	// Act
+	notifier.Observe(web, pipeline("1", domain.StatusFailed, 0))   // baseline failure: no alerts
+	notifier.Observe(web, pipeline("2", domain.StatusFailed, 10))  // still failing, but already failing at startup
+	notifier.Observe(api, pipeline("3", domain.StatusSuccess, 0))  // baseline
+	notifier.Observe(api, pipeline("4", domain.StatusRunning, 5))  // ignored
+	notifier.Observe(api, pipeline("5", domain.StatusFailed, 10))  // broken + still-broken (failing for ~3h)
+	notifier.Observe(api, pipeline("6", domain.StatusFailed, 20))  // already alerted
+	notifier.Observe(api, pipeline("7", domain.StatusSuccess, 40)) // fixed after 30m
+	notifier.Wait()
+
+	// Assert
+	counts := make(map[string]int)
+	for _, event := range sink.events {
+		counts[event.Rule]++
+		if event.ProjectID != api.ID {
+			t.Errorf("expected events only for %s, got %+v", api.ID, event)
+		}
+	}
+
+	if counts["broken"] != 1 || counts["still-broken"] != 1 || counts["fixed"] != 1 || len(sink.events) != 3 {
+		t.Fatalf("expected one event per rule, got %v", counts)
+	}
+
+	for _, event := range sink.events {
+		if event.Rule == "fixed" && (event.FailedSeconds != 30*60 || event.PreviousStatus != domain.StatusFailed) {
+			t.Errorf("expected recovery after 30m from failed, got %+v", event)
+		}
+	}
+}
+
+// TestNew_UnknownSink tests that rules referencing undefined sinks are rejected.
+func TestNew_UnknownSink(t *testing.T) {
+	// Arrange
+	rules := []Rule{{Name: "broken", Status: domain.StatusFailed, Sinks: []string{"missing"}}}
+
+	// Act
+	_, err := New(rules, []Sink{&recordingSink{}})
+
+	// Assert
+	if err == nil {
+		t.Fatal("expected error for unknown sink, got nil")
+	}
+}
@@ -0,0 +1,175 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// HTTPClient interface for making HTTP requests (Dependency Inversion Principle).
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WebhookSink posts events as JSON to a URL.
+type WebhookSink struct {
+	name       string
+	url        string
+	httpClient HTTPClient
+}
+
+// NewWebhookSink creates a sink that posts each event as a JSON object.
+func NewWebhookSink(name, url string, httpClient HTTPClient) *WebhookSink {
+	return &WebhookSink{name: name, url: url, httpClient: httpClient}
+}
+
+// Name returns the sink name.
+func (s *WebhookSink) Name() string {
+	return s.name
+}
+
+// Send posts the event as JSON.
+func (s *WebhookSink) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, s.httpClient, s.url, event)
+}
+
+// SlackSink posts events to a Slack-compatible incoming webhook (also accepted by Mattermost, Rocket.Chat and Teams connectors).
+type SlackSink struct {
+	name       string
+	url        string
+	httpClient HTTPClient
+}
+
+// NewSlackSink creates a sink that posts events as incoming webhook messages.
+func NewSlackSink(name, url string, httpClient HTTPClient) *SlackSink {
+	return &SlackSink{name: name, url: url, httpClient: httpClient}
+}
+
+// Name returns the sink name.
+func (s *SlackSink) Name() string {
+	return s.name
+}
+
+// Send posts the event as a Slack message.
+func (s *SlackSink) Send(ctx context.Context, event Event) error {
+	icon := ":red_circle:"
+	if event.Status == domain.StatusSuccess {
+		icon = ":large_green_circle:"
+	}
+
+	text := fmt.Sprintf("%s %s", icon, event.Summary())
+	if event.PipelineURL != "" {
+		text += fmt.Sprintf(" (<%s|pipeline %s>)", event.PipelineURL, event.PipelineID)
+	}
+
+	return postJSON(ctx, s.httpClient, s.url, map[string]string{"text": text})
+}
+
+// EmailConfig holds SMTP settings for the email sink.
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string // empty = no authentication
+	Password string
+	From     string
+	To       []string
+}
+
+// EmailSink sends events as plain text emails over SMTP.
+type EmailSink struct {
+	name     string
+	config   EmailConfig
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailSink creates a sink that emails each event.
+func NewEmailSink(name string, config EmailConfig) *EmailSink {
+	return &EmailSink{name: name, config: config, sendMail: smtp.SendMail}
+}
+
+// Name returns the sink name.
+func (s *EmailSink) Name() string {
+	return s.name
+}
+
+// Send emails the event to all recipients.
+// smtp.SendMail doesn't take a context, so the send runs until the server responds.
+func (s *EmailSink) Send(ctx context.Context, event Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	if err := s.sendMail(addr, auth, s.config.From, s.config.To, s.message(event)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// message builds the RFC 5322 email for an event.
+func (s *EmailSink) message(event Event) []byte {
+	var body strings.Builder
+	body.WriteString(event.Summary() + "\r\n\r\n")
+	body.WriteString(fmt.Sprintf("Repository: %s\r\n", event.Repository))
+	body.WriteString(fmt.Sprintf("Branch: %s\r\n", event.Branch))
+	body.WriteString(fmt.Sprintf("Status: %s (was %s)\r\n", event.Status, event.PreviousStatus))
+	if !event.FailedSince.IsZero() {
+		body.WriteString(fmt.Sprintf("Failing since: %s\r\n", event.FailedSince.Format(time.RFC1123)))
+	}
+	if event.PipelineURL != "" {
+		body.WriteString(fmt.Sprintf("Pipeline: %s\r\n", event.PipelineURL))
+	}
+	body.WriteString(fmt.Sprintf("Rule: %s\r\n", event.Rule))
+
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", s.config.From))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(s.config.To, ", ")))
+	msg.WriteString(fmt.Sprintf("Subject: [CI Dashboard] %s\r\n", event.Summary()))
+	msg.WriteString(fmt.Sprintf("Date: %s\r\n", event.Time.Format(time.RFC1123Z)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(body.String())
+
+	return []byte(msg.String())
+}
+
+// postJSON posts a JSON payload and treats non-2xx responses as errors.
+func postJSON(ctx context.Context, httpClient HTTPClient, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
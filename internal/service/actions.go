@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// PipelineAction is a write action on a project's pipelines.
+type PipelineAction string
+
+const (
+	ActionRetry       PipelineAction = "retry"        // run all jobs of a pipeline again
+	ActionRerunFailed PipelineAction = "rerun-failed" // run the failed jobs of a pipeline again
+	ActionCancel      PipelineAction = "cancel"       // cancel a pending or running pipeline
+	ActionRun         PipelineAction = "run"          // start a new pipeline for a ref
+)
+
+// PipelineActionRequest describes a write action and its target.
+type PipelineActionRequest struct {
+	Action     PipelineAction
+	PipelineID string // required by every action but run
+	Ref        string // branch or tag of the pipeline; required by run
+	WorkflowID string // workflow to run (GitHub Actions)
+}
+
+// RegisterActionClient registers the client performing write actions on a connection's pipelines.
+// It is separate from the connection's reading client so reads keep using a read-only token.
+func (s *PipelineService) RegisterActionClient(connection string, client api.ActionClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actionClients[connection] = client
+}
+
+// HasPipelineActions returns true if write actions are enabled for a project.
+func (s *PipelineService) HasPipelineActions(project domain.Project) bool {
+	return s.getActionClient(project) != nil
+}
+
+// getActionClient returns the write action client of a project's connection, or nil when actions are disabled.
+// Projects whose pipelines run on a pipeline-only client (e.g. Jenkins) have no actions.
+func (s *PipelineService) getActionClient(project domain.Project) api.ActionClient {
+	connection := connectionName(project.Platform, project.ID)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, linked := s.pipelineLinks[connection+":"+project.ID]; linked {
+		return nil
+	}
+	return s.actionClients[connection]
+}
+
+// RunPipelineAction performs a write action on a project's pipelines, then refreshes the
+// cached pipelines of the project so the dashboard reflects the change.
+func (s *PipelineService) RunPipelineAction(ctx context.Context, project domain.Project, req PipelineActionRequest) error {
+	client := s.getActionClient(project)
+	if client == nil {
+		return fmt.Errorf("actions are not enabled for %s", project.ID)
+	}
+
+	if req.Action != ActionRun && req.PipelineID == "" {
+		return fmt.Errorf("pipeline is required to %s", req.Action)
+	}
+
+	var err error
+	switch req.Action {
+	case ActionRetry:
+		err = client.RetryPipeline(ctx, project.ID, req.PipelineID)
+	case ActionRerunFailed:
+		err = client.RerunFailedJobs(ctx, project.ID, req.PipelineID)
+	case ActionCancel:
+		err = client.CancelPipeline(ctx, project.ID, req.PipelineID)
+	case ActionRun:
+		if req.Ref == "" {
+			return fmt.Errorf("ref is required to run a pipeline")
+		}
+		err = client.RunPipeline(ctx, project.ID, req.Ref, req.WorkflowID)
+	default:
+		return fmt.Errorf("unknown action %q", req.Action)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.refreshAfterAction(ctx, project, req.Ref)
+	return nil
+}
+
+// refreshAfterAction re-fetches the cached pipeline lists an action changed.
+// Failures are only logged - the action itself succeeded and later refreshes catch up.
+func (s *PipelineService) refreshAfterAction(ctx context.Context, project domain.Project, ref string) {
+	client := s.getClientForProject(project.Platform, project.ID)
+	cacher, ok := client.(eventCacher)
+	if !ok {
+		return
+	}
+
+	keys := []string{fmt.Sprintf("GetPipelines:%s:50", project.ID)}
+	if ref != "" {
+		keys = append(keys, fmt.Sprintf("GetLatestPipeline:%s:%s", project.ID, ref))
+	}
+
+	for _, key := range keys {
+		cacher.Invalidate(key)
+		if err := cacher.ForceRefresh(ctx, key); err != nil {
+			log.Printf("[%s] Failed to refresh %s after action: %v", project.Platform, key, err)
+		}
+	}
+}
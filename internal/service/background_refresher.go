@@ -122,8 +122,8 @@ func (r *BackgroundRefresher) refreshData() {
 
 	// Fetch repositories with recent runs - this sorts by most recent activity
 	// This ensures we prioritize active repositories (fetched in order of most recent commits)
-	// Fetch 200 pipelines per repo to populate cache for repository detail pages
-	reposWithRuns, err := r.pipelineService.GetRepositoriesWithRecentRuns(ctx, 200)
+	// Read the 50 pipelines per repo cached by ForceRefreshAllCaches (same key as repository detail pages)
+	reposWithRuns, err := r.pipelineService.GetRepositoriesWithRecentRuns(ctx, 50)
 	if err != nil {
 		r.logger.Printf("Background refresher: Failed to fetch repositories with runs: %v", err)
 	}
@@ -132,11 +132,34 @@ func (r *BackgroundRefresher) refreshData() {
 
 	// Collect ALL pipelines from all repositories (not just recent 50)
 	// This ensures we cache default branch pipelines for ALL projects
+	// Finished pipelines are also recorded in the pipeline history for trends
 	var pipelines []domain.Pipeline
 	for _, repo := range reposWithRuns {
 		pipelines = append(pipelines, repo.Runs...)
+		r.pipelineService.RecordPipelines(repo.Project.Platform, repo.Runs)
 	}
 	pipelineCount := len(pipelines)
+
+	// Latest default branch pipelines carry their jobs - record them for flaky job detection
+	// and watch them for status transitions to notify on
+	if r.pipelineService.HasPipelineHistory() || r.pipelineService.HasStatusNotifier() {
+		for _, repo := range reposWithRuns {
+			_, latest, _, err := r.pipelineService.GetDefaultBranchForProject(ctx, repo.Project)
+			if err == nil && latest != nil {
+				r.pipelineService.RecordPipelines(repo.Project.Platform, []domain.Pipeline{*latest})
+				r.pipelineService.ObserveDefaultBranchPipeline(repo.Project, *latest)
+			}
+		}
+	}
+
+	// Test results of finished default branch pipelines tell new test failures from long-standing ones
+	if r.pipelineService.HasPipelineHistory() {
+		projects := make([]domain.Project, 0, len(reposWithRuns))
+		for _, repo := range reposWithRuns {
+			projects = append(projects, repo.Project)
+		}
+		r.pipelineService.RecordDefaultBranchTestResults(ctx, projects)
+	}
 	r.logger.Printf("Background refresher: Collected %d pipelines from %d repositories", pipelineCount, len(reposWithRuns))
 
 	// Fetch branches (these are sorted by last commit date, most recent first)
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheSnapshotter is implemented by caching clients that can persist their cache to disk.
+type cacheSnapshotter interface {
+	SaveSnapshot(path string) (int, error)
+	LoadSnapshot(path string) (int, error)
+}
+
+// cacheSnapshotPath returns the snapshot file for a cache.
+func cacheSnapshotPath(dataDir, name string) string {
+	return filepath.Join(dataDir, fmt.Sprintf("cache-%s.json", name))
+}
+
+// SaveCacheSnapshots writes the cache of every registered client to dataDir (one file per client).
+// Returns the total number of entries written.
+func (s *PipelineService) SaveCacheSnapshots(dataDir string) (int, error) {
+	total := 0
+	var errs []error
+	for name, cacher := range s.cacheSnapshotters() {
+		count, err := cacher.SaveSnapshot(cacheSnapshotPath(dataDir, name))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		total += count
+	}
+
+	if len(errs) > 0 {
+		return total, fmt.Errorf("failed to save cache snapshots: %v", errs)
+	}
+	return total, nil
+}
+
+// LoadCacheSnapshots restores the cache of every registered client from dataDir.
+// Returns the total number of entries restored.
+func (s *PipelineService) LoadCacheSnapshots(dataDir string) (int, error) {
+	total := 0
+	var errs []error
+	for name, cacher := range s.cacheSnapshotters() {
+		count, err := cacher.LoadSnapshot(cacheSnapshotPath(dataDir, name))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		total += count
+	}
+
+	if len(errs) > 0 {
+		return total, fmt.Errorf("failed to load cache snapshots: %v", errs)
+	}
+	return total, nil
+}
+
+// cacheSnapshotters returns the registered clients that support snapshots, keyed by snapshot name:
+// the connection name for connection clients, "pipelines-<connection>" for pipeline-only clients.
+func (s *PipelineService) cacheSnapshotters() map[string]cacheSnapshotter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[string]cacheSnapshotter)
+	for connection, client := range s.clients {
+		if cacher, ok := client.(cacheSnapshotter); ok {
+			result[connection] = cacher
+		}
+	}
+	// Pipeline-only clients are linked per project, with one client per connection
+	for link, client := range s.pipelineLinks {
+		connection, _, _ := strings.Cut(link, ":")
+		if cacher, ok := client.(cacheSnapshotter); ok {
+			result["pipelines-"+connection] = cacher
+		}
+	}
+	return result
+}
+
+// CacheSnapshotter restores the cache from disk on start and saves it periodically and on stop,
+// so restarts serve the last known state immediately instead of starting cold.
+// Follows Single Responsibility Principle - only handles cache persistence.
+type CacheSnapshotter struct {
+	pipelineService *PipelineService
+	dataDir         string
+	interval        time.Duration
+	logger          Logger
+	stopChan        chan struct{}
+	wg              sync.WaitGroup
+	mu              sync.Mutex
+	running         bool
+}
+
+// NewCacheSnapshotter creates a new cache snapshotter.
+// Follows Dependency Injection - accepts dependencies via constructor.
+func NewCacheSnapshotter(pipelineService *PipelineService, dataDir string, interval time.Duration, logger Logger) *CacheSnapshotter {
+	return &CacheSnapshotter{
+		pipelineService: pipelineService,
+		dataDir:         dataDir,
+		interval:        interval,
+		logger:          logger,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// Start restores the cache from disk, then begins periodic snapshots.
+// Restoring is synchronous so it completes before other workers start refreshing.
+func (s *CacheSnapshotter) Start() {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	start := time.Now()
+	restored, err := s.pipelineService.LoadCacheSnapshots(s.dataDir)
+	if err != nil {
+		s.logger.Printf("Cache snapshotter: %v", err)
+	}
+	s.logger.Printf("Cache snapshotter: Restored %d cache entries from %s in %v", restored, s.dataDir, time.Since(start))
+
+	s.logger.Printf("Cache snapshotter: Starting with %v interval", s.interval)
+
+	s.wg.Add(1)
+	go s.snapshotLoop()
+}
+
+// Stop stops periodic snapshots and writes a final snapshot.
+func (s *CacheSnapshotter) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	s.mu.Unlock()
+
+	s.logger.Printf("Cache snapshotter: Stopping...")
+	close(s.stopChan)
+	s.wg.Wait()
+	s.save()
+	s.logger.Printf("Cache snapshotter: Stopped")
+}
+
+// snapshotLoop saves a snapshot on every tick.
+func (s *CacheSnapshotter) snapshotLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.save()
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// save writes a snapshot of all caches and logs the outcome.
+func (s *CacheSnapshotter) save() {
+	start := time.Now()
+	saved, err := s.pipelineService.SaveCacheSnapshots(s.dataDir)
+	if err != nil {
+		s.logger.Printf("Cache snapshotter: %v", err)
+		return
+	}
+	s.logger.Printf("Cache snapshotter: Saved %d cache entries in %v", saved, time.Since(start))
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// fakeSnapshotCache is a test double for a caching client that persists its cache.
+// Other client methods panic if called.
+type fakeSnapshotCache struct {
+	api.Client
+}
+
+func (f *fakeSnapshotCache) SaveSnapshot(path string) (int, error) { return 0, nil }
+
+func (f *fakeSnapshotCache) LoadSnapshot(path string) (int, error) { return 0, nil }
+
+// TestCacheSnapshotters tests that connection and pipeline-only clients get one stable snapshot each.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestCacheSnapshotters(t *testing.T) {
+	// Arrange
+	s := NewPipelineService(nil, false)
+	gitlab, internal := &fakeSnapshotCache{}, &fakeSnapshotCache{}
+	gitlabJenkins, internalJenkins := &fakeSnapshotCache{}, &fakeSnapshotCache{}
+	s.RegisterConnection(domain.PlatformGitLab, domain.PlatformGitLab, gitlab)
+	s.RegisterConnection("gitlab-internal", domain.PlatformGitLab, internal)
+	s.RegisterPipelineClient(domain.PlatformGitLab, []string{"1", "2"}, gitlabJenkins)
+	s.RegisterPipelineClient("gitlab-internal", []string{"1@gitlab-internal"}, internalJenkins)
+
+	// Act
+	snapshotters := s.cacheSnapshotters()
+
+	// Assert
+	expected := map[string]cacheSnapshotter{
+		"gitlab":                    gitlab,
+		"gitlab-internal":           internal,
+		"pipelines-gitlab":          gitlabJenkins,
+		"pipelines-gitlab-internal": internalJenkins,
+	}
+	if len(snapshotters) != len(expected) {
+		t.Fatalf("expected %d snapshots, got %v", len(expected), snapshotters)
+	}
+	for name, cacher := range expected {
+		if snapshotters[name] != cacher {
+			t.Errorf("expected snapshot %s of its own client", name)
+		}
+	}
+}
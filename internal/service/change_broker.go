@@ -0,0 +1,87 @@
+package service
+
+import (
+	"log"
+	"strings"
+	"sync"
+)
+
+// ChangeSubscriberBuffer is the number of pending changes buffered per subscriber.
+// Changes for slow subscribers are dropped once the buffer is full.
+const ChangeSubscriberBuffer = 1024
+
+// RepositoryChange identifies a repository whose cached data changed.
+// An empty ProjectID means the project list itself changed.
+type RepositoryChange struct {
+	Platform  string
+	ProjectID string
+}
+
+// ChangeBroker fans out repository changes to subscribers (e.g. SSE streams).
+// Follows Single Responsibility Principle - only handles change distribution.
+type ChangeBroker struct {
+	subscribers map[chan RepositoryChange]struct{}
+	mu          sync.Mutex
+}
+
+// NewChangeBroker creates a new change broker.
+func NewChangeBroker() *ChangeBroker {
+	return &ChangeBroker{
+		subscribers: make(map[chan RepositoryChange]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber.
+// Returns the channel changes are delivered on and a function that unsubscribes and closes it.
+func (b *ChangeBroker) Subscribe() (<-chan RepositoryChange, func()) {
+	ch := make(chan RepositoryChange, ChangeSubscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish delivers a change to all subscribers without blocking.
+func (b *ChangeBroker) Publish(change RepositoryChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- change:
+		default:
+			log.Printf("[ChangeBroker] Subscriber buffer full, dropping change for %s:%s", change.Platform, change.ProjectID)
+		}
+	}
+}
+
+// repositoryChangeForKey maps a cache key to the repository change it represents.
+// Only keys shown in the repositories table are relevant: the project list,
+// latest pipelines, branches and merge requests.
+func repositoryChangeForKey(platform, key string) (RepositoryChange, bool) {
+	parts := strings.Split(key, ":")
+
+	switch parts[0] {
+	case "GetProjects":
+		return RepositoryChange{Platform: platform}, true
+	case "GetLatestPipeline", "GetBranches", "GetMergeRequests":
+		if len(parts) < 2 || parts[1] == "" {
+			return RepositoryChange{}, false
+		}
+		return RepositoryChange{Platform: platform, ProjectID: parts[1]}, true
+	default:
+		return RepositoryChange{}, false
+	}
+}
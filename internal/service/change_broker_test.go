@@ -0,0 +1,87 @@
+package service
+
+import (
+	"testing"
+)
+
+// TestChangeBroker_Publish tests that changes reach every subscriber, and none after unsubscribing.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestChangeBroker_Publish(t *testing.T) {
+	// Arrange
+	broker := NewChangeBroker()
+	first, unsubscribeFirst := broker.Subscribe()
+	second, unsubscribeSecond := broker.Subscribe()
+	defer unsubscribeSecond()
+	change := RepositoryChange{Platform: "gitlab", ProjectID: "123"}
+
+	// Act
+	broker.Publish(change)
+	unsubscribeFirst()
+	unsubscribeFirst() // unsubscribing twice is harmless
+	broker.Publish(RepositoryChange{Platform: "gitlab", ProjectID: "456"})
+
+	// Assert
+	if got := <-first; got != change {
+		t.Errorf("expected first subscriber to receive %+v, got %+v", change, got)
+	}
+	if _, open := <-first; open {
+		t.Error("expected the first subscriber's channel to be closed after unsubscribing")
+	}
+	if got := <-second; got != change {
+		t.Errorf("expected second subscriber to receive %+v, got %+v", change, got)
+	}
+	if got := <-second; got.ProjectID != "456" {
+		t.Errorf("expected second subscriber to receive the later change, got %+v", got)
+	}
+}
+
+// TestChangeBroker_SlowSubscriber tests that publishing never blocks on a full subscriber buffer.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestChangeBroker_SlowSubscriber(t *testing.T) {
+	// Arrange
+	broker := NewChangeBroker()
+	changes, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	// Act
+	for i := 0; i < ChangeSubscriberBuffer+10; i++ {
+		broker.Publish(RepositoryChange{Platform: "github", ProjectID: "acme/api"})
+	}
+
+	// Assert
+	if len(changes) != ChangeSubscriberBuffer {
+		t.Errorf("expected %d buffered changes with the rest dropped, got %d", ChangeSubscriberBuffer, len(changes))
+	}
+}
+
+// TestRepositoryChangeForKey tests mapping cache keys to repository changes.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestRepositoryChangeForKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected RepositoryChange
+		ok       bool
+	}{
+		{"GetProjects", RepositoryChange{Platform: "gitlab"}, true},
+		{"GetLatestPipeline:123:main", RepositoryChange{Platform: "gitlab", ProjectID: "123"}, true},
+		{"GetBranches:123:200", RepositoryChange{Platform: "gitlab", ProjectID: "123"}, true},
+		{"GetMergeRequests:123", RepositoryChange{Platform: "gitlab", ProjectID: "123"}, true},
+		{"GetMergeRequests:", RepositoryChange{}, false},
+		{"GetLatestPipeline", RepositoryChange{}, false},
+		{"GetPipelines:123:50", RepositoryChange{}, false},
+		{"GetProjectCount", RepositoryChange{}, false},
+		{"GetIssues:123", RepositoryChange{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			// Act
+			change, ok := repositoryChangeForKey("gitlab", tt.key)
+
+			// Assert
+			if ok != tt.ok || change != tt.expected {
+				t.Errorf("expected %+v (%v), got %+v (%v)", tt.expected, tt.ok, change, ok)
+			}
+		})
+	}
+}
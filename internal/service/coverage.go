@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+)
+
+// CoverageTrendDays is how far back pipeline history is searched for the coverage of earlier pipelines
+const CoverageTrendDays = 90
+
+// CoveragePoint is the coverage of a recorded pipeline.
+type CoveragePoint struct {
+	PipelineID string    `json:"pipeline_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	Coverage   float64   `json:"coverage"`
+}
+
+// coverageCacher is implemented by caching clients that can read coverage from pipeline artifacts
+// and store it with a cached latest pipeline.
+type coverageCacher interface {
+	GetLatestPipeline(ctx context.Context, projectID, branch string) (*domain.Pipeline, error)
+	GetCoverage(ctx context.Context, projectID, pipelineID string) (*float64, error)
+	SetCoverage(projectID, branch, pipelineID string, coverage float64)
+}
+
+// refreshCoverage reads the coverage of a branch's cached latest pipeline from its artifacts when the
+// pipeline didn't report any, and caches it with the pipeline. Only finished pipelines are read.
+func (s *PipelineService) refreshCoverage(ctx context.Context, client interface{}, projectID, branch string) {
+	cacher, ok := client.(coverageCacher)
+	if !ok {
+		return
+	}
+
+	pipeline, err := cacher.GetLatestPipeline(ctx, projectID, branch)
+	if err != nil || pipeline == nil || !pipeline.Status.IsTerminal() || pipeline.Coverage != nil {
+		return
+	}
+
+	coverage, err := cacher.GetCoverage(ctx, projectID, pipeline.ID)
+	if err != nil {
+		log.Printf("[Coverage] Failed to read coverage of pipeline %s of %s: %v", pipeline.ID, projectID, err)
+		return
+	}
+	if coverage != nil {
+		cacher.SetCoverage(projectID, branch, pipeline.ID, *coverage)
+	}
+}
+
+// GetCoverageTrend returns the coverage of the recorded pipelines of a project's branch, oldest first.
+// Returns nil when history is disabled or no pipeline reported coverage.
+func (s *PipelineService) GetCoverageTrend(project domain.Project, branch string) []CoveragePoint {
+	h := s.pipelineHistory()
+	if h == nil {
+		return nil
+	}
+	since := time.Now().AddDate(0, 0, -CoverageTrendDays)
+	return CoverageTrend(h.Records(project.Platform, project.ID, branch, since))
+}
+
+// GetCoverageDelta returns how much a pipeline's coverage changed since the previous recorded
+// pipeline of its branch with coverage. Returns nil when either coverage is unknown.
+func (s *PipelineService) GetCoverageDelta(project domain.Project, pipeline *domain.Pipeline) *float64 {
+	h := s.pipelineHistory()
+	if h == nil || pipeline == nil || pipeline.Coverage == nil {
+		return nil
+	}
+
+	previous, ok := h.PreviousCoverage(project.Platform, project.ID, pipeline.Branch, pipeline.ID, pipeline.CreatedAt)
+	if !ok {
+		return nil
+	}
+	delta := *pipeline.Coverage - previous
+	return &delta
+}
+
+// CoverageTrend returns the coverage of the records that have it, in record order.
+func CoverageTrend(records []history.Record) []CoveragePoint {
+	var points []CoveragePoint
+	for _, r := range records {
+		if r.Coverage != nil {
+			points = append(points, CoveragePoint{PipelineID: r.PipelineID, CreatedAt: r.CreatedAt, Coverage: *r.Coverage})
+		}
+	}
+	return points
+}
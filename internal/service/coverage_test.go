@@ -0,0 +1,53 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+)
+
+// TestGetCoverageDelta tests comparing a pipeline's coverage with the previous pipeline of its branch that reported coverage.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestGetCoverageDelta(t *testing.T) {
+	// Arrange
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.jsonl"), 90*24*time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	start := time.Now().Add(-24 * time.Hour)
+	percent := func(v float64) *float64 { return &v }
+	store.Add("gitlab", []domain.Pipeline{
+		{ID: "1", ProjectID: "123", Branch: "main", Status: domain.StatusSuccess, CreatedAt: start, Coverage: percent(80)},
+		{ID: "2", ProjectID: "123", Branch: "main", Status: domain.StatusSuccess, CreatedAt: start.Add(time.Hour), Coverage: percent(82.5)},
+		{ID: "3", ProjectID: "123", Branch: "main", Status: domain.StatusSuccess, CreatedAt: start.Add(2 * time.Hour)},                               // no coverage reported
+		{ID: "6", ProjectID: "123", Branch: "feature", Status: domain.StatusSuccess, CreatedAt: start.Add(150 * time.Minute), Coverage: percent(50)}, // other branch
+		{ID: "4", ProjectID: "123", Branch: "main", Status: domain.StatusSuccess, CreatedAt: start.Add(3 * time.Hour), Coverage: percent(81)},
+		{ID: "5", ProjectID: "123", Branch: "main", Status: domain.StatusSuccess, CreatedAt: start.Add(4 * time.Hour), Coverage: percent(90)}, // after the pipeline
+	})
+	s := NewPipelineService(nil, false)
+	s.SetPipelineHistory(store)
+	project := domain.Project{ID: "123", Platform: "gitlab"}
+
+	// Act
+	delta := s.GetCoverageDelta(project, &domain.Pipeline{ID: "4", Branch: "main", CreatedAt: start.Add(3 * time.Hour), Coverage: percent(81)})
+	first := s.GetCoverageDelta(project, &domain.Pipeline{ID: "1", Branch: "main", CreatedAt: start, Coverage: percent(80)})
+	unreported := s.GetCoverageDelta(project, &domain.Pipeline{ID: "3", Branch: "main", CreatedAt: start.Add(2 * time.Hour)})
+	trend := CoverageTrend(store.Records("gitlab", "123", "main", time.Time{}))
+
+	// Assert
+	if delta == nil || *delta != -1.5 {
+		t.Errorf("expected a delta of -1.5 versus pipeline 2, got %v", delta)
+	}
+	if first != nil {
+		t.Errorf("expected no delta without an earlier pipeline, got %v", *first)
+	}
+	if unreported != nil {
+		t.Errorf("expected no delta without coverage, got %v", *unreported)
+	}
+	if len(trend) != 4 || trend[0].PipelineID != "1" || trend[3].Coverage != 90 {
+		t.Errorf("expected the 4 pipelines with coverage, got %+v", trend)
+	}
+}
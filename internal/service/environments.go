@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+const (
+	// EnvironmentsRefreshInterval is how often the environments of projects that deploy are refreshed.
+	// Deployments change less often than pipelines, and GitHub needs a request per environment.
+	EnvironmentsRefreshInterval = 30 * time.Minute
+
+	// IdleEnvironmentsRefreshInterval is how often projects without deployments are checked for new ones.
+	IdleEnvironmentsRefreshInterval = 6 * time.Hour
+)
+
+// cacheRefresher is implemented by caching clients that can re-fetch a cache key.
+type cacheRefresher interface {
+	ForceRefresh(ctx context.Context, key string) error
+}
+
+// ProjectEnvironments is a project with the environments it deploys to.
+type ProjectEnvironments struct {
+	Project      domain.Project
+	Environments []domain.Environment
+}
+
+// GetEnvironmentsForProject retrieves the environments of a project with their latest deployment.
+// Returns an empty list for platforms without deployments.
+func (s *PipelineService) GetEnvironmentsForProject(ctx context.Context, project domain.Project) ([]domain.Environment, error) {
+	c := s.getClientForProject(project.Platform, project.ID)
+	if c == nil {
+		return nil, fmt.Errorf("no client for platform: %s", project.Platform)
+	}
+
+	// Check if client supports DeploymentsClient interface
+	client, ok := c.(api.DeploymentsClient)
+	if !ok {
+		return []domain.Environment{}, nil
+	}
+
+	environments, err := client.GetEnvironments(ctx, project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get environments for %s: %w", project.Name, err)
+	}
+
+	// Set repository name from project
+	for i := range environments {
+		environments[i].Repository = project.Name
+	}
+
+	return environments, nil
+}
+
+// GetAllEnvironments retrieves the environments of every project that has any, sorted by project name.
+func (s *PipelineService) GetAllEnvironments(ctx context.Context) ([]ProjectEnvironments, error) {
+	projects, err := s.GetAllProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	all := processProjectsConcurrently(ctx, projects, MaxConcurrentWorkers,
+		func(ctx context.Context, project domain.Project) ([]ProjectEnvironments, error) {
+			environments, err := s.GetEnvironmentsForProject(ctx, project)
+			if err != nil || len(environments) == 0 {
+				return nil, err
+			}
+			return []ProjectEnvironments{{Project: project, Environments: environments}}, nil
+		})
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Project.Name < all[j].Project.Name
+	})
+	return all, nil
+}
+
+// GetProductionDeploymentsForProject retrieves the deployments of a project to production environments since the given time.
+// Returns an empty list for platforms without deployments.
+func (s *PipelineService) GetProductionDeploymentsForProject(ctx context.Context, project domain.Project, since time.Time) ([]domain.Deployment, error) {
+	c := s.getClientForProject(project.Platform, project.ID)
+	if c == nil {
+		return nil, fmt.Errorf("no client for platform: %s", project.Platform)
+	}
+
+	client, ok := c.(api.ProductionDeploymentsClient)
+	if !ok {
+		return []domain.Deployment{}, nil
+	}
+
+	deployments, err := client.GetProductionDeployments(ctx, project.ID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get production deployments for %s: %w", project.Name, err)
+	}
+	return deployments, nil
+}
+
+// refreshEnvironments refreshes the cached environments of a project when they are due.
+// Projects whose last refresh found no deployments are refreshed on IdleEnvironmentsRefreshInterval.
+// With pipeline history, the deployment history of projects that deploy to production is refreshed
+// with them for DORA metrics.
+func (s *PipelineService) refreshEnvironments(ctx context.Context, client cacheRefresher, platform, projectID string) {
+	key := platform + ":" + projectID
+	now := time.Now()
+
+	s.refreshMu.Lock()
+	due := !now.Before(s.environmentsDue[key])
+	if due {
+		s.environmentsDue[key] = now.Add(EnvironmentsRefreshInterval)
+	}
+	s.refreshMu.Unlock()
+	if !due {
+		return
+	}
+
+	if err := client.ForceRefresh(ctx, fmt.Sprintf("GetEnvironments:%s", projectID)); err != nil {
+		// Ignore errors - not all clients support this
+		return
+	}
+
+	cached, ok := client.(interface {
+		GetEnvironments(ctx context.Context, projectID string) ([]domain.Environment, error)
+	})
+	if !ok {
+		return
+	}
+	environments, err := cached.GetEnvironments(ctx, projectID)
+	if err != nil {
+		return
+	}
+	if !hasDeployments(environments) {
+		s.refreshMu.Lock()
+		s.environmentsDue[key] = now.Add(IdleEnvironmentsRefreshInterval)
+		s.refreshMu.Unlock()
+		return
+	}
+
+	// No lock needed - ForceRefreshAllCaches holds s.mu
+	if s.history != nil && hasProductionDeployments(environments) {
+		if err := client.ForceRefresh(ctx, fmt.Sprintf("GetProductionDeployments:%s", projectID)); err != nil {
+			log.Printf("[%s] Failed to refresh production deployments of %s: %v", platform, projectID, err)
+		}
+	}
+}
+
+// hasProductionDeployments returns true if anything was deployed to a production environment.
+func hasProductionDeployments(environments []domain.Environment) bool {
+	for _, environment := range environments {
+		if environment.LastDeployment != nil && environment.LastDeployment.Production {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDeployments returns true if anything was deployed to any of the environments.
+func hasDeployments(environments []domain.Environment) bool {
+	for _, environment := range environments {
+		if environment.LastDeployment != nil {
+			return true
+		}
+	}
+	return false
+}
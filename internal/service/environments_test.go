@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// fakeEnvironmentsCache is a test double for a caching client that counts environment refreshes.
+type fakeEnvironmentsCache struct {
+	environments []domain.Environment
+	refreshes    int
+}
+
+func (f *fakeEnvironmentsCache) ForceRefresh(ctx context.Context, key string) error {
+	f.refreshes++
+	return nil
+}
+
+func (f *fakeEnvironmentsCache) GetEnvironments(ctx context.Context, projectID string) ([]domain.Environment, error) {
+	return f.environments, nil
+}
+
+// TestRefreshEnvironments_Interval tests that environments are refreshed once per interval,
+// and projects without deployments on the longer idle interval.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestRefreshEnvironments_Interval(t *testing.T) {
+	// Arrange
+	s := NewPipelineService(nil, false)
+	deploying := &fakeEnvironmentsCache{environments: []domain.Environment{
+		{Name: "production", LastDeployment: &domain.Deployment{ID: "1"}},
+	}}
+	idle := &fakeEnvironmentsCache{environments: []domain.Environment{{Name: "staging"}}}
+	ctx := context.Background()
+
+	// Act
+	s.refreshEnvironments(ctx, deploying, domain.PlatformGitHub, "acme/api")
+	s.refreshEnvironments(ctx, deploying, domain.PlatformGitHub, "acme/api")
+	s.refreshEnvironments(ctx, idle, domain.PlatformGitHub, "acme/docs")
+	s.refreshEnvironments(ctx, idle, domain.PlatformGitHub, "acme/docs")
+
+	// Assert
+	if deploying.refreshes != 1 || idle.refreshes != 1 {
+		t.Fatalf("expected one refresh per interval, got %d and %d", deploying.refreshes, idle.refreshes)
+	}
+
+	deployingDue := s.environmentsDue[domain.PlatformGitHub+":acme/api"]
+	idleDue := s.environmentsDue[domain.PlatformGitHub+":acme/docs"]
+	if got := idleDue.Sub(deployingDue); got < IdleEnvironmentsRefreshInterval-EnvironmentsRefreshInterval-time.Minute {
+		t.Errorf("expected idle project to be refreshed %v later, got %v", IdleEnvironmentsRefreshInterval-EnvironmentsRefreshInterval, got)
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+const (
+	// EventPollActivityWindow limits event polling to projects with recent activity.
+	// Dormant projects are still picked up by the periodic full refresh.
+	EventPollActivityWindow = 7 * 24 * time.Hour
+
+	// EventPollTimeout is the maximum time allowed for a single polling round
+	EventPollTimeout = 2 * time.Minute
+
+	// MaxConcurrentEventPolls limits concurrent event requests per polling round
+	MaxConcurrentEventPolls = 10
+)
+
+// EventPoller periodically polls project events and refreshes only the affected cache keys.
+// This makes pushes and merge request changes visible within seconds instead of waiting
+// for the next full background refresh.
+// Follows Single Responsibility Principle - only handles event-driven cache invalidation.
+type EventPoller struct {
+	pipelineService *PipelineService
+	pollInterval    time.Duration
+	logger          Logger
+	lastPoll        map[string]time.Time // platform:projectID -> time of last successful poll
+	lastPollMu      sync.Mutex
+	stopChan        chan struct{}
+	wg              sync.WaitGroup
+	mu              sync.Mutex
+	running         bool
+}
+
+// NewEventPoller creates a new event poller.
+// Follows Dependency Injection - accepts dependencies via constructor.
+func NewEventPoller(pipelineService *PipelineService, pollInterval time.Duration, logger Logger) *EventPoller {
+	return &EventPoller{
+		pipelineService: pipelineService,
+		pollInterval:    pollInterval,
+		logger:          logger,
+		lastPoll:        make(map[string]time.Time),
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// Start begins periodic event polling.
+// Non-blocking - launches goroutine and returns immediately.
+func (p *EventPoller) Start() {
+	p.mu.Lock()
+	if p.running {
+		p.mu.Unlock()
+		return
+	}
+	p.running = true
+	p.mu.Unlock()
+
+	p.logger.Printf("Event poller: Starting with %v interval", p.pollInterval)
+
+	p.wg.Add(1)
+	go p.pollLoop()
+}
+
+// Stop gracefully stops the event poller.
+func (p *EventPoller) Stop() {
+	p.mu.Lock()
+	if !p.running {
+		p.mu.Unlock()
+		return
+	}
+	p.running = false
+	p.mu.Unlock()
+
+	p.logger.Printf("Event poller: Stopping...")
+	close(p.stopChan)
+	p.wg.Wait()
+	p.logger.Printf("Event poller: Stopped")
+}
+
+// pollLoop polls events on every tick.
+// Events that happened before the poller started are ignored - the full refresh covers them.
+func (p *EventPoller) pollLoop() {
+	defer p.wg.Done()
+
+	startedAt := time.Now()
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.poll(startedAt)
+		case <-p.stopChan:
+			return
+		}
+	}
+}
+
+// poll checks all recently active projects for new events and refreshes affected caches.
+func (p *EventPoller) poll(startedAt time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), EventPollTimeout)
+	defer cancel()
+
+	// Projects come from cache, so this doesn't cost API calls
+	projects, err := p.pipelineService.GetAllProjects(ctx)
+	if err != nil {
+		p.logger.Printf("Event poller: Failed to get projects: %v", err)
+		return
+	}
+
+	cutoff := time.Now().Add(-EventPollActivityWindow)
+	var active []domain.Project
+	for _, project := range projects {
+		if project.LastActivity.IsZero() || project.LastActivity.After(cutoff) {
+			active = append(active, project)
+		}
+	}
+
+	refreshed := processProjectsConcurrently(ctx, active, MaxConcurrentEventPolls,
+		func(ctx context.Context, project domain.Project) ([]int, error) {
+			return []int{p.pollProject(ctx, project, startedAt)}, nil
+		})
+
+	total := 0
+	for _, count := range refreshed {
+		total += count
+	}
+	if total > 0 {
+		p.logger.Printf("Event poller: Refreshed %d cache entries across %d active projects", total, len(active))
+	}
+}
+
+// pollProject fetches new events for a single project and refreshes affected caches.
+// Returns the number of refreshed cache keys.
+func (p *EventPoller) pollProject(ctx context.Context, project domain.Project, startedAt time.Time) int {
+	pollStart := time.Now()
+	key := project.Platform + ":" + project.ID
+
+	p.lastPollMu.Lock()
+	since, ok := p.lastPoll[key]
+	p.lastPollMu.Unlock()
+	if !ok {
+		since = startedAt
+	}
+
+	events, err := p.pipelineService.GetEventsForProject(ctx, project, since)
+	if err != nil {
+		p.logger.Printf("Event poller: Failed to get events for %s: %v", project.Name, err)
+		return 0
+	}
+
+	p.lastPollMu.Lock()
+	p.lastPoll[key] = pollStart
+	p.lastPollMu.Unlock()
+
+	if len(events) == 0 {
+		return 0
+	}
+
+	p.logger.Printf("Event poller: %d new event(s) for %s", len(events), project.Name)
+	return p.pipelineService.RefreshForEvents(ctx, project, events)
+}
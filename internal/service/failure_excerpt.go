@@ -0,0 +1,258 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+const (
+	// MaxExcerptJobs is the number of failed jobs whose logs are read for a pipeline's failure excerpt.
+	MaxExcerptJobs = 2
+
+	maxLogTailBytes = 256 * 1024 // failures are at the end of a log - only its tail is kept
+	maxExcerptLines = 8
+	maxExcerptBytes = 600
+	maxFailingTests = 10
+)
+
+var (
+	ansiEscapePattern    = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+	sectionMarkerPattern = regexp.MustCompile(`section_(?:start|end):\d+:\S*`)
+	logTimestampPattern  = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?Z ?`)
+	errorLinePattern     = regexp.MustCompile(`(?i)\b(?:error|errors|fatal|failed|failure|exception|panic)\b`)
+
+	// Lines runners print after any failure - they don't tell why it failed
+	noiseLinePattern = regexp.MustCompile(`^(?:ERROR: Job failed|##\[error\]Process completed with exit code|##\[(?:end)?group\]|Cleaning up|Uploading artifacts|Post job cleanup)`)
+
+	// Failing test names reported by common test runners
+	failingTestPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^--- FAIL: (\S+)`),                         // go test
+		regexp.MustCompile(`^FAILED (\S+)`),                            // pytest
+		regexp.MustCompile(`^[✕×] (.+?)(?: \(\d+ ?m?s\))?$`),           // jest
+		regexp.MustCompile(`^rspec (\./\S+)`),                          // rspec
+		regexp.MustCompile(`^\[ERROR\] (\S+).*<<< (?:FAILURE|ERROR)!`), // maven surefire
+	}
+)
+
+// failureExcerptCacher is implemented by caching clients that keep failure excerpts with cached pipelines.
+type failureExcerptCacher interface {
+	GetLatestPipeline(ctx context.Context, projectID, branch string) (*domain.Pipeline, error)
+	GetJobLog(ctx context.Context, projectID, jobID string) (io.ReadCloser, error)
+	SetFailureExcerpt(projectID, branch, pipelineID, excerpt string)
+}
+
+// GetJobLog returns the raw log of a job of a project's pipelines. The caller must close it.
+func (s *PipelineService) GetJobLog(ctx context.Context, project domain.Project, jobID string) (io.ReadCloser, error) {
+	client, ok := s.getPipelineClient(project.Platform, project.ID).(api.JobLogClient)
+	if !ok {
+		return nil, fmt.Errorf("job logs are not available for %s", project.Name)
+	}
+	return client.GetJobLog(ctx, project.ID, jobID)
+}
+
+// refreshFailureExcerpt extracts why a branch's cached latest pipeline failed from the logs of its
+// failed jobs, and caches the excerpt with the pipeline. Logs are read once per pipeline: pipelines that
+// have an excerpt already, or whose logs didn't yield one, are skipped.
+func (s *PipelineService) refreshFailureExcerpt(ctx context.Context, client interface{}, projectID, branch string) {
+	cacher, ok := client.(failureExcerptCacher)
+	if !ok {
+		return
+	}
+
+	pipeline, err := cacher.GetLatestPipeline(ctx, projectID, branch)
+	if err != nil || pipeline == nil || pipeline.Status != domain.StatusFailed || pipeline.FailureExcerpt != "" {
+		return
+	}
+
+	key := projectID + ":" + branch
+	s.refreshMu.Lock()
+	tried := s.excerptTried[key] == pipeline.ID
+	s.excerptTried[key] = pipeline.ID
+	s.refreshMu.Unlock()
+	if tried {
+		return
+	}
+
+	var excerpts []string
+	for _, build := range pipeline.FailedBuilds() {
+		if len(excerpts) == MaxExcerptJobs {
+			break
+		}
+
+		excerpt, err := jobFailureExcerpt(ctx, cacher, projectID, build.ID)
+		if err != nil {
+			log.Printf("[FailureExcerpt] Failed to read log of job %s of %s: %v", build.Name, projectID, err)
+			continue
+		}
+		if excerpt != "" {
+			excerpts = append(excerpts, build.Name+":\n"+excerpt)
+		}
+	}
+
+	if len(excerpts) > 0 {
+		cacher.SetFailureExcerpt(projectID, branch, pipeline.ID, strings.Join(excerpts, "\n\n"))
+	}
+}
+
+// jobFailureExcerpt downloads the log of a job and extracts its failure excerpt.
+func jobFailureExcerpt(ctx context.Context, cacher failureExcerptCacher, projectID, jobID string) (string, error) {
+	logReader, err := cacher.GetJobLog(ctx, projectID, jobID)
+	if err != nil {
+		return "", err
+	}
+	defer logReader.Close()
+
+	tail, err := readLogTail(logReader, maxLogTailBytes)
+	if err != nil {
+		return "", err
+	}
+	return ExtractFailureExcerpt(tail), nil
+}
+
+// readLogTail reads a log to its end and returns its last n bytes, starting at a line boundary.
+func readLogTail(r io.Reader, n int) (string, error) {
+	var tail []byte
+	truncated := false
+	chunk := make([]byte, 32*1024)
+	for {
+		read, err := r.Read(chunk)
+		tail = append(tail, chunk[:read]...)
+		if len(tail) > 2*n {
+			tail = append([]byte(nil), tail[len(tail)-n:]...)
+			truncated = true
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if len(tail) > n {
+		tail = tail[len(tail)-n:]
+		truncated = true
+	}
+	if truncated {
+		// Drop the partial first line
+		if i := bytes.IndexByte(tail, '\n'); i >= 0 {
+			tail = tail[i+1:]
+		}
+	}
+	return string(tail), nil
+}
+
+// ExtractFailureExcerpt returns a short explanation of why a job failed from its log:
+// the names of the failing tests when a known test runner reports them, otherwise the last
+// block of output ending in an error, otherwise the last lines of the log.
+func ExtractFailureExcerpt(jobLog string) string {
+	lines := cleanLogLines(jobLog)
+
+	if tests := failingTests(lines); len(tests) > 0 {
+		return truncateExcerpt("Failing tests: " + strings.Join(tests, ", "))
+	}
+	if block := lastErrorBlock(lines); len(block) > 0 {
+		return truncateExcerpt(strings.Join(block, "\n"))
+	}
+	return truncateExcerpt(strings.Join(lastLogLines(lines), "\n"))
+}
+
+// cleanLogLines splits a log into lines as a terminal shows them: without escape sequences,
+// overwritten text, GitLab section markers or GitHub timestamps.
+func cleanLogLines(jobLog string) []string {
+	rawLines := strings.Split(jobLog, "\n")
+	lines := make([]string, 0, len(rawLines))
+	for _, line := range rawLines {
+		line = strings.TrimRight(line, "\r")
+		if i := strings.LastIndexByte(line, '\r'); i >= 0 {
+			line = line[i+1:]
+		}
+		line = ansiEscapePattern.ReplaceAllString(line, "")
+		line = sectionMarkerPattern.ReplaceAllString(line, "")
+		line = logTimestampPattern.ReplaceAllString(line, "")
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	return lines
+}
+
+// failingTests returns the distinct names of failing tests reported in the log, in order.
+func failingTests(lines []string) []string {
+	seen := make(map[string]bool)
+	var tests []string
+	for _, line := range lines {
+		for _, pattern := range failingTestPatterns {
+			match := pattern.FindStringSubmatch(line)
+			if match == nil || seen[match[1]] {
+				continue
+			}
+			seen[match[1]] = true
+			tests = append(tests, match[1])
+		}
+	}
+
+	if len(tests) > maxFailingTests {
+		tests = append(tests[:maxFailingTests], fmt.Sprintf("and %d more", len(tests)-maxFailingTests))
+	}
+	return tests
+}
+
+// lastErrorBlock returns the last line mentioning an error, with the output leading up to it.
+// The block stops at an empty line, so unrelated earlier output is left out.
+func lastErrorBlock(lines []string) []string {
+	end := -1
+	for i := len(lines) - 1; i >= 0; i-- {
+		if isExcerptLine(lines[i]) && errorLinePattern.MatchString(lines[i]) {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil
+	}
+
+	start := end
+	for start > 0 && end-start < maxExcerptLines-1 && isExcerptLine(lines[start-1]) {
+		start--
+	}
+	return lines[start : end+1]
+}
+
+// lastLogLines returns the last lines of the log worth showing.
+func lastLogLines(lines []string) []string {
+	var last []string
+	for i := len(lines) - 1; i >= 0 && len(last) < maxExcerptLines; i-- {
+		if isExcerptLine(lines[i]) {
+			last = append([]string{lines[i]}, last...)
+		}
+	}
+	return last
+}
+
+// isExcerptLine returns true if a cleaned log line can be part of a failure excerpt.
+func isExcerptLine(line string) bool {
+	return line != "" && !noiseLinePattern.MatchString(line)
+}
+
+// truncateExcerpt shortens an excerpt to maxExcerptBytes, without splitting a character.
+func truncateExcerpt(excerpt string) string {
+	excerpt = strings.TrimRightFunc(excerpt, unicode.IsSpace)
+	if len(excerpt) <= maxExcerptBytes {
+		return excerpt
+	}
+
+	cut := maxExcerptBytes
+	for cut > 0 && !utf8.RuneStart(excerpt[cut]) {
+		cut--
+	}
+	return excerpt[:cut] + "…"
+}
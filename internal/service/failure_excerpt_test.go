@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestExtractFailureExcerpt_FailingTests tests that failing test names are preferred over error lines.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestExtractFailureExcerpt_FailingTests(t *testing.T) {
+	// Arrange
+	jobLog := "section_start:1700000000:step_script\r\x1b[0K\x1b[32;1m$ go test ./...\x1b[0;m\n" +
+		"--- FAIL: TestParse (0.00s)\n" +
+		"    parse_test.go:12: expected 1, got 2\n" +
+		"    --- FAIL: TestLoad/missing_file (0.01s)\n" +
+		"FAIL\n" +
+		"section_end:1700000001:step_script\r\x1b[0K\n" +
+		"\x1b[31;1mERROR: Job failed: exit code 1\x1b[0;m\n"
+
+	// Act
+	excerpt := ExtractFailureExcerpt(jobLog)
+
+	// Assert
+	expected := "Failing tests: TestParse, TestLoad/missing_file"
+	if excerpt != expected {
+		t.Errorf("expected %q, got %q", expected, excerpt)
+	}
+}
+
+// TestExtractFailureExcerpt_LastErrorBlock tests that the last error is shown with the output leading up to it,
+// without the runner's own failure lines or GitHub timestamps.
+func TestExtractFailureExcerpt_LastErrorBlock(t *testing.T) {
+	// Arrange
+	jobLog := strings.Join([]string{
+		"2024-01-01T10:00:00.0000000Z ##[group]Run make build",
+		"2024-01-01T10:00:01.0000000Z go build ./...",
+		"2024-01-01T10:00:02.0000000Z ##[endgroup]",
+		"2024-01-01T10:00:03.0000000Z ./main.go:12:2: undefined: config",
+		"2024-01-01T10:00:03.1000000Z make: *** [Makefile:8: build] Error 1",
+		"2024-01-01T10:00:03.2000000Z ##[error]Process completed with exit code 2.",
+		"",
+	}, "\n")
+
+	// Act
+	excerpt := ExtractFailureExcerpt(jobLog)
+
+	// Assert
+	expected := "./main.go:12:2: undefined: config\nmake: *** [Makefile:8: build] Error 1"
+	if excerpt != expected {
+		t.Errorf("expected %q, got %q", expected, excerpt)
+	}
+}
+
+// TestReadLogTail tests that only the last bytes of a log are kept, from a line boundary.
+func TestReadLogTail(t *testing.T) {
+	// Arrange
+	jobLog := strings.Repeat("building...\n", 10000) + "first\nsecond\n"
+
+	// Act
+	tail, err := readLogTail(strings.NewReader(jobLog), 20)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tail != "first\nsecond\n" {
+		t.Errorf("expected the last complete lines, got %q", tail)
+	}
+}
+
+// fakeExcerptCacher is a test double for a caching client that counts job log downloads.
+type fakeExcerptCacher struct {
+	pipeline *domain.Pipeline
+	jobLog   string
+	excerpt  string
+	reads    int
+}
+
+func (f *fakeExcerptCacher) GetLatestPipeline(ctx context.Context, projectID, branch string) (*domain.Pipeline, error) {
+	return f.pipeline, nil
+}
+
+func (f *fakeExcerptCacher) GetJobLog(ctx context.Context, projectID, jobID string) (io.ReadCloser, error) {
+	f.reads++
+	return io.NopCloser(strings.NewReader(f.jobLog)), nil
+}
+
+func (f *fakeExcerptCacher) SetFailureExcerpt(projectID, branch, pipelineID, excerpt string) {
+	f.excerpt = excerpt
+}
+
+// TestRefreshFailureExcerpt_OncePerPipeline tests that logs without an excerpt aren't downloaded again
+// on every refresh, while a new failed pipeline is read.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestRefreshFailureExcerpt_OncePerPipeline(t *testing.T) {
+	// Arrange
+	s := NewPipelineService(nil, false)
+	failed := func(id string) *domain.Pipeline {
+		return &domain.Pipeline{ID: id, Status: domain.StatusFailed, Builds: []domain.Build{{ID: "job-" + id, Name: "test", Status: domain.StatusFailed}}}
+	}
+	cacher := &fakeExcerptCacher{pipeline: failed("1")}
+	ctx := context.Background()
+
+	// Act
+	s.refreshFailureExcerpt(ctx, cacher, "acme/api", "main")
+	s.refreshFailureExcerpt(ctx, cacher, "acme/api", "main")
+	cacher.pipeline = failed("2")
+	cacher.jobLog = "FAIL: expected 1, got 2\n"
+	s.refreshFailureExcerpt(ctx, cacher, "acme/api", "main")
+
+	// Assert
+	if cacher.reads != 2 {
+		t.Errorf("expected 2 log downloads (one per pipeline), got %d", cacher.reads)
+	}
+	if cacher.excerpt == "" {
+		t.Error("expected an excerpt for the second pipeline")
+	}
+}
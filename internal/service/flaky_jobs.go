@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+)
+
+const (
+	// FlakyJobsWindowDays is how far back pipeline history is searched for flaky jobs
+	FlakyJobsWindowDays = 30
+
+	// FlakyMinFlips is how many status flips on the default branch flag a job as flaky on their own.
+	// A single break and fix is two flips, so it takes more than that.
+	FlakyMinFlips = 3
+)
+
+// FlakyJob is a job whose outcome changes without code changes, with its flakiness score in a repository.
+type FlakyJob struct {
+	ProjectID        string    `json:"project_id"`
+	Platform         string    `json:"platform"`
+	Repository       string    `json:"repository"`
+	Name             string    `json:"name"`
+	Stage            string    `json:"stage,omitempty"`
+	Runs             int       `json:"runs"`               // pipelines the job ran in
+	Failures         int       `json:"failures"`           // pipelines where the final attempt failed
+	RetryPasses      int       `json:"retry_passes"`       // pipelines where a failed attempt passed on retry
+	SameCommitFlakes int       `json:"same_commit_flakes"` // commits where the job both failed and passed across pipelines
+	Flips            int       `json:"flips"`              // status changes across consecutive default branch pipelines
+	Score            float64   `json:"score"`              // (retry passes + same-commit flakes + flips) / runs, capped at 1
+	LastFlakyAt      time.Time `json:"last_flaky_at"`
+}
+
+// flakyJobStats accumulates the outcomes of one job across pipelines.
+type flakyJobStats struct {
+	job           FlakyJob
+	commits       map[string]*commitOutcome
+	defaultStatus domain.Status // final status in the previous default branch pipeline
+}
+
+// commitOutcome tracks the final outcomes of a job on one commit.
+type commitOutcome struct {
+	failed  bool
+	passed  bool
+	counted bool
+}
+
+// DetectFlakyJobs finds flaky jobs in the pipeline history of one repository.
+// Records must be ordered oldest first; records without jobs are ignored.
+// A job is flaky when it failed and then passed on the same commit (retried in the same pipeline
+// or re-run in another pipeline), or when its status flipped at least FlakyMinFlips times
+// across consecutive default branch pipelines. Results are sorted by score, highest first.
+func DetectFlakyJobs(records []history.Record, defaultBranch string) []FlakyJob {
+	jobs := make(map[string]*flakyJobStats)
+
+	for _, record := range records {
+		for key, attempts := range jobAttempts(record.Jobs) {
+			stats, exists := jobs[key]
+			if !exists {
+				stats = &flakyJobStats{
+					job:     FlakyJob{Name: attempts.final.Name, Stage: attempts.final.Stage},
+					commits: make(map[string]*commitOutcome),
+				}
+				jobs[key] = stats
+			}
+			stats.observe(record, attempts, defaultBranch)
+		}
+	}
+
+	var flaky []FlakyJob
+	for _, stats := range jobs {
+		job := stats.job
+		if job.RetryPasses+job.SameCommitFlakes == 0 && job.Flips < FlakyMinFlips {
+			continue
+		}
+
+		job.Score = float64(job.RetryPasses+job.SameCommitFlakes+job.Flips) / float64(job.Runs)
+		if job.Score > 1 {
+			job.Score = 1
+		}
+		flaky = append(flaky, job)
+	}
+
+	sortFlakyJobs(flaky)
+	return flaky
+}
+
+// jobAttemptSet holds the final attempt of a job in a pipeline and whether an earlier attempt failed.
+type jobAttemptSet struct {
+	final        history.JobRecord
+	failedBefore bool
+}
+
+// jobAttempts groups the job attempts of a pipeline by stage and name.
+func jobAttempts(jobs []history.JobRecord) map[string]jobAttemptSet {
+	result := make(map[string]jobAttemptSet, len(jobs))
+	for _, job := range jobs {
+		key := job.Stage + "\x00" + job.Name
+		attempts := result[key]
+		if job.Retried {
+			attempts.failedBefore = attempts.failedBefore || job.Status == domain.StatusFailed
+			if attempts.final.Name == "" {
+				attempts.final = job // placeholder until the final attempt is seen
+			}
+		} else {
+			attempts.final = job
+		}
+		result[key] = attempts
+	}
+	return result
+}
+
+// observe adds the outcome of the job in one pipeline.
+func (s *flakyJobStats) observe(record history.Record, attempts jobAttemptSet, defaultBranch string) {
+	status := attempts.final.Status
+	if attempts.final.Retried || (status != domain.StatusSuccess && status != domain.StatusFailed) {
+		// Still running, canceled or skipped - not a signal either way
+		return
+	}
+
+	s.job.Runs++
+	if status == domain.StatusFailed {
+		s.job.Failures++
+	}
+
+	if status == domain.StatusSuccess && attempts.failedBefore {
+		s.job.RetryPasses++
+		s.markFlaky(record.CreatedAt)
+	}
+
+	if record.CommitSHA != "" {
+		outcome, exists := s.commits[record.CommitSHA]
+		if !exists {
+			outcome = &commitOutcome{}
+			s.commits[record.CommitSHA] = outcome
+		}
+		outcome.failed = outcome.failed || status == domain.StatusFailed
+		outcome.passed = outcome.passed || status == domain.StatusSuccess
+		if outcome.failed && outcome.passed && !outcome.counted {
+			outcome.counted = true
+			s.job.SameCommitFlakes++
+			s.markFlaky(record.CreatedAt)
+		}
+	}
+
+	if record.Branch == defaultBranch {
+		if s.defaultStatus != "" && s.defaultStatus != status {
+			s.job.Flips++
+			s.markFlaky(record.CreatedAt)
+		}
+		s.defaultStatus = status
+	}
+}
+
+// markFlaky records when the job last behaved flakily.
+func (s *flakyJobStats) markFlaky(at time.Time) {
+	if at.After(s.job.LastFlakyAt) {
+		s.job.LastFlakyAt = at
+	}
+}
+
+// sortFlakyJobs orders flaky jobs by score (highest first), then by repository and name.
+func sortFlakyJobs(jobs []FlakyJob) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].Score != jobs[j].Score {
+			return jobs[i].Score > jobs[j].Score
+		}
+		if jobs[i].Repository != jobs[j].Repository {
+			return jobs[i].Repository < jobs[j].Repository
+		}
+		return jobs[i].Name < jobs[j].Name
+	})
+}
+
+// GetFlakyJobsForProject detects flaky jobs of a project from the last FlakyJobsWindowDays of pipeline history.
+// Returns nil when history is disabled.
+func (s *PipelineService) GetFlakyJobsForProject(project domain.Project) []FlakyJob {
+	h := s.pipelineHistory()
+	if h == nil {
+		return nil
+	}
+
+	since := time.Now().AddDate(0, 0, -FlakyJobsWindowDays)
+	jobs := DetectFlakyJobs(h.Records(project.Platform, project.ID, "", since), project.DefaultBranch)
+	for i := range jobs {
+		jobs[i].ProjectID = project.ID
+		jobs[i].Platform = project.Platform
+		jobs[i].Repository = project.Name
+	}
+	return jobs
+}
+
+// GetFlakyJobs detects flaky jobs across all projects, highest score first.
+func (s *PipelineService) GetFlakyJobs(ctx context.Context) ([]FlakyJob, error) {
+	if s.pipelineHistory() == nil {
+		return nil, nil
+	}
+
+	projects, err := s.GetAllProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	var all []FlakyJob
+	for _, project := range projects {
+		all = append(all, s.GetFlakyJobsForProject(project)...)
+	}
+
+	sortFlakyJobs(all)
+	return all, nil
+}
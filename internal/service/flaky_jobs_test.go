@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+)
+
+// TestDetectFlakyJobs tests detection of retry passes, same-commit flakes and default branch flips.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestDetectFlakyJobs(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	pipeline := func(i int, branch, sha string, jobs ...history.JobRecord) history.Record {
+		return history.Record{Branch: branch, CommitSHA: sha, CreatedAt: start.Add(time.Duration(i) * time.Hour), Jobs: jobs}
+	}
+	job := func(name string, status domain.Status, retried bool) history.JobRecord {
+		return history.JobRecord{Name: name, Stage: "test", Status: status, Retried: retried}
+	}
+
+	records := []history.Record{
+		// "unit" fails and passes on retry in the same pipeline
+		pipeline(0, "main", "a", job("unit", domain.StatusFailed, true), job("unit", domain.StatusSuccess, false), job("lint", domain.StatusSuccess, false)),
+		// "e2e" fails on a feature branch and passes when the same commit is re-run
+		pipeline(1, "feature", "b", job("e2e", domain.StatusFailed, false), job("lint", domain.StatusFailed, false)),
+		pipeline(2, "feature", "b", job("e2e", domain.StatusSuccess, false), job("lint", domain.StatusFailed, false)),
+		// "lint" breaks and gets fixed on the default branch - two flips, not flaky
+		pipeline(3, "main", "c", job("lint", domain.StatusFailed, false)),
+		pipeline(4, "main", "d", job("lint", domain.StatusSuccess, false)),
+	}
+
+	// Act
+	flaky := DetectFlakyJobs(records, "main")
+
+	// Assert
+	if len(flaky) != 2 {
+		t.Fatalf("expected 2 flaky jobs, got %d: %+v", len(flaky), flaky)
+	}
+
+	byName := make(map[string]FlakyJob)
+	for _, job := range flaky {
+		byName[job.Name] = job
+	}
+
+	if unit := byName["unit"]; unit.RetryPasses != 1 || unit.Runs != 1 || unit.Score != 1 {
+		t.Errorf("expected 'unit' to have 1 retry pass in 1 run (score 1), got %+v", unit)
+	}
+
+	if e2e := byName["e2e"]; e2e.SameCommitFlakes != 1 || e2e.Runs != 2 || e2e.Score != 0.5 {
+		t.Errorf("expected 'e2e' to have 1 same-commit flake in 2 runs (score 0.5), got %+v", e2e)
+	}
+
+	if _, found := byName["lint"]; found {
+		t.Errorf("expected 'lint' not to be flagged as flaky")
+	}
+
+	if flaky[0].Name != "unit" {
+		t.Errorf("expected highest score first, got %q", flaky[0].Name)
+	}
+}
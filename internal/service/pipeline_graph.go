@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// GraphNode is a job of a pipeline graph with the jobs it waits for.
+type GraphNode struct {
+	Build    domain.Build
+	Needs    []int // indexes of the nodes this job waits for
+	Explicit bool  // Needs come from the job's needs rather than stage order
+	Column   int   // position in the graph: one past the furthest column of its needs
+	Critical bool  // on the critical path
+}
+
+// PipelineGraph is the jobs of a pipeline laid out in columns by their dependencies.
+// Jobs run after every job of the previous stage unless they declare needs.
+type PipelineGraph struct {
+	Pipeline     domain.Pipeline
+	Nodes        []GraphNode
+	Columns      [][]int       // node indexes per column, in job order
+	CriticalPath time.Duration // total duration of the longest chain of jobs
+}
+
+// GetPipelineGraph returns the job graph of a pipeline of a project.
+// Needs are best-effort - without them jobs are laid out by stage.
+func (s *PipelineService) GetPipelineGraph(ctx context.Context, project domain.Project, pipelineID string) (*PipelineGraph, error) {
+	pipeline, builds, err := s.getPipelineJobs(ctx, project, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	if needsClient, ok := s.getPipelineClient(project.Platform, project.ID).(api.JobNeedsClient); ok {
+		needs, err := needsClient.GetJobNeeds(ctx, project.ID, pipeline.ID)
+		if err != nil {
+			log.Printf("[PipelineGraph] Failed to get job needs of pipeline %s of %s: %v", pipeline.ID, project.Name, err)
+		}
+		for i := range builds {
+			if jobNeeds, ok := needs[builds[i].Name]; ok {
+				builds[i].Needs = jobNeeds
+			}
+		}
+	}
+
+	graph := NewPipelineGraph(*pipeline, builds)
+	return &graph, nil
+}
+
+// getPipelineJobs returns a pipeline of a project with its jobs, read from the platform.
+func (s *PipelineService) getPipelineJobs(ctx context.Context, project domain.Project, pipelineID string) (*domain.Pipeline, []domain.Build, error) {
+	pipeline, err := s.findPipeline(ctx, project, pipelineID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, ok := s.getPipelineClient(project.Platform, project.ID).(api.JobsClient)
+	if !ok {
+		return nil, nil, fmt.Errorf("jobs are not available for %s", project.Name)
+	}
+	builds, err := client.GetPipelineJobs(ctx, project.ID, pipeline.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get jobs of pipeline %s of %s: %w", pipeline.ID, project.Name, err)
+	}
+	return pipeline, builds, nil
+}
+
+// NewPipelineGraph lays out the latest attempt of each job of a pipeline and finds its critical path.
+// Builds must be in execution order, so stages appear in the order they run.
+func NewPipelineGraph(pipeline domain.Pipeline, builds []domain.Build) PipelineGraph {
+	graph := PipelineGraph{Pipeline: pipeline}
+	var stages []string
+	stageIndex := make(map[string]int)
+	for _, build := range builds {
+		if build.Retried {
+			continue
+		}
+		if _, ok := stageIndex[build.Stage]; !ok {
+			stageIndex[build.Stage] = len(stages)
+			stages = append(stages, build.Stage)
+		}
+		graph.Nodes = append(graph.Nodes, GraphNode{Build: build})
+	}
+
+	// Jobs without needs wait for every job of the previous stage that has jobs
+	for i := range graph.Nodes {
+		node := &graph.Nodes[i]
+		if node.Build.Needs != nil {
+			node.Explicit = true
+			for j, other := range graph.Nodes {
+				if j != i && neededBy(other.Build.Name, node.Build.Needs) {
+					node.Needs = append(node.Needs, j)
+				}
+			}
+			continue
+		}
+
+		previous := stageIndex[node.Build.Stage] - 1
+		if previous < 0 {
+			continue
+		}
+		for j, other := range graph.Nodes {
+			if stageIndex[other.Build.Stage] == previous {
+				node.Needs = append(node.Needs, j)
+			}
+		}
+	}
+
+	graph.layout()
+	graph.markCriticalPath()
+	return graph
+}
+
+// parallelJobSuffix matches the suffix GitLab adds to the jobs of parallel: N ("test 2/4")
+// and parallel:matrix ("test: [linux, 1.22]").
+var parallelJobSuffix = regexp.MustCompile(`^( \d+/\d+|: \[.*\])$`)
+
+// neededBy returns true if a job is one of needs, or one of the parallel jobs of one of them.
+func neededBy(jobName string, needs []string) bool {
+	for _, need := range needs {
+		if jobName == need || (strings.HasPrefix(jobName, need) && parallelJobSuffix.MatchString(jobName[len(need):])) {
+			return true
+		}
+	}
+	return false
+}
+
+// layout assigns each node the column after the furthest of its needs and groups nodes by column.
+// Needs that form a cycle are ignored.
+func (g *PipelineGraph) layout() {
+	const unvisited, visiting = -1, -2
+	columns := make([]int, len(g.Nodes))
+	for i := range columns {
+		columns[i] = unvisited
+	}
+
+	var column func(i int) int
+	column = func(i int) int {
+		switch columns[i] {
+		case visiting:
+			return -1
+		case unvisited:
+			columns[i] = visiting
+			c := 0
+			for _, j := range g.Nodes[i].Needs {
+				c = max(c, column(j)+1)
+			}
+			columns[i] = c
+		}
+		return columns[i]
+	}
+
+	for i := range g.Nodes {
+		c := column(i)
+		g.Nodes[i].Column = c
+		for len(g.Columns) <= c {
+			g.Columns = append(g.Columns, nil)
+		}
+		g.Columns[c] = append(g.Columns[c], i)
+	}
+}
+
+// markCriticalPath marks the chain of jobs that finishes last when every job starts as soon as
+// its needs finish, and records its duration.
+func (g *PipelineGraph) markCriticalPath() {
+	finish := make([]time.Duration, len(g.Nodes))
+	previous := make([]int, len(g.Nodes))
+	end := -1
+	for _, column := range g.Columns {
+		for _, i := range column {
+			previous[i] = -1
+			for _, j := range g.Nodes[i].Needs {
+				// Needs are in earlier columns, unless they form a cycle
+				if g.Nodes[j].Column < g.Nodes[i].Column && (previous[i] < 0 || finish[j] > finish[previous[i]]) {
+					previous[i] = j
+				}
+			}
+			if previous[i] >= 0 {
+				finish[i] = finish[previous[i]]
+			}
+			finish[i] += g.Nodes[i].Build.Duration
+
+			if end < 0 || finish[i] > finish[end] {
+				end = i
+			}
+		}
+	}
+	if end < 0 {
+		return
+	}
+
+	g.CriticalPath = finish[end]
+	for i := end; i >= 0; i = previous[i] {
+		g.Nodes[i].Critical = true
+	}
+}
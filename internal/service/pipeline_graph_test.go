@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestNewPipelineGraph tests laying out jobs by stage and needs, and finding the critical path.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestNewPipelineGraph(t *testing.T) {
+	// Arrange
+	job := func(name, stage string, minutes int, needs ...string) domain.Build {
+		return domain.Build{Name: name, Stage: stage, Duration: time.Duration(minutes) * time.Minute, Needs: needs}
+	}
+	builds := []domain.Build{
+		job("compile", "build", 2),
+		job("lint", "build", 1),
+		{Name: "unit", Stage: "test", Duration: time.Minute, Retried: true},
+		job("unit", "test", 3),
+		job("e2e 1/2", "test", 5),
+		job("e2e 2/2", "test", 4),
+		job("docs", "test", 1),
+		job("package", "deploy", 1, "compile"),
+		job("release", "deploy", 2),
+	}
+	builds[6].Needs = []string{} // needs: [] starts right away
+
+	// Act
+	graph := NewPipelineGraph(domain.Pipeline{ID: "1"}, builds)
+
+	// Assert
+	if len(graph.Nodes) != 8 {
+		t.Fatalf("expected retried attempts to be left out, got %d nodes", len(graph.Nodes))
+	}
+
+	byName := make(map[string]GraphNode)
+	for _, node := range graph.Nodes {
+		byName[node.Build.Name] = node
+	}
+	columns := map[string]int{"compile": 0, "lint": 0, "docs": 0, "unit": 1, "e2e 1/2": 1, "package": 1, "release": 2}
+	for name, want := range columns {
+		if got := byName[name].Column; got != want {
+			t.Errorf("expected %s in column %d, got %d", name, want, got)
+		}
+	}
+	if release := byName["release"]; release.Explicit || len(release.Needs) != 4 {
+		t.Errorf("expected release to wait for the 4 test jobs, got %+v", release)
+	}
+
+	critical := map[string]bool{"compile": true, "e2e 1/2": true, "release": true}
+	for name, node := range byName {
+		if node.Critical != critical[name] {
+			t.Errorf("expected %s critical=%v, got %v", name, critical[name], node.Critical)
+		}
+	}
+	if graph.CriticalPath != 9*time.Minute {
+		t.Errorf("expected a 9 minute critical path, got %v", graph.CriticalPath)
+	}
+}
+
+// TestNeededBy tests matching needs to the parallel jobs GitLab creates from one job.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestNeededBy(t *testing.T) {
+	tests := []struct {
+		job  string
+		want bool
+	}{
+		{"build", true},
+		{"build 2/3", true},
+		{"build: [linux, 1.22]", true},
+		{"build-image", false},
+		{"build 2", false},
+	}
+
+	for _, tt := range tests {
+		// Act
+		got := neededBy(tt.job, []string{"build"})
+
+		// Assert
+		if got != tt.want {
+			t.Errorf("neededBy(%q) = %v, want %v", tt.job, got, tt.want)
+		}
+	}
+}
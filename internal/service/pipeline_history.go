@@ -0,0 +1,105 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+)
+
+// PipelineHistory records finished pipelines and computes trends from them (Dependency Inversion Principle).
+type PipelineHistory interface {
+	Add(platform string, pipelines []domain.Pipeline) (int, error)
+	SetTestResults(platform, projectID, pipelineID string, tests history.TestRecord) (bool, error)
+	Records(platform, projectID, branch string, since time.Time) []history.Record
+	PreviousCoverage(platform, projectID, branch, pipelineID string, before time.Time) (float64, bool)
+	Branches(platform, projectID string) []string
+	Stats(platform, projectID, branch string, now time.Time) []history.Stats
+}
+
+// RepositoryStats holds pipeline trends for a repository over every stats window.
+type RepositoryStats struct {
+	ProjectID string          `json:"project_id"`
+	Platform  string          `json:"platform"`
+	Branch    string          `json:"branch,omitempty"` // empty = all branches
+	Windows   []history.Stats `json:"windows"`
+	Branches  []BranchStats   `json:"branches,omitempty"` // per-branch trends, only when Branch is empty
+}
+
+// BranchStats holds pipeline trends for a single branch.
+type BranchStats struct {
+	Branch  string          `json:"branch"`
+	Windows []history.Stats `json:"windows"`
+}
+
+// SetPipelineHistory enables recording of observed pipelines into h.
+func (s *PipelineService) SetPipelineHistory(h PipelineHistory) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = h
+}
+
+// HasPipelineHistory reports whether pipeline history is enabled.
+func (s *PipelineService) HasPipelineHistory() bool {
+	return s.pipelineHistory() != nil
+}
+
+// RecordPipelines adds observed pipelines of a platform to the history.
+// Does nothing when history is disabled.
+func (s *PipelineService) RecordPipelines(platform string, pipelines []domain.Pipeline) {
+	h := s.pipelineHistory()
+	if h == nil || len(pipelines) == 0 {
+		return
+	}
+
+	if _, err := h.Add(platform, pipelines); err != nil {
+		log.Printf("[History] Failed to record %d %s pipelines: %v", len(pipelines), platform, err)
+	}
+}
+
+// GetRepositoryStats returns pipeline trends for a project.
+// With an empty branch, the result covers all branches and includes per-branch trends.
+// Returns nil when history is disabled.
+func (s *PipelineService) GetRepositoryStats(project domain.Project, branch string) *RepositoryStats {
+	h := s.pipelineHistory()
+	if h == nil {
+		return nil
+	}
+
+	now := time.Now()
+	stats := &RepositoryStats{
+		ProjectID: project.ID,
+		Platform:  project.Platform,
+		Branch:    branch,
+		Windows:   h.Stats(project.Platform, project.ID, branch, now),
+	}
+
+	if branch == "" {
+		for _, name := range h.Branches(project.Platform, project.ID) {
+			stats.Branches = append(stats.Branches, BranchStats{
+				Branch:  name,
+				Windows: h.Stats(project.Platform, project.ID, name, now),
+			})
+		}
+	}
+
+	return stats
+}
+
+// GetPipelineHistory returns the recorded pipelines of a project created at or after since, oldest first.
+// An empty branch covers all branches. Returns nil when history is disabled.
+func (s *PipelineService) GetPipelineHistory(project domain.Project, branch string, since time.Time) []history.Record {
+	h := s.pipelineHistory()
+	if h == nil {
+		return nil
+	}
+	return h.Records(project.Platform, project.ID, branch, since)
+}
+
+// pipelineHistory returns the configured history, or nil when disabled.
+func (s *PipelineService) pipelineHistory() PipelineHistory {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.history
+}
@@ -35,22 +35,37 @@ const (
 // PipelineService handles business logic for pipeline operations.
 // Follows Single Responsibility Principle - orchestrates pipeline operations.
 type PipelineService struct {
-	clients          map[string]api.Client // platform name -> client
-	gitlabWhitelist  []string              // allowed GitLab repository IDs (nil = allow all)
-	githubWhitelist  []string              // allowed GitHub repository IDs (nil = allow all)
+	clients          map[string]api.Client // connection name -> client (default connections are named after their platform)
+	platforms        map[string]string     // connection name -> platform
+	pipelineLinks    map[string]api.Client // "connection:projectID" -> pipeline-only client running the project's pipelines
+	actionClients    map[string]api.ActionClient // connection name -> client with a write token (none = read-only)
+	whitelists       map[string][]string   // connection name -> allowed repository IDs (none = allow all)
 	filterUserRepos  bool                  // if true, only fetch repositories where user has membership
+	changes          *ChangeBroker         // publishes repository changes detected by client caches
+	history          PipelineHistory       // records observed pipelines (nil = disabled)
+	notifier         StatusNotifier        // alerts on default branch status transitions (nil = disabled)
 	mu               sync.RWMutex
+
+	// Refresh schedules, written by refreshes running under mu's read lock
+	refreshMu       sync.Mutex
+	environmentsDue map[string]time.Time // "platform:projectID" -> when the project's environments are next refreshed
+	excerptTried    map[string]string    // "projectID:branch" -> failed pipeline whose logs were read for an excerpt
 }
 
 // NewPipelineService creates a new pipeline service.
-// gitlabWhitelist and githubWhitelist restrict access to specified repositories (nil = allow all).
+// whitelists restrict each connection to the specified repositories (no entry = allow all).
 // filterUserRepos, when true, only fetches repositories where user has membership (default: true).
-func NewPipelineService(gitlabWhitelist, githubWhitelist []string, filterUserRepos bool) *PipelineService {
+func NewPipelineService(whitelists map[string][]string, filterUserRepos bool) *PipelineService {
 	return &PipelineService{
 		clients:         make(map[string]api.Client),
-		gitlabWhitelist: gitlabWhitelist,
-		githubWhitelist: githubWhitelist,
+		platforms:       make(map[string]string),
+		pipelineLinks:   make(map[string]api.Client),
+		actionClients:   make(map[string]api.ActionClient),
+		whitelists:      whitelists,
 		filterUserRepos: filterUserRepos,
+		changes:         NewChangeBroker(),
+		environmentsDue: make(map[string]time.Time),
+		excerptTried:    make(map[string]string),
 	}
 }
 
@@ -177,12 +192,59 @@ func processProjectsConcurrently[T any](
 	return allResults
 }
 
-// RegisterClient registers a CI/CD platform client.
+// RegisterClient registers the default client of a CI/CD platform.
 // Follows Open/Closed Principle - can add new platforms without modifying service.
 func (s *PipelineService) RegisterClient(platform string, client api.Client) {
+	s.RegisterConnection(platform, platform, client)
+}
+
+// RegisterConnection registers a named client of a CI/CD platform, for platforms with several instances.
+// Project IDs of every connection but the platform's default one must be qualified with the
+// connection name (see api.NamespacedClient), so that the project's client can be found from its ID.
+// Caching clients that report changes are wired to publish repository changes.
+func (s *PipelineService) RegisterConnection(name, platform string, client api.Client) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.clients[platform] = client
+	s.clients[name] = client
+	s.platforms[name] = platform
+
+	if notifier, ok := client.(interface{ SetChangeListener(api.ChangeListener) }); ok {
+		notifier.SetChangeListener(func(key string) {
+			if change, ok := repositoryChangeForKey(platform, key); ok {
+				s.changes.Publish(change)
+			}
+		})
+	}
+}
+
+// RegisterPipelineClient registers a pipeline-only client (e.g. Jenkins) running the pipelines of
+// projects of a connection. Pipelines of those projects are read from it instead of the connection's client.
+// Caching clients that report changes are wired to publish changes of the linked repositories.
+func (s *PipelineService) RegisterPipelineClient(connection string, projectIDs []string, client api.Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, projectID := range projectIDs {
+		s.pipelineLinks[connection+":"+projectID] = client
+	}
+
+	platform, ok := s.platforms[connection]
+	if !ok {
+		platform = connection
+	}
+
+	if notifier, ok := client.(interface{ SetChangeListener(api.ChangeListener) }); ok {
+		notifier.SetChangeListener(func(key string) {
+			if change, ok := repositoryChangeForKey(platform, key); ok {
+				s.changes.Publish(change)
+			}
+		})
+	}
+}
+
+// SubscribeRepositoryChanges subscribes to changes of cached repository data.
+// Returns the change channel and a function to unsubscribe.
+func (s *PipelineService) SubscribeRepositoryChanges() (<-chan RepositoryChange, func()) {
+	return s.changes.Subscribe()
 }
 
 // ForceRefreshAllCaches forces all clients to fetch fresh data page-by-page and populate their caches.
@@ -232,6 +294,7 @@ func (s *PipelineService) forceRefreshClientPageByPage(ctx context.Context, plat
 
 	// Check if client is a stale caching client
 	type staleCacher interface {
+		GetProjects(ctx context.Context) ([]domain.Project, error)
 		GetProjectsPage(ctx context.Context, page int) ([]domain.Project, bool, error)
 		ForceRefresh(ctx context.Context, key string) error
 		PopulateProjects(projects []domain.Project)
@@ -242,6 +305,12 @@ func (s *PipelineService) forceRefreshClientPageByPage(ctx context.Context, plat
 		return fmt.Errorf("client does not support page-by-page refresh")
 	}
 
+	// Without a cached project list (first start), the list grows project by project so the dashboard
+	// fills in as data arrives. Otherwise it is replaced once after the last page: a partial list would
+	// hide rows and tell every browser to reload for each project.
+	cached, _ := cacher.GetProjects(ctx)
+	incremental := len(cached) == 0
+
 	page := 1
 	var allProjects []domain.Project
 
@@ -263,13 +332,26 @@ func (s *PipelineService) forceRefreshClientPageByPage(ctx context.Context, plat
 			break
 		}
 
+		// Let batching clients load the page's data in a few requests instead of several per project
+		if batcher, ok := client.(api.BatchClient); ok {
+			projectIDs := make([]string, len(projects))
+			for i, project := range projects {
+				projectIDs[i] = project.ID
+			}
+			if err := batcher.PrefetchProjects(ctx, projectIDs); err != nil {
+				log.Printf("[%s] Warning: failed to prefetch page %d, fetching per project: %v", platform, page, err)
+			}
+		}
+
 		// Process each project individually and cache incrementally
 		for _, project := range projects {
 			// Add this project to accumulator
 			allProjects = append(allProjects, project)
 
 			// Cache immediately after each project (1, 2, 3... 17...)
-			cacher.PopulateProjects(allProjects)
+			if incremental {
+				cacher.PopulateProjects(allProjects)
+			}
 
 			// Fetch data for this single project
 			if err := s.forceRefreshDataForProjects(ctx, platform, cacher, []domain.Project{project}); err != nil {
@@ -284,6 +366,7 @@ func (s *PipelineService) forceRefreshClientPageByPage(ctx context.Context, plat
 		page++
 	}
 
+	cacher.PopulateProjects(allProjects)
 	return nil
 }
 
@@ -300,20 +383,36 @@ func (s *PipelineService) forceRefreshDataForProjects(ctx context.Context, platf
 			defaultBranch = "main"
 		}
 
+		// Pipelines of projects linked to a pipeline-only client are cached by that client
+		// No lock needed - ForceRefreshAllCaches holds s.mu
+		pipelineClient := client
+		if linked, ok := s.pipelineLinks[platform+":"+projectID].(interface{ ForceRefresh(context.Context, string) error }); ok {
+			pipelineClient = linked
+		}
+
 		// Fetch default branch pipeline
 		wg.Add(1)
 		go func(pid, pname, branch string) {
 			defer wg.Done()
 			key := fmt.Sprintf("GetLatestPipeline:%s:%s", pid, branch)
-			if err := client.ForceRefresh(ctx, key); err != nil {
+			if err := pipelineClient.ForceRefresh(ctx, key); err != nil {
 				// Try master if main fails
-				if branch == "main" {
-					key = fmt.Sprintf("GetLatestPipeline:%s:master", pid)
-					if err := client.ForceRefresh(ctx, key); err != nil {
-						log.Printf("Failed to fetch pipeline for %s on both main and master branches: %v", pname, err)
-					}
+				if branch != "main" {
+					return
+				}
+				branch = "master"
+				key = fmt.Sprintf("GetLatestPipeline:%s:%s", pid, branch)
+				if err := pipelineClient.ForceRefresh(ctx, key); err != nil {
+					log.Printf("Failed to fetch pipeline for %s on both main and master branches: %v", pname, err)
+					return
 				}
 			}
+
+			// Explain a failure from the job logs (only once per failed pipeline)
+			s.refreshFailureExcerpt(ctx, pipelineClient, pid, branch)
+
+			// Read coverage from artifacts where pipelines don't report it (only once per pipeline)
+			s.refreshCoverage(ctx, pipelineClient, pid, branch)
 		}(projectID, projectName, defaultBranch)
 
 		// Fetch branches (use 200 to match GetDefaultBranchForProject cache key)
@@ -331,7 +430,7 @@ func (s *PipelineService) forceRefreshDataForProjects(ctx context.Context, platf
 		go func(pid string) {
 			defer wg.Done()
 			key := fmt.Sprintf("GetPipelines:%s:50", pid)
-			if err := client.ForceRefresh(ctx, key); err != nil {
+			if err := pipelineClient.ForceRefresh(ctx, key); err != nil {
 				// Ignore errors - will retry on next refresh
 			}
 		}(projectID)
@@ -355,6 +454,26 @@ func (s *PipelineService) forceRefreshDataForProjects(ctx context.Context, platf
 				// Ignore errors - not all clients support this
 			}
 		}(projectID)
+
+		// Fetch environments with their latest deployment (on a longer interval than pipelines)
+		wg.Add(1)
+		go func(pid string) {
+			defer wg.Done()
+			s.refreshEnvironments(ctx, client, platform, pid)
+		}(projectID)
+
+		// Fetch merged merge requests for lead time metrics (only used with pipeline history)
+		// No lock needed - ForceRefreshAllCaches holds s.mu
+		if s.history != nil {
+			wg.Add(1)
+			go func(pid string) {
+				defer wg.Done()
+				key := fmt.Sprintf("GetMergedMergeRequests:%s", pid)
+				if err := client.ForceRefresh(ctx, key); err != nil {
+					// Ignore errors - not all clients support this
+				}
+			}(projectID)
+		}
 	}
 
 	wg.Wait()
@@ -368,7 +487,91 @@ func (s *PipelineService) forceRefreshDataForProjects(ctx context.Context, platf
 	return nil
 }
 
-// getClientForPlatform returns the appropriate client for a given platform.
+// eventCacher is implemented by caching clients that can poll events and refresh individual cache keys.
+type eventCacher interface {
+	GetEvents(ctx context.Context, projectID string, since time.Time) ([]domain.Event, error)
+	Invalidate(key string)
+	ForceRefresh(ctx context.Context, key string) error
+}
+
+// GetEventsForProject retrieves activity events for a project since the given time.
+// Returns an error if the project's client doesn't support event polling.
+func (s *PipelineService) GetEventsForProject(ctx context.Context, project domain.Project, since time.Time) ([]domain.Event, error) {
+	client := s.getClientForProject(project.Platform, project.ID)
+	if client == nil {
+		return nil, fmt.Errorf("no client for platform: %s", project.Platform)
+	}
+
+	cacher, ok := client.(eventCacher)
+	if !ok {
+		return nil, fmt.Errorf("client for %s does not support events", project.Platform)
+	}
+
+	return cacher.GetEvents(ctx, project.ID, since)
+}
+
+// RefreshForEvents invalidates and re-fetches only the cache keys affected by the given events.
+// Returns the number of keys that were refreshed successfully.
+func (s *PipelineService) RefreshForEvents(ctx context.Context, project domain.Project, events []domain.Event) int {
+	client := s.getClientForProject(project.Platform, project.ID)
+	if client == nil {
+		return 0
+	}
+
+	cacher, ok := client.(eventCacher)
+	if !ok {
+		return 0
+	}
+
+	refreshed := 0
+	for _, key := range cacheKeysForEvents(project.ID, events) {
+		cacher.Invalidate(key)
+		if err := cacher.ForceRefresh(ctx, key); err != nil {
+			log.Printf("[%s] Failed to refresh %s after event: %v", project.Platform, key, err)
+			continue
+		}
+		refreshed++
+	}
+
+	return refreshed
+}
+
+// cacheKeysForEvents maps events to the (deduplicated) cache keys they make outdated.
+// Push events affect the pushed branch's pipeline and the branch list.
+// Pipeline events affect the pipeline list and the pipeline's branch.
+// Merge request events affect the open merge request list.
+func cacheKeysForEvents(projectID string, events []domain.Event) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	add := func(key string) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	for _, event := range events {
+		switch event.Type {
+		case "push":
+			if event.TargetType != "branch" || event.TargetID == "" {
+				continue
+			}
+			add(fmt.Sprintf("GetLatestPipeline:%s:%s", projectID, event.TargetID))
+			add(fmt.Sprintf("GetBranches:%s:200", projectID))
+		case "pipeline":
+			add(fmt.Sprintf("GetPipelines:%s:50", projectID))
+			if event.Pipeline != nil && event.Pipeline.Branch != "" {
+				add(fmt.Sprintf("GetLatestPipeline:%s:%s", projectID, event.Pipeline.Branch))
+			}
+		case "merge_request":
+			add(fmt.Sprintf("GetMergeRequests:%s", projectID))
+		}
+	}
+
+	return keys
+}
+
+// getClientForPlatform returns the appropriate client for a given platform or connection name.
 // Returns nil if no client is registered for the platform.
 func (s *PipelineService) getClientForPlatform(platform string) api.Client {
 	s.mu.RLock()
@@ -376,6 +579,34 @@ func (s *PipelineService) getClientForPlatform(platform string) api.Client {
 	return s.clients[platform]
 }
 
+// getClientForProject returns the client of the connection a project belongs to.
+// Returns nil if no client is registered for the connection.
+func (s *PipelineService) getClientForProject(platform, projectID string) api.Client {
+	return s.getClientForPlatform(connectionName(platform, projectID))
+}
+
+// connectionName returns the name of the connection a project belongs to.
+// Projects of named connections carry the name in their ID, others belong to the platform's default connection.
+func connectionName(platform, projectID string) string {
+	if _, connection := api.SplitProjectID(projectID); connection != "" {
+		return connection
+	}
+	return platform
+}
+
+// getPipelineClient returns the client running a project's pipelines:
+// a registered pipeline-only client when the project is linked to one, otherwise the connection's client.
+func (s *PipelineService) getPipelineClient(platform, projectID string) api.Client {
+	connection := connectionName(platform, projectID)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if client, ok := s.pipelineLinks[connection+":"+projectID]; ok {
+		return client
+	}
+	return s.clients[connection]
+}
+
 // GetPipelinesForProject retrieves pipelines for a single project.
 func (s *PipelineService) GetPipelinesForProject(ctx context.Context, projectID string, limit int) ([]domain.Pipeline, error) {
 	s.mu.RLock()
@@ -399,7 +630,7 @@ func (s *PipelineService) GetPipelinesForProject(ctx context.Context, projectID
 		return nil, fmt.Errorf("project not found: %s", projectID)
 	}
 
-	client := s.getClientForPlatform(platform)
+	client := s.getPipelineClient(platform, projectID)
 	if client == nil {
 		return nil, fmt.Errorf("no client for platform: %s", platform)
 	}
@@ -424,7 +655,7 @@ func (s *PipelineService) GetBranchesForProject(ctx context.Context, project dom
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	client := s.getClientForPlatform(project.Platform)
+	client := s.getClientForProject(project.Platform, project.ID)
 	if client == nil {
 		return nil, fmt.Errorf("no client for platform: %s", project.Platform)
 	}
@@ -444,10 +675,11 @@ func (s *PipelineService) GetBranchesForProject(ctx context.Context, project dom
 	// branches = filterOpenBranches(branches)
 
 	// Get pipeline for each branch
+	pipelineClient := s.getPipelineClient(project.Platform, project.ID)
 	var results []domain.BranchWithPipeline
 	for _, branch := range branches {
 		var pipeline *domain.Pipeline
-		p, err := client.GetLatestPipeline(ctx, branch.ProjectID, branch.Name)
+		p, err := pipelineClient.GetLatestPipeline(ctx, branch.ProjectID, branch.Name)
 		if err == nil && p != nil {
 			pipeline = p
 		}
@@ -468,7 +700,7 @@ func (s *PipelineService) GetDefaultBranchForProject(ctx context.Context, projec
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	client := s.getClientForPlatform(project.Platform)
+	client := s.getClientForProject(project.Platform, project.ID)
 	if client == nil {
 		return nil, nil, 0, fmt.Errorf("no client for platform: %s", project.Platform)
 	}
@@ -533,7 +765,8 @@ func (s *PipelineService) GetDefaultBranchForProject(ctx context.Context, projec
 	// Get pipeline only for default branch
 	var defaultPipeline *domain.Pipeline
 	if defaultBranch != nil {
-		p, err := client.GetLatestPipeline(ctx, defaultBranch.ProjectID, defaultBranch.Name)
+		pipelineClient := s.getPipelineClient(project.Platform, project.ID)
+		p, err := pipelineClient.GetLatestPipeline(ctx, defaultBranch.ProjectID, defaultBranch.Name)
 		if err == nil && p != nil {
 			defaultPipeline = p
 		}
@@ -545,27 +778,18 @@ func (s *PipelineService) GetDefaultBranchForProject(ctx context.Context, projec
 // isWhitelisted checks if a project is in the appropriate whitelist.
 // Returns true if whitelist is empty (allow all) or if project is in whitelist.
 func (s *PipelineService) isWhitelisted(project domain.Project) bool {
-	var whitelist []string
-
-	// Select the appropriate whitelist based on platform
-	switch project.Platform {
-	case "gitlab":
-		whitelist = s.gitlabWhitelist
-	case "github":
-		whitelist = s.githubWhitelist
-	default:
-		// Unknown platform - deny by default if any whitelist is set
-		return len(s.gitlabWhitelist) == 0 && len(s.githubWhitelist) == 0
-	}
+	// Select the appropriate whitelist based on connection
+	whitelist := s.whitelists[connectionName(project.Platform, project.ID)]
 
-	// No whitelist for this platform means allow all
+	// No whitelist for this connection means allow all
 	if len(whitelist) == 0 {
 		return true
 	}
 
-	// Check if project ID is in whitelist
+	// Check if project ID is in whitelist (whitelists hold the platform's own IDs)
+	projectID, _ := api.SplitProjectID(project.ID)
 	for _, allowed := range whitelist {
-		if project.ID == allowed {
+		if projectID == allowed {
 			return true
 		}
 	}
@@ -573,6 +797,16 @@ func (s *PipelineService) isWhitelisted(project domain.Project) bool {
 	return false
 }
 
+// hasWhitelist reports whether any connection restricts its repositories.
+func (s *PipelineService) hasWhitelist() bool {
+	for _, whitelist := range s.whitelists {
+		if len(whitelist) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // hasUserMembership checks if the user has any membership/permissions in the project.
 // Returns true if the user has any access level (read, write, or admin).
 func (s *PipelineService) hasUserMembership(project domain.Project) bool {
@@ -587,8 +821,8 @@ func (s *PipelineService) hasUserMembership(project domain.Project) bool {
 		// 10=Guest, 20=Reporter, 30=Developer, 40=Maintainer, 50=Owner
 		return project.Permissions.AccessLevel > 0
 
-	case "github":
-		// GitHub: Any of Admin, Push, or Pull permission means user has access
+	case "github", "gitea":
+		// GitHub/Gitea: Any of Admin, Push, or Pull permission means user has access
 		return project.Permissions.Admin || project.Permissions.Push || project.Permissions.Pull
 
 	default:
@@ -604,20 +838,11 @@ func (s *PipelineService) GetTotalProjectCount(ctx context.Context) (int, error)
 
 	totalCount := 0
 
-	// Get count from GitLab
-	if gitlabClient := s.getClientForPlatform("gitlab"); gitlabClient != nil {
-		count, err := gitlabClient.GetProjectCount(ctx)
-		if err != nil {
-			return 0, fmt.Errorf("gitlab: %w", err)
-		}
-		totalCount += count
-	}
-
-	// Get count from GitHub
-	if githubClient := s.getClientForPlatform("github"); githubClient != nil {
-		count, err := githubClient.GetProjectCount(ctx)
+	// Sum counts from every configured platform
+	for platform, client := range s.clients {
+		count, err := client.GetProjectCount(ctx)
 		if err != nil {
-			return 0, fmt.Errorf("github: %w", err)
+			return 0, fmt.Errorf("%s: %w", platform, err)
 		}
 		totalCount += count
 	}
@@ -641,14 +866,7 @@ func (s *PipelineService) GetProjectsPageByPlatform(ctx context.Context, platfor
 	}
 
 	// Filter projects based on whitelist
-	var whitelist []string
-	if platform == domain.PlatformGitLab {
-		whitelist = s.gitlabWhitelist
-	} else if platform == domain.PlatformGitHub {
-		whitelist = s.githubWhitelist
-	}
-
-	if len(whitelist) > 0 {
+	if len(s.whitelists[platform]) > 0 {
 		filtered := make([]domain.Project, 0, len(projects))
 		for _, project := range projects {
 			if s.isWhitelisted(project) {
@@ -708,7 +926,7 @@ func (s *PipelineService) getAllProjectsLocked(ctx context.Context) ([]domain.Pr
 	}
 
 	// Filter projects based on whitelist
-	if len(s.gitlabWhitelist) > 0 || len(s.githubWhitelist) > 0 {
+	if s.hasWhitelist() {
 		filtered := make([]domain.Project, 0, len(allProjects))
 		for _, project := range allProjects {
 			if s.isWhitelisted(project) {
@@ -760,6 +978,11 @@ func (s *PipelineService) GetPipelinesByProject(ctx context.Context, projectIDs
 			go func(projID, plat string, c api.Client) {
 				defer wg.Done()
 
+				// Pipelines of projects linked to a pipeline-only client are read from it
+				if linked, ok := s.pipelineLinks[plat+":"+projID]; ok {
+					c = linked
+				}
+
 				// Get latest pipeline (branch can be "main" or "master" by default)
 				pipeline, err := c.GetLatestPipeline(ctx, projID, "main")
 				if err != nil {
@@ -871,7 +1094,7 @@ func (s *PipelineService) GetRepositoriesWithRecentRuns(ctx context.Context, run
 			var runs []domain.Pipeline
 
 			// Get the appropriate client for this project's platform
-			client := s.getClientForPlatform(proj.Platform)
+			client := s.getPipelineClient(proj.Platform, proj.ID)
 			if client != nil {
 				pipelines, err := client.GetPipelines(ctx, proj.ID, runsPerRepo)
 				if err == nil && len(pipelines) > 0 {
@@ -951,7 +1174,7 @@ func (s *PipelineService) GetRecentPipelines(ctx context.Context, totalLimit int
 			}
 
 			// Get the appropriate client for this project's platform
-			client := s.getClientForPlatform(proj.Platform)
+			client := s.getPipelineClient(proj.Platform, proj.ID)
 			if client != nil {
 				pipelines, err := client.GetPipelines(ctx, proj.ID, pipelinesPerProject)
 				if err == nil && len(pipelines) > 0 {
@@ -1016,7 +1239,7 @@ func (s *PipelineService) GetAllMergeRequests(ctx context.Context) ([]domain.Mer
 			}
 
 			// Get the appropriate client for this project's platform
-			c := s.getClientForPlatform(proj.Platform)
+			c := s.getClientForProject(proj.Platform, proj.ID)
 			if c == nil {
 				results <- result{mrs: nil, err: nil}
 				return
@@ -1073,7 +1296,7 @@ func (s *PipelineService) GetMergeRequestsForProject(ctx context.Context, projec
 	defer s.mu.RUnlock()
 
 	// Get the appropriate client for this project's platform
-	c := s.getClientForPlatform(project.Platform)
+	c := s.getClientForProject(project.Platform, project.ID)
 	if c == nil {
 		return nil, fmt.Errorf("no client for platform: %s", project.Platform)
 	}
@@ -1095,6 +1318,30 @@ func (s *PipelineService) GetMergeRequestsForProject(ctx context.Context, projec
 	return mrs, nil
 }
 
+// GetMergedMergeRequestsForProject retrieves merge requests of a project merged since the given time.
+func (s *PipelineService) GetMergedMergeRequestsForProject(ctx context.Context, project domain.Project, since time.Time) ([]domain.MergeRequest, error) {
+	c := s.getClientForProject(project.Platform, project.ID)
+	if c == nil {
+		return nil, fmt.Errorf("no client for platform: %s", project.Platform)
+	}
+
+	// Check if client supports MergedMergeRequestsClient interface
+	client, ok := c.(api.MergedMergeRequestsClient)
+	if !ok {
+		return []domain.MergeRequest{}, nil // Platform doesn't support merged MRs, return empty list
+	}
+
+	mrs, err := client.GetMergedMergeRequests(ctx, project.ID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merged MRs for %s: %w", project.Name, err)
+	}
+
+	// Set repository name from project
+	fixMRRepositoryNames(mrs, project)
+
+	return mrs, nil
+}
+
 // GetUserProfiles retrieves user profiles from all configured platforms.
 func (s *PipelineService) GetUserProfiles(ctx context.Context) ([]domain.UserProfile, error) {
 	s.mu.RLock()
@@ -1174,7 +1421,7 @@ func (s *PipelineService) GetAllIssues(ctx context.Context) ([]domain.Issue, err
 			}
 
 			// Get the appropriate client for this project's platform
-			c := s.getClientForPlatform(proj.Platform)
+			c := s.getClientForProject(proj.Platform, proj.ID)
 			if c == nil {
 				results <- result{issues: nil, err: nil}
 				return
@@ -1260,7 +1507,7 @@ func (s *PipelineService) GetAllBranches(ctx context.Context, limit int) ([]doma
 			}
 
 			// Get the appropriate client for this project's platform
-			client := s.getClientForPlatform(proj.Platform)
+			client := s.getClientForProject(proj.Platform, proj.ID)
 			if client != nil {
 				branches, err := client.GetBranches(ctx, proj.ID, limit)
 				if err == nil && len(branches) > 0 {
@@ -1318,7 +1565,7 @@ func (s *PipelineService) GetBranchesWithPipelines(ctx context.Context, limit in
 
 			// Try to get latest pipeline for this branch
 			var pipeline *domain.Pipeline
-			client := s.getClientForPlatform(b.Platform)
+			client := s.getPipelineClient(b.Platform, b.ProjectID)
 			if client != nil {
 				p, err := client.GetLatestPipeline(ctx, b.ProjectID, b.Name)
 				if err == nil && p != nil {
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestIsWhitelisted_QualifiedIDs tests that whitelists apply per connection, so a Gitea repository
+// qualified with its platform name never matches GitHub's whitelist entry of the same name.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestIsWhitelisted_QualifiedIDs(t *testing.T) {
+	// Arrange
+	s := NewPipelineService(map[string][]string{
+		domain.PlatformGitHub: {"acme/api"},
+		domain.PlatformGitea:  {"acme/web"},
+	}, false)
+
+	tests := []struct {
+		name     string
+		project  domain.Project
+		expected bool
+	}{
+		{"github listed", domain.Project{Platform: domain.PlatformGitHub, ID: "acme/api"}, true},
+		{"github not listed", domain.Project{Platform: domain.PlatformGitHub, ID: "acme/web"}, false},
+		{"gitea listed by raw ID", domain.Project{Platform: domain.PlatformGitea, ID: "acme/web@gitea"}, true},
+		{"gitea listed on github only", domain.Project{Platform: domain.PlatformGitea, ID: "acme/api@gitea"}, false},
+		{"bitbucket without whitelist", domain.Project{Platform: domain.PlatformBitbucket, ID: "acme/api@bitbucket"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := s.isWhitelisted(tt.project)
+
+			// Assert
+			if got != tt.expected {
+				t.Errorf("expected isWhitelisted(%s) = %v, got %v", tt.project.ID, tt.expected, got)
+			}
+		})
+	}
+}
+
+// fakeProjectsCache is a test double for a caching client that serves pages of projects
+// and records the project lists it is populated with. Other client methods panic if called.
+type fakeProjectsCache struct {
+	api.Client
+	cached    []domain.Project
+	pages     [][]domain.Project
+	populated [][]domain.Project
+}
+
+func (f *fakeProjectsCache) GetProjects(ctx context.Context) ([]domain.Project, error) {
+	return f.cached, nil
+}
+
+func (f *fakeProjectsCache) GetProjectsPage(ctx context.Context, page int) ([]domain.Project, bool, error) {
+	return f.pages[page-1], page < len(f.pages), nil
+}
+
+func (f *fakeProjectsCache) ForceRefresh(ctx context.Context, key string) error {
+	return nil
+}
+
+func (f *fakeProjectsCache) PopulateProjects(projects []domain.Project) {
+	f.populated = append(f.populated, append([]domain.Project(nil), projects...))
+}
+
+// TestForceRefreshClientPageByPage_ProjectList tests that the project list grows project by project
+// only on the first sweep, and is otherwise replaced once with the complete list.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestForceRefreshClientPageByPage_ProjectList(t *testing.T) {
+	pages := [][]domain.Project{
+		{{ID: "1", Name: "one"}, {ID: "2", Name: "two"}},
+		{{ID: "3", Name: "three"}},
+	}
+
+	tests := []struct {
+		name                string
+		cached              []domain.Project
+		expectedPopulations int
+	}{
+		{"first sweep", nil, 4},
+		{"later sweep", []domain.Project{{ID: "1", Name: "one"}}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			s := NewPipelineService(nil, false)
+			cache := &fakeProjectsCache{cached: tt.cached, pages: pages}
+
+			// Act
+			err := s.forceRefreshClientPageByPage(context.Background(), domain.PlatformGitLab, cache)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(cache.populated) != tt.expectedPopulations {
+				t.Fatalf("expected %d project list updates, got %d", tt.expectedPopulations, len(cache.populated))
+			}
+			if last := cache.populated[len(cache.populated)-1]; len(last) != 3 {
+				t.Errorf("expected the complete list of 3 projects last, got %d", len(last))
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TimelineSlack is how long after a job finished the next job may become ready and still be
+// considered waiting for it. Covers the platform's scheduling delay between the two.
+const TimelineSlack = 10 * time.Second
+
+// TimelineJob is a started job of a pipeline timeline.
+type TimelineJob struct {
+	Build    domain.Build
+	QueuedAt time.Time // when the job became ready and started waiting for a runner
+	EndedAt  time.Time // when it finished, or now if it is still running
+	Critical bool      // on the chain of jobs that determined when the pipeline finished
+}
+
+// PipelineTimeline is the started jobs of a pipeline on a time axis, with the chain of jobs
+// that determined the pipeline's duration.
+type PipelineTimeline struct {
+	Pipeline      domain.Pipeline
+	Jobs          []TimelineJob // by queue time, then start time
+	NotStarted    int           // jobs left out because they never started (pending, skipped, manual)
+	Start         time.Time     // when the first job was queued
+	End           time.Time     // when the last job finished
+	CriticalRun   time.Duration // time the critical chain's jobs ran
+	CriticalQueue time.Duration // time the critical chain's jobs waited for a runner
+}
+
+// GetPipelineTimeline returns the jobs of a pipeline of a project on a time axis.
+func (s *PipelineService) GetPipelineTimeline(ctx context.Context, project domain.Project, pipelineID string) (*PipelineTimeline, error) {
+	pipeline, builds, err := s.getPipelineJobs(ctx, project, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := NewPipelineTimeline(*pipeline, builds, time.Now())
+	return &timeline, nil
+}
+
+// NewPipelineTimeline places the latest attempt of each started job of a pipeline on a time axis.
+// Walking back from the job that finished last, each job on the critical chain waited for the job
+// that finished last before it became ready.
+func NewPipelineTimeline(pipeline domain.Pipeline, builds []domain.Build, now time.Time) PipelineTimeline {
+	timeline := PipelineTimeline{Pipeline: pipeline}
+	for _, build := range builds {
+		if build.Retried {
+			continue
+		}
+		if build.StartedAt.IsZero() {
+			timeline.NotStarted++
+			continue
+		}
+
+		job := TimelineJob{
+			Build:    build,
+			QueuedAt: build.StartedAt.Add(-build.Queued),
+			EndedAt:  build.StartedAt.Add(build.Duration),
+		}
+		if build.Status == domain.StatusRunning {
+			job.EndedAt = now
+		}
+		timeline.Jobs = append(timeline.Jobs, job)
+	}
+	if len(timeline.Jobs) == 0 {
+		return timeline
+	}
+
+	sort.SliceStable(timeline.Jobs, func(i, j int) bool {
+		a, b := timeline.Jobs[i], timeline.Jobs[j]
+		if !a.QueuedAt.Equal(b.QueuedAt) {
+			return a.QueuedAt.Before(b.QueuedAt)
+		}
+		return a.Build.StartedAt.Before(b.Build.StartedAt)
+	})
+
+	last := 0
+	timeline.Start = timeline.Jobs[0].QueuedAt
+	for i, job := range timeline.Jobs {
+		if job.EndedAt.After(timeline.Jobs[last].EndedAt) {
+			last = i
+		}
+	}
+	timeline.End = timeline.Jobs[last].EndedAt
+
+	for i := last; i >= 0; i = timeline.waitedFor(i) {
+		job := &timeline.Jobs[i]
+		job.Critical = true
+		timeline.CriticalRun += job.EndedAt.Sub(job.Build.StartedAt)
+		timeline.CriticalQueue += job.Build.StartedAt.Sub(job.QueuedAt)
+	}
+	return timeline
+}
+
+// waitedFor returns the job that finished last before a job became ready, or -1 if none did.
+// Only jobs that finished before the job itself count, so the chain always moves back in time.
+func (t *PipelineTimeline) waitedFor(i int) int {
+	ready := t.Jobs[i].QueuedAt.Add(TimelineSlack)
+	previous := -1
+	for j, job := range t.Jobs {
+		if job.EndedAt.After(ready) || !job.EndedAt.Before(t.Jobs[i].EndedAt) {
+			continue
+		}
+		if previous < 0 || job.EndedAt.After(t.Jobs[previous].EndedAt) {
+			previous = j
+		}
+	}
+	return previous
+}
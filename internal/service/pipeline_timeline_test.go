@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// TestNewPipelineTimeline tests placing jobs on a time axis and finding the chain that determined the duration.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestNewPipelineTimeline(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes float64) time.Time { return start.Add(time.Duration(minutes * float64(time.Minute))) }
+	job := func(name string, queuedAt, startedAt, endedAt float64) domain.Build {
+		return domain.Build{
+			Name:      name,
+			Status:    domain.StatusSuccess,
+			StartedAt: at(startedAt),
+			Queued:    at(startedAt).Sub(at(queuedAt)),
+			Duration:  at(endedAt).Sub(at(startedAt)),
+		}
+	}
+	builds := []domain.Build{
+		job("compile", 0, 1, 4),
+		job("lint", 0, 0.5, 2),
+		{Name: "unit", StartedAt: at(4), Duration: time.Minute, Retried: true},
+		job("unit", 4, 6, 9), // waited 2 minutes for a runner
+		job("e2e", 4, 4.5, 8),
+		job("deploy", 9, 9.5, 11),
+		{Name: "release", Status: domain.StatusSkipped},
+	}
+
+	// Act
+	timeline := NewPipelineTimeline(domain.Pipeline{ID: "1"}, builds, at(20))
+
+	// Assert
+	if len(timeline.Jobs) != 5 || timeline.NotStarted != 1 {
+		t.Fatalf("expected 5 started jobs and 1 not started, got %d and %d", len(timeline.Jobs), timeline.NotStarted)
+	}
+	if !timeline.Start.Equal(at(0)) || !timeline.End.Equal(at(11)) {
+		t.Errorf("expected the timeline to span 0-11 minutes, got %v to %v", timeline.Start, timeline.End)
+	}
+	if timeline.Jobs[0].Build.Name != "lint" || timeline.Jobs[4].Build.Name != "deploy" {
+		t.Errorf("expected jobs by queue time, then start time, got %+v", timeline.Jobs)
+	}
+
+	critical := map[string]bool{"compile": true, "unit": true, "deploy": true}
+	for _, j := range timeline.Jobs {
+		if j.Critical != critical[j.Build.Name] {
+			t.Errorf("expected %s critical=%v, got %v", j.Build.Name, critical[j.Build.Name], j.Critical)
+		}
+	}
+	if timeline.CriticalRun != 450*time.Second || timeline.CriticalQueue != 210*time.Second {
+		t.Errorf("expected 7m30s running and 3m30s queued on the critical chain, got %v and %v", timeline.CriticalRun, timeline.CriticalQueue)
+	}
+}
+
+// TestNewPipelineTimeline_RunningJob tests that running jobs extend to now.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestNewPipelineTimeline_RunningJob(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	now := start.Add(5 * time.Minute)
+	builds := []domain.Build{{Name: "build", Status: domain.StatusRunning, StartedAt: start}}
+
+	// Act
+	timeline := NewPipelineTimeline(domain.Pipeline{ID: "1"}, builds, now)
+
+	// Assert
+	if !timeline.End.Equal(now) || !timeline.Jobs[0].Critical || timeline.CriticalRun != 5*time.Minute {
+		t.Errorf("expected the running job to end now and be critical, got %+v", timeline)
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// StatusNotifier alerts on default branch status transitions (Dependency Inversion Principle).
+type StatusNotifier interface {
+	Observe(project domain.Project, pipeline domain.Pipeline)
+}
+
+// SetStatusNotifier enables alerting on default branch status transitions.
+func (s *PipelineService) SetStatusNotifier(n StatusNotifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifier = n
+}
+
+// HasStatusNotifier reports whether notifications are enabled.
+func (s *PipelineService) HasStatusNotifier() bool {
+	return s.statusNotifier() != nil
+}
+
+// ObserveDefaultBranchPipeline passes a finished pipeline of a project's default branch to the notifier.
+// Pipelines of other branches are ignored. Does nothing when notifications are disabled.
+func (s *PipelineService) ObserveDefaultBranchPipeline(project domain.Project, pipeline domain.Pipeline) {
+	n := s.statusNotifier()
+	if n == nil || project.DefaultBranch == "" || pipeline.Branch != project.DefaultBranch {
+		return
+	}
+	n.Observe(project, pipeline)
+}
+
+// observeWebhookPipeline passes a webhook pipeline to the notifier, looking up its project in the cache.
+func (s *PipelineService) observeWebhookPipeline(ctx context.Context, platform, projectID string, pipeline domain.Pipeline) {
+	if !s.HasStatusNotifier() || !pipeline.Status.IsTerminal() {
+		return
+	}
+
+	projects, err := s.GetAllProjects(ctx)
+	if err != nil {
+		return
+	}
+	for _, project := range projects {
+		if project.Platform == platform && project.ID == projectID {
+			s.ObserveDefaultBranchPipeline(project, pipeline)
+			return
+		}
+	}
+}
+
+// statusNotifier returns the configured notifier, or nil when disabled.
+func (s *PipelineService) statusNotifier() StatusNotifier {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.notifier
+}
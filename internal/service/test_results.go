@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+)
+
+// TestHistoryWindowDays is how far back pipeline history is searched for earlier failures of a test
+const TestHistoryWindowDays = 30
+
+// TestFailure is a failed test of a pipeline, with how it fared in earlier pipelines of the same branch.
+// Only pipelines whose test results were recorded in the pipeline history count.
+type TestFailure struct {
+	Test         domain.TestCase
+	FailingRuns  int       // consecutive pipelines up to this one where the test failed, this one included
+	FailingSince time.Time // creation time of the first pipeline of that streak
+	Failures     int       // pipelines in the window where the test failed
+	Runs         int       // pipelines in the window with test results, up to this one
+}
+
+// IsNew returns true if the test didn't fail in the previous pipeline with test results.
+// Without earlier results, it can't tell.
+func (f TestFailure) IsNew() bool {
+	return f.FailingRuns == 1 && f.Runs > 1
+}
+
+// PipelineTestResults is the test report of a pipeline with the history of its failed tests.
+type PipelineTestResults struct {
+	Pipeline       domain.Pipeline
+	Report         *domain.TestReport // nil = the pipeline has no test results
+	Failures       []TestFailure      // new failures first, then by how long they have been failing
+	HistoryEnabled bool
+}
+
+// GetPipelineTestResults returns the test report of a finished pipeline of a project, with how each
+// failed test fared in earlier pipelines of the same branch. The results are recorded in the pipeline history.
+func (s *PipelineService) GetPipelineTestResults(ctx context.Context, project domain.Project, pipelineID string) (*PipelineTestResults, error) {
+	pipeline, err := s.findPipeline(ctx, project, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	if !pipeline.Status.IsTerminal() {
+		return nil, fmt.Errorf("pipeline %s has not finished", pipelineID)
+	}
+
+	report, err := s.getTestReport(ctx, project, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	h := s.pipelineHistory()
+	results := &PipelineTestResults{Pipeline: *pipeline, Report: report, HistoryEnabled: h != nil}
+	if report == nil {
+		return results, nil
+	}
+
+	var records []history.Record
+	if h != nil {
+		s.recordTestResults(h, project.Platform, *pipeline, report)
+		since := pipeline.CreatedAt.AddDate(0, 0, -TestHistoryWindowDays)
+		records = h.Records(project.Platform, project.ID, pipeline.Branch, since)
+	}
+	results.Failures = testFailureHistory(records, *pipeline, report.FailedCases())
+	return results, nil
+}
+
+// RecordDefaultBranchTestResults reads the test reports of the latest finished default branch pipelines
+// of projects and records their results in the pipeline history, so failures can be traced across pipelines.
+// Reports are cached once read, so each pipeline's report is downloaded once. Does nothing when history is disabled.
+func (s *PipelineService) RecordDefaultBranchTestResults(ctx context.Context, projects []domain.Project) {
+	h := s.pipelineHistory()
+	if h == nil {
+		return
+	}
+
+	processProjectsConcurrently(ctx, projects, MaxConcurrentWorkers,
+		func(ctx context.Context, project domain.Project) ([]struct{}, error) {
+			_, pipeline, _, err := s.GetDefaultBranchForProject(ctx, project)
+			if err != nil || pipeline == nil || !pipeline.Status.IsTerminal() {
+				return nil, err
+			}
+
+			report, err := s.getTestReport(ctx, project, pipeline.ID)
+			if err != nil {
+				log.Printf("[TestResults] Failed to get test report of pipeline %s of %s: %v", pipeline.ID, project.Name, err)
+				return nil, err
+			}
+			if report != nil {
+				s.recordTestResults(h, project.Platform, *pipeline, report)
+			}
+			return nil, nil
+		})
+}
+
+// getTestReport returns the test report of a project's pipeline, or nil if its client has no test reports.
+func (s *PipelineService) getTestReport(ctx context.Context, project domain.Project, pipelineID string) (*domain.TestReport, error) {
+	client, ok := s.getPipelineClient(project.Platform, project.ID).(api.TestReportClient)
+	if !ok {
+		return nil, nil
+	}
+
+	report, err := client.GetTestReport(ctx, project.ID, pipelineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test report for %s: %w", project.Name, err)
+	}
+	return report, nil
+}
+
+// findPipeline returns a pipeline of a project from its cached recent pipelines or latest default branch pipeline.
+func (s *PipelineService) findPipeline(ctx context.Context, project domain.Project, pipelineID string) (*domain.Pipeline, error) {
+	client := s.getPipelineClient(project.Platform, project.ID)
+	if client == nil {
+		return nil, fmt.Errorf("no client for platform: %s", project.Platform)
+	}
+
+	// Same key as the repository detail page
+	pipelines, err := client.GetPipelines(ctx, project.ID, 50)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipelines for %s: %w", project.Name, err)
+	}
+	for i := range pipelines {
+		if pipelines[i].ID == pipelineID {
+			return &pipelines[i], nil
+		}
+	}
+
+	if _, latest, _, err := s.GetDefaultBranchForProject(ctx, project); err == nil && latest != nil && latest.ID == pipelineID {
+		return latest, nil
+	}
+	return nil, fmt.Errorf("pipeline %s not found in %s", pipelineID, project.Name)
+}
+
+// recordTestResults records a finished pipeline and the results of its tests in the history.
+func (s *PipelineService) recordTestResults(h PipelineHistory, platform string, pipeline domain.Pipeline, report *domain.TestReport) {
+	if _, err := h.Add(platform, []domain.Pipeline{pipeline}); err != nil {
+		log.Printf("[History] Failed to record pipeline %s: %v", pipeline.ID, err)
+		return
+	}
+	if _, err := h.SetTestResults(platform, pipeline.ProjectID, pipeline.ID, testRecord(report)); err != nil {
+		log.Printf("[History] Failed to record test results of pipeline %s: %v", pipeline.ID, err)
+	}
+}
+
+// testRecord converts a test report to the distinct names of its failed tests.
+func testRecord(report *domain.TestReport) history.TestRecord {
+	record := history.TestRecord{Total: report.Total()}
+	seen := make(map[string]bool)
+	for _, tc := range report.FailedCases() {
+		name := tc.FullName()
+		if !seen[name] {
+			seen[name] = true
+			record.Failed = append(record.Failed, name)
+		}
+	}
+	return record
+}
+
+// testFailureHistory returns how each failed test of a pipeline fared in the recorded pipelines of its branch.
+// Records must be ordered oldest first; records without test results or created after the pipeline are ignored.
+// Results are sorted with new failures first, then by how long the test has been failing.
+func testFailureHistory(records []history.Record, pipeline domain.Pipeline, failed []domain.TestCase) []TestFailure {
+	type run struct {
+		createdAt time.Time
+		failed    map[string]bool
+	}
+
+	// Test results of the pipelines up to this one, oldest first, this one last
+	var runs []run
+	for _, r := range records {
+		if r.Tests == nil || r.PipelineID == pipeline.ID || r.CreatedAt.After(pipeline.CreatedAt) {
+			continue
+		}
+		failedTests := make(map[string]bool, len(r.Tests.Failed))
+		for _, name := range r.Tests.Failed {
+			failedTests[name] = true
+		}
+		runs = append(runs, run{createdAt: r.CreatedAt, failed: failedTests})
+	}
+	current := run{createdAt: pipeline.CreatedAt, failed: make(map[string]bool, len(failed))}
+	for _, tc := range failed {
+		current.failed[tc.FullName()] = true
+	}
+	runs = append(runs, current)
+
+	failures := make([]TestFailure, 0, len(failed))
+	seen := make(map[string]bool)
+	for _, tc := range failed {
+		name := tc.FullName()
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		failure := TestFailure{Test: tc, Runs: len(runs)}
+		streak := true
+		for i := len(runs) - 1; i >= 0; i-- {
+			if !runs[i].failed[name] {
+				streak = false
+				continue
+			}
+			failure.Failures++
+			if streak {
+				failure.FailingRuns++
+				failure.FailingSince = runs[i].createdAt
+			}
+		}
+		failures = append(failures, failure)
+	}
+
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[i].FailingRuns < failures[j].FailingRuns
+	})
+	return failures
+}
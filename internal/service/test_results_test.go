@@ -0,0 +1,87 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/domain"
+	"github.com/vilaca/ci-dashboard/internal/history"
+)
+
+// TestTestFailureHistory tests telling new test failures from long-standing and intermittent ones.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestTestFailureHistory(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	record := func(id string, hours int, failed ...string) history.Record {
+		return history.Record{
+			PipelineID: id,
+			CreatedAt:  start.Add(time.Duration(hours) * time.Hour),
+			Tests:      &history.TestRecord{Total: 10, Failed: failed},
+		}
+	}
+	records := []history.Record{
+		record("1", 0, "pkg.TestBroken", "pkg.TestFlaky"),
+		record("2", 1, "pkg.TestBroken"),
+		{PipelineID: "3", CreatedAt: start.Add(2 * time.Hour)}, // no test results
+		record("4", 3, "pkg.TestBroken", "pkg.TestFlaky"),
+		record("5", 4, "pkg.TestBroken"),
+		record("7", 6, "pkg.TestNew"), // after the pipeline
+	}
+	pipeline := domain.Pipeline{ID: "6", CreatedAt: start.Add(5 * time.Hour)}
+	failed := []domain.TestCase{
+		{ClassName: "pkg", Name: "TestBroken"},
+		{ClassName: "pkg", Name: "TestFlaky"},
+		{ClassName: "pkg", Name: "TestNew"},
+	}
+
+	// Act
+	failures := testFailureHistory(records, pipeline, failed)
+
+	// Assert
+	if len(failures) != 3 {
+		t.Fatalf("expected 3 failures, got %+v", failures)
+	}
+
+	byName := make(map[string]TestFailure)
+	for _, f := range failures {
+		byName[f.Test.FullName()] = f
+	}
+
+	broken := byName["pkg.TestBroken"]
+	if broken.FailingRuns != 5 || !broken.FailingSince.Equal(start) || broken.IsNew() {
+		t.Errorf("expected TestBroken to be failing for 5 runs since the first, got %+v", broken)
+	}
+
+	flaky := byName["pkg.TestFlaky"]
+	if !flaky.IsNew() || flaky.Failures != 3 || flaky.Runs != 5 {
+		t.Errorf("expected TestFlaky to be a new failure that failed 3 of 5 runs, got %+v", flaky)
+	}
+
+	newTest := byName["pkg.TestNew"]
+	if !newTest.IsNew() || newTest.Failures != 1 {
+		t.Errorf("expected TestNew to be a new failure, got %+v", newTest)
+	}
+
+	if failures[len(failures)-1].Test.Name != "TestBroken" {
+		t.Errorf("expected the long-standing failure last, got %+v", failures)
+	}
+}
+
+// TestTestFailureHistory_NoHistory tests that failures without earlier results aren't reported as new.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestTestFailureHistory_NoHistory(t *testing.T) {
+	// Arrange
+	pipeline := domain.Pipeline{ID: "1", CreatedAt: time.Now()}
+
+	// Act
+	failures := testFailureHistory(nil, pipeline, []domain.TestCase{{Name: "TestA"}, {Name: "TestA"}})
+
+	// Assert
+	if len(failures) != 1 {
+		t.Fatalf("expected duplicate failures to be merged, got %+v", failures)
+	}
+	if failures[0].IsNew() || failures[0].Runs != 1 || failures[0].FailingRuns != 1 {
+		t.Errorf("expected a single run that can't be called new, got %+v", failures[0])
+	}
+}
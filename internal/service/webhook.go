@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// webhookCacher is implemented by caching clients that can parse webhooks and be updated in place.
+type webhookCacher interface {
+	ParseWebhook(eventType string, payload []byte) ([]domain.Event, error)
+	GetPipelines(ctx context.Context, projectID string, limit int) ([]domain.Pipeline, error)
+	GetBranches(ctx context.Context, projectID string, limit int) ([]domain.Branch, error)
+	GetMergeRequests(ctx context.Context, projectID string) ([]domain.MergeRequest, error)
+	PopulatePipelines(pipelines []domain.Pipeline)
+	PopulateBranches(branches []domain.Branch)
+	PopulateMergeRequests(mrs []domain.MergeRequest)
+	Invalidate(key string)
+}
+
+// HandleWebhook parses a webhook payload sent by a connection (the platform name for a platform's
+// default connection) and applies the resulting events to that connection's cache.
+// Returns the number of events applied.
+func (s *PipelineService) HandleWebhook(ctx context.Context, connection, eventType string, payload []byte) (int, error) {
+	client := s.getClientForPlatform(connection)
+	if client == nil {
+		return 0, fmt.Errorf("no client for connection: %s", connection)
+	}
+
+	cacher, ok := client.(webhookCacher)
+	if !ok {
+		return 0, fmt.Errorf("client for %s does not support webhooks", connection)
+	}
+
+	events, err := cacher.ParseWebhook(eventType, payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s webhook: %w", connection, err)
+	}
+
+	s.mu.RLock()
+	platform := s.platforms[connection]
+	s.mu.RUnlock()
+
+	return s.ApplyEvents(ctx, platform, qualifyWebhookEvents(platform, connection, events)), nil
+}
+
+// qualifyWebhookEvents qualifies the project IDs of events sent by a connection with its name,
+// so they resolve to the connection's cache. Events of other connections are dropped.
+func qualifyWebhookEvents(platform, connection string, events []domain.Event) []domain.Event {
+	qualified := make([]domain.Event, 0, len(events))
+	for _, event := range events {
+		if connectionName(platform, event.ProjectID) != connection {
+			if _, named := api.SplitProjectID(event.ProjectID); named != "" {
+				continue
+			}
+			event.ProjectID = api.NamespaceProjectID(event.ProjectID, connection)
+			if event.Pipeline != nil {
+				pipeline := *event.Pipeline
+				pipeline.ProjectID = event.ProjectID
+				event.Pipeline = &pipeline
+			}
+			if event.Branch != nil {
+				branch := *event.Branch
+				branch.ProjectID = event.ProjectID
+				event.Branch = &branch
+			}
+			if event.MergeRequest != nil {
+				mr := *event.MergeRequest
+				mr.ProjectID = event.ProjectID
+				event.MergeRequest = &mr
+			}
+		}
+		qualified = append(qualified, event)
+	}
+	return qualified
+}
+
+// ApplyEvents updates cached data from events of a platform, in the cache of each event's connection.
+// Events carrying snapshots are merged into the cached lists without API calls.
+// Events without snapshots fall back to re-fetching the affected cache keys.
+// Returns the number of events applied.
+func (s *PipelineService) ApplyEvents(ctx context.Context, platform string, events []domain.Event) int {
+	applied := 0
+	for _, event := range events {
+		cacher, ok := s.getClientForProject(platform, event.ProjectID).(webhookCacher)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case event.Pipeline != nil:
+			s.applyPipelineEvent(ctx, platform, cacher, event)
+		case event.Branch != nil:
+			s.applyBranchEvent(ctx, cacher, event)
+		case event.MergeRequest != nil:
+			s.applyMergeRequestEvent(ctx, cacher, event)
+		default:
+			project := domain.Project{ID: event.ProjectID, Platform: platform}
+			if s.RefreshForEvents(ctx, project, []domain.Event{event}) == 0 {
+				continue
+			}
+		}
+		applied++
+	}
+
+	return applied
+}
+
+// applyPipelineEvent merges a pipeline snapshot into the cached pipeline list.
+// PopulatePipelines also updates the latest pipeline of the snapshot's branch.
+// Without a cached list the affected keys are re-fetched instead, so the list never shrinks to the snapshot.
+// Finished pipelines are recorded in the pipeline history and, on default branches, passed to the notifier.
+func (s *PipelineService) applyPipelineEvent(ctx context.Context, platform string, cacher webhookCacher, event domain.Event) {
+	snapshot := *event.Pipeline
+	pipelines, _ := cacher.GetPipelines(ctx, event.ProjectID, 50)
+
+	if len(pipelines) == 0 {
+		s.RefreshForEvents(ctx, domain.Project{ID: event.ProjectID, Platform: platform}, []domain.Event{event})
+	} else {
+		cacher.PopulatePipelines(mergePipelineSnapshot(pipelines, snapshot))
+	}
+
+	s.RecordPipelines(platform, []domain.Pipeline{snapshot})
+	s.observeWebhookPipeline(ctx, platform, event.ProjectID, snapshot)
+	log.Printf("[Webhook] Applied pipeline %s (%s) on %s:%s", snapshot.ID, snapshot.Status, event.ProjectID, snapshot.Branch)
+}
+
+// mergePipelineSnapshot returns the cached pipeline list with a webhook snapshot added or replacing its
+// cached version, most recent first and capped at 50 pipelines.
+func mergePipelineSnapshot(pipelines []domain.Pipeline, snapshot domain.Pipeline) []domain.Pipeline {
+	merged := make([]domain.Pipeline, 0, len(pipelines)+1)
+	merged = append(merged, snapshot)
+	for _, p := range pipelines {
+		if p.ID == snapshot.ID {
+			// Keep fields the webhook payload doesn't carry
+			if merged[0].Repository == "" {
+				merged[0].Repository = p.Repository
+			}
+			if len(merged[0].Builds) == 0 {
+				merged[0].Builds = p.Builds
+			}
+			// What was read from the logs and artifacts only holds for the same finished run
+			if p.Status == snapshot.Status {
+				if merged[0].FailureExcerpt == "" {
+					merged[0].FailureExcerpt = p.FailureExcerpt
+				}
+				if merged[0].Coverage == nil {
+					merged[0].Coverage = p.Coverage
+				}
+			}
+			continue
+		}
+		merged = append(merged, p)
+	}
+
+	// Most recent first, as returned by the APIs
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].CreatedAt.After(merged[j].CreatedAt)
+	})
+	if len(merged) > 50 {
+		merged = merged[:50]
+	}
+	return merged
+}
+
+// applyBranchEvent merges a branch snapshot into the cached branch list, or removes a deleted branch.
+func (s *PipelineService) applyBranchEvent(ctx context.Context, cacher webhookCacher, event domain.Event) {
+	snapshot := *event.Branch
+	branches, _ := cacher.GetBranches(ctx, event.ProjectID, 200)
+
+	merged := make([]domain.Branch, 0, len(branches)+1)
+	for _, b := range branches {
+		if b.Name == snapshot.Name {
+			// Keep fields the webhook payload doesn't carry
+			snapshot.IsProtected = b.IsProtected
+			continue
+		}
+		merged = append(merged, b)
+	}
+
+	if event.ActionName == "deleted" {
+		cacher.Invalidate(fmt.Sprintf("GetLatestPipeline:%s:%s", event.ProjectID, snapshot.Name))
+	} else {
+		merged = append(merged, snapshot)
+		// Branch lists are ordered by name, as returned by the APIs
+		sort.SliceStable(merged, func(i, j int) bool {
+			return merged[i].Name < merged[j].Name
+		})
+	}
+
+	if len(merged) == 0 {
+		cacher.Invalidate(fmt.Sprintf("GetBranches:%s:200", event.ProjectID))
+	} else {
+		cacher.PopulateBranches(merged)
+	}
+	log.Printf("[Webhook] Applied branch %s (%s) on %s", snapshot.Name, event.ActionName, event.ProjectID)
+}
+
+// applyMergeRequestEvent merges a merge request snapshot into the cached open merge request list.
+// Merge requests that are no longer open are removed.
+func (s *PipelineService) applyMergeRequestEvent(ctx context.Context, cacher webhookCacher, event domain.Event) {
+	snapshot := *event.MergeRequest
+	mrs, _ := cacher.GetMergeRequests(ctx, event.ProjectID)
+
+	merged := make([]domain.MergeRequest, 0, len(mrs)+1)
+	for _, mr := range mrs {
+		if mr.ID == snapshot.ID {
+			// Keep fields the webhook payload doesn't carry
+			if snapshot.Author == "" {
+				snapshot.Author = mr.Author
+			}
+			if snapshot.Repository == "" {
+				snapshot.Repository = mr.Repository
+			}
+			continue
+		}
+		merged = append(merged, mr)
+	}
+
+	// Only open merge requests are cached
+	if snapshot.State == "opened" || snapshot.State == "open" {
+		merged = append(merged, snapshot)
+	}
+
+	// Most recently updated first, as returned by the APIs
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].UpdatedAt.After(merged[j].UpdatedAt)
+	})
+
+	if len(merged) == 0 {
+		cacher.Invalidate(fmt.Sprintf("GetMergeRequests:%s", event.ProjectID))
+	} else {
+		cacher.PopulateMergeRequests(merged)
+	}
+	log.Printf("[Webhook] Applied merge request !%s (%s) on %s", snapshot.ID, event.ActionName, event.ProjectID)
+}
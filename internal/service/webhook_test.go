@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vilaca/ci-dashboard/internal/api"
+	"github.com/vilaca/ci-dashboard/internal/domain"
+)
+
+// fakeWebhookCache is a test double for a caching client that parses webhooks into fixed events
+// and records the lists it is populated with. Other client methods panic if called.
+type fakeWebhookCache struct {
+	api.Client
+	events    []domain.Event
+	pipelines []domain.Pipeline
+	populated []domain.Pipeline
+	mrs       []domain.MergeRequest
+	refreshed []string
+}
+
+func (f *fakeWebhookCache) ParseWebhook(eventType string, payload []byte) ([]domain.Event, error) {
+	return f.events, nil
+}
+
+func (f *fakeWebhookCache) GetPipelines(ctx context.Context, projectID string, limit int) ([]domain.Pipeline, error) {
+	return f.pipelines, nil
+}
+
+func (f *fakeWebhookCache) GetBranches(ctx context.Context, projectID string, limit int) ([]domain.Branch, error) {
+	return nil, nil
+}
+
+func (f *fakeWebhookCache) GetMergeRequests(ctx context.Context, projectID string) ([]domain.MergeRequest, error) {
+	return f.mrs, nil
+}
+
+func (f *fakeWebhookCache) PopulatePipelines(pipelines []domain.Pipeline) {
+	f.populated = pipelines
+}
+
+func (f *fakeWebhookCache) PopulateBranches(branches []domain.Branch) {}
+
+func (f *fakeWebhookCache) PopulateMergeRequests(mrs []domain.MergeRequest) {
+	f.mrs = mrs
+}
+
+func (f *fakeWebhookCache) Invalidate(key string) {}
+
+func (f *fakeWebhookCache) GetEvents(ctx context.Context, projectID string, since time.Time) ([]domain.Event, error) {
+	return nil, nil
+}
+
+func (f *fakeWebhookCache) ForceRefresh(ctx context.Context, key string) error {
+	f.refreshed = append(f.refreshed, key)
+	return nil
+}
+
+// TestHandleWebhook_NamedConnection tests that webhooks of a named connection update that connection's
+// cache with qualified project IDs, and never the default connection of the same platform.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestHandleWebhook_NamedConnection(t *testing.T) {
+	// Arrange
+	s := NewPipelineService(nil, false)
+	defaultCache := &fakeWebhookCache{}
+	namedCache := &fakeWebhookCache{events: []domain.Event{
+		{Type: "merge_request", ProjectID: "123", ActionName: "opened", MergeRequest: &domain.MergeRequest{ID: "7", ProjectID: "123", State: "opened"}},
+		{Type: "merge_request", ProjectID: "456@other", ActionName: "opened", MergeRequest: &domain.MergeRequest{ID: "8", ProjectID: "456@other", State: "opened"}},
+	}}
+	s.RegisterConnection(domain.PlatformGitLab, domain.PlatformGitLab, defaultCache)
+	s.RegisterConnection("gitlab-internal", domain.PlatformGitLab, namedCache)
+
+	// Act
+	applied, err := s.HandleWebhook(context.Background(), "gitlab-internal", "Merge Request Hook", nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if applied != 1 {
+		t.Errorf("expected only the connection's event to be applied, got %d", applied)
+	}
+	if len(defaultCache.mrs) != 0 {
+		t.Errorf("expected the default connection's cache untouched, got %+v", defaultCache.mrs)
+	}
+	if len(namedCache.mrs) != 1 || namedCache.mrs[0].ProjectID != "123@gitlab-internal" {
+		t.Errorf("expected the merge request cached with a qualified project ID, got %+v", namedCache.mrs)
+	}
+}
+
+// TestHandleWebhook_UnknownConnection tests that webhooks of unregistered connections are rejected.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestHandleWebhook_UnknownConnection(t *testing.T) {
+	// Arrange
+	s := NewPipelineService(nil, false)
+
+	// Act
+	_, err := s.HandleWebhook(context.Background(), "gitlab-internal", "Pipeline Hook", nil)
+
+	// Assert
+	if err == nil {
+		t.Error("expected an error for an unknown connection")
+	}
+}
+
+// TestApplyEvents_PipelineNotCached tests that a pipeline event without a cached pipeline list re-fetches
+// the list instead of caching a list holding only the event's pipeline.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestApplyEvents_PipelineNotCached(t *testing.T) {
+	// Arrange
+	s := NewPipelineService(nil, false)
+	cache := &fakeWebhookCache{}
+	s.RegisterConnection(domain.PlatformGitLab, domain.PlatformGitLab, cache)
+	event := domain.Event{Type: "pipeline", ProjectID: "123", Pipeline: &domain.Pipeline{ID: "9", ProjectID: "123", Branch: "main", Status: domain.StatusRunning}}
+
+	// Act
+	applied := s.ApplyEvents(context.Background(), domain.PlatformGitLab, []domain.Event{event})
+
+	// Assert
+	if applied != 1 {
+		t.Errorf("expected 1 applied event, got %d", applied)
+	}
+	if cache.populated != nil {
+		t.Errorf("expected no partial pipeline list to be cached, got %+v", cache.populated)
+	}
+	expected := []string{"GetPipelines:123:50", "GetLatestPipeline:123:main"}
+	if len(cache.refreshed) != len(expected) || cache.refreshed[0] != expected[0] || cache.refreshed[1] != expected[1] {
+		t.Errorf("expected %v to be refreshed, got %v", expected, cache.refreshed)
+	}
+}
+
+// TestMergePipelineSnapshot tests merging a webhook snapshot into the cached pipeline list.
+// Follows AAA (Arrange, Act, Assert) pattern.
+func TestMergePipelineSnapshot(t *testing.T) {
+	now := time.Now()
+	coverage := 87.5
+	cached := []domain.Pipeline{
+		{ID: "2", Repository: "api", Status: domain.StatusFailed, CreatedAt: now, FailureExcerpt: "boom", Coverage: &coverage,
+			Builds: []domain.Build{{ID: "21", Name: "test", Status: domain.StatusFailed}}},
+		{ID: "1", Repository: "api", Status: domain.StatusSuccess, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name            string
+		snapshot        domain.Pipeline
+		expectedIDs     []string
+		expectedExcerpt string
+		expectCoverage  bool
+	}{
+		{"same run", domain.Pipeline{ID: "2", Status: domain.StatusFailed, CreatedAt: now}, []string{"2", "1"}, "boom", true},
+		{"retried run", domain.Pipeline{ID: "2", Status: domain.StatusRunning, CreatedAt: now}, []string{"2", "1"}, "", false},
+		{"new run", domain.Pipeline{ID: "3", Status: domain.StatusRunning, CreatedAt: now.Add(time.Minute)}, []string{"3", "2", "1"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			merged := mergePipelineSnapshot(cached, tt.snapshot)
+
+			// Assert
+			if len(merged) != len(tt.expectedIDs) {
+				t.Fatalf("expected %d pipelines, got %d", len(tt.expectedIDs), len(merged))
+			}
+			for i, id := range tt.expectedIDs {
+				if merged[i].ID != id {
+					t.Errorf("expected pipeline %s at %d, got %s", id, i, merged[i].ID)
+				}
+			}
+			if merged[0].FailureExcerpt != tt.expectedExcerpt || (merged[0].Coverage != nil) != tt.expectCoverage {
+				t.Errorf("expected excerpt %q and coverage %v, got %q and %v", tt.expectedExcerpt, tt.expectCoverage, merged[0].FailureExcerpt, merged[0].Coverage)
+			}
+			if tt.snapshot.ID == "2" && (merged[0].Repository != "api" || len(merged[0].Builds) != 1) {
+				t.Errorf("expected the repository and builds of the cached run, got %+v", merged[0])
+			}
+		})
+	}
+}